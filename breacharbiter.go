@@ -30,6 +30,26 @@ import (
 // continue from the persisted state.
 var retributionBucket = []byte("retribution")
 
+// maxOutputIndex is the largest output index which can be referenced by an
+// outpoint. As each output of a transaction takes up at least 9 bytes (an
+// 8-byte value and a 1-byte script length), no transaction within a block can
+// have more outputs than fit within the maximum block payload.
+const maxOutputIndex = wire.MaxBlockPayload / 9
+
+var (
+	// ErrBreachedAmountOutOfRange is returned when a deserialized
+	// breachedOutput carries an amount outside of the range of valid
+	// amounts.
+	ErrBreachedAmountOutOfRange = errors.New("breached output amount " +
+		"out of range")
+
+	// ErrBreachedIndexOutOfRange is returned when a deserialized
+	// breachedOutput references an output index that can't exist within
+	// any valid transaction.
+	ErrBreachedIndexOutOfRange = errors.New("breached output index " +
+		"out of range")
+)
+
 // BreachConfig bundles the required subsystems used by the breach arbiter. An
 // instance of BreachConfig is passed to newBreachArbiter during instantiation.
 type BreachConfig struct {
@@ -237,6 +257,7 @@ func (b *breachArbiter) Start() error {
 
 			// Ensure channeldb is consistent with the persisted
 			// breach.
+			closeSummary.ShortChanID = chanState.ShortChanID
 			err := channel.DeleteState(&closeSummary)
 			if err != nil {
 				brarLog.Errorf("unable to delete channel "+
@@ -783,6 +804,7 @@ func (b *breachArbiter) breachObserver(contract *lnwallet.LightningChannel,
 			SettledBalance: chanInfo.LocalBalance.ToSatoshis(),
 			CloseType:      channeldb.BreachClose,
 			IsPending:      true,
+			ShortChanID:    contract.ShortChanID(),
 		}
 
 		// Next, persist the channel close to disk. Upon restart, the
@@ -1449,9 +1471,19 @@ func (bo *breachedOutput) Decode(r io.Reader) error {
 	}
 	bo.amt = btcutil.Amount(binary.BigEndian.Uint64(scratch[:8]))
 
+	// A corrupted amount could wrap around to a negative value, or exceed
+	// the total supply, either of which would break any fee computations
+	// involving the output.
+	if bo.amt < 0 || bo.amt > btcutil.MaxSatoshi {
+		return ErrBreachedAmountOutOfRange
+	}
+
 	if err := readOutpoint(r, &bo.outpoint); err != nil {
 		return err
 	}
+	if bo.outpoint.Index > maxOutputIndex {
+		return ErrBreachedIndexOutOfRange
+	}
 
 	if err := lnwallet.ReadSignDescriptor(r, &bo.signDesc); err != nil {
 		return err
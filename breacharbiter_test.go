@@ -818,3 +818,52 @@ restartCheck:
 		goto restartCheck
 	}
 }
+
+// Test that decoding a breachedOutput with an out of range amount or output
+// index fails.
+func TestBreachedOutputDecodeOutOfRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*breachedOutput)
+		err    error
+	}{
+		{
+			name: "negative amount",
+			modify: func(bo *breachedOutput) {
+				bo.amt = -1
+			},
+			err: ErrBreachedAmountOutOfRange,
+		},
+		{
+			name: "amount above max",
+			modify: func(bo *breachedOutput) {
+				bo.amt = btcutil.MaxSatoshi + 1
+			},
+			err: ErrBreachedAmountOutOfRange,
+		},
+		{
+			name: "implausible output index",
+			modify: func(bo *breachedOutput) {
+				bo.outpoint.Index = maxOutputIndex + 1
+			},
+			err: ErrBreachedIndexOutOfRange,
+		},
+	}
+
+	for _, test := range tests {
+		bo := breachedOutputs[0]
+		test.modify(&bo)
+
+		var buf bytes.Buffer
+		if err := bo.Encode(&buf); err != nil {
+			t.Fatalf("%v: unable to serialize breached output: %v",
+				test.name, err)
+		}
+
+		desBo := &breachedOutput{}
+		if err := desBo.Decode(&buf); err != test.err {
+			t.Fatalf("%v: expected error %v, got %v", test.name,
+				test.err, err)
+		}
+	}
+}
@@ -87,6 +87,10 @@ type BtcdNotifier struct {
 // Ensure BtcdNotifier implements the ChainNotifier interface at compile time.
 var _ chainntnfs.ChainNotifier = (*BtcdNotifier)(nil)
 
+// Ensure BtcdNotifier implements the StaleBlockNotifier interface at compile
+// time.
+var _ chainntnfs.StaleBlockNotifier = (*BtcdNotifier)(nil)
+
 // New returns a new BtcdNotifier instance. This function assumes the btcd node
 // detailed in the passed configuration is already running, and willing to
 // accept new websockets clients.
@@ -218,6 +222,15 @@ func (b *BtcdNotifier) onBlockConnected(hash *chainhash.Hash, height int32, t ti
 
 // onBlockDisconnected implements on OnBlockDisconnected callback for rpcclient.
 func (b *BtcdNotifier) onBlockDisconnected(hash *chainhash.Hash, height int32, t time.Time) {
+	// Launch a goroutine to hand the stale block to the notification
+	// dispatcher, in order to avoid blocking the main loop of the rpc
+	// client.
+	go func() {
+		select {
+		case b.disconnectedBlockHashes <- &blockNtfn{hash, height}:
+		case <-b.quit:
+		}
+	}()
 }
 
 // onRedeemingTx implements on OnRedeemingTx callback for rpcclient.
@@ -312,7 +325,10 @@ out:
 			//    re-org?
 			//  * notify of negative confirmations
 			chainntnfs.Log.Warnf("Block disconnected from main "+
-				"chain: %v", staleBlockHash)
+				"chain: %v", staleBlockHash.sha)
+
+			b.notifyStaleBlocks(staleBlockHash.height,
+				staleBlockHash.sha)
 
 		case <-b.chainUpdateSignal:
 			// A new update is available, so pop the new chain
@@ -501,6 +517,10 @@ func (b *BtcdNotifier) notifyBlockEpochs(newHeight int32, newSha *chainhash.Hash
 	}
 
 	for _, epochClient := range b.blockEpochClients {
+		if epochClient.stale {
+			continue
+		}
+
 		b.wg.Add(1)
 		epochClient.wg.Add(1)
 		go func(ntfnChan chan *chainntnfs.BlockEpoch, cancelChan chan struct{},
@@ -525,6 +545,43 @@ func (b *BtcdNotifier) notifyBlockEpochs(newHeight int32, newSha *chainhash.Hash
 	}
 }
 
+// notifyStaleBlocks notifies all registered stale block clients of the block
+// which has been disconnected from the main chain.
+func (b *BtcdNotifier) notifyStaleBlocks(staleHeight int32,
+	staleSha *chainhash.Hash) {
+
+	epoch := &chainntnfs.BlockEpoch{
+		Height: staleHeight,
+		Hash:   staleSha,
+	}
+
+	for _, epochClient := range b.blockEpochClients {
+		if !epochClient.stale {
+			continue
+		}
+
+		b.wg.Add(1)
+		epochClient.wg.Add(1)
+		go func(ntfnChan chan *chainntnfs.BlockEpoch, cancelChan chan struct{},
+			clientWg *sync.WaitGroup) {
+
+			defer clientWg.Done()
+			defer b.wg.Done()
+
+			select {
+			case ntfnChan <- epoch:
+
+			case <-cancelChan:
+				return
+
+			case <-b.quit:
+				return
+			}
+
+		}(epochClient.epochChan, epochClient.cancelChan, &epochClient.wg)
+	}
+}
+
 // notifyConfs examines the current confirmation heap, sending off any
 // notifications which have been triggered by the connection of a new block at
 // newBlockHeight.
@@ -755,10 +812,13 @@ func (b *BtcdNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 }
 
 // blockEpochRegistration represents a client's intent to receive a
-// notification with each newly connected block.
+// notification with each newly connected block, or with each block
+// disconnected due to a re-org if stale is set.
 type blockEpochRegistration struct {
 	epochID uint64
 
+	stale bool
+
 	epochChan chan *chainntnfs.BlockEpoch
 
 	cancelChan chan struct{}
@@ -776,7 +836,23 @@ type epochCancel struct {
 // caller to receive notifications, of each new block connected to the main
 // chain.
 func (b *BtcdNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent, error) {
+	return b.registerEpochs(false)
+}
+
+// RegisterStaleBlockNtfn returns a BlockEpochEvent which subscribes the caller
+// to receive notifications, of each block disconnected from the main chain due
+// to a re-org.
+//
+// NOTE: This is part of the chainntnfs.StaleBlockNotifier interface.
+func (b *BtcdNotifier) RegisterStaleBlockNtfn() (*chainntnfs.BlockEpochEvent, error) {
+	return b.registerEpochs(true)
+}
+
+// registerEpochs registers a new block epoch client, which is notified of
+// disconnected blocks if stale is true, and of connected blocks otherwise.
+func (b *BtcdNotifier) registerEpochs(stale bool) (*chainntnfs.BlockEpochEvent, error) {
 	registration := &blockEpochRegistration{
+		stale:      stale,
 		epochChan:  make(chan *chainntnfs.BlockEpoch, 20),
 		cancelChan: make(chan struct{}),
 		epochID:    atomic.AddUint64(&b.epochClientCounter, 1),
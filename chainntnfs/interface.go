@@ -166,6 +166,17 @@ type BlockEpochEvent struct {
 	Cancel func()
 }
 
+// StaleBlockNotifier is an optional extension of the ChainNotifier interface,
+// which is implemented by notifiers that are able to report blocks which have
+// been disconnected from the tip of the main chain due to a re-org.
+type StaleBlockNotifier interface {
+	// RegisterStaleBlockNtfn registers an intent to be notified of each
+	// block disconnected from the tip of the main chain. The Epochs
+	// channel of the returned BlockEpochEvent will be sent upon with the
+	// hash and height of each disconnected block.
+	RegisterStaleBlockNtfn() (*BlockEpochEvent, error)
+}
+
 // NotifierDriver represents a "driver" for a particular interface. A driver is
 // identified by a globally unique string identifier along with a 'New()'
 // method which is responsible for initializing a particular ChainNotifier
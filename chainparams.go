@@ -66,78 +66,163 @@ var regTestNetParams = bitcoinNetParams{
 	rpcPort: "18334",
 }
 
-// applyLitecoinParams applies the relevant chain configuration parameters that
-// differ for litecoin to the chain parameters typed for btcsuite derivation.
-// This function is used in place of using something like interface{} to
-// abstract over _which_ chain (or fork) the parameters are for.
-func applyLitecoinParams(params *bitcoinNetParams) {
-	params.Name = liteTestNetParams.Name
-	params.Net = wire.BitcoinNet(liteTestNetParams.Net)
-	params.DefaultPort = liteTestNetParams.DefaultPort
-	params.CoinbaseMaturity = liteTestNetParams.CoinbaseMaturity
+// altcoinCheckpoint is a checkpoint of an altcoin chain, detached from the
+// chaincfg package of the altcoin.
+type altcoinCheckpoint struct {
+	Height int32
+	Hash   chainhash.Hash
+}
 
-	copy(params.GenesisHash[:], liteTestNetParams.GenesisHash[:])
+// altcoinNetParams holds the chain parameters of an altcoin which differ from
+// those of bitcoin, detached from the chaincfg package of the altcoin. This
+// allows the parameters of any altcoin to be applied to the chain parameters
+// typed for btcsuite derivation through applyAltcoinParams.
+type altcoinNetParams struct {
+	Name             string
+	Net              uint32
+	DefaultPort      string
+	CoinbaseMaturity uint16
 
-	// Address encoding magics
-	params.PubKeyHashAddrID = liteTestNetParams.PubKeyHashAddrID
-	params.ScriptHashAddrID = liteTestNetParams.ScriptHashAddrID
-	params.PrivateKeyID = liteTestNetParams.PrivateKeyID
-	params.WitnessPubKeyHashAddrID = liteTestNetParams.WitnessPubKeyHashAddrID
-	params.WitnessScriptHashAddrID = liteTestNetParams.WitnessScriptHashAddrID
-	params.Bech32HRPSegwit = liteTestNetParams.Bech32HRPSegwit
+	GenesisHash chainhash.Hash
 
-	copy(params.HDPrivateKeyID[:], liteTestNetParams.HDPrivateKeyID[:])
-	copy(params.HDPublicKeyID[:], liteTestNetParams.HDPublicKeyID[:])
+	PubKeyHashAddrID        byte
+	ScriptHashAddrID        byte
+	PrivateKeyID            byte
+	WitnessPubKeyHashAddrID byte
+	WitnessScriptHashAddrID byte
+	Bech32HRPSegwit         string
 
-	params.HDCoinType = liteTestNetParams.HDCoinType
+	HDPrivateKeyID [4]byte
+	HDPublicKeyID  [4]byte
+	HDCoinType     uint32
 
-	checkPoints := make([]chaincfg.Checkpoint, len(liteTestNetParams.Checkpoints))
-	for i := 0; i < len(liteTestNetParams.Checkpoints); i++ {
-		var chainHash chainhash.Hash
-		copy(chainHash[:], liteTestNetParams.Checkpoints[i].Hash[:])
+	Checkpoints []altcoinCheckpoint
 
-		checkPoints[i] = chaincfg.Checkpoint{
-			Height: liteTestNetParams.Checkpoints[i].Height,
-			Hash:   &chainHash,
-		}
+	rpcPort string
+}
+
+// altcoinParams returns the litecoin parameters as altcoinNetParams.
+func (p litecoinNetParams) altcoinParams() altcoinNetParams {
+	checkpoints := make([]altcoinCheckpoint, len(p.Checkpoints))
+	for i, checkpoint := range p.Checkpoints {
+		checkpoints[i].Height = checkpoint.Height
+		copy(checkpoints[i].Hash[:], checkpoint.Hash[:])
 	}
-	params.Checkpoints = checkPoints
 
-	params.rpcPort = liteTestNetParams.rpcPort
+	params := altcoinNetParams{
+		Name:                    p.Name,
+		Net:                     uint32(p.Net),
+		DefaultPort:             p.DefaultPort,
+		CoinbaseMaturity:        p.CoinbaseMaturity,
+		PubKeyHashAddrID:        p.PubKeyHashAddrID,
+		ScriptHashAddrID:        p.ScriptHashAddrID,
+		PrivateKeyID:            p.PrivateKeyID,
+		WitnessPubKeyHashAddrID: p.WitnessPubKeyHashAddrID,
+		WitnessScriptHashAddrID: p.WitnessScriptHashAddrID,
+		Bech32HRPSegwit:         p.Bech32HRPSegwit,
+		HDPrivateKeyID:          p.HDPrivateKeyID,
+		HDPublicKeyID:           p.HDPublicKeyID,
+		HDCoinType:              p.HDCoinType,
+		Checkpoints:             checkpoints,
+		rpcPort:                 p.rpcPort,
+	}
+	copy(params.GenesisHash[:], p.GenesisHash[:])
+
+	return params
 }
 
-func applyViacoinParams(params *bitcoinNetParams) {
-	params.Name = viaTestNetParams.Name
-	params.Net = wire.BitcoinNet(viaTestNetParams.Net)
-	params.DefaultPort = viaTestNetParams.DefaultPort
-	params.CoinbaseMaturity = viaTestNetParams.CoinbaseMaturity
+// altcoinParams returns the viacoin parameters as altcoinNetParams.
+func (p viacoinNetParams) altcoinParams() altcoinNetParams {
+	checkpoints := make([]altcoinCheckpoint, len(p.Checkpoints))
+	for i, checkpoint := range p.Checkpoints {
+		checkpoints[i].Height = checkpoint.Height
+		copy(checkpoints[i].Hash[:], checkpoint.Hash[:])
+	}
+
+	params := altcoinNetParams{
+		Name:                    p.Name,
+		Net:                     uint32(p.Net),
+		DefaultPort:             p.DefaultPort,
+		CoinbaseMaturity:        p.CoinbaseMaturity,
+		PubKeyHashAddrID:        p.PubKeyHashAddrID,
+		ScriptHashAddrID:        p.ScriptHashAddrID,
+		PrivateKeyID:            p.PrivateKeyID,
+		WitnessPubKeyHashAddrID: p.WitnessPubKeyHashAddrID,
+		WitnessScriptHashAddrID: p.WitnessScriptHashAddrID,
+		Bech32HRPSegwit:         p.Bech32HRPSegwit,
+		HDPrivateKeyID:          p.HDPrivateKeyID,
+		HDPublicKeyID:           p.HDPublicKeyID,
+		HDCoinType:              p.HDCoinType,
+		Checkpoints:             checkpoints,
+		rpcPort:                 p.rpcPort,
+	}
+	copy(params.GenesisHash[:], p.GenesisHash[:])
+
+	return params
+}
 
-	copy(params.GenesisHash[:], viaTestNetParams.GenesisHash[:])
+// applyAltcoinParams applies the relevant chain configuration parameters that
+// differ for an altcoin to the chain parameters typed for btcsuite
+// derivation.
+func applyAltcoinParams(params *bitcoinNetParams, src altcoinNetParams) {
+	params.Name = src.Name
+	params.Net = wire.BitcoinNet(src.Net)
+	params.DefaultPort = src.DefaultPort
+	params.CoinbaseMaturity = src.CoinbaseMaturity
+
+	copy(params.GenesisHash[:], src.GenesisHash[:])
 
 	// Address encoding magics
-	params.PubKeyHashAddrID = viaTestNetParams.PubKeyHashAddrID
-	params.ScriptHashAddrID = viaTestNetParams.ScriptHashAddrID
-	params.PrivateKeyID = viaTestNetParams.PrivateKeyID
-	params.WitnessPubKeyHashAddrID = viaTestNetParams.WitnessPubKeyHashAddrID
-	params.WitnessScriptHashAddrID = viaTestNetParams.WitnessScriptHashAddrID
-	params.Bech32HRPSegwit = viaTestNetParams.Bech32HRPSegwit
+	params.PubKeyHashAddrID = src.PubKeyHashAddrID
+	params.ScriptHashAddrID = src.ScriptHashAddrID
+	params.PrivateKeyID = src.PrivateKeyID
+	params.WitnessPubKeyHashAddrID = src.WitnessPubKeyHashAddrID
+	params.WitnessScriptHashAddrID = src.WitnessScriptHashAddrID
+	params.Bech32HRPSegwit = src.Bech32HRPSegwit
 
-	copy(params.HDPrivateKeyID[:], viaTestNetParams.HDPrivateKeyID[:])
-	copy(params.HDPublicKeyID[:], viaTestNetParams.HDPublicKeyID[:])
+	copy(params.HDPrivateKeyID[:], src.HDPrivateKeyID[:])
+	copy(params.HDPublicKeyID[:], src.HDPublicKeyID[:])
 
-	params.HDCoinType = viaTestNetParams.HDCoinType
+	params.HDCoinType = src.HDCoinType
 
-	checkPoints := make([]chaincfg.Checkpoint, len(viaTestNetParams.Checkpoints))
-	for i := 0; i < len(viaTestNetParams.Checkpoints); i++ {
-		var chainHash chainhash.Hash
-		copy(chainHash[:], viaTestNetParams.Checkpoints[i].Hash[:])
+	checkPoints := make([]chaincfg.Checkpoint, len(src.Checkpoints))
+	for i := 0; i < len(src.Checkpoints); i++ {
+		chainHash := src.Checkpoints[i].Hash
 
 		checkPoints[i] = chaincfg.Checkpoint{
-			Height: viaTestNetParams.Checkpoints[i].Height,
+			Height: src.Checkpoints[i].Height,
 			Hash:   &chainHash,
 		}
 	}
 	params.Checkpoints = checkPoints
 
-	params.rpcPort = viaTestNetParams.rpcPort
+	params.rpcPort = src.rpcPort
+}
+
+// applyLitecoinParams applies the relevant chain configuration parameters that
+// differ for litecoin to the chain parameters typed for btcsuite derivation.
+// This function is used in place of using something like interface{} to
+// abstract over _which_ chain (or fork) the parameters are for.
+func applyLitecoinParams(params *bitcoinNetParams) {
+	applyAltcoinParams(params, liteTestNetParams.altcoinParams())
+}
+
+// applyViacoinParams applies the relevant chain configuration parameters that
+// differ for viacoin to the chain parameters typed for btcsuite derivation.
+func applyViacoinParams(params *bitcoinNetParams) {
+	applyAltcoinParams(params, viaTestNetParams.altcoinParams())
+}
+
+// isMainNet returns true if the passed network parameters, which may have
+// been derived from those of an altcoin, are those of the main network of any
+// of the supported chains.
+func isMainNet(params bitcoinNetParams) bool {
+	switch params.Name {
+	case bitcoinCfg.MainNetParams.Name, litecoinCfg.MainNetParams.Name,
+		viacoinCfg.MainNetParams.Name:
+
+		return true
+	}
+
+	return false
 }
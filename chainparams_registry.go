@@ -0,0 +1,215 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	flags "github.com/btcsuite/go-flags"
+	bitcoinCfg "github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcutil"
+	"github.com/viacoin/lnd/htlcswitch"
+)
+
+// ChainParams describes a chain which isn't natively supported by lnd, but
+// which has been registered through RegisterChainParams.
+type ChainParams struct {
+	// NetParams are the network parameters of the chain, typed for
+	// btcsuite derivation.
+	NetParams *bitcoinCfg.Params
+
+	// RPCPort is the default RPC port of the chain's daemon, used if the
+	// configured RPC host doesn't specify one.
+	RPCPort string
+
+	// DaemonName is the name of the chain's daemon. The daemon's RPC
+	// credentials are automatically read from its configuration file,
+	// named after the daemon, if they aren't configured.
+	DaemonName string
+
+	// RoutingPolicy is the default forwarding policy used when
+	// advertising channels on the chain.
+	RoutingPolicy htlcswitch.ForwardingPolicy
+
+	// FeeRate is the static fee rate in satoshis per byte used for
+	// transactions on the chain.
+	FeeRate uint64
+
+	// DNSSeeds is the set of DNS seeds used to bootstrap peers on the
+	// chain upon first startup.
+	DNSSeeds []string
+}
+
+// registeredChain is a chain registered through RegisterChainParams.
+type registeredChain struct {
+	name   string
+	params ChainParams
+}
+
+// customChains maps the chainCode of each chain registered through
+// RegisterChainParams to the chain.
+//
+// NOTE: This map is only written to by RegisterChainParams, which is to be
+// called from an init function, so it doesn't require any locking.
+var customChains = make(map[chainCode]*registeredChain)
+
+// RegisterChainParams registers a chain which isn't natively supported by lnd
+// under the passed chainCode and name, such that it can be activated through
+// the configuration options within the namespace of the name. This allows
+// forks to add their chain without modifying the core chain selection, and
+// should be called from an init function.
+func RegisterChainParams(code chainCode, name string,
+	params ChainParams) error {
+
+	switch code {
+	case bitcoinChain, litecoinChain, viacoinChain:
+		return fmt.Errorf("chain code %v is reserved for %v", uint32(code),
+			code)
+	}
+	if _, ok := customChains[code]; ok {
+		return fmt.Errorf("chain code %v is already registered",
+			uint32(code))
+	}
+
+	if name == "" {
+		return fmt.Errorf("chain code %v registered without a name",
+			uint32(code))
+	}
+	if params.NetParams == nil {
+		return fmt.Errorf("chain %v registered without net params", name)
+	}
+	for _, chain := range []chainCode{bitcoinChain, litecoinChain,
+		viacoinChain} {
+
+		if chain.String() == name {
+			return fmt.Errorf("chain name %v is reserved", name)
+		}
+	}
+	for _, chain := range customChains {
+		if chain.name == name {
+			return fmt.Errorf("chain name %v is already registered",
+				name)
+		}
+	}
+
+	customChains[code] = &registeredChain{
+		name:   name,
+		params: params,
+	}
+
+	genesisHash := *params.NetParams.GenesisHash
+	chainMap[genesisHash] = code
+	reverseChainMap[code] = genesisHash
+	if len(params.DNSSeeds) != 0 {
+		chainDNSSeeds[genesisHash] = params.DNSSeeds
+	}
+
+	return nil
+}
+
+// customChainCodes returns the codes of all registered chains in ascending
+// order, such that they're always processed in the same order.
+func customChainCodes() []chainCode {
+	codes := make([]chainCode, 0, len(customChains))
+	for code := range customChains {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i] < codes[j]
+	})
+
+	return codes
+}
+
+// defaultCustomChainConfigs returns the default configuration of each
+// registered chain.
+func defaultCustomChainConfigs() map[chainCode]*chainConfig {
+	chainCfgs := make(map[chainCode]*chainConfig, len(customChains))
+	for code, chain := range customChains {
+		daemonHomeDir := btcutil.AppDataDir(chain.params.DaemonName, false)
+		chainCfgs[code] = &chainConfig{
+			RPCHost: defaultRPCHost,
+			RPCCert: filepath.Join(daemonHomeDir, "rpc.cert"),
+		}
+	}
+
+	return chainCfgs
+}
+
+// newConfigParser returns a new parser for the passed config, which also
+// parses the options of each registered chain within the namespace of the
+// chain's name.
+func newConfigParser(cfg *config, options flags.Options) (*flags.Parser,
+	error) {
+
+	parser := flags.NewParser(cfg, options)
+	for _, code := range customChainCodes() {
+		chain := customChains[code]
+
+		group, err := parser.AddGroup(strings.Title(chain.name), "",
+			cfg.customChains[code])
+		if err != nil {
+			return nil, err
+		}
+		group.Namespace = chain.name
+	}
+
+	return parser, nil
+}
+
+// applyCustomChainConfig selects the registered chain which is marked as
+// active within the passed config, if any, as our primary chain.
+func applyCustomChainConfig(cfg *config, funcName string) error {
+	var numActive int
+	for _, code := range customChainCodes() {
+		chain := customChains[code]
+		chainCfg := cfg.customChains[code]
+		if !chainCfg.Active {
+			continue
+		}
+		numActive++
+
+		// At this moment, multiple active chains are not supported.
+		if cfg.Bitcoin.Active || cfg.Litecoin.Active ||
+			cfg.Viacoin.Active || numActive > 1 {
+
+			str := "%s: Currently %v cannot be active together " +
+				"with another chain"
+			return fmt.Errorf(str, funcName, chain.name)
+		}
+
+		// The SPV mode implemented currently only supports Bitcoin, so
+		// the two modes are incompatible.
+		if cfg.NeutrinoMode.Active {
+			str := "%s: The light client mode currently supported " +
+				"does not yet support execution on the %v network"
+			return fmt.Errorf(str, funcName, chain.name)
+		}
+
+		if chainCfg.SimNet {
+			str := "%s: simnet mode for %v not currently supported"
+			return fmt.Errorf(str, funcName, chain.name)
+		}
+
+		activeNetParams = bitcoinNetParams{
+			Params:  chain.params.NetParams,
+			rpcPort: chain.params.RPCPort,
+		}
+
+		// Attempt to parse out the RPC credentials for the chain if
+		// the information wasn't specified.
+		if err := parseRPCParams(chainCfg, code, funcName); err != nil {
+			return fmt.Errorf("unable to load RPC credentials for "+
+				"%v: %v", chain.params.DaemonName, err)
+		}
+
+		chainCfg.ChainDir = filepath.Join(cfg.DataDir, code.String())
+
+		// Finally we'll register the chain as our current primary
+		// chain.
+		registeredChains.RegisterPrimaryChain(code)
+	}
+
+	return nil
+}
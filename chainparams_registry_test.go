@@ -0,0 +1,132 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+
+	flags "github.com/btcsuite/go-flags"
+	bitcoinCfg "github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestRegisterChainParams ensures that a chain registered through
+// RegisterChainParams can be configured through its own namespace, and is
+// selected as our primary chain once it's marked as active.
+func TestRegisterChainParams(t *testing.T) {
+	const (
+		fakeChain chainCode = 100
+		fakeName            = "fakecoin"
+	)
+
+	fakeParams := bitcoinCfg.MainNetParams
+	fakeParams.Name = fakeName
+	fakeParams.GenesisHash = &chainhash.Hash{0x01, 0x02, 0x03}
+
+	// Restore all global state touched by the registration once we're
+	// done.
+	prevNetParams := activeNetParams
+	prevPrimaryChain := registeredChains.PrimaryChain()
+	defer func() {
+		delete(customChains, fakeChain)
+		delete(chainMap, *fakeParams.GenesisHash)
+		delete(reverseChainMap, fakeChain)
+		delete(chainDNSSeeds, *fakeParams.GenesisHash)
+
+		activeNetParams = prevNetParams
+		registeredChains.RegisterPrimaryChain(prevPrimaryChain)
+	}()
+
+	params := ChainParams{
+		NetParams:  &fakeParams,
+		RPCPort:    "12345",
+		DaemonName: "faked",
+		FeeRate:    10,
+		DNSSeeds:   []string{"seed.fakecoin.example"},
+	}
+	if err := RegisterChainParams(fakeChain, fakeName, params); err != nil {
+		t.Fatalf("unable to register chain: %v", err)
+	}
+
+	// Registering a chain under a built-in or taken code or name should
+	// fail.
+	if err := RegisterChainParams(bitcoinChain, "other", params); err == nil {
+		t.Fatalf("expected registration of built-in code to fail")
+	}
+	if err := RegisterChainParams(fakeChain, "other", params); err == nil {
+		t.Fatalf("expected duplicate registration of code to fail")
+	}
+	if err := RegisterChainParams(fakeChain+1, fakeName, params); err == nil {
+		t.Fatalf("expected duplicate registration of name to fail")
+	}
+	if err := RegisterChainParams(fakeChain+1, "viacoin", params); err == nil {
+		t.Fatalf("expected registration of built-in name to fail")
+	}
+
+	if fakeChain.String() != fakeName {
+		t.Fatalf("expected chain name %v, got %v", fakeName,
+			fakeChain.String())
+	}
+	if chainMap[*fakeParams.GenesisHash] != fakeChain {
+		t.Fatalf("chain not mapped by its genesis hash")
+	}
+	if len(chainDNSSeeds[*fakeParams.GenesisHash]) != 1 {
+		t.Fatalf("dns seeds of chain not registered")
+	}
+
+	// The options of the chain should be parsed within its namespace.
+	cfg := config{
+		DataDir:      defaultDataDir,
+		Bitcoin:      &chainConfig{},
+		Litecoin:     &chainConfig{},
+		Viacoin:      &chainConfig{},
+		NeutrinoMode: &neutrinoConfig{},
+		customChains: defaultCustomChainConfigs(),
+	}
+	parser, err := newConfigParser(&cfg, flags.None)
+	if err != nil {
+		t.Fatalf("unable to create config parser: %v", err)
+	}
+	_, err = parser.ParseArgs([]string{
+		"--fakecoin.active", "--fakecoin.rpcuser=user",
+		"--fakecoin.rpcpass=pass",
+	})
+	if err != nil {
+		t.Fatalf("unable to parse args: %v", err)
+	}
+
+	chainCfg := cfg.customChains[fakeChain]
+	if !chainCfg.Active {
+		t.Fatalf("chain wasn't marked as active")
+	}
+	if chainCfg.RPCUser != "user" || chainCfg.RPCPass != "pass" {
+		t.Fatalf("rpc credentials of chain not parsed")
+	}
+	if chainCfg.RPCHost != defaultRPCHost {
+		t.Fatalf("expected default rpc host %v, got %v",
+			defaultRPCHost, chainCfg.RPCHost)
+	}
+
+	// Once applied, the chain should be selected as our primary chain.
+	if err := applyCustomChainConfig(&cfg, "test"); err != nil {
+		t.Fatalf("unable to apply chain config: %v", err)
+	}
+	if registeredChains.PrimaryChain() != fakeChain {
+		t.Fatalf("expected primary chain %v, got %v", fakeChain,
+			registeredChains.PrimaryChain())
+	}
+	if activeNetParams.Params != &fakeParams {
+		t.Fatalf("expected net params %v, got %v", fakeParams.Name,
+			activeNetParams.Name)
+	}
+	if activeNetParams.rpcPort != params.RPCPort {
+		t.Fatalf("expected rpc port %v, got %v", params.RPCPort,
+			activeNetParams.rpcPort)
+	}
+
+	// The chain can't be active along with a built-in chain.
+	cfg.Bitcoin.Active = true
+	if err := applyCustomChainConfig(&cfg, "test"); err == nil {
+		t.Fatalf("expected multiple active chains to be rejected")
+	}
+}
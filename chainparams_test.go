@@ -0,0 +1,230 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+
+	bitcoinCfg "github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	viacoinCfg "github.com/viacoin/viad/chaincfg"
+)
+
+// newTestBitcoinParams returns a deep enough copy of the bitcoin testnet
+// params for the altcoin params to be applied to it without mutating the
+// global params.
+func newTestBitcoinParams() bitcoinNetParams {
+	params := *bitcoinTestNetParams.Params
+	genesisHash := *params.GenesisHash
+	params.GenesisHash = &genesisHash
+
+	return bitcoinNetParams{
+		Params:  &params,
+		rpcPort: bitcoinTestNetParams.rpcPort,
+	}
+}
+
+// TestApplyAltcoinParams ensures that the litecoin and viacoin params are
+// applied field by field to the bitcoin params through applyAltcoinParams.
+func TestApplyAltcoinParams(t *testing.T) {
+	t.Parallel()
+
+	lite := liteTestNetParams
+	via := viaTestNetParams
+
+	liteCheckpoints := make([]bitcoinCfg.Checkpoint, len(lite.Checkpoints))
+	for i, checkpoint := range lite.Checkpoints {
+		hash := chainhash.Hash(*checkpoint.Hash)
+		liteCheckpoints[i].Height = checkpoint.Height
+		liteCheckpoints[i].Hash = &hash
+	}
+	viaCheckpoints := make([]bitcoinCfg.Checkpoint, len(via.Checkpoints))
+	for i, checkpoint := range via.Checkpoints {
+		hash := chainhash.Hash(*checkpoint.Hash)
+		viaCheckpoints[i].Height = checkpoint.Height
+		viaCheckpoints[i].Hash = &hash
+	}
+
+	tests := []struct {
+		name  string
+		apply func(*bitcoinNetParams)
+		src   altcoinNetParams
+
+		// The expected values of the fields of the resulting params,
+		// taken directly from the params of the altcoin.
+		netName          string
+		net              wire.BitcoinNet
+		defaultPort      string
+		coinbaseMaturity uint16
+		genesisHash      []byte
+		addrIDs          [5]byte
+		bech32HRP        string
+		hdPrivateKeyID   [4]byte
+		hdPublicKeyID    [4]byte
+		hdCoinType       uint32
+		checkpoints      []bitcoinCfg.Checkpoint
+		rpcPort          string
+	}{
+		{
+			name:             "litecoin",
+			apply:            applyLitecoinParams,
+			src:              lite.altcoinParams(),
+			netName:          lite.Name,
+			net:              wire.BitcoinNet(lite.Net),
+			defaultPort:      lite.DefaultPort,
+			coinbaseMaturity: lite.CoinbaseMaturity,
+			genesisHash:      lite.GenesisHash[:],
+			addrIDs: [5]byte{
+				lite.PubKeyHashAddrID, lite.ScriptHashAddrID,
+				lite.PrivateKeyID, lite.WitnessPubKeyHashAddrID,
+				lite.WitnessScriptHashAddrID,
+			},
+			bech32HRP:      lite.Bech32HRPSegwit,
+			hdPrivateKeyID: lite.HDPrivateKeyID,
+			hdPublicKeyID:  lite.HDPublicKeyID,
+			hdCoinType:     lite.HDCoinType,
+			checkpoints:    liteCheckpoints,
+			rpcPort:        lite.rpcPort,
+		},
+		{
+			name:             "viacoin",
+			apply:            applyViacoinParams,
+			src:              via.altcoinParams(),
+			netName:          via.Name,
+			net:              wire.BitcoinNet(via.Net),
+			defaultPort:      via.DefaultPort,
+			coinbaseMaturity: via.CoinbaseMaturity,
+			genesisHash:      via.GenesisHash[:],
+			addrIDs: [5]byte{
+				via.PubKeyHashAddrID, via.ScriptHashAddrID,
+				via.PrivateKeyID, via.WitnessPubKeyHashAddrID,
+				via.WitnessScriptHashAddrID,
+			},
+			bech32HRP:      via.Bech32HRPSegwit,
+			hdPrivateKeyID: via.HDPrivateKeyID,
+			hdPublicKeyID:  via.HDPublicKeyID,
+			hdCoinType:     via.HDCoinType,
+			checkpoints:    viaCheckpoints,
+			rpcPort:        via.rpcPort,
+		},
+	}
+
+	for _, test := range tests {
+		p := newTestBitcoinParams()
+		applyAltcoinParams(&p, test.src)
+
+		switch {
+		case p.Name != test.netName:
+			t.Fatalf("%v: expected name %v, got %v", test.name,
+				test.netName, p.Name)
+		case p.Net != test.net:
+			t.Fatalf("%v: expected net %v, got %v", test.name,
+				test.net, p.Net)
+		case p.DefaultPort != test.defaultPort:
+			t.Fatalf("%v: expected port %v, got %v", test.name,
+				test.defaultPort, p.DefaultPort)
+		case p.CoinbaseMaturity != test.coinbaseMaturity:
+			t.Fatalf("%v: expected coinbase maturity %v, got %v",
+				test.name, test.coinbaseMaturity,
+				p.CoinbaseMaturity)
+		case string(p.GenesisHash[:]) != string(test.genesisHash):
+			t.Fatalf("%v: genesis hash mismatch", test.name)
+		case p.PubKeyHashAddrID != test.addrIDs[0] ||
+			p.ScriptHashAddrID != test.addrIDs[1] ||
+			p.PrivateKeyID != test.addrIDs[2] ||
+			p.WitnessPubKeyHashAddrID != test.addrIDs[3] ||
+			p.WitnessScriptHashAddrID != test.addrIDs[4]:
+			t.Fatalf("%v: address encoding magics mismatch",
+				test.name)
+		case p.Bech32HRPSegwit != test.bech32HRP:
+			t.Fatalf("%v: expected bech32 hrp %v, got %v",
+				test.name, test.bech32HRP, p.Bech32HRPSegwit)
+		case p.HDPrivateKeyID != test.hdPrivateKeyID ||
+			p.HDPublicKeyID != test.hdPublicKeyID:
+			t.Fatalf("%v: hd key ids mismatch", test.name)
+		case p.HDCoinType != test.hdCoinType:
+			t.Fatalf("%v: expected hd coin type %v, got %v",
+				test.name, test.hdCoinType, p.HDCoinType)
+		case p.rpcPort != test.rpcPort:
+			t.Fatalf("%v: expected rpc port %v, got %v", test.name,
+				test.rpcPort, p.rpcPort)
+		case len(p.Checkpoints) != len(test.checkpoints):
+			t.Fatalf("%v: expected %v checkpoints, got %v",
+				test.name, len(test.checkpoints),
+				len(p.Checkpoints))
+		}
+		for i, checkpoint := range p.Checkpoints {
+			expected := test.checkpoints[i]
+			if checkpoint.Height != expected.Height ||
+				*checkpoint.Hash != *expected.Hash {
+
+				t.Fatalf("%v: checkpoint #%v mismatch", test.name,
+					i)
+			}
+		}
+
+		// The wrapper of the altcoin should produce the exact same
+		// params as the generic function.
+		wrapped := newTestBitcoinParams()
+		test.apply(&wrapped)
+		if *wrapped.GenesisHash != *p.GenesisHash ||
+			wrapped.Name != p.Name ||
+			wrapped.rpcPort != p.rpcPort ||
+			len(wrapped.Checkpoints) != len(p.Checkpoints) {
+
+			t.Fatalf("%v: wrapper params differ from generic "+
+				"params", test.name)
+		}
+	}
+}
+
+// TestIsMainNet ensures that the main network is detected from the params of
+// a chain, including those derived from the params of an altcoin.
+func TestIsMainNet(t *testing.T) {
+	t.Parallel()
+
+	viaMainNetParams := viacoinNetParams{
+		Params: &viacoinCfg.MainNetParams,
+	}
+
+	viaMainNet := newTestBitcoinParams()
+	applyAltcoinParams(&viaMainNet, viaMainNetParams.altcoinParams())
+
+	viaTestNet := newTestBitcoinParams()
+	applyViacoinParams(&viaTestNet)
+
+	tests := []struct {
+		name    string
+		params  bitcoinNetParams
+		mainNet bool
+	}{
+		{
+			name:    "viacoin mainnet",
+			params:  viaMainNet,
+			mainNet: true,
+		},
+		{
+			name:    "viacoin testnet",
+			params:  viaTestNet,
+			mainNet: false,
+		},
+		{
+			name:    "bitcoin testnet",
+			params:  bitcoinTestNetParams,
+			mainNet: false,
+		},
+		{
+			name:    "bitcoin simnet",
+			params:  bitcoinSimNetParams,
+			mainNet: false,
+		},
+	}
+
+	for _, test := range tests {
+		if isMainNet(test.params) != test.mainNet {
+			t.Fatalf("%v: expected mainnet=%v", test.name,
+				test.mainNet)
+		}
+	}
+}
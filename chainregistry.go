@@ -86,9 +86,13 @@ func (c chainCode) String() string {
 		return "litecoin"
 	case viacoinChain:
 		return "viacoin"
-	default:
-		return "kekcoin"
 	}
+
+	if chain, ok := customChains[c]; ok {
+		return chain.name
+	}
+
+	return "kekcoin"
 }
 
 // chainControl couples the three primary interfaces lnd utilizes for a
@@ -128,6 +132,10 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB) (*chainControl
 		homeChainConfig = cfg.Viacoin
 	}
 
+	if chainCfg, ok := cfg.customChains[registeredChains.PrimaryChain()]; ok {
+		homeChainConfig = chainCfg
+	}
+
 	ltndLog.Infof("Primary chain is set to: %v",
 		registeredChains.PrimaryChain())
 
@@ -150,6 +158,15 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB) (*chainControl
 			FeeRate: 100, // Needs double check
 		}
 	default:
+		chain, ok := customChains[registeredChains.PrimaryChain()]
+		if ok {
+			cc.routingPolicy = chain.params.RoutingPolicy
+			cc.feeEstimator = lnwallet.StaticFeeEstimator{
+				FeeRate: chain.params.FeeRate,
+			}
+			break
+		}
+
 		return nil, nil, fmt.Errorf("Default routing policy for "+
 			"chain %v is unknown", registeredChains.PrimaryChain())
 	}
@@ -312,6 +329,7 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB) (*chainControl
 		ChainIO:            cc.chainIO,
 		DefaultConstraints: defaultChannelConstraints,
 		NetParams:          *activeNetParams.Params,
+		ChangeAddressType:  cfg.changeAddrType,
 	}
 	wallet, err := lnwallet.NewLightningWallet(walletCfg)
 	if err != nil {
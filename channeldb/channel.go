@@ -839,7 +839,10 @@ type ChannelCloseSummary struct {
 	// funds have been swept.
 	IsPending bool
 
-	// TODO(roasbeef): also store short_chan_id?
+	// ShortChanID is the short channel ID of the channel. It's blank for
+	// channels whose funding transaction never confirmed, and for those
+	// closed before it was recorded within the summary.
+	ShortChanID lnwire.ShortChannelID
 }
 
 // CloseChannel closes a previously active lightning channel. Closing a channel
@@ -1031,6 +1034,11 @@ func serializeChannelCloseSummary(w io.Writer, cs *ChannelCloseSummary) error {
 		return err
 	}
 
+	chanID := cs.ShortChanID.ToUint64()
+	if err := binary.Write(w, byteOrder, chanID); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1092,6 +1100,18 @@ func deserializeCloseChannelSummary(r io.Reader) (*ChannelCloseSummary, error) {
 		return nil, err
 	}
 
+	// Summaries written before the short channel ID was recorded end
+	// right after the public key of the remote peer.
+	var chanID uint64
+	err = binary.Read(r, byteOrder, &chanID)
+	switch {
+	case err == io.EOF:
+		return c, nil
+	case err != nil:
+		return nil, err
+	}
+	c.ShortChanID = lnwire.NewShortChanIDFromInt(chanID)
+
 	return c, nil
 }
 
@@ -688,6 +688,7 @@ func TestFetchClosedChannels(t *testing.T) {
 		TimeLockedBalance: state.LocalBalance.ToSatoshis() + 10000,
 		CloseType:         ForceClose,
 		IsPending:         true,
+		ShortChanID:       chanOpenLoc,
 	}
 	if err := state.CloseChannel(summary); err != nil {
 		t.Fatalf("unable to close channel: %v", err)
@@ -746,3 +747,37 @@ func TestFetchClosedChannels(t *testing.T) {
 			"got %v", 0, len(closed))
 	}
 }
+
+// TestLegacyCloseSummary ensures that close summaries written before the
+// short channel ID was recorded within them can still be read.
+func TestLegacyCloseSummary(t *testing.T) {
+	t.Parallel()
+
+	summary := &ChannelCloseSummary{
+		ChanPoint:      wire.OutPoint{Index: 1},
+		ClosingTXID:    rev,
+		RemotePub:      pubKey,
+		Capacity:       btcutil.Amount(10000),
+		SettledBalance: btcutil.Amount(5000),
+		CloseType:      CooperativeClose,
+		ShortChanID:    lnwire.NewShortChanIDFromInt(1),
+	}
+
+	var b bytes.Buffer
+	if err := serializeChannelCloseSummary(&b, summary); err != nil {
+		t.Fatalf("unable to serialize summary: %v", err)
+	}
+
+	// The legacy encoding lacks the trailing short channel ID.
+	legacy := b.Bytes()[:b.Len()-8]
+	decoded, err := deserializeCloseChannelSummary(bytes.NewReader(legacy))
+	if err != nil {
+		t.Fatalf("unable to deserialize legacy summary: %v", err)
+	}
+
+	summary.ShortChanID = lnwire.ShortChannelID{}
+	if !reflect.DeepEqual(summary, decoded) {
+		t.Fatalf("summaries don't match: expected %v got %v",
+			spew.Sdump(summary), spew.Sdump(decoded))
+	}
+}
@@ -40,6 +40,12 @@ var (
 			number:    0,
 			migration: nil,
 		},
+		{
+			// The version of waiting proofs was added to their
+			// serialization.
+			number:    1,
+			migration: migrateWaitingProofVersion,
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
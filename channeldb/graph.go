@@ -811,45 +811,69 @@ func delChannelByEdge(edges *bolt.Bucket, edgeIndex *bolt.Bucket,
 // determined tby the lexicographical ordering of the identity public keys of
 // the nodes on either side of the channel.
 func (c *ChannelGraph) UpdateEdgePolicy(edge *ChannelEdgePolicy) error {
+	return c.UpdateEdgePolicies([]*ChannelEdgePolicy{edge})
+}
+
+// UpdateEdgePolicies updates the routing policies of the passed directed
+// edges, as with UpdateEdgePolicy, within a single database transaction. If
+// any of the edges can't be updated, then none of them are.
+func (c *ChannelGraph) UpdateEdgePolicies(edges []*ChannelEdgePolicy) error {
 	return c.db.Update(func(tx *bolt.Tx) error {
-		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
+		edgesBucket, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
 		}
-		edgeIndex, err := edges.CreateBucketIfNotExists(edgeIndexBucket)
+		edgeIndex, err := edgesBucket.CreateBucketIfNotExists(
+			edgeIndexBucket,
+		)
 		if err != nil {
 			return err
 		}
 
-		// Create the channelID key be converting the channel ID
-		// integer into a byte slice.
-		var chanID [8]byte
-		byteOrder.PutUint64(chanID[:], edge.ChannelID)
-
-		// With the channel ID, we then fetch the value storing the two
-		// nodes which connect this channel edge.
-		nodeInfo := edgeIndex.Get(chanID[:])
-		if nodeInfo == nil {
-			return ErrEdgeNotFound
-		}
-
-		// Depending on the flags value passed above, either the first
-		// or second edge policy is being updated.
-		var fromNode, toNode []byte
-		if edge.Flags == 0 {
-			fromNode = nodeInfo[:33]
-			toNode = nodeInfo[33:67]
-		} else {
-			fromNode = nodeInfo[33:67]
-			toNode = nodeInfo[:33]
+		for _, edge := range edges {
+			err := updateEdgePolicy(edgesBucket, edgeIndex, edge)
+			if err != nil {
+				return err
+			}
 		}
 
-		// Finally, with the direction of the edge being updated
-		// identified, we update the on-disk edge representation.
-		return putChanEdgePolicy(edges, edge, fromNode, toNode)
+		return nil
 	})
 }
 
+// updateEdgePolicy updates the on-disk routing policy of the passed directed
+// edge within the given edge buckets.
+func updateEdgePolicy(edges, edgeIndex *bolt.Bucket,
+	edge *ChannelEdgePolicy) error {
+
+	// Create the channelID key be converting the channel ID integer into
+	// a byte slice.
+	var chanID [8]byte
+	byteOrder.PutUint64(chanID[:], edge.ChannelID)
+
+	// With the channel ID, we then fetch the value storing the two nodes
+	// which connect this channel edge.
+	nodeInfo := edgeIndex.Get(chanID[:])
+	if nodeInfo == nil {
+		return ErrEdgeNotFound
+	}
+
+	// Depending on the flags value passed above, either the first or
+	// second edge policy is being updated.
+	var fromNode, toNode []byte
+	if edge.Flags&lnwire.ChanUpdateDirection == 0 {
+		fromNode = nodeInfo[:33]
+		toNode = nodeInfo[33:67]
+	} else {
+		fromNode = nodeInfo[33:67]
+		toNode = nodeInfo[:33]
+	}
+
+	// Finally, with the direction of the edge being updated identified,
+	// we update the on-disk edge representation.
+	return putChanEdgePolicy(edges, edge, fromNode, toNode)
+}
+
 // LightningNode represents an individual vertex/node within the channel graph.
 // A node is connected to other nodes by one or more channel edges emanating
 // from it. As the graph is directed, a node will also have an incoming edge
@@ -1155,6 +1179,12 @@ type ChannelEdgeInfo struct {
 // channel. Each of these signatures signs the following digest: chanID ||
 // nodeID1 || nodeID2 || bitcoinKey1|| bitcoinKey2 || 2-byte-feature-len ||
 // features.
+//
+// NOTE: All four signatures must be stored, as they're required to
+// reconstruct the channel announcement relayed to other nodes. The signatures
+// are ECDSA signatures under four distinct keys, so they can't be aggregated
+// into a single signature. A more compact proof can only be stored once the
+// protocol moves to a signature scheme which supports aggregation.
 type ChannelAuthProof struct {
 	// NodeSig1 is the signature using the identity key of the node that is
 	// first in a lexicographical ordering of the serialized public keys of
@@ -1217,6 +1247,11 @@ type ChannelEdgePolicy struct {
 	// in millisatoshi.
 	MinHTLC lnwire.MilliSatoshi
 
+	// MaxHTLC is the largest value HTLC this node will accept, expressed
+	// in millisatoshi. It's only set if the ChanUpdateOptionMaxHtlc bit
+	// of the flags is set.
+	MaxHTLC lnwire.MilliSatoshi
+
 	// FeeBaseMSat is the base HTLC fee that will be charged for forwarding
 	// ANY HTLC, expressed in mSAT's.
 	FeeBaseMSat lnwire.MilliSatoshi
@@ -1225,6 +1260,12 @@ type ChannelEdgePolicy struct {
 	// HTLCs for each millionth of a satoshi forwarded.
 	FeeProportionalMillionths lnwire.MilliSatoshi
 
+	// ExtraOpaqueData is the set of data that was appended to the channel
+	// update of this policy, beyond the fields known to us, such as its
+	// inbound fee. As it's covered by the signature of the update, it's
+	// retained as is, such that the update can be re-created.
+	ExtraOpaqueData []byte
+
 	// Node is the LightningNode that this directed edge leads to. Using
 	// this pointer the channel graph can further be traversed.
 	Node *LightningNode
@@ -1504,6 +1545,21 @@ func putLightningNode(nodeBucket *bolt.Bucket, aliasBucket *bolt.Bucket, node *L
 			if _, err := b.Write(scratch[:2]); err != nil {
 				return err
 			}
+		} else if onion, ok := address.(*lnwire.OnionAddr); ok {
+			scratch[0] = uint8(onionAddr)
+			if _, err := b.Write(scratch[:1]); err != nil {
+				return err
+			}
+			err := wire.WriteVarString(&b, 0, onion.OnionService)
+			if err != nil {
+				return err
+			}
+			byteOrder.PutUint16(scratch[:2], uint16(onion.Port))
+			if _, err := b.Write(scratch[:2]); err != nil {
+				return err
+			}
+		} else {
+			return ErrUnknownAddressType
 		}
 	}
 
@@ -1630,6 +1686,17 @@ func deserializeLightningNode(r io.Reader) (*LightningNode, error) {
 			}
 			addr.Port = int(byteOrder.Uint16(scratch[:2]))
 			address = addr
+		case onionAddr:
+			addr := &lnwire.OnionAddr{}
+			addr.OnionService, err = wire.ReadVarString(r, 0)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := r.Read(scratch[:2]); err != nil {
+				return nil, err
+			}
+			addr.Port = int(byteOrder.Uint16(scratch[:2]))
+			address = addr
 		default:
 			return nil, ErrUnknownAddressType
 		}
@@ -1855,6 +1922,22 @@ func putChanEdgePolicy(edges *bolt.Bucket, edge *ChannelEdgePolicy, from, to []b
 		return err
 	}
 
+	// The maximum HTLC is appended to the end of the policy, such that
+	// policies written without it can still be read.
+	if edge.Flags&lnwire.ChanUpdateOptionMaxHtlc != 0 {
+		err := binary.Write(&b, byteOrder, uint64(edge.MaxHTLC))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Any extra data of the update follows, again such that policies
+	// written without it can still be read.
+	err = wire.WriteVarBytes(&b, 0, edge.ExtraOpaqueData)
+	if err != nil {
+		return err
+	}
+
 	return edges.Put(edgeKey[:], b.Bytes()[:])
 }
 
@@ -1970,6 +2053,26 @@ func deserializeChanEdgePolicy(r io.Reader,
 		return nil, err
 	}
 
+	if edge.Flags&lnwire.ChanUpdateOptionMaxHtlc != 0 {
+		if err := binary.Read(r, byteOrder, &n); err != nil {
+			return nil, err
+		}
+		edge.MaxHTLC = lnwire.MilliSatoshi(n)
+	}
+
+	// Policies written before the extra data was stored end here, in
+	// which case we'll leave it empty.
+	extraData, err := wire.ReadVarBytes(
+		r, 0, lnwire.MaxMessagePayload, "extra data",
+	)
+	switch {
+	case err == io.EOF:
+	case err != nil:
+		return nil, err
+	case len(extraData) > 0:
+		edge.ExtraOpaqueData = extraData
+	}
+
 	node, err := fetchLightningNode(nodes, pub[:])
 	if err != nil {
 		return nil, err
@@ -501,11 +501,13 @@ func TestEdgeInfoUpdates(t *testing.T) {
 		Signature:                 testSig,
 		ChannelID:                 chanID,
 		LastUpdate:                time.Unix(124234, 0),
-		Flags:                     1,
+		Flags:                     1 | lnwire.ChanUpdateOptionMaxHtlc,
 		TimeLockDelta:             99,
 		MinHTLC:                   2342135,
+		MaxHTLC:                   9823423,
 		FeeBaseMSat:               4352345,
 		FeeProportionalMillionths: 90392423,
+		ExtraOpaqueData:           []byte{0x01, 0x02, 0xaa, 0xbb},
 		Node: firstNode,
 		db:   db,
 	}
@@ -1043,6 +1045,10 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 		return fmt.Errorf("MinHTLC doesn't match: expected %v, "+
 			"got %v", a.MinHTLC, b.MinHTLC)
 	}
+	if a.MaxHTLC != b.MaxHTLC {
+		return fmt.Errorf("MaxHTLC doesn't match: expected %v, "+
+			"got %v", a.MaxHTLC, b.MaxHTLC)
+	}
 	if a.FeeBaseMSat != b.FeeBaseMSat {
 		return fmt.Errorf("FeeBaseMSat doesn't match: expected %v, "+
 			"got %v", a.FeeBaseMSat, b.FeeBaseMSat)
@@ -1052,6 +1058,10 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 			"expected %v, got %v", a.FeeProportionalMillionths,
 			b.FeeProportionalMillionths)
 	}
+	if !bytes.Equal(a.ExtraOpaqueData, b.ExtraOpaqueData) {
+		return fmt.Errorf("ExtraOpaqueData doesn't match: expected "+
+			"%x, got %x", a.ExtraOpaqueData, b.ExtraOpaqueData)
+	}
 	if err := compareNodes(a.Node, b.Node); err != nil {
 		return err
 	}
@@ -1061,3 +1071,102 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 	}
 	return nil
 }
+
+// TestUpdateEdgePolicies tests that a batch of edge policies is written within
+// a single transaction, such that none of them are written if any of them
+// references an unknown channel.
+func TestUpdateEdgePolicies(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	node1, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	node2, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	for _, node := range []*LightningNode{node1, node2} {
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	// We'll create a few channels between the two nodes, along with a
+	// policy for each of them.
+	const numChannels = 3
+	policies := make([]*ChannelEdgePolicy, 0, numChannels)
+	for i := 0; i < numChannels; i++ {
+		chanID := uint64(i + 1)
+		op := wire.OutPoint{
+			Hash: sha256.Sum256([]byte{byte(i)}),
+		}
+
+		edgeInfo := &ChannelEdgeInfo{
+			ChannelID:    chanID,
+			ChainHash:    key,
+			NodeKey1:     node1.PubKey,
+			NodeKey2:     node2.PubKey,
+			BitcoinKey1:  node1.PubKey,
+			BitcoinKey2:  node2.PubKey,
+			ChannelPoint: op,
+			Capacity:     1000,
+		}
+		if err := graph.AddChannelEdge(edgeInfo); err != nil {
+			t.Fatalf("unable to add edge: %v", err)
+		}
+
+		policy := randEdgePolicy(chanID, op, db)
+		policy.Node = node2
+		policy.Signature = testSig
+		policies = append(policies, policy)
+	}
+
+	// All policies should be written once applied within a single batch.
+	if err := graph.UpdateEdgePolicies(policies); err != nil {
+		t.Fatalf("unable to update edge policies: %v", err)
+	}
+	for _, policy := range policies {
+		_, dbPolicy, _, err := graph.FetchChannelEdgesByID(
+			policy.ChannelID,
+		)
+		if err != nil {
+			t.Fatalf("unable to fetch channel: %v", err)
+		}
+		if err := compareEdgePolicies(dbPolicy, policy); err != nil {
+			t.Fatalf("edge policy doesn't match: %v", err)
+		}
+	}
+
+	// A batch which also references an unknown channel should fail, and
+	// leave the policies of the known channels untouched.
+	updated := *policies[0]
+	updated.LastUpdate = updated.LastUpdate.Add(time.Second)
+	updated.FeeBaseMSat++
+	unknown := randEdgePolicy(numChannels+1, wire.OutPoint{}, db)
+	unknown.Node = node2
+	unknown.Signature = testSig
+
+	err = graph.UpdateEdgePolicies([]*ChannelEdgePolicy{&updated, unknown})
+	if err != ErrEdgeNotFound {
+		t.Fatalf("expected ErrEdgeNotFound, got %v", err)
+	}
+
+	_, dbPolicy, _, err := graph.FetchChannelEdgesByID(
+		policies[0].ChannelID,
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch channel: %v", err)
+	}
+	if err := compareEdgePolicies(dbPolicy, policies[0]); err != nil {
+		t.Fatalf("edge policy was modified: %v", err)
+	}
+}
@@ -1 +1,46 @@
 package channeldb
+
+import (
+	"github.com/boltdb/bolt"
+)
+
+// migrateWaitingProofVersion is a migration function which prefixes all
+// waiting proofs, which were previously stored without any version, with
+// version 0 of their serialization.
+func migrateWaitingProofVersion(tx *bolt.Tx) error {
+	bucket := tx.Bucket(waitingProofsBucketKey)
+	if bucket == nil {
+		return nil
+	}
+
+	// We can't modify the bucket while iterating over it, so we'll first
+	// gather all records, and then write them back in their new form.
+	records := make(map[string][]byte)
+	err := bucket.ForEach(func(k, v []byte) error {
+		// Skip nested buckets.
+		if v == nil {
+			return nil
+		}
+
+		record := make([]byte, 0, len(v)+1)
+		record = append(record, waitingProofVersion0)
+		record = append(record, v...)
+		records[string(k)] = record
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for k, record := range records {
+		if err := bucket.Put([]byte(k), record); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Migrated %v waiting proofs to versioned serialization",
+		len(records))
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package channeldb
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestMigrateWaitingProofVersion checks that waiting proofs stored without a
+// version can be read back after the migration.
+func TestMigrateWaitingProofVersion(t *testing.T) {
+	t.Parallel()
+
+	proof := NewWaitingProof(true, &lnwire.AnnounceSignatures{
+		ShortChannelID:   lnwire.NewShortChanIDFromInt(1),
+		NodeSignature:    testSig,
+		BitcoinSignature: testSig,
+	})
+
+	// Store the proof in its original, unversioned, form.
+	beforeMigration := func(d *DB) {
+		var b bytes.Buffer
+		b.WriteByte(1)
+		if err := proof.AnnounceSignatures.Encode(&b, 0); err != nil {
+			t.Fatalf("unable to encode announce signatures: %v", err)
+		}
+
+		err := d.Update(func(tx *bolt.Tx) error {
+			bucket, err := tx.CreateBucketIfNotExists(
+				waitingProofsBucketKey,
+			)
+			if err != nil {
+				return err
+			}
+
+			key := proof.Key()
+			return bucket.Put(key[:], b.Bytes())
+		})
+		if err != nil {
+			t.Fatalf("unable to store proof: %v", err)
+		}
+	}
+
+	afterMigration := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration wasn't applied")
+		}
+
+		store, err := NewWaitingProofStore(d)
+		if err != nil {
+			t.Fatalf("unable to create the waiting proofs storage: %v",
+				err)
+		}
+		migrated, err := store.Get(proof.Key())
+		if err != nil {
+			t.Fatalf("unable retrieve proof from storage: %v", err)
+		}
+		if !reflect.DeepEqual(proof, migrated) {
+			t.Fatalf("wrong proof migrated: expected %v, got %v",
+				spew.Sdump(proof), spew.Sdump(migrated))
+		}
+	}
+
+	applyMigration(t, beforeMigration, afterMigration,
+		migrateWaitingProofVersion, false)
+}
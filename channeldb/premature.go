@@ -0,0 +1,291 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/boltdb/bolt"
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+var (
+	// prematureAnnsBucketKey is the name of the bucket which holds the
+	// queue of premature announcements. Each announcement is keyed by a
+	// sequence number, such that the announcements are ordered by their
+	// time of insertion.
+	prematureAnnsBucketKey = []byte("prematureanns")
+
+	// ErrPrematureAnnTooLarge is returned when a single premature
+	// announcement exceeds the byte budget of the queue by itself.
+	ErrPrematureAnnTooLarge = errors.New("premature announcement " +
+		"exceeds the size of the queue")
+)
+
+// PrematureAnnouncement is an announcement which references a block beyond
+// our view of the chain tip, and is therefore held back until the chain has
+// reached the height at which it can be validated.
+type PrematureAnnouncement struct {
+	// Height is the block height at which the announcement is to be
+	// processed.
+	Height uint32
+
+	// IsRemote denotes whether the announcement was received from a
+	// remote peer, rather than being one of our own.
+	IsRemote bool
+
+	// Peer is the compressed public key of the peer which sent us the
+	// announcement. It's blank for announcements of our own.
+	Peer [33]byte
+
+	// Msg is the announcement itself.
+	Msg lnwire.Message
+}
+
+// Encode writes the premature announcement to the passed writer.
+func (p *PrematureAnnouncement) Encode(w io.Writer) error {
+	if err := binary.Write(w, byteOrder, p.Height); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, p.IsRemote); err != nil {
+		return err
+	}
+	if _, err := w.Write(p.Peer[:]); err != nil {
+		return err
+	}
+
+	_, err := lnwire.WriteMessage(w, p.Msg, 0)
+	return err
+}
+
+// Decode reads a premature announcement written by Encode from the passed
+// reader.
+func (p *PrematureAnnouncement) Decode(r io.Reader) error {
+	if err := binary.Read(r, byteOrder, &p.Height); err != nil {
+		return err
+	}
+	if err := binary.Read(r, byteOrder, &p.IsRemote); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(r, p.Peer[:]); err != nil {
+		return err
+	}
+
+	msg, err := lnwire.ReadMessage(r, 0)
+	if err != nil {
+		return err
+	}
+	p.Msg = msg
+
+	return nil
+}
+
+// PrematureAnnStore is a persistent FIFO queue of premature announcements,
+// which is bounded by the total size of the serialized announcements. Once
+// the queue is full, the oldest announcements are evicted to make room for
+// new ones.
+type PrematureAnnStore struct {
+	db *DB
+
+	// maxBytes is the byte budget of the queue.
+	maxBytes uint64
+
+	// numBytes is the total size of all queued announcements.
+	numBytes uint64
+
+	// numAnns is the number of queued announcements.
+	numAnns int
+}
+
+// NewPrematureAnnStore returns the queue of premature announcements within
+// the database, which retains at most maxBytes of announcements.
+func NewPrematureAnnStore(db *DB, maxBytes uint64) (*PrematureAnnStore, error) {
+	s := &PrematureAnnStore{
+		db:       db,
+		maxBytes: maxBytes,
+	}
+
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(prematureAnnsBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			s.numBytes += uint64(len(v))
+			s.numAnns++
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Add appends the passed announcement to the queue, evicting the oldest
+// announcements if needed to stay within the byte budget. The number of
+// evicted announcements is returned.
+func (s *PrematureAnnStore) Add(ann *PrematureAnnouncement) (int, error) {
+	var b bytes.Buffer
+	if err := ann.Encode(&b); err != nil {
+		return 0, err
+	}
+	if uint64(b.Len()) > s.maxBytes {
+		return 0, ErrPrematureAnnTooLarge
+	}
+
+	numBytes := s.numBytes
+	numAnns := s.numAnns
+	var numEvicted int
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(prematureAnnsBucketKey)
+		if err != nil {
+			return err
+		}
+
+		// The announcements are keyed by an increasing sequence
+		// number, so the oldest announcement is always the first one
+		// of the bucket.
+		c := bucket.Cursor()
+		for k, v := c.First(); k != nil &&
+			numBytes+uint64(b.Len()) > s.maxBytes; k, v = c.First() {
+
+			numBytes -= uint64(len(v))
+			numAnns--
+			numEvicted++
+
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+
+		seqNo, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var key [8]byte
+		byteOrder.PutUint64(key[:], seqNo)
+		if err := bucket.Put(key[:], b.Bytes()); err != nil {
+			return err
+		}
+
+		numBytes += uint64(b.Len())
+		numAnns++
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.numBytes = numBytes
+	s.numAnns = numAnns
+
+	return numEvicted, nil
+}
+
+// FetchMature removes all announcements which are to be processed at or
+// before the passed height from the queue, and returns them in the order in
+// which they were added.
+func (s *PrematureAnnStore) FetchMature(height uint32) (
+	[]*PrematureAnnouncement, error) {
+
+	return s.Remove(func(ann *PrematureAnnouncement) bool {
+		return ann.Height <= height
+	})
+}
+
+// Remove removes all announcements for which the passed match function
+// returns true from the queue, and returns them in the order in which they
+// were added.
+func (s *PrematureAnnStore) Remove(match func(*PrematureAnnouncement) bool) (
+	[]*PrematureAnnouncement, error) {
+
+	var (
+		anns     []*PrematureAnnouncement
+		numBytes = s.numBytes
+		numAnns  = s.numAnns
+	)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(prematureAnnsBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		var matchedKeys [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			ann := &PrematureAnnouncement{}
+			if err := ann.Decode(bytes.NewReader(v)); err != nil {
+				return err
+			}
+			if !match(ann) {
+				return nil
+			}
+
+			key := make([]byte, len(k))
+			copy(key, k)
+
+			anns = append(anns, ann)
+			matchedKeys = append(matchedKeys, key)
+			numBytes -= uint64(len(v))
+			numAnns--
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range matchedKeys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.numBytes = numBytes
+	s.numAnns = numAnns
+
+	return anns, nil
+}
+
+// Len returns the number of queued announcements.
+func (s *PrematureAnnStore) Len() int {
+	return s.numAnns
+}
+
+// HeightCounts returns the number of queued announcements for each height at
+// which announcements are to be processed.
+func (s *PrematureAnnStore) HeightCounts() (map[uint32]int, error) {
+	counts := make(map[uint32]int)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(prematureAnnsBucketKey)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			ann := &PrematureAnnouncement{}
+			if err := ann.Decode(bytes.NewReader(v)); err != nil {
+				return err
+			}
+
+			counts[ann.Height]++
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
@@ -2,6 +2,7 @@ package channeldb
 
 import (
 	"encoding/binary"
+	"time"
 
 	"io"
 
@@ -27,6 +28,36 @@ var (
 		"key already exist")
 )
 
+const (
+	// waitingProofExportVersion0 is the initial version of the format in
+	// which the contents of the waiting proof store are exported. Its
+	// proofs are serialized in version 0 of their serialization, without
+	// being prefixed by the version.
+	waitingProofExportVersion0 uint8 = 0
+
+	// waitingProofExportVersion1 is identical to version 0 of the export
+	// format, except that each proof is prefixed by its serialization
+	// version.
+	waitingProofExportVersion1 uint8 = 1
+
+	// waitingProofExportVersion is the current version of the format in
+	// which the contents of the waiting proof store are exported.
+	waitingProofExportVersion = waitingProofExportVersion1
+
+	// waitingProofVersion0 is the initial serialization version of a
+	// waiting proof, consisting of the side the proof came from followed
+	// by the announcement signatures.
+	waitingProofVersion0 uint8 = 0
+
+	// waitingProofVersion1 extends version 0 of the serialization with the
+	// time at which the proof was created.
+	waitingProofVersion1 uint8 = 1
+
+	// waitingProofVersion is the current serialization version of a
+	// waiting proof.
+	waitingProofVersion = waitingProofVersion1
+)
+
 // WaitingProofStore is the bold db map-like storage for half announcement
 // signatures. The one responsibility of this storage is to be able to
 // retrieve waiting proofs after client restart.
@@ -161,6 +192,85 @@ func (s *WaitingProofStore) Get(key WaitingProofKey) (*WaitingProof, error) {
 	return proof, err
 }
 
+// ExportWaitingProofs writes all waiting proofs within the store to the
+// passed writer, such that they can be restored within another store through
+// ImportWaitingProofs. This allows in-flight channel announcement proofs to
+// survive the migration of a node without the remote party having to re-send
+// its half of the proof.
+func (s *WaitingProofStore) ExportWaitingProofs(w io.Writer) error {
+	var proofs []*WaitingProof
+	err := s.ForAll(func(proof *WaitingProof) error {
+		proofs = append(proofs, proof)
+		return nil
+	})
+	if err != nil && err != ErrWaitingProofNotFound {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, waitingProofExportVersion); err != nil {
+		return err
+	}
+	if err := binary.Write(w, byteOrder, uint32(len(proofs))); err != nil {
+		return err
+	}
+
+	for _, proof := range proofs {
+		if err := proof.Encode(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportWaitingProofs adds all waiting proofs written by ExportWaitingProofs
+// to the passed reader to the store. Any proofs which the store already
+// contains are skipped, such that an import can safely be repeated. Exports
+// of all prior versions of the format are supported.
+func (s *WaitingProofStore) ImportWaitingProofs(r io.Reader) error {
+	var version uint8
+	if err := binary.Read(r, byteOrder, &version); err != nil {
+		return err
+	}
+	if version > waitingProofExportVersion {
+		return errors.Errorf("unknown waiting proof export version %v",
+			version)
+	}
+
+	var numProofs uint32
+	if err := binary.Read(r, byteOrder, &numProofs); err != nil {
+		return err
+	}
+
+	// We'll decode all proofs before adding any of them to the store, so
+	// that a truncated export doesn't result in a partial import.
+	var proofs []*WaitingProof
+	for i := uint32(0); i < numProofs; i++ {
+		proof := &WaitingProof{}
+
+		var err error
+		if version == waitingProofExportVersion0 {
+			err = proof.decode(r, waitingProofVersion0)
+		} else {
+			err = proof.Decode(r)
+		}
+		if err != nil {
+			return err
+		}
+
+		proofs = append(proofs, proof)
+	}
+
+	for _, proof := range proofs {
+		err := s.Add(proof)
+		if err != nil && err != ErrWaitingProofAlreadyExist {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // WaitingProofKey is the proof key which uniquely identifies the waiting
 // proof object. The goal of this key is distinguish the local and remote
 // proof for the same channel id.
@@ -173,6 +283,10 @@ type WaitingProofKey [9]byte
 type WaitingProof struct {
 	*lnwire.AnnounceSignatures
 	isRemote bool
+
+	// Timestamp is the time at which the proof was created. It's zero for
+	// proofs which were stored before timestamps were recorded.
+	Timestamp time.Time
 }
 
 // NewWaitingProof constructs a new waiting prof instance.
@@ -206,7 +320,12 @@ func (p *WaitingProof) Key() WaitingProofKey {
 }
 
 // Encode writes the internal representation of waiting proof in byte stream.
+// The proof is always written using the current serialization version.
 func (p *WaitingProof) Encode(w io.Writer) error {
+	if err := binary.Write(w, byteOrder, waitingProofVersion); err != nil {
+		return err
+	}
+
 	if err := binary.Write(w, byteOrder, p.isRemote); err != nil {
 		return err
 	}
@@ -215,12 +334,35 @@ func (p *WaitingProof) Encode(w io.Writer) error {
 		return err
 	}
 
-	return nil
+	// A zero timestamp is written as zero, rather than as the (negative)
+	// unix time of the zero time, so it's decoded as the zero time again.
+	var timestamp int64
+	if !p.Timestamp.IsZero() {
+		timestamp = p.Timestamp.Unix()
+	}
+
+	return binary.Write(w, byteOrder, timestamp)
 }
 
 // Decode reads the data from the byte stream and initializes the
-// waiting proof object with it.
+// waiting proof object with it. Fields which aren't present within the
+// serialization version of the proof are left at their default values.
 func (p *WaitingProof) Decode(r io.Reader) error {
+	var version uint8
+	if err := binary.Read(r, byteOrder, &version); err != nil {
+		return err
+	}
+	if version > waitingProofVersion {
+		return errors.Errorf("unknown waiting proof version %v",
+			version)
+	}
+
+	return p.decode(r, version)
+}
+
+// decode reads the fields of a waiting proof of the passed serialization
+// version, which isn't prefixed to them, from the byte stream.
+func (p *WaitingProof) decode(r io.Reader, version uint8) error {
 	if err := binary.Read(r, byteOrder, &p.isRemote); err != nil {
 		return err
 	}
@@ -231,5 +373,18 @@ func (p *WaitingProof) Decode(r io.Reader) error {
 	}
 
 	(*p).AnnounceSignatures = msg
+
+	if version < waitingProofVersion1 {
+		return nil
+	}
+
+	var timestamp int64
+	if err := binary.Read(r, byteOrder, &timestamp); err != nil {
+		return err
+	}
+	if timestamp != 0 {
+		p.Timestamp = time.Unix(timestamp, 0)
+	}
+
 	return nil
 }
@@ -1,10 +1,13 @@
 package channeldb
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
 	"reflect"
 
+	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/viacoin/lnd/lnwire"
 )
@@ -57,3 +60,173 @@ func TestWaitingProofStore(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestWaitingProofExportImport tests that the waiting proofs exported from a
+// store are restored within a fresh store once imported.
+func TestWaitingProofExportImport(t *testing.T) {
+	t.Parallel()
+
+	db, cleanup, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanup()
+
+	store, err := NewWaitingProofStore(db)
+	if err != nil {
+		t.Fatalf("unable to create the waiting proofs storage: %v",
+			err)
+	}
+
+	// We'll populate the store with two half proofs, one of our own and
+	// one of the remote party.
+	proofs := []*WaitingProof{
+		NewWaitingProof(false, &lnwire.AnnounceSignatures{
+			ShortChannelID:   lnwire.NewShortChanIDFromInt(1),
+			NodeSignature:    testSig,
+			BitcoinSignature: testSig,
+		}),
+		NewWaitingProof(true, &lnwire.AnnounceSignatures{
+			ShortChannelID:   lnwire.NewShortChanIDFromInt(2),
+			NodeSignature:    testSig,
+			BitcoinSignature: testSig,
+		}),
+	}
+	for _, proof := range proofs {
+		if err := store.Add(proof); err != nil {
+			t.Fatalf("unable add proof to storage: %v", err)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := store.ExportWaitingProofs(&b); err != nil {
+		t.Fatalf("unable to export proofs: %v", err)
+	}
+
+	freshDB, freshCleanup, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer freshCleanup()
+
+	freshStore, err := NewWaitingProofStore(freshDB)
+	if err != nil {
+		t.Fatalf("unable to create the waiting proofs storage: %v",
+			err)
+	}
+	if err := freshStore.ImportWaitingProofs(&b); err != nil {
+		t.Fatalf("unable to import proofs: %v", err)
+	}
+
+	// Both proofs should now be retrievable from the fresh store.
+	for _, proof := range proofs {
+		imported, err := freshStore.Get(proof.Key())
+		if err != nil {
+			t.Fatalf("unable retrieve proof from storage: %v", err)
+		}
+		if !reflect.DeepEqual(proof, imported) {
+			t.Fatalf("wrong proof imported: expected %v, got %v",
+				spew.Sdump(proof), spew.Sdump(imported))
+		}
+	}
+
+	// An export of version 0 of the format, whose proofs aren't prefixed
+	// by their serialization version, should be imported as well.
+	v0Proof := NewWaitingProof(true, &lnwire.AnnounceSignatures{
+		ShortChannelID:   lnwire.NewShortChanIDFromInt(3),
+		NodeSignature:    testSig,
+		BitcoinSignature: testSig,
+	})
+
+	var v0 bytes.Buffer
+	v0.WriteByte(waitingProofExportVersion0)
+	v0.Write([]byte{0, 0, 0, 1})
+	v0.WriteByte(1)
+	if err := v0Proof.AnnounceSignatures.Encode(&v0, 0); err != nil {
+		t.Fatalf("unable to encode announce signatures: %v", err)
+	}
+
+	if err := freshStore.ImportWaitingProofs(&v0); err != nil {
+		t.Fatalf("unable to import v0 export: %v", err)
+	}
+	imported, err := freshStore.Get(v0Proof.Key())
+	if err != nil {
+		t.Fatalf("unable retrieve proof from storage: %v", err)
+	}
+	if !reflect.DeepEqual(v0Proof, imported) {
+		t.Fatalf("wrong v0 proof imported: expected %v, got %v",
+			spew.Sdump(v0Proof), spew.Sdump(imported))
+	}
+
+	// An export of an unknown version should be rejected.
+	unknownVersion := bytes.NewReader([]byte{
+		waitingProofExportVersion + 1, 0, 0, 0, 0,
+	})
+	if err := freshStore.ImportWaitingProofs(unknownVersion); err == nil {
+		t.Fatal("import of unknown version should fail")
+	}
+}
+
+// TestWaitingProofDecodeVersions tests that waiting proofs of all
+// serialization versions are decoded, with the fields absent from older
+// versions left at their defaults.
+func TestWaitingProofDecodeVersions(t *testing.T) {
+	t.Parallel()
+
+	sigs := &lnwire.AnnounceSignatures{
+		ShortChannelID:   lnwire.NewShortChanIDFromInt(1),
+		NodeSignature:    testSig,
+		BitcoinSignature: testSig,
+	}
+
+	var sigsBytes bytes.Buffer
+	if err := sigs.Encode(&sigsBytes, 0); err != nil {
+		t.Fatalf("unable to encode announce signatures: %v", err)
+	}
+
+	// A version 0 record consists of only the side the proof came from
+	// and the announcement signatures.
+	var v0 bytes.Buffer
+	v0.WriteByte(waitingProofVersion0)
+	v0.WriteByte(1)
+	v0.Write(sigsBytes.Bytes())
+
+	proof := &WaitingProof{}
+	if err := proof.Decode(&v0); err != nil {
+		t.Fatalf("unable to decode v0 proof: %v", err)
+	}
+	expected := NewWaitingProof(true, sigs)
+	if !reflect.DeepEqual(expected, proof) {
+		t.Fatalf("wrong v0 proof decoded: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(proof))
+	}
+
+	// A version 1 record additionally carries the time at which the proof
+	// was created.
+	expected = NewWaitingProof(false, sigs)
+	expected.Timestamp = time.Unix(1500000000, 0)
+
+	var v1 bytes.Buffer
+	if err := expected.Encode(&v1); err != nil {
+		t.Fatalf("unable to encode proof: %v", err)
+	}
+	if v1.Bytes()[0] != waitingProofVersion1 {
+		t.Fatalf("expected proof to be encoded with version %v, got %v",
+			waitingProofVersion1, v1.Bytes()[0])
+	}
+
+	proof = &WaitingProof{}
+	if err := proof.Decode(&v1); err != nil {
+		t.Fatalf("unable to decode v1 proof: %v", err)
+	}
+	if !reflect.DeepEqual(expected, proof) {
+		t.Fatalf("wrong v1 proof decoded: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(proof))
+	}
+
+	// A record of an unknown version should fail to decode.
+	unknown := bytes.NewReader([]byte{waitingProofVersion + 1, 0})
+	if err := (&WaitingProof{}).Decode(unknown); err == nil {
+		t.Fatal("decoding proof of unknown version should fail")
+	}
+}
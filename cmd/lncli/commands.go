@@ -1724,6 +1724,74 @@ func feeReport(ctx *cli.Context) error {
 	return nil
 }
 
+var estimateFundingFeeCommand = cli.Command{
+	Name:  "estimatefundingfee",
+	Usage: "estimate the on-chain fee of funding a channel",
+	Description: "Returns the estimated on-chain fee of the funding " +
+		"transaction for a channel of the given size, targeting " +
+		"confirmation within the given number of blocks.",
+	ArgsUsage: "local_amt [conf_target]",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "local_amt",
+			Usage: "the size of the channel to be funded",
+		},
+		cli.Int64Flag{
+			Name:  "conf_target",
+			Usage: "the number of blocks to target for confirmation",
+			Value: 6,
+		},
+	},
+	Action: estimateFundingFee,
+}
+
+func estimateFundingFee(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		localAmt   int64
+		confTarget int64
+		err        error
+	)
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("local_amt"):
+		localAmt = ctx.Int64("local_amt")
+	case args.Present():
+		localAmt, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode local amt: %v", err)
+		}
+		args = args.Tail()
+	default:
+		return fmt.Errorf("local amt argument missing")
+	}
+
+	confTarget = ctx.Int64("conf_target")
+	if !ctx.IsSet("conf_target") && args.Present() {
+		confTarget, err = strconv.ParseInt(args.First(), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unable to decode conf target: %v",
+				err)
+		}
+	}
+
+	req := &lnrpc.EstimateFundingFeeRequest{
+		LocalFundingAmount: localAmt,
+		TargetConf:         int32(confTarget),
+	}
+	resp, err := client.EstimateFundingFee(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var updateFeesCommand = cli.Command{
 	Name:      "updatefees",
 	Usage:     "update the fee policy for all channels, or a single channel",
@@ -176,6 +176,7 @@ func main() {
 		signMessageCommand,
 		verifyMessageCommand,
 		feeReportCommand,
+		estimateFundingFeeCommand,
 		updateFeesCommand,
 	}
 
@@ -16,6 +16,8 @@ import (
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcutil"
 	"github.com/viacoin/lnd/brontide"
+	"github.com/viacoin/lnd/discovery"
+	"github.com/viacoin/lnd/lnwallet"
 	"github.com/viacoin/lnd/lnwire"
 )
 
@@ -35,6 +37,15 @@ const (
 	defaultRPCHost            = "localhost"
 	defaultMaxPendingChannels = 1
 	defaultNumChanConfs       = 1
+	defaultMinChanSize        = 0
+	defaultMaxChanSize        = int64(maxFundingAmount)
+	defaultChangeAddressType  = "p2wkh"
+	defaultInitialSync        = "full"
+	defaultTrickleDelay       = 300 * time.Millisecond
+	defaultRetransmitDelay    = 30 * time.Minute
+	defaultMinChansToAnnounce = 1
+	defaultRouterRetryBackoff = 100 * time.Millisecond
+	defaultStartupBackoff     = time.Second
 )
 
 var (
@@ -105,13 +116,16 @@ type config struct {
 	ReadMacPath  string `long:"readonlymacaroonpath" description:"Path to write the read-only macaroon for lnd's RPC and REST services if it doesn't exist"`
 	LogDir       string `long:"logdir" description:"Directory to log output."`
 
-	Listeners   []string `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 9735)"`
-	ExternalIPs []string `long:"externalip" description:"Add an ip to the list of local addresses we claim to listen on to peers"`
+	Listeners      []string `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 9735)"`
+	ExternalIPs    []string `long:"externalip" description:"Add an ip to the list of local addresses we claim to listen on to peers"`
+	ExternalOnions []string `long:"externalonion" description:"Add a Tor onion service (host.onion[:port]) to the list of addresses we claim to be reachable at by peers"`
 
 	DebugLevel string `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 
 	Profile string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 
+	MetricsPort string `long:"metricsport" description:"Expose the metrics of the gossiper and the process in the Prometheus format on the given port at /metrics -- NOTE port must be between 1024 and 65536"`
+
 	PeerPort           int  `long:"peerport" description:"The port to listen on for incoming p2p connections"`
 	RPCPort            int  `long:"rpcport" description:"The port for the rpc server"`
 	RESTPort           int  `long:"restport" description:"The port for the REST server"`
@@ -119,6 +133,13 @@ type config struct {
 	HodlHTLC           bool `long:"hodlhtlc" description:"Activate the hodl HTLC mode.  With hodl HTLC mode, all incoming HTLCs will be accepted by the receiving node, but no attempt will be made to settle the payment with the sender."`
 	MaxPendingChannels int  `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
 
+	MinChanSize int64 `long:"minchansize" description:"The smallest channel size (in satoshis) that we should accept. Incoming channels smaller than this will be rejected."`
+	MaxChanSize int64 `long:"maxchansize" description:"The largest channel size (in satoshis) that we should accept or open. Incoming channels larger than this will be rejected."`
+
+	MaxAcceptedPushAmt int64 `long:"maxacceptedpushamt" description:"The largest push amount (in satoshis) that we should accept within an incoming channel. Incoming channels pushing more than this will be rejected. If zero, then any push amount is accepted."`
+
+	ChangeAddressType string `long:"changeaddresstype" description:"The type of address used for the change output of funding transactions {p2wkh, np2wkh}"`
+
 	Viacoin  *chainConfig `group:"Viacoin" namespace:"viacoin"`
 	Litecoin *chainConfig `group:"Litecoin" namespace:"litecoin"`
 	Bitcoin  *chainConfig `group:"Bitcoin" namespace:"bitcoin"`
@@ -130,6 +151,61 @@ type config struct {
 	Autopilot *autoPilotConfig `group:"autopilot" namespace:"autopilot"`
 
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
+
+	TrickleDelay    time.Duration `long:"trickledelay" description:"The interval at which batches of new announcements are broadcast to our peers, as a duration such as 300ms or 5s."`
+	RetransmitDelay time.Duration `long:"retransmitdelay" description:"The interval at which the announcements of our own channels are checked for staleness and re-broadcast, as a duration such as 30m or 1h."`
+
+	MinPeersForBroadcast int `long:"minpeersforbroadcast" description:"The minimum number of peers we must be connected to before our announcements are first broadcast to the network. Set to 0 to broadcast right away."`
+
+	MinChansToAnnounce int `long:"minchanstoannounce" description:"The minimum number of announced channels we must have before our node announcement is broadcast to the network. Set to 0 to always broadcast it."`
+
+	NoRelayGossip bool `long:"norelaygossip" description:"Run the gossiper in listen-only mode, in which announcements received from peers are still applied to our view of the graph, but never relayed to other peers. Our own announcements are broadcast as usual."`
+
+	LazyNodeAnnValidation bool `long:"lazynodeannvalidation" description:"Defer the validation of node announcements received from peers until their node is first used for path finding, lowering CPU usage on resource constrained clients. Until then, the announced information of such nodes is absent from the graph and isn't relayed."`
+
+	ResendSharedUpdates bool `long:"resendsharedupdates" description:"Resend the latest updates of the channels we share with a peer to that peer each time it connects."`
+
+	InitialSync        string        `long:"initialsync" description:"The strategy used to sync the channel graph with newly connected peers {full, timestamp}. The timestamp strategy only sends the announcements updated within the initialsynchorizon. The range-query strategy isn't supported yet."`
+	InitialSyncHorizon time.Duration `long:"initialsynchorizon" description:"The period within which announcements must have been updated to be sent to newly connected peers with the timestamp initial sync strategy, as a duration such as 24h."`
+
+	CompressGossip bool `long:"compressgossip" description:"Compress the channel graph sent to newly connected peers which support receiving compressed announcements."`
+
+	ValidateCheckpoints bool `long:"validatecheckpoints" description:"Reject channel announcements at heights covered by a checkpoint of the active network if our chain conflicts with that checkpoint."`
+
+	MaxWrongChainAnns int `long:"maxwrongchainanns" description:"The number of announcements for a chain other than ours that a peer may send us, beyond which we disconnect from it. Set to 0 to never disconnect from such peers."`
+
+	PruneClosedChans bool `long:"pruneclosedchans" description:"Check each received channel update against the chain, dropping updates of channels that have been closed and pruning those channels from the graph. This requires a chain query per update, which is costly in light client mode."`
+
+	RejectClosedChanAnns bool `long:"rejectclosedchananns" description:"Reject channel announcements received from peers for our own channels that we've closed, rather than storing those channels once more."`
+
+	SkipAnnValidation bool `long:"skipannvalidation" description:"DANGEROUS: Skip the signature validation of all gossip announcements received from peers. Only use this within closed test networks where all peers are trusted. Refused on mainnet."`
+
+	AnnAuditLog string `long:"annauditlog" description:"If set, each rejected gossip announcement will be recorded within this file along with the peer that sent it and the reason for its rejection."`
+
+	OutdatedUpdateGrace time.Duration `long:"outdatedupdategrace" description:"Accept, but don't relay, channel updates received from peers for a direction of a channel we hold no update for yet, if they're at most this much older than the update of the other direction, as a duration such as 10m. Older such updates are rejected. Set to 0 to accept all such updates."`
+
+	EdgeUpdateBatchSize int `long:"edgeupdatebatchsize" description:"The number of updated policies of our own channels, such as after a fee update, that are written to the graph within a single database transaction. Set to 0 to write each policy within a transaction of its own."`
+
+	RouterRetries      int           `long:"routerretries" description:"The number of times a gossip announcement is applied to the graph once more after failing with an error which may be transient, such as a momentary database failure, before it's dropped. Set to 0 to drop such announcements right away."`
+	RouterRetryBackoff time.Duration `long:"routerretrybackoff" description:"The delay before the first retry of a gossip announcement that failed to be applied to the graph, which doubles with each further retry, as a duration such as 100ms."`
+
+	StartupRetries      int           `long:"startupretries" description:"The number of times the gossiper retries registering for block notifications with the chain backend when starting, such that a momentary failure of the backend doesn't abort the startup. Set to 0 to abort right away."`
+	StartupRetryBackoff time.Duration `long:"startupretrybackoff" description:"The delay before the first retry of a failed startup registration of the gossiper, which doubles with each further retry, as a duration such as 1s."`
+
+	NurseryImport string `long:"nurseryimport" description:"If set, the outputs within this file, as written by the nurseryexport option of another node, are imported into the nursery on startup. This allows in-flight outputs to be migrated along with a node."`
+
+	NurseryExport string `long:"nurseryexport" description:"If set, all outputs incubated by the nursery are written to this file on shutdown, such that they can be imported into another node through the nurseryimport option."`
+
+	// customChains holds the config of each chain registered through
+	// RegisterChainParams. The options of these chains are parsed within
+	// the namespace of the chain's name.
+	customChains map[chainCode]*chainConfig
+
+	// changeAddrType is the parsed form of the ChangeAddressType option.
+	changeAddrType lnwallet.AddressType
+
+	// initialSync is the parsed form of the InitialSync option.
+	initialSync discovery.InitialSyncStrategy
 }
 
 // loadConfig initializes and parses the config using a config file and command
@@ -155,6 +231,15 @@ func loadConfig() (*config, error) {
 		RESTPort:            defaultRESTPort,
 		MaxPendingChannels:  defaultMaxPendingChannels,
 		DefaultNumChanConfs: defaultNumChanConfs,
+		MinChanSize:         defaultMinChanSize,
+		MaxChanSize:         defaultMaxChanSize,
+		ChangeAddressType:   defaultChangeAddressType,
+		InitialSync:         defaultInitialSync,
+		TrickleDelay:        defaultTrickleDelay,
+		RetransmitDelay:     defaultRetransmitDelay,
+		MinChansToAnnounce:  defaultMinChansToAnnounce,
+		RouterRetryBackoff:  defaultRouterRetryBackoff,
+		StartupRetryBackoff: defaultStartupBackoff,
 		Bitcoin: &chainConfig{
 			RPCHost: defaultRPCHost,
 			RPCCert: defaultBtcdRPCCertFile,
@@ -171,12 +256,17 @@ func loadConfig() (*config, error) {
 			MaxChannels: 5,
 			Allocation:  0.6,
 		},
+		customChains: defaultCustomChainConfigs(),
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
 	// file.
 	preCfg := defaultCfg
-	if _, err := flags.Parse(&preCfg); err != nil {
+	preParser, err := newConfigParser(&preCfg, flags.Default)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := preParser.Parse(); err != nil {
 		return nil, err
 	}
 
@@ -211,13 +301,18 @@ func loadConfig() (*config, error) {
 	// Next, load any additional configuration options from the file.
 	var configFileError error
 	var cfg = defaultCfg // can't do a := 1 (:) only works inside functions we use var a = 1
-	if err := flags.IniParse(preCfg.ConfigFile, &cfg); err != nil {
+	parser, err := newConfigParser(&cfg, flags.Default)
+	if err != nil {
+		return nil, err
+	}
+	err = flags.NewIniParser(parser).ParseFile(preCfg.ConfigFile)
+	if err != nil {
 		configFileError = err
 	}
 
 	// Finally, parse the remaining command line options again to ensure
 	// they take precedence.
-	if _, err := flags.Parse(&cfg); err != nil {
+	if _, err := parser.Parse(); err != nil {
 		return nil, err
 	}
 
@@ -362,6 +457,37 @@ func loadConfig() (*config, error) {
 		registeredChains.RegisterPrimaryChain(bitcoinChain)
 	}
 
+	// Chains registered through RegisterChainParams are selected last, as
+	// they can't be active together with any of the built-in chains.
+	if err := applyCustomChainConfig(&cfg, funcName); err != nil {
+		return nil, err
+	}
+
+	// If we're in SPV mode, then we'll ensure that all the peers that
+	// we've been instructed to connect to are well formed, so a typo is
+	// caught now rather than later on as a connection failure.
+	if cfg.NeutrinoMode.Active {
+		err := validateNeutrinoPeers(
+			cfg.NeutrinoMode.AddPeers, activeNetParams.DefaultPort,
+		)
+		if err != nil {
+			err := fmt.Errorf("%s: invalid neutrino addpeer: %v",
+				funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+
+		err = validateNeutrinoPeers(
+			cfg.NeutrinoMode.ConnectPeers, activeNetParams.DefaultPort,
+		)
+		if err != nil {
+			err := fmt.Errorf("%s: invalid neutrino connect: %v",
+				funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			return nil, err
+		}
+	}
+
 	// Validate profile port number.
 	if cfg.Profile != "" {
 		profilePort, err := strconv.Atoi(cfg.Profile)
@@ -374,6 +500,140 @@ func loadConfig() (*config, error) {
 		}
 	}
 
+	// Validate gossip metrics port number.
+	if cfg.MetricsPort != "" {
+		metricsPort, err := strconv.Atoi(cfg.MetricsPort)
+		if err != nil || metricsPort < 1024 || metricsPort > 65535 {
+			str := "%s: The metrics port must be between " +
+				"1024 and 65535"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
+	// Validate the channel size limits. The maximum channel size can't
+	// exceed the current soft-limit of the protocol.
+	switch {
+	case cfg.MinChanSize < 0:
+		str := "%s: The minimum channel size must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+
+	case cfg.MaxChanSize > int64(maxFundingAmount):
+		str := "%s: The maximum channel size must not exceed %v"
+		err := fmt.Errorf(str, funcName, maxFundingAmount)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+
+	case cfg.MaxChanSize < cfg.MinChanSize:
+		str := "%s: The maximum channel size must be at least the " +
+			"minimum channel size"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+
+	case cfg.MaxAcceptedPushAmt < 0:
+		str := "%s: The maximum accepted push amount must not be " +
+			"negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Validate the gossip timings.
+	if cfg.TrickleDelay <= 0 || cfg.RetransmitDelay <= 0 {
+		str := "%s: The trickle and retransmit delays must be positive"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	if cfg.MinChansToAnnounce < 0 {
+		str := "%s: The minchanstoannounce must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.MinPeersForBroadcast < 0 {
+		str := "%s: The minpeersforbroadcast must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.MaxWrongChainAnns < 0 {
+		str := "%s: The maxwrongchainanns must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.OutdatedUpdateGrace < 0 {
+		str := "%s: The outdatedupdategrace must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.EdgeUpdateBatchSize < 0 {
+		str := "%s: The edgeupdatebatchsize must not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.RouterRetries < 0 || cfg.RouterRetryBackoff < 0 {
+		str := "%s: The routerretries and routerretrybackoff must " +
+			"not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+	if cfg.StartupRetries < 0 || cfg.StartupRetryBackoff < 0 {
+		str := "%s: The startupretries and startupretrybackoff must " +
+			"not be negative"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Skipping the validation of announcements is only tolerable within
+	// test networks, as anyone could otherwise feed us a bogus graph.
+	if cfg.SkipAnnValidation && isMainNet(activeNetParams) {
+		str := "%s: Announcement validation can't be skipped on mainnet"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
+	// Parse the change address type, so that it doesn't need to be parsed
+	// again once the wallet is created.
+	cfg.changeAddrType, err = parseChangeAddressType(cfg.ChangeAddressType)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Similarly, we'll parse the initial sync strategy, which requires a
+	// horizon if only the recent announcements are to be sent.
+	cfg.initialSync, err = parseInitialSyncStrategy(cfg.InitialSync)
+	if err != nil {
+		err := fmt.Errorf("%s: %v", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.initialSync == discovery.InitialSyncTimestamp &&
+		cfg.InitialSyncHorizon <= 0 {
+
+		str := "%s: The initialsynchorizon must be positive with the " +
+			"timestamp initial sync strategy"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
 	// At this point, we'll save the base data directory in order to ensure
 	// we don't store the macaroon database within any of the chain
 	// namespaced directories.
@@ -414,6 +674,20 @@ func loadConfig() (*config, error) {
 	cfg.TLSCertPath = cleanAndExpandPath(cfg.TLSCertPath)
 	cfg.TLSKeyPath = cleanAndExpandPath(cfg.TLSKeyPath)
 
+	// If an announcement audit log was specified, then we'll also ensure
+	// its path is expanded and cleaned.
+	if cfg.AnnAuditLog != "" {
+		cfg.AnnAuditLog = cleanAndExpandPath(cfg.AnnAuditLog)
+	}
+
+	// The same holds for the paths of nursery imports and exports.
+	if cfg.NurseryImport != "" {
+		cfg.NurseryImport = cleanAndExpandPath(cfg.NurseryImport)
+	}
+	if cfg.NurseryExport != "" {
+		cfg.NurseryExport = cleanAndExpandPath(cfg.NurseryExport)
+	}
+
 	// Initialize logging at the default logging level.
 	initLogRotator(filepath.Join(cfg.LogDir, defaultLogFilename))
 
@@ -435,6 +709,46 @@ func loadConfig() (*config, error) {
 	return &cfg, nil
 }
 
+// parseChangeAddressType parses the passed change address type option into
+// the address type used by the wallet.
+func parseChangeAddressType(addrType string) (lnwallet.AddressType, error) {
+	switch addrType {
+	case "p2wkh":
+		return lnwallet.WitnessPubKey, nil
+	case "np2wkh":
+		return lnwallet.NestedWitnessPubKey, nil
+	default:
+		return 0, fmt.Errorf("invalid change address type %q, must be "+
+			"one of {p2wkh, np2wkh}", addrType)
+	}
+}
+
+// parseInitialSyncStrategy parses the passed initial sync option into the
+// strategy used by the gossiper to sync the graph with newly connected peers.
+func parseInitialSyncStrategy(
+	strategy string) (discovery.InitialSyncStrategy, error) {
+
+	switch strategy {
+	case "full":
+		return discovery.InitialSyncFull, nil
+	case "timestamp":
+		return discovery.InitialSyncTimestamp, nil
+
+	// Range queries rely on the query_channel_range and
+	// query_short_channel_ids messages, which lnwire doesn't implement
+	// yet, so we'll reject the strategy explicitly rather than silently
+	// falling back to another one.
+	case "range-query":
+		return 0, fmt.Errorf("the range-query initial sync strategy " +
+			"isn't supported yet, as range queries aren't " +
+			"implemented")
+
+	default:
+		return 0, fmt.Errorf("invalid initial sync strategy %q, must "+
+			"be one of {full, timestamp}", strategy)
+	}
+}
+
 // cleanAndExpandPath expands environment variables and leading ~ in the
 // passed path, cleans the result, and returns it.
 // This function is taken from https://github.com/btcsuite/btcd
@@ -534,6 +848,26 @@ func supportedSubsystems() []string {
 	return subsystems
 }
 
+// validateNeutrinoPeers ensures that each of the passed peer addresses can be
+// resolved to a TCP address. If an address doesn't specify a port, then the
+// passed default port is assumed. An error naming the first malformed address
+// is returned.
+func validateNeutrinoPeers(peers []string, defaultPort string) error {
+	for _, peer := range peers {
+		addr := peer
+		if _, _, err := net.SplitHostPort(peer); err != nil {
+			addr = net.JoinHostPort(peer, defaultPort)
+		}
+
+		if _, err := net.ResolveTCPAddr("tcp", addr); err != nil {
+			return fmt.Errorf("unable to resolve peer address "+
+				"%q: %v", peer, err)
+		}
+	}
+
+	return nil
+}
+
 // noiseDial is a factory function which creates a connmgr compliant dialing
 // function by returning a closure which includes the server's identity key.
 func noiseDial(idPriv *btcec.PrivateKey) func(net.Addr) (net.Conn, error) {
@@ -543,6 +877,17 @@ func noiseDial(idPriv *btcec.PrivateKey) func(net.Addr) (net.Conn, error) {
 	}
 }
 
+// parseRPCParams attempts to automatically obtain the RPC credentials of the
+// daemon backing the passed chain from the daemon's configuration file, in
+// case they haven't been configured.
+//
+// NOTE: Only btcd style daemons (btcd, ltcd, viad) are supported as a chain
+// backend. A bitcoind style backend, such as litecoind, would first require a
+// chain notifier and filtered chain view driven by the daemon's ZMQ
+// notifications, along with the extraction of the credentials from its RPC
+// cookie, none of which exist yet.
+//
+// TODO: add a bitcoind backend, with litecoind support on top of it.
 func parseRPCParams(cConfig *chainConfig, net chainCode, funcName string) error {
 	// If the rpcuser and rpcpass parameters aren't set, then we'll attempt
 	// to automatically obtain the proper credentials for btcd and set
@@ -569,6 +914,10 @@ func parseRPCParams(cConfig *chainConfig, net chainCode, funcName string) error
 		daemonName = "viad"
 	}
 
+	if chain, ok := customChains[net]; ok {
+		daemonName = chain.params.DaemonName
+	}
+
 	fmt.Println("Attempting automatic RPC configuration to " + daemonName)
 
 	homeDir := btcdHomeDir
@@ -580,6 +929,10 @@ func parseRPCParams(cConfig *chainConfig, net chainCode, funcName string) error
 		homeDir = viadHomeDir
 	}
 
+	if _, ok := customChains[net]; ok {
+		homeDir = btcutil.AppDataDir(daemonName, false)
+	}
+
 	confFile := filepath.Join(homeDir, fmt.Sprintf("%v.conf", daemonName))
 	rpcUser, rpcPass, err := extractRPCParams(confFile)
 	if err != nil {
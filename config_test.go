@@ -0,0 +1,219 @@
+// +build !rpctest
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	flags "github.com/btcsuite/go-flags"
+	"github.com/viacoin/lnd/discovery"
+	"github.com/viacoin/lnd/lnwallet"
+)
+
+// TestValidateNeutrinoPeers ensures that well formed neutrino peer addresses
+// are accepted, while malformed addresses are rejected.
+func TestValidateNeutrinoPeers(t *testing.T) {
+	t.Parallel()
+
+	const defaultPort = "18333"
+
+	tests := []struct {
+		peers []string
+		valid bool
+	}{
+		{
+			peers: nil,
+			valid: true,
+		},
+		{
+			peers: []string{"127.0.0.1", "127.0.0.1:8333"},
+			valid: true,
+		},
+		{
+			peers: []string{"[::1]:8333", "::1"},
+			valid: true,
+		},
+		{
+			peers: []string{"127.0.0.1", "127.0.0.1:99999"},
+			valid: false,
+		},
+		{
+			peers: []string{"127.0.0.1:notaport"},
+			valid: false,
+		},
+		{
+			peers: []string{"[::1:8333"},
+			valid: false,
+		},
+	}
+
+	for i, test := range tests {
+		err := validateNeutrinoPeers(test.peers, defaultPort)
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("test #%v: unable to validate peers %v: %v", i,
+				test.peers, err)
+
+		case !test.valid && err == nil:
+			t.Fatalf("test #%v: malformed peers %v were accepted",
+				i, test.peers)
+		}
+	}
+}
+
+// TestParseChangeAddressType ensures that the supported change address types
+// are parsed into their wallet address type, while others are rejected.
+func TestParseChangeAddressType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addrType string
+		expected lnwallet.AddressType
+		valid    bool
+	}{
+		{
+			addrType: "p2wkh",
+			expected: lnwallet.WitnessPubKey,
+			valid:    true,
+		},
+		{
+			addrType: "np2wkh",
+			expected: lnwallet.NestedWitnessPubKey,
+			valid:    true,
+		},
+		{
+			addrType: "p2pkh",
+			valid:    false,
+		},
+	}
+
+	for i, test := range tests {
+		addrType, err := parseChangeAddressType(test.addrType)
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("test #%v: unable to parse change address "+
+				"type %v: %v", i, test.addrType, err)
+
+		case !test.valid && err == nil:
+			t.Fatalf("test #%v: invalid change address type %v "+
+				"was accepted", i, test.addrType)
+
+		case test.valid && addrType != test.expected:
+			t.Fatalf("test #%v: expected address type %v, got %v",
+				i, test.expected, addrType)
+		}
+	}
+}
+
+// TestParseInitialSyncStrategy ensures that the supported initial sync
+// strategies are parsed into the strategies of the gossiper, while others are
+// rejected.
+func TestParseInitialSyncStrategy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		strategy string
+		expected discovery.InitialSyncStrategy
+		valid    bool
+	}{
+		{
+			strategy: "full",
+			expected: discovery.InitialSyncFull,
+			valid:    true,
+		},
+		{
+			strategy: "timestamp",
+			expected: discovery.InitialSyncTimestamp,
+			valid:    true,
+		},
+		{
+			strategy: "range-query",
+			valid:    false,
+		},
+	}
+
+	for i, test := range tests {
+		strategy, err := parseInitialSyncStrategy(test.strategy)
+		switch {
+		case test.valid && err != nil:
+			t.Fatalf("test #%v: unable to parse initial sync "+
+				"strategy %v: %v", i, test.strategy, err)
+
+		case !test.valid && err == nil:
+			t.Fatalf("test #%v: invalid initial sync strategy %v "+
+				"was accepted", i, test.strategy)
+
+		case test.valid && strategy != test.expected:
+			t.Fatalf("test #%v: expected strategy %v, got %v", i,
+				test.expected, strategy)
+		}
+	}
+
+	// The range-query strategy should be rejected as unsupported, rather
+	// than as an unknown strategy.
+	_, err := parseInitialSyncStrategy("range-query")
+	if err == nil || !strings.Contains(err.Error(), "isn't supported") {
+		t.Fatalf("range-query strategy wasn't rejected as "+
+			"unsupported: %v", err)
+	}
+}
+
+// TestGossipTimingOptions ensures that the gossip timing options are parsed
+// from duration strings, and fall back to their defaults if unset.
+func TestGossipTimingOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args            []string
+		trickleDelay    time.Duration
+		retransmitDelay time.Duration
+	}{
+		{
+			args:            nil,
+			trickleDelay:    defaultTrickleDelay,
+			retransmitDelay: defaultRetransmitDelay,
+		},
+		{
+			args: []string{
+				"--trickledelay=30s", "--retransmitdelay=1h",
+			},
+			trickleDelay:    30 * time.Second,
+			retransmitDelay: time.Hour,
+		},
+		{
+			args:            []string{"--trickledelay=1m30s"},
+			trickleDelay:    90 * time.Second,
+			retransmitDelay: defaultRetransmitDelay,
+		},
+	}
+
+	for i, test := range tests {
+		cfg := config{
+			TrickleDelay:    defaultTrickleDelay,
+			RetransmitDelay: defaultRetransmitDelay,
+			Bitcoin:         &chainConfig{},
+			Litecoin:        &chainConfig{},
+			Viacoin:         &chainConfig{},
+			NeutrinoMode:    &neutrinoConfig{},
+			Autopilot:       &autoPilotConfig{},
+		}
+
+		parser := flags.NewParser(&cfg, flags.None)
+		if _, err := parser.ParseArgs(test.args); err != nil {
+			t.Fatalf("test #%v: unable to parse %v: %v", i,
+				test.args, err)
+		}
+
+		if cfg.TrickleDelay != test.trickleDelay {
+			t.Fatalf("test #%v: expected trickle delay of %v, "+
+				"got %v", i, test.trickleDelay, cfg.TrickleDelay)
+		}
+		if cfg.RetransmitDelay != test.retransmitDelay {
+			t.Fatalf("test #%v: expected retransmit delay of %v, "+
+				"got %v", i, test.retransmitDelay,
+				cfg.RetransmitDelay)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package discovery
+
+import (
+	"bytes"
+
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// AdvertisedChannel pairs the channel update that we last advertised for one
+// of our channels with the local policy of that channel.
+type AdvertisedChannel struct {
+	// ChannelID is the short channel ID of the channel.
+	ChannelID lnwire.ShortChannelID
+
+	// LastUpdate is the last channel update that we've signed and
+	// broadcast to our peers. If we haven't done so since the gossiper
+	// was started, then it's reconstructed from the signed policy stored
+	// within the graph instead.
+	LastUpdate *lnwire.ChannelUpdate
+
+	// LocalPolicy is our current local policy of the channel.
+	LocalPolicy *channeldb.ChannelEdgePolicy
+
+	// Discrepancy is true if the last advertised update doesn't reflect
+	// the local policy, such as a fee change that the network hasn't been
+	// told about yet.
+	Discrepancy bool
+}
+
+// OurAdvertisedChannels returns, for each of our channels, the channel update
+// that we last advertised to the network along with our current local policy
+// of the channel, flagging any discrepancies between the two.
+func (d *AuthenticatedGossiper) OurAdvertisedChannels() ([]AdvertisedChannel,
+	error) {
+
+	d.advertisedMtx.Lock()
+	defer d.advertisedMtx.Unlock()
+
+	var channels []AdvertisedChannel
+	err := d.cfg.Router.ForAllOutgoingChannels(func(
+		info *channeldb.ChannelEdgeInfo,
+		edge *channeldb.ChannelEdgePolicy) error {
+
+		// If we haven't advertised an update for this channel since
+		// we've started, then the last update we've advertised is the
+		// one whose signature is stored along with the policy.
+		lastUpdate, ok := d.advertisedUpdates[edge.ChannelID]
+		if !ok {
+			lastUpdate = ChanUpdateFromPolicy(info.ChainHash, edge)
+		}
+
+		channels = append(channels, AdvertisedChannel{
+			ChannelID:   lnwire.NewShortChanIDFromInt(edge.ChannelID),
+			LastUpdate:  lastUpdate,
+			LocalPolicy: edge,
+			Discrepancy: !updateMatchesPolicy(lastUpdate, edge),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Errorf("unable to retrieve outgoing "+
+			"channels: %v", err)
+	}
+
+	return channels, nil
+}
+
+// stageAdvertisedUpdate records the passed freshly signed update of one of
+// our own channels, which is only considered advertised once it has actually
+// been broadcast. Until then, any discrepancy between the last advertised
+// update and the new local policy of the channel is still flagged.
+func (d *AuthenticatedGossiper) stageAdvertisedUpdate(
+	update *lnwire.ChannelUpdate) {
+
+	d.advertisedMtx.Lock()
+	d.stagedUpdates[update.ShortChannelID.ToUint64()] = update
+	d.advertisedMtx.Unlock()
+}
+
+// recordAdvertisedUpdates records the staged updates of our own channels
+// among the passed announcements, which have just been broadcast, as the last
+// ones we've advertised for their channels.
+func (d *AuthenticatedGossiper) recordAdvertisedUpdates(
+	msgs ...lnwire.Message) {
+
+	d.advertisedMtx.Lock()
+	defer d.advertisedMtx.Unlock()
+
+	for _, msg := range msgs {
+		update, ok := msg.(*lnwire.ChannelUpdate)
+		if !ok {
+			continue
+		}
+
+		// Only the latest update we've signed for a channel is
+		// recorded, as any update staged after it supersedes it.
+		chanID := update.ShortChannelID.ToUint64()
+		if d.stagedUpdates[chanID] != update {
+			continue
+		}
+
+		delete(d.stagedUpdates, chanID)
+		d.advertisedUpdates[chanID] = update
+	}
+}
+
+// updateMatchesPolicy returns true if the routing parameters advertised by the
+// channel update match those of the passed policy.
+func updateMatchesPolicy(update *lnwire.ChannelUpdate,
+	policy *channeldb.ChannelEdgePolicy) bool {
+
+	return update.Flags == policy.Flags &&
+		update.TimeLockDelta == policy.TimeLockDelta &&
+		update.HtlcMinimumMsat == policy.MinHTLC &&
+		update.HtlcMaximumMsat == policy.MaxHTLC &&
+		lnwire.MilliSatoshi(update.BaseFee) == policy.FeeBaseMSat &&
+		lnwire.MilliSatoshi(update.FeeRate) ==
+			policy.FeeProportionalMillionths &&
+		bytes.Equal(update.ExtraOpaqueData, policy.ExtraOpaqueData)
+}
@@ -0,0 +1,118 @@
+package discovery
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/routing"
+)
+
+// TestOurAdvertisedChannels ensures that a change to the local policy of one
+// of our channels is flagged as a discrepancy until the update advertising it
+// has been broadcast.
+func TestOurAdvertisedChannels(t *testing.T) {
+	t.Parallel()
+
+	// We'll hold back broadcasts until we're connected to a peer, such
+	// that the new update can't be broadcast right away.
+	var numPeers int32
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.MinPeersForBroadcast = 1
+		cfg.NumConnectedPeers = func() int {
+			return int(atomic.LoadInt32(&numPeers))
+		}
+	})
+	defer cleanup()
+
+	// We'll populate the graph with two of our own channels.
+	const numChans = 2
+	for i := uint64(1); i <= numChans; i++ {
+		selfPub, err := btcec.ParsePubKey(
+			nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+		)
+		if err != nil {
+			t.Fatalf("unable to parse pubkey: %v", err)
+		}
+
+		ctx.router.infos[i] = &channeldb.ChannelEdgeInfo{
+			ChannelID:    i,
+			ChannelPoint: wire.OutPoint{Index: uint32(i)},
+			NodeKey1:     nodeKeyPub1,
+			NodeKey2:     nodeKeyPub2,
+		}
+		ctx.router.edges[i] = []*channeldb.ChannelEdgePolicy{{
+			ChannelID:  i,
+			LastUpdate: time.Now(),
+			Node: &channeldb.LightningNode{
+				PubKey: selfPub,
+			},
+		}}
+	}
+
+	// We'll advertise a new fee schema for the first channel, while the
+	// second channel remains as is.
+	feeSchema := routing.FeeSchema{
+		BaseFee: 1000,
+		FeeRate: 1,
+	}
+	err := ctx.gossiper.PropagateFeeUpdate(
+		feeSchema, ctx.router.infos[1].ChannelPoint,
+	)
+	if err != nil {
+		t.Fatalf("unable to propagate fee update: %v", err)
+	}
+
+	// matchDiscrepancies returns true if the discrepancies flagged for
+	// our channels match the expected ones.
+	matchDiscrepancies := func(expected map[uint64]bool) bool {
+		channels, err := ctx.gossiper.OurAdvertisedChannels()
+		if err != nil {
+			t.Fatalf("unable to fetch advertised channels: %v", err)
+		}
+		if len(channels) != len(expected) {
+			t.Fatalf("expected %v channels, got %v",
+				len(expected), len(channels))
+		}
+
+		for _, channel := range channels {
+			chanID := channel.ChannelID.ToUint64()
+			if channel.Discrepancy != expected[chanID] {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// As the new update of the first channel hasn't been broadcast yet,
+	// its new local policy should be flagged.
+	if !matchDiscrepancies(map[uint64]bool{1: true, 2: false}) {
+		t.Fatal("unadvertised local policy wasn't flagged")
+	}
+
+	// Once we're connected to a peer, the update should be broadcast,
+	// after which neither channel should be flagged.
+	atomic.StoreInt32(&numPeers, 1)
+
+	select {
+	case <-ctx.broadcastedMessage:
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("channel update wasn't broadcast")
+	}
+
+	// The update is recorded as advertised once the broadcast completes,
+	// so we'll give the gossiper a moment to do so.
+	deadline := time.After(time.Second)
+	for !matchDiscrepancies(map[uint64]bool{1: false, 2: false}) {
+		select {
+		case <-deadline:
+			t.Fatal("broadcast update wasn't recorded as " +
+				"advertised")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package discovery
+
+import (
+	"io/ioutil"
+
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// checkAnnouncementSize returns an error if the size of the passed
+// announcement on the wire, including its message type, exceeds the
+// MaxAnnouncementSize. As the size is measured over all fields of the
+// announcement, it guards against oversized announcements of any type, such
+// as node announcements carrying an excessive number of addresses. If the
+// size isn't known, i.e. zero, then it's determined by serializing the
+// announcement.
+func (d *AuthenticatedGossiper) checkAnnouncementSize(msg lnwire.Message,
+	size int) error {
+
+	if size == 0 {
+		var err error
+		size, err = lnwire.WriteMessage(ioutil.Discard, msg, 0)
+		if err != nil {
+			return errors.Errorf("unable to serialize "+
+				"announcement: %v", err)
+		}
+	}
+
+	if size > d.cfg.MaxAnnouncementSize {
+		return errors.Errorf("announcement of %v bytes exceeds "+
+			"maximum size of %v bytes", size,
+			d.cfg.MaxAnnouncementSize)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestMaxAnnouncementSize ensures that remote announcements exceeding the
+// MaxAnnouncementSize are rejected before their signatures are validated.
+func TestMaxAnnouncementSize(t *testing.T) {
+	t.Parallel()
+
+	na, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	// We'll only allow announcements up to the size of the one we've
+	// just created.
+	var b bytes.Buffer
+	size, err := lnwire.WriteMessage(&b, na, 0)
+	if err != nil {
+		t.Fatalf("unable to serialize node announcement: %v", err)
+	}
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.RejectionLogSize = 1
+		cfg.MaxAnnouncementSize = size
+	})
+	defer cleanup()
+
+	// We'll now pad the announcement with a large number of addresses.
+	// This also invalidates its signature, so it must be rejected for
+	// its size to show that it wasn't validated.
+	oversized := *na
+	oversized.Addresses = nil
+	for i := 0; i < 100; i++ {
+		oversized.Addresses = append(oversized.Addresses, testAddr)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+		&oversized, nodeKeyPub2,
+	):
+		if err == nil {
+			t.Fatal("oversized node announcement was accepted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't processed")
+	}
+
+	records := ctx.gossiper.RecentRejections(1)
+	if len(records) != 1 || records[0].Reason != RejectOversized {
+		t.Fatalf("expected oversized rejection, got %v", records)
+	}
+
+	// If the size of the announcement on the wire is known, then it
+	// should be checked rather than the size of its serialized form, so
+	// the announcement within the limit should be rejected if it was
+	// padded on the wire.
+	select {
+	case err := <-ctx.gossiper.ProcessSizedRemoteAnnouncement(
+		na, size+1, nodeKeyPub2,
+	):
+		if err == nil {
+			t.Fatal("oversized node announcement was accepted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't processed")
+	}
+
+	// The announcement within the size limit should be accepted.
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("unable to process node announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't processed")
+	}
+}
@@ -0,0 +1,82 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/chaincfg"
+	viacoinCfg "github.com/viacoin/viad/chaincfg"
+)
+
+// TestSkipAnnValidation ensures that remote announcements carrying an invalid
+// signature are accepted once the gossiper is configured to skip their
+// validation, and that doing so is refused on mainnet.
+func TestSkipAnnValidation(t *testing.T) {
+	t.Parallel()
+
+	// Tampering with the announcement after it has been signed renders
+	// its signature invalid.
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	na.Timestamp++
+
+	// processNodeAnn has a gossiper which may skip the validation of
+	// announcements process the invalid announcement, returning the
+	// result along with the number of nodes added to the router.
+	processNodeAnn := func(skipValidation bool) (int, error) {
+		ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+			cfg.SkipAnnValidation = skipValidation
+		})
+		defer cleanup()
+
+		select {
+		case err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+			na, nodeKeyPub2,
+		):
+		case <-time.After(time.Second):
+			t.Fatal("node announcement wasn't processed")
+		}
+
+		return len(ctx.router.nodes), err
+	}
+
+	if _, err := processNodeAnn(false); err == nil {
+		t.Fatal("announcement with invalid signature accepted")
+	}
+
+	// Once validation is skipped, the very same announcement should be
+	// accepted.
+	numNodes, err := processNodeAnn(true)
+	if err != nil {
+		t.Fatalf("announcement rejected despite skipped validation: %v",
+			err)
+	}
+	if numNodes != 1 {
+		t.Fatalf("node wasn't added to router")
+	}
+
+	// Finally, a gossiper operating on mainnet must refuse to skip the
+	// validation of announcements.
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	cfg := *ctx.gossiper.cfg
+	cfg.SkipAnnValidation = true
+	cfg.ChainHash = *chaincfg.MainNetParams.GenesisHash
+	if _, err := New(cfg, nodeKeyPub1); err == nil {
+		t.Fatal("gossiper skipping validation created on mainnet")
+	}
+
+	copy(cfg.ChainHash[:], viacoinCfg.MainNetParams.GenesisHash[:])
+	if _, err := New(cfg, nodeKeyPub1); err == nil {
+		t.Fatal("gossiper skipping validation created on viacoin " +
+			"mainnet")
+	}
+
+	cfg.ChainHash = *chaincfg.TestNet3Params.GenesisHash
+	if _, err := New(cfg, nodeKeyPub1); err != nil {
+		t.Fatalf("unable to create gossiper on testnet: %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package discovery
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwallet"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// BatchMessageSigner is an optional interface of the AnnSigner, which allows
+// the gossiper to have the updates of many channels signed within a single
+// call, rather than one call per channel. This amortizes the round trips to
+// remote signers, such as HSMs.
+type BatchMessageSigner interface {
+	lnwallet.MessageSigner
+
+	// SignMessages signs each of the passed messages with the private key
+	// that corresponds to the passed public key, returning the signatures
+	// in the order of the messages. As with SignMessage, the actual
+	// digest signed is the double SHA-256 of each message.
+	SignMessages(pubKey *btcec.PublicKey,
+		msgs [][]byte) ([]*btcec.Signature, error)
+}
+
+// channelEdge is one of our own channels, along with our policy for it.
+type channelEdge struct {
+	info *channeldb.ChannelEdgeInfo
+	edge *channeldb.ChannelEdgePolicy
+}
+
+// BatchEdgeUpdater is an optional interface of the Router, which allows the
+// gossiper to have the updated policies of many of our channels written to
+// the graph within a single database transaction, rather than one transaction
+// per channel.
+type BatchEdgeUpdater interface {
+	// UpdateEdges applies the passed edge updates within a single
+	// database transaction. If any of the updates is rejected, then none
+	// of them are applied.
+	UpdateEdges(updates []*channeldb.ChannelEdgePolicy) error
+}
+
+// updateChannels creates a new fully signed update for each of the passed
+// channels, and updates the underlying graph with the new state. The channel
+// announcements and updates are returned in the order of the channels, where
+// the announcement of a channel is nil unless we have a full announcement for
+// it. If the AnnSigner is a BatchMessageSigner, all updates are signed within
+// a single call. If EdgeUpdateBatchSize is set and the Router is a
+// BatchEdgeUpdater, the updates are written to the graph in batches of up to
+// that size.
+func (d *AuthenticatedGossiper) updateChannels(chans []channelEdge) (
+	[]*lnwire.ChannelAnnouncement, []*lnwire.ChannelUpdate, error) {
+
+	chanAnns := make([]*lnwire.ChannelAnnouncement, len(chans))
+	chanUpdates := make([]*lnwire.ChannelUpdate, len(chans))
+
+	batchUpdater, batchWrites := d.cfg.Router.(BatchEdgeUpdater)
+	batchWrites = batchWrites && d.cfg.EdgeUpdateBatchSize != 0
+
+	batchSigner, batchSigs := d.cfg.AnnSigner.(BatchMessageSigner)
+	if !batchSigs && !batchWrites {
+		for i, c := range chans {
+			chanAnn, chanUpdate, err := d.updateChannel(
+				c.info, c.edge,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			chanAnns[i] = chanAnn
+			chanUpdates[i] = chanUpdate
+		}
+
+		return chanAnns, chanUpdates, nil
+	}
+
+	if len(chans) == 0 {
+		return chanAnns, chanUpdates, nil
+	}
+
+	sigs := make([]*btcec.Signature, len(chans))
+	if batchSigs {
+		var err error
+		sigs, err = d.batchSignChannelUpdates(
+			batchSigner, chans, chanUpdates,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		for i, c := range chans {
+			var err error
+			chanUpdates[i], sigs[i], err = d.newSignedChannelUpdate(
+				c.info, c.edge,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if !batchWrites {
+		for i, c := range chans {
+			var err error
+			chanAnns[i], err = d.applyChannelUpdate(
+				c.info, c.edge, chanUpdates[i], sigs[i],
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return chanAnns, chanUpdates, nil
+	}
+
+	// With all updates signed, we'll write them to the graph in batches,
+	// each within a single transaction.
+	batchSize := d.cfg.EdgeUpdateBatchSize
+	for start := 0; start < len(chans); start += batchSize {
+		end := start + batchSize
+		if end > len(chans) {
+			end = len(chans)
+		}
+
+		edges := make([]*channeldb.ChannelEdgePolicy, 0, end-start)
+		for i := start; i < end; i++ {
+			err := d.finalizeChannelUpdate(
+				chans[i].edge, chanUpdates[i], sigs[i],
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			edges = append(edges, chans[i].edge)
+		}
+
+		if err := batchUpdater.UpdateEdges(edges); err != nil {
+			return nil, nil, err
+		}
+
+		for i := start; i < end; i++ {
+			d.stageAdvertisedUpdate(chanUpdates[i])
+			chanAnns[i] = newChanAnnFromInfo(chans[i].info)
+		}
+	}
+
+	return chanAnns, chanUpdates, nil
+}
+
+// batchSignChannelUpdates creates a new update for each of the passed
+// channels, which are signed within a single call to the batch signer. The
+// updates are written to the passed slice, and their signatures are returned
+// in the same order.
+func (d *AuthenticatedGossiper) batchSignChannelUpdates(
+	batchSigner BatchMessageSigner, chans []channelEdge,
+	chanUpdates []*lnwire.ChannelUpdate) ([]*btcec.Signature, error) {
+
+	// First, we'll craft all updates, and collect the data to be signed
+	// for each of them.
+	data := make([][]byte, len(chans))
+	for i, c := range chans {
+		chanUpdates[i] = d.newChannelUpdate(c.info, c.edge)
+
+		var err error
+		data[i], err = d.channelUpdateData(chanUpdates[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// With the data collected, we'll have all of it signed at once.
+	sigs, err := batchSigner.SignMessages(d.selfKey, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(sigs) != len(data) {
+		return nil, fmt.Errorf("signer returned %v signatures for %v "+
+			"updates", len(sigs), len(data))
+	}
+
+	// If instructed to, we'll sign the updates once more to ensure that
+	// our signer produces deterministic signatures.
+	if d.cfg.VerifyDeterministicSigs {
+		sigs2, err := batchSigner.SignMessages(d.selfKey, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(sigs2) != len(data) {
+			return nil, fmt.Errorf("signer returned %v signatures "+
+				"for %v updates", len(sigs2), len(data))
+		}
+
+		for i, sig := range sigs {
+			if !bytes.Equal(sig.Serialize(), sigs2[i].Serialize()) {
+				return nil, fmt.Errorf("signer produced "+
+					"non-deterministic signatures for "+
+					"update of short_chan_id=%v",
+					chans[i].edge.ChannelID)
+			}
+		}
+	}
+
+	return sigs, nil
+}
@@ -0,0 +1,339 @@
+package discovery
+
+import (
+	"crypto/ecdsa"
+	crand "crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+	"github.com/viacoin/lnd/routing"
+)
+
+// randNonceSigner is a signer which uses random rather than deterministic
+// RFC6979 nonces, so its signatures over the same message differ.
+type randNonceSigner struct {
+	privKey *btcec.PrivateKey
+}
+
+func (n *randNonceSigner) SignMessage(pubKey *btcec.PublicKey,
+	msg []byte) (*btcec.Signature, error) {
+
+	if !pubKey.IsEqual(n.privKey.PubKey()) {
+		return nil, fmt.Errorf("unknown public key")
+	}
+
+	digest := chainhash.DoubleHashB(msg)
+	r, s, err := ecdsa.Sign(crand.Reader, n.privKey.ToECDSA(), digest)
+	if err != nil {
+		return nil, fmt.Errorf("can't sign the message: %v", err)
+	}
+
+	return &btcec.Signature{R: r, S: s}, nil
+}
+
+// batchMockSigner is a signer which is able to sign batches of messages, and
+// counts the calls made to it.
+type batchMockSigner struct {
+	mockSigner
+
+	// signCalls is the number of calls to SignMessage.
+	signCalls int
+
+	// batchSizes is the size of each batch passed to SignMessages.
+	batchSizes []int
+}
+
+func (n *batchMockSigner) SignMessage(pubKey *btcec.PublicKey,
+	msg []byte) (*btcec.Signature, error) {
+
+	n.signCalls++
+	return n.mockSigner.SignMessage(pubKey, msg)
+}
+
+func (n *batchMockSigner) SignMessages(pubKey *btcec.PublicKey,
+	msgs [][]byte) ([]*btcec.Signature, error) {
+
+	n.batchSizes = append(n.batchSizes, len(msgs))
+
+	sigs := make([]*btcec.Signature, 0, len(msgs))
+	for _, msg := range msgs {
+		sig, err := n.mockSigner.SignMessage(pubKey, msg)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, sig)
+	}
+
+	return sigs, nil
+}
+
+// TestVerifyDeterministicSigs ensures that, once enabled, updates of our own
+// channels are only signed by signers which produce deterministic signatures.
+func TestVerifyDeterministicSigs(t *testing.T) {
+	t.Parallel()
+
+	selfPub, err := btcec.ParsePubKey(
+		nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	// updateChannel has a gossiper, which may verify that its signatures
+	// are deterministic, sign a channel update. If randNonce is set, the
+	// gossiper's signer uses random nonces.
+	updateChannel := func(verify, randNonce bool) error {
+		ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+			cfg.VerifyDeterministicSigs = verify
+			if randNonce {
+				cfg.AnnSigner = &randNonceSigner{nodeKeyPriv1}
+			}
+		})
+		defer cleanup()
+
+		info := &channeldb.ChannelEdgeInfo{
+			ChannelID: 1,
+			NodeKey1:  nodeKeyPub1,
+			NodeKey2:  nodeKeyPub2,
+		}
+		edge := &channeldb.ChannelEdgePolicy{
+			ChannelID:  1,
+			LastUpdate: time.Unix(1, 0),
+			Node: &channeldb.LightningNode{
+				PubKey: selfPub,
+			},
+		}
+
+		_, _, err = ctx.gossiper.updateChannel(info, edge)
+		return err
+	}
+
+	// Our default signer uses deterministic nonces, so the update should
+	// succeed.
+	if err := updateChannel(true, false); err != nil {
+		t.Fatalf("unable to update channel: %v", err)
+	}
+
+	// A signer using random nonces should however be detected.
+	if err := updateChannel(true, true); err == nil {
+		t.Fatal("non-deterministic signer wasn't detected")
+	}
+
+	// Without the verification, such a signer is still accepted.
+	if err := updateChannel(false, true); err != nil {
+		t.Fatalf("unable to update channel: %v", err)
+	}
+}
+
+// TestBatchSignFeeUpdates ensures that the updates of all channels affected by
+// a fee update are signed within a single call if the signer supports
+// batching.
+func TestBatchSignFeeUpdates(t *testing.T) {
+	t.Parallel()
+
+	signer := &batchMockSigner{
+		mockSigner: mockSigner{nodeKeyPriv1},
+	}
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.AnnSigner = signer
+	})
+	defer cleanup()
+
+	// We'll populate the graph with three of our own channels.
+	const numChans = 3
+	for i := uint64(1); i <= numChans; i++ {
+		selfPub, err := btcec.ParsePubKey(
+			nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+		)
+		if err != nil {
+			t.Fatalf("unable to parse pubkey: %v", err)
+		}
+
+		ctx.router.infos[i] = &channeldb.ChannelEdgeInfo{
+			ChannelID:    i,
+			ChannelPoint: wire.OutPoint{Index: uint32(i)},
+			NodeKey1:     nodeKeyPub1,
+			NodeKey2:     nodeKeyPub2,
+		}
+		ctx.router.edges[i] = []*channeldb.ChannelEdgePolicy{{
+			ChannelID:  i,
+			LastUpdate: time.Now(),
+			Node: &channeldb.LightningNode{
+				PubKey: selfPub,
+			},
+		}}
+	}
+
+	// Updating the fees of all channels should have all of their updates
+	// signed within a single batch.
+	feeSchema := routing.FeeSchema{
+		BaseFee: 1000,
+		FeeRate: 1,
+	}
+	if err := ctx.gossiper.PropagateFeeUpdate(feeSchema); err != nil {
+		t.Fatalf("unable to propagate fee update: %v", err)
+	}
+
+	if len(signer.batchSizes) != 1 || signer.batchSizes[0] != numChans {
+		t.Fatalf("expected a single batch of %v updates, got "+
+			"batches of %v", numChans, signer.batchSizes)
+	}
+	if signer.signCalls != 0 {
+		t.Fatalf("expected no individual signatures, got %v",
+			signer.signCalls)
+	}
+
+	// Each channel should carry the new fees, along with a valid
+	// signature.
+	for i := uint64(1); i <= numChans; i++ {
+		edges := ctx.router.edges[i]
+		policy := edges[len(edges)-1]
+		if policy.FeeBaseMSat != feeSchema.BaseFee {
+			t.Fatalf("expected base fee %v for channel %v, got %v",
+				feeSchema.BaseFee, i, policy.FeeBaseMSat)
+		}
+
+		update := &lnwire.ChannelUpdate{
+			Signature:       policy.Signature,
+			ChainHash:       ctx.router.infos[i].ChainHash,
+			ShortChannelID:  lnwire.NewShortChanIDFromInt(i),
+			Timestamp:       uint32(policy.LastUpdate.Unix()),
+			Flags:           policy.Flags,
+			TimeLockDelta:   policy.TimeLockDelta,
+			HtlcMinimumMsat: policy.MinHTLC,
+			BaseFee:         uint32(policy.FeeBaseMSat),
+			FeeRate:         uint32(policy.FeeProportionalMillionths),
+		}
+		err := ctx.gossiper.validateChannelUpdateAnn(nodeKeyPub1, update)
+		if err != nil {
+			t.Fatalf("invalid signature for channel %v: %v", i, err)
+		}
+	}
+}
+
+// batchGraphSource is a mockGraphSource which also implements the
+// BatchEdgeUpdater interface, keeping track of the number of writes of edge
+// policies to the graph.
+type batchGraphSource struct {
+	*mockGraphSource
+
+	singleWrites int
+	batchWrites  int
+}
+
+func (r *batchGraphSource) UpdateEdge(edge *channeldb.ChannelEdgePolicy) error {
+	r.singleWrites++
+	return r.mockGraphSource.UpdateEdge(edge)
+}
+
+func (r *batchGraphSource) UpdateEdges(
+	edges []*channeldb.ChannelEdgePolicy) error {
+
+	r.batchWrites++
+	for _, edge := range edges {
+		if err := r.mockGraphSource.UpdateEdge(edge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TestFeeUpdateBatchedWrites ensures that a fee update across many of our
+// channels is written to the graph in batches of the configured size if the
+// router supports it.
+func TestFeeUpdateBatchedWrites(t *testing.T) {
+	t.Parallel()
+
+	selfPub, err := btcec.ParsePubKey(
+		nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	const numChans = 10
+
+	// assertWrites has a gossiper with the given batch size propagate a
+	// fee update across all of its channels, and asserts the number of
+	// single and batch writes to the graph that result from it.
+	assertWrites := func(batchSize, singleWrites, batchWrites int) {
+		var router *batchGraphSource
+		ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+			router = &batchGraphSource{
+				mockGraphSource: cfg.Router.(*mockGraphSource),
+			}
+			cfg.Router = router
+			cfg.EdgeUpdateBatchSize = batchSize
+		})
+		defer cleanup()
+
+		for i := uint64(1); i <= numChans; i++ {
+			ctx.router.infos[i] = &channeldb.ChannelEdgeInfo{
+				ChannelID:    i,
+				ChannelPoint: wire.OutPoint{Index: uint32(i)},
+				NodeKey1:     nodeKeyPub1,
+				NodeKey2:     nodeKeyPub2,
+			}
+			ctx.router.edges[i] = []*channeldb.ChannelEdgePolicy{{
+				ChannelID:  i,
+				LastUpdate: time.Now(),
+				Node: &channeldb.LightningNode{
+					PubKey: selfPub,
+				},
+			}}
+		}
+
+		// The fee update should result in an update of every channel
+		// being broadcast, which we'll consume to not block the
+		// gossiper.
+		feeSchema := routing.FeeSchema{BaseFee: 1000, FeeRate: 1}
+		err = ctx.gossiper.PropagateFeeUpdate(feeSchema)
+		if err != nil {
+			t.Fatalf("unable to propagate fee update: %v", err)
+		}
+
+		for i := 0; i < numChans; i++ {
+			select {
+			case <-ctx.broadcastedMessage:
+			case <-time.After(2 * trickleDelay):
+				t.Fatal("channel update wasn't broadcast")
+			}
+		}
+
+		if router.singleWrites != singleWrites {
+			t.Fatalf("expected %v single writes, got %v",
+				singleWrites, router.singleWrites)
+		}
+		if router.batchWrites != batchWrites {
+			t.Fatalf("expected %v batch writes, got %v",
+				batchWrites, router.batchWrites)
+		}
+
+		for i := uint64(1); i <= numChans; i++ {
+			edges := ctx.router.edges[i]
+			latest := edges[len(edges)-1]
+			if latest.FeeBaseMSat != feeSchema.BaseFee {
+				t.Fatalf("expected base fee of %v for channel "+
+					"%v, got %v", feeSchema.BaseFee, i,
+					latest.FeeBaseMSat)
+			}
+		}
+	}
+
+	// Without a batch size, each edge should be written on its own.
+	assertWrites(0, numChans, 0)
+
+	// With a batch size covering all channels, all edges should be
+	// written within a single batch.
+	assertWrites(numChans, 0, 1)
+
+	// A smaller batch size should split the writes into several batches.
+	assertWrites(4, 0, 3)
+}
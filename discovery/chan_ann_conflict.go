@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"bytes"
+
+	"github.com/go-errors/errors"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// DupChanAnnPolicy is the policy which is applied once a channel announcement
+// arrives for a channel that we already know of, but with a proof which
+// differs from the one we have on record. As there can only be a single valid
+// announcement for each short channel ID, such conflicts hint at forged
+// announcements targeting the channel.
+type DupChanAnnPolicy uint8
+
+const (
+	// DupChanAnnKeepFirst keeps the announcement we already know of, and
+	// rejects the conflicting one.
+	DupChanAnnKeepFirst DupChanAnnPolicy = iota
+
+	// DupChanAnnKeepValid keeps whichever of the two announcements is
+	// valid. If both are valid, then the announcement we already know of
+	// is kept.
+	DupChanAnnKeepValid
+
+	// DupChanAnnRejectBoth removes the announcement we already know of
+	// from the graph, and rejects the conflicting one. The known
+	// announcement is only removed if the conflicting one is valid, as
+	// anyone could otherwise have a channel removed by sending a forged
+	// announcement for it.
+	DupChanAnnRejectBoth
+)
+
+// String returns a human readable description of the policy.
+func (p DupChanAnnPolicy) String() string {
+	switch p {
+	case DupChanAnnKeepFirst:
+		return "keep-first"
+	case DupChanAnnKeepValid:
+		return "keep-valid"
+	case DupChanAnnRejectBoth:
+		return "reject-both"
+	default:
+		return "unknown"
+	}
+}
+
+// sigsEqual returns true if both signatures are either nil, or have the same
+// serialization.
+func sigsEqual(s1, s2 *btcec.Signature) bool {
+	if s1 == nil || s2 == nil {
+		return s1 == s2
+	}
+	return bytes.Equal(s1.Serialize(), s2.Serialize())
+}
+
+// proofsEqual returns true if the signatures of the passed channel
+// announcement match the passed proof.
+func proofsEqual(a *lnwire.ChannelAnnouncement,
+	proof *channeldb.ChannelAuthProof) bool {
+
+	return sigsEqual(a.NodeSig1, proof.NodeSig1) &&
+		sigsEqual(a.NodeSig2, proof.NodeSig2) &&
+		sigsEqual(a.BitcoinSig1, proof.BitcoinSig1) &&
+		sigsEqual(a.BitcoinSig2, proof.BitcoinSig2)
+}
+
+// resolveKnownChanAnn checks a remote channel announcement for a channel that
+// we already know of against the known channel. If either its funding keys or
+// its proof differ from the known ones, then the conflict is resolved
+// according to the configured policy. If the announcement should be processed
+// further, then nil is returned. Otherwise, an error describing why it's
+// rejected is returned.
+func (d *AuthenticatedGossiper) resolveKnownChanAnn(
+	a *lnwire.ChannelAnnouncement, known *channeldb.ChannelEdgeInfo) error {
+
+	switch {
+	case !fundingKeysEqual(a, known):
+		return d.resolveChanIDCollision(a, known)
+
+	case known.AuthProof != nil && !proofsEqual(a, known.AuthProof):
+		return d.resolveConflictingChanAnn(a, known)
+
+	default:
+		return nil
+	}
+}
+
+// resolveConflictingChanAnn applies the configured DupChanAnnPolicy to a
+// remote channel announcement whose proof conflicts with that of the known
+// channel. If the announcement should be processed further, then nil is
+// returned. Otherwise, an error describing why it's rejected is returned.
+func (d *AuthenticatedGossiper) resolveConflictingChanAnn(
+	a *lnwire.ChannelAnnouncement, known *channeldb.ChannelEdgeInfo) error {
+
+	shortChanID := a.ShortChannelID.ToUint64()
+	policy := d.cfg.DupChanAnnPolicy
+
+	log.Warnf("CONFLICTING channel announcements detected for "+
+		"short_chan_id=%v: received proof differs from the known "+
+		"one, applying %v policy", shortChanID, policy)
+
+	// Our own channels are never removed in favor of an announcement
+	// received from a peer, as we know their proof to be genuine.
+	if d.isSelfChannel(known) {
+		return errors.Errorf("conflicting announcement for our own "+
+			"short_chan_id=%v, keeping the known one", shortChanID)
+	}
+
+	// Either of the remaining policies may remove the known announcement,
+	// which we'll only do for a conflicting announcement that's valid
+	// itself. As its funding keys match those of the known channel, it
+	// references the very funding output that was verified on-chain once
+	// the known channel was added to the graph.
+	if policy == DupChanAnnKeepValid || policy == DupChanAnnRejectBoth {
+		if !fundingKeysEqual(a, known) {
+			return errors.Errorf("conflicting announcement for "+
+				"short_chan_id=%v references another funding "+
+				"output", shortChanID)
+		}
+
+		if err := d.validateChannelAnn(a); err != nil {
+			return errors.Errorf("conflicting announcement for "+
+				"short_chan_id=%v is invalid: %v", shortChanID,
+				err)
+		}
+	}
+
+	switch policy {
+	case DupChanAnnKeepValid:
+
+		// Otherwise, we'll only replace the known announcement if it
+		// turns out to be invalid itself.
+		knownAnn, _, _ := createChanAnnouncement(
+			known.AuthProof, known, nil, nil,
+		)
+		if err := d.validateChannelAnn(knownAnn); err == nil {
+			return errors.Errorf("conflicting announcement for "+
+				"short_chan_id=%v, keeping the known valid one",
+				shortChanID)
+		}
+
+		log.Warnf("Known announcement for short_chan_id=%v is "+
+			"invalid, replacing it with the conflicting one",
+			shortChanID)
+
+		return d.deleteChannel(a.ShortChannelID)
+
+	case DupChanAnnRejectBoth:
+		if err := d.deleteChannel(a.ShortChannelID); err != nil {
+			return err
+		}
+
+		return errors.Errorf("conflicting announcement for "+
+			"short_chan_id=%v, rejecting both", shortChanID)
+
+	default:
+		return errors.Errorf("conflicting announcement for "+
+			"short_chan_id=%v, keeping the known one", shortChanID)
+	}
+}
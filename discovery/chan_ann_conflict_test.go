@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestDupChanAnnPolicy ensures that the configured policy is applied once a
+// channel announcement arrives for a known channel with a differing proof.
+func TestDupChanAnnPolicy(t *testing.T) {
+	t.Parallel()
+
+	// We'll use a channel that we're not part of, as our own channels are
+	// never removed in favor of a conflicting announcement.
+	nodeKeyPriv3, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	ca, err := createChannelAnnouncementBetween(
+		0, nodeKeyPriv2, nodeKeyPriv3,
+	)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	chanID := ca.ShortChannelID.ToUint64()
+
+	// The forged announcement carries the same short channel ID as the
+	// valid one, but with a bogus node signature.
+	forged := *ca
+	forged.NodeSig1 = testSig
+
+	// The same goes for an announcement of our own channel.
+	selfCa, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	selfForged := *selfCa
+	selfForged.NodeSig1 = testSig
+
+	tests := []struct {
+		name   string
+		policy DupChanAnnPolicy
+
+		// first and second are the announcements processed in order.
+		first  *lnwire.ChannelAnnouncement
+		second *lnwire.ChannelAnnouncement
+
+		// secondAccepted is whether the second announcement should be
+		// accepted.
+		secondAccepted bool
+
+		// finalSig is the first node signature of the channel within
+		// the graph once both have been processed, or nil if the
+		// channel should no longer be known.
+		finalSig *btcec.Signature
+	}{
+		{
+			name:           "keep first",
+			policy:         DupChanAnnKeepFirst,
+			first:          ca,
+			second:         &forged,
+			secondAccepted: false,
+			finalSig:       ca.NodeSig1,
+		},
+		{
+			name:           "keep valid",
+			policy:         DupChanAnnKeepValid,
+			first:          &forged,
+			second:         ca,
+			secondAccepted: true,
+			finalSig:       ca.NodeSig1,
+		},
+		{
+			name:           "reject both",
+			policy:         DupChanAnnRejectBoth,
+			first:          &forged,
+			second:         ca,
+			secondAccepted: false,
+			finalSig:       nil,
+		},
+		{
+			// A forged announcement mustn't have the known channel
+			// removed.
+			name:           "reject both forged",
+			policy:         DupChanAnnRejectBoth,
+			first:          ca,
+			second:         &forged,
+			secondAccepted: false,
+			finalSig:       ca.NodeSig1,
+		},
+		{
+			name:           "reject both own channel",
+			policy:         DupChanAnnRejectBoth,
+			first:          &selfForged,
+			second:         selfCa,
+			secondAccepted: false,
+			finalSig:       selfForged.NodeSig1,
+		},
+		{
+			name:           "keep valid own channel",
+			policy:         DupChanAnnKeepValid,
+			first:          &selfForged,
+			second:         selfCa,
+			secondAccepted: false,
+			finalSig:       selfForged.NodeSig1,
+		},
+	}
+
+	for _, test := range tests {
+		ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+			cfg.DupChanAnnPolicy = test.policy
+		})
+
+		// As the forged announcement wouldn't pass validation, we'll
+		// add the first announcement to the graph directly.
+		ctx.router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+			ChannelID:   chanID,
+			ChainHash:   test.first.ChainHash,
+			NodeKey1:    test.first.NodeID1,
+			NodeKey2:    test.first.NodeID2,
+			BitcoinKey1: test.first.BitcoinKey1,
+			BitcoinKey2: test.first.BitcoinKey2,
+			AuthProof: &channeldb.ChannelAuthProof{
+				NodeSig1:    test.first.NodeSig1,
+				NodeSig2:    test.first.NodeSig2,
+				BitcoinSig1: test.first.BitcoinSig1,
+				BitcoinSig2: test.first.BitcoinSig2,
+			},
+		}
+
+		err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+			test.second, nodeKeyPub2,
+		)
+		if test.secondAccepted && err != nil {
+			t.Fatalf("%v: conflicting announcement rejected: %v",
+				test.name, err)
+		}
+		if !test.secondAccepted && err == nil {
+			t.Fatalf("%v: conflicting announcement accepted",
+				test.name)
+		}
+
+		info, ok := ctx.router.infos[chanID]
+		switch {
+		case test.finalSig == nil && ok:
+			t.Fatalf("%v: channel wasn't removed", test.name)
+
+		case test.finalSig != nil && !ok:
+			t.Fatalf("%v: channel was removed", test.name)
+
+		case test.finalSig != nil && !bytes.Equal(
+			info.AuthProof.NodeSig1.Serialize(),
+			test.finalSig.Serialize()):
+
+			t.Fatalf("%v: wrong proof retained", test.name)
+		}
+
+		cleanup()
+	}
+}
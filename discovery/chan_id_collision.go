@@ -0,0 +1,76 @@
+package discovery
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// ChanIDCollisionPolicy is the policy which is applied once a channel
+// announcement arrives for a short channel ID that we already know of, but
+// with funding keys that differ from those of the known channel. As a short
+// channel ID pins down a single funding output, such collisions hint at a
+// forged short channel ID, or at a channel that was re-mined at the same
+// position after a reorg.
+//
+// NOTE: As the funding output of the known channel was verified on-chain once
+// it was added to the graph, the colliding announcement can't be genuine, so
+// the known channel is always kept.
+type ChanIDCollisionPolicy uint8
+
+const (
+	// ChanIDCollisionRejectNew keeps the channel we already know of, and
+	// rejects the colliding announcement.
+	ChanIDCollisionRejectNew ChanIDCollisionPolicy = iota
+)
+
+// String returns a human readable description of the policy.
+func (p ChanIDCollisionPolicy) String() string {
+	switch p {
+	case ChanIDCollisionRejectNew:
+		return "reject-new"
+	default:
+		return "unknown"
+	}
+}
+
+// fundingKeysEqual returns true if the node and bitcoin keys of the passed
+// channel announcement match those of the passed channel.
+func fundingKeysEqual(a *lnwire.ChannelAnnouncement,
+	info *channeldb.ChannelEdgeInfo) bool {
+
+	keysEqual := func(k1, k2 *btcec.PublicKey) bool {
+		if k1 == nil || k2 == nil {
+			return k1 == k2
+		}
+		return k1.IsEqual(k2)
+	}
+
+	return keysEqual(a.NodeID1, info.NodeKey1) &&
+		keysEqual(a.NodeID2, info.NodeKey2) &&
+		keysEqual(a.BitcoinKey1, info.BitcoinKey1) &&
+		keysEqual(a.BitcoinKey2, info.BitcoinKey2)
+}
+
+// resolveChanIDCollision applies the configured ChanIDCollisionPolicy to a
+// remote channel announcement whose funding keys collide with those of the
+// known channel with the same short channel ID. The colliding announcement is
+// always rejected, so the returned error describes why.
+func (d *AuthenticatedGossiper) resolveChanIDCollision(
+	a *lnwire.ChannelAnnouncement, known *channeldb.ChannelEdgeInfo) error {
+
+	shortChanID := a.ShortChannelID.ToUint64()
+	policy := d.cfg.ChanIDCollisionPolicy
+
+	log.Warnf("SHORT CHANNEL ID COLLISION detected for short_chan_id=%v: "+
+		"announced keys (node1=%x, node2=%x) differ from known keys "+
+		"(node1=%x, node2=%x), applying %v policy", shortChanID,
+		a.NodeID1.SerializeCompressed(),
+		a.NodeID2.SerializeCompressed(),
+		known.NodeKey1.SerializeCompressed(),
+		known.NodeKey2.SerializeCompressed(), policy)
+
+	return errors.Errorf("short_chan_id=%v collides with a known "+
+		"channel, keeping the known one", shortChanID)
+}
@@ -0,0 +1,87 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestChanIDCollisionPolicy ensures that a channel announcement for a known
+// short channel ID, but with differing funding keys, is detected as a
+// collision and handled according to the configured policy.
+func TestChanIDCollisionPolicy(t *testing.T) {
+	t.Parallel()
+
+	otherPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("can't create private key: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		policy ChanIDCollisionPolicy
+
+		// keepKnown is whether the known channel should remain within
+		// the graph once the colliding announcement is processed.
+		keepKnown bool
+	}{
+		{
+			name:      "reject new",
+			policy:    ChanIDCollisionRejectNew,
+			keepKnown: true,
+		},
+	}
+
+	for _, test := range tests {
+		ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+			cfg.ChanIDCollisionPolicy = test.policy
+		})
+
+		ca, err := createRemoteChannelAnnouncement(0)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+		err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+		if err != nil {
+			t.Fatalf("%v: can't process channel announcement: %v",
+				test.name, err)
+		}
+		chanID := ca.ShortChannelID.ToUint64()
+
+		// The colliding announcement carries the same short channel
+		// ID, but with different node and bitcoin keys.
+		colliding := *ca
+		colliding.NodeID2 = otherPriv.PubKey()
+		colliding.BitcoinKey2 = otherPriv.PubKey()
+
+		err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+			&colliding, nodeKeyPub2,
+		)
+		if err == nil {
+			t.Fatalf("%v: colliding announcement accepted",
+				test.name)
+		}
+
+		rejections := ctx.gossiper.RecentRejections(1)
+		if len(rejections) != 1 ||
+			rejections[0].Reason != RejectConflict {
+
+			t.Fatalf("%v: expected collision to be recorded as a "+
+				"conflict, got %v", test.name, rejections)
+		}
+
+		info, ok := ctx.router.infos[chanID]
+		switch {
+		case test.keepKnown && !ok:
+			t.Fatalf("%v: known channel was removed", test.name)
+
+		case !test.keepKnown && ok:
+			t.Fatalf("%v: known channel wasn't removed", test.name)
+
+		case ok && !info.NodeKey2.IsEqual(ca.NodeID2):
+			t.Fatalf("%v: known channel was replaced", test.name)
+		}
+
+		cleanup()
+	}
+}
@@ -0,0 +1,69 @@
+package discovery
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// minTimeLockDelta is the smallest CLTV delta that may be set for our
+// channels. A smaller delta wouldn't leave us enough blocks to claim an
+// incoming HTLC on-chain once the outgoing HTLC has been settled.
+const minTimeLockDelta = 4
+
+// ChanPolicyUpdateError is returned by PropagateFeeUpdate if the policy
+// update couldn't be applied to some of the target channels. The update has
+// still been applied to all other channels.
+type ChanPolicyUpdateError struct {
+	// Failures maps the channel point of each channel the update wasn't
+	// applied to, to the reason why.
+	Failures map[wire.OutPoint]error
+}
+
+// Error returns a description of the channels the update couldn't be applied
+// to, ordered by channel point.
+//
+// NOTE: This is part of the error interface.
+func (e *ChanPolicyUpdateError) Error() string {
+	chanPoints := make([]wire.OutPoint, 0, len(e.Failures))
+	for chanPoint := range e.Failures {
+		chanPoints = append(chanPoints, chanPoint)
+	}
+	sort.Slice(chanPoints, func(i, j int) bool {
+		a, b := chanPoints[i], chanPoints[j]
+		if cmp := bytes.Compare(a.Hash[:], b.Hash[:]); cmp != 0 {
+			return cmp < 0
+		}
+		return a.Index < b.Index
+	})
+
+	failures := make([]string, 0, len(chanPoints))
+	for _, chanPoint := range chanPoints {
+		failures = append(failures, fmt.Sprintf("%v: %v", chanPoint,
+			e.Failures[chanPoint]))
+	}
+
+	return fmt.Sprintf("unable to update policy of %v channels: %v",
+		len(failures), strings.Join(failures, ", "))
+}
+
+// validateTimeLockDelta returns an error if the passed CLTV delta is below
+// minTimeLockDelta, or beyond the MaxTimeLockDelta if one is set.
+func (d *AuthenticatedGossiper) validateTimeLockDelta(delta uint16) error {
+	if delta < minTimeLockDelta {
+		return errors.Errorf("time lock delta of %v is below the "+
+			"minimum of %v", delta, minTimeLockDelta)
+	}
+
+	maxDelta := d.cfg.MaxTimeLockDelta
+	if maxDelta != 0 && delta > maxDelta {
+		return errors.Errorf("time lock delta of %v is above the "+
+			"maximum of %v", delta, maxDelta)
+	}
+
+	return nil
+}
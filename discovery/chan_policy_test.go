@@ -0,0 +1,111 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+	"github.com/viacoin/lnd/routing"
+)
+
+// TestFeeUpdateTimeLockDelta ensures that a policy update setting an invalid
+// CLTV delta for some channels is still applied to all other channels, while
+// the invalid ones are reported back to the caller.
+func TestFeeUpdateTimeLockDelta(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.MaxTimeLockDelta = 1000
+	})
+	defer cleanup()
+
+	selfPub, err := btcec.ParsePubKey(
+		nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	const numChans = 3
+	for i := uint64(1); i <= numChans; i++ {
+		ctx.router.infos[i] = &channeldb.ChannelEdgeInfo{
+			ChannelID:    i,
+			ChannelPoint: wire.OutPoint{Index: uint32(i)},
+			NodeKey1:     nodeKeyPub1,
+			NodeKey2:     nodeKeyPub2,
+		}
+		ctx.router.edges[i] = []*channeldb.ChannelEdgePolicy{{
+			ChannelID:     i,
+			LastUpdate:    time.Now(),
+			TimeLockDelta: 144,
+			Node: &channeldb.LightningNode{
+				PubKey: selfPub,
+			},
+		}}
+	}
+
+	// The first channel is given a delta below the minimum, and the
+	// second one a delta beyond the maximum. Only the third one should be
+	// updated.
+	tooLow := wire.OutPoint{Index: 1}
+	tooHigh := wire.OutPoint{Index: 2}
+	valid := wire.OutPoint{Index: 3}
+	feeSchema := routing.FeeSchema{
+		BaseFee: 1000,
+		FeeRate: 1,
+		ChanTimeLockDeltas: map[wire.OutPoint]uint16{
+			tooLow:  minTimeLockDelta - 1,
+			tooHigh: 1001,
+			valid:   40,
+		},
+	}
+	err = ctx.gossiper.PropagateFeeUpdate(feeSchema)
+	policyErr, ok := err.(*ChanPolicyUpdateError)
+	if !ok {
+		t.Fatalf("expected ChanPolicyUpdateError, got %v", err)
+	}
+	if len(policyErr.Failures) != 2 {
+		t.Fatalf("expected 2 failed channels, got %v", err)
+	}
+	for _, chanPoint := range []wire.OutPoint{tooLow, tooHigh} {
+		if _, ok := policyErr.Failures[chanPoint]; !ok {
+			t.Fatalf("expected failure for %v, got %v", chanPoint,
+				err)
+		}
+	}
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		update, ok := msg.(*lnwire.ChannelUpdate)
+		if !ok {
+			t.Fatalf("expected channel update, got %T", msg)
+		}
+		if update.ShortChannelID.ToUint64() != 3 {
+			t.Fatalf("expected update for channel 3, got %v",
+				update.ShortChannelID.ToUint64())
+		}
+		if update.TimeLockDelta != 40 {
+			t.Fatalf("expected time lock delta of 40, got %v",
+				update.TimeLockDelta)
+		}
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("channel update wasn't broadcast")
+	}
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("unexpected broadcast of %T", msg)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// The policies of the failed channels should be left untouched.
+	for i := uint64(1); i < numChans; i++ {
+		edges := ctx.router.edges[i]
+		if len(edges) != 1 || edges[0].TimeLockDelta != 144 {
+			t.Fatalf("policy of channel %v was modified", i)
+		}
+	}
+}
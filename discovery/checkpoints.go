@@ -0,0 +1,64 @@
+package discovery
+
+import (
+	"github.com/go-errors/errors"
+)
+
+// verifyCheckpoint ensures that a block at the passed height is part of a
+// chain consistent with the configured checkpoints. A block at the height is
+// only part of such a chain if our chain agrees with the first checkpoint at
+// or above that height, so we'll compare the hash of our block at the height
+// of that checkpoint against it. Heights beyond the last checkpoint, or below
+// a checkpoint we haven't reached yet, can't be verified and are allowed.
+// The result of each checkpoint is cached, so our block at its height is
+// only fetched once.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) verifyCheckpoint(height uint32) error {
+	for _, checkpoint := range d.cfg.Checkpoints {
+		checkpointHeight := uint32(checkpoint.Height)
+		if checkpointHeight < height {
+			continue
+		}
+		if checkpointHeight > d.bestHeight {
+			return nil
+		}
+
+		if result, ok := d.checkpointResults[checkpointHeight]; ok {
+			return result
+		}
+
+		// If we're unable to fetch our block, then we won't cache
+		// the failure, as the next attempt may succeed.
+		hash, err := d.cfg.FetchBlockHash(checkpointHeight)
+		if err != nil {
+			return errors.Errorf("unable to fetch block hash at "+
+				"checkpoint height %v: %v", checkpointHeight, err)
+		}
+
+		var result error
+		if !hash.IsEqual(checkpoint.Hash) {
+			result = errors.Errorf("block at height %v is %v, "+
+				"which conflicts with checkpoint %v",
+				checkpointHeight, hash, checkpoint.Hash)
+		}
+		d.checkpointResults[checkpointHeight] = result
+
+		return result
+	}
+
+	return nil
+}
+
+// dropCheckpointResults drops the cached results of the checkpoints at or
+// above the passed height, as the blocks at their heights have been
+// disconnected from our chain.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) dropCheckpointResults(height uint32) {
+	for checkpointHeight := range d.checkpointResults {
+		if checkpointHeight >= height {
+			delete(d.checkpointResults, checkpointHeight)
+		}
+	}
+}
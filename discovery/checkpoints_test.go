@@ -0,0 +1,68 @@
+package discovery
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestCheckpointValidation ensures that remote channel announcements at
+// heights covered by a checkpoint are rejected if our chain conflicts with
+// the checkpoint, while those beyond the last checkpoint are accepted, and
+// that our block at the checkpoint height is only fetched once.
+func TestCheckpointValidation(t *testing.T) {
+	t.Parallel()
+
+	// The hash of the block at the checkpoint height differs from the
+	// checkpoint itself.
+	checkpointHash := chainhash.Hash{1}
+
+	var fetches uint32
+	ctx, cleanup := newTestCtx(t, 100, func(cfg *Config) {
+		cfg.Checkpoints = []chaincfg.Checkpoint{
+			{Height: 10, Hash: &checkpointHash},
+		}
+		cfg.FetchBlockHash = func(uint32) (*chainhash.Hash, error) {
+			atomic.AddUint32(&fetches, 1)
+			return &chainhash.Hash{2}, nil
+		}
+	})
+	defer cleanup()
+
+	processAnn := func(height uint32) error {
+		ca, err := createRemoteChannelAnnouncement(height)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			ca, nodeKeyPub2,
+		):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("channel announcement wasn't processed")
+		}
+
+		return nil
+	}
+
+	if err := processAnn(5); err == nil {
+		t.Fatal("announcement conflicting with checkpoint was accepted")
+	}
+	if err := processAnn(10); err == nil {
+		t.Fatal("announcement conflicting with checkpoint was accepted")
+	}
+	if err := processAnn(20); err != nil {
+		t.Fatalf("announcement beyond checkpoints was rejected: %v",
+			err)
+	}
+
+	if n := atomic.LoadUint32(&fetches); n != 1 {
+		t.Fatalf("expected checkpoint block to be fetched once, "+
+			"fetched %v times", n)
+	}
+}
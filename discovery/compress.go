@@ -0,0 +1,52 @@
+package discovery
+
+import (
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// compressMessages packs the passed messages into as few compressed batches
+// as possible, preserving their order. Each batch holds at most
+// lnwire.MaxCompressedBatchSize bytes of messages before compression.
+func compressMessages(msgs []lnwire.Message) ([]lnwire.Message, error) {
+	var (
+		batches   []lnwire.Message
+		pending   []lnwire.Message
+		batchSize int
+	)
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		batch, err := lnwire.NewCompressedBatch(pending...)
+		if err != nil {
+			return err
+		}
+		batches = append(batches, batch)
+
+		pending = nil
+		batchSize = 0
+		return nil
+	}
+
+	for _, msg := range msgs {
+		size, err := lnwire.BatchedMessageSize(msg)
+		if err != nil {
+			return nil, err
+		}
+
+		if batchSize+size > lnwire.MaxCompressedBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+
+		pending = append(pending, msg)
+		batchSize += size
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return batches, nil
+}
@@ -0,0 +1,131 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestSynchronizeNodeCompressed ensures that the graph sent to a peer which
+// supports compression arrives within compressed batches which decompress to
+// the original announcements, while other peers receive it uncompressed.
+func TestSynchronizeNodeCompressed(t *testing.T) {
+	t.Parallel()
+
+	router := newMockRouter(0)
+
+	// We'll populate a synthetic graph with enough channels for the sync
+	// to span multiple compressed batches.
+	const numChans = 200
+	for i := 0; i < numChans; i++ {
+		var keys [4]*btcec.PublicKey
+		for j := range keys {
+			priv, err := btcec.NewPrivateKey(btcec.S256())
+			if err != nil {
+				t.Fatalf("unable to generate key: %v", err)
+			}
+			keys[j] = priv.PubKey()
+		}
+
+		chanID := uint64(i)
+		router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+			ChannelID:   chanID,
+			NodeKey1:    keys[0],
+			NodeKey2:    keys[1],
+			BitcoinKey1: keys[2],
+			BitcoinKey2: keys[3],
+			AuthProof: &channeldb.ChannelAuthProof{
+				NodeSig1:    testSig,
+				NodeSig2:    testSig,
+				BitcoinSig1: testSig,
+				BitcoinSig2: testSig,
+			},
+		}
+		router.edges[chanID] = []*channeldb.ChannelEdgePolicy{{
+			Signature:     testSig,
+			ChannelID:     chanID,
+			LastUpdate:    time.Unix(int64(i), 0),
+			TimeLockDelta: 144,
+		}}
+	}
+
+	for _, compress := range []bool{true, false} {
+		var sent []lnwire.Message
+		gossiper := &AuthenticatedGossiper{
+			cfg: &Config{
+				Router: router,
+				SendToPeer: func(_ *btcec.PublicKey,
+					msgs ...lnwire.Message) error {
+
+					sent = append(sent, msgs...)
+					return nil
+				},
+				SupportsCompression: func(
+					*btcec.PublicKey) bool {
+
+					return compress
+				},
+			},
+		}
+
+		original, _, _, err := gossiper.fetchGraphAnnouncements()
+		if err != nil {
+			t.Fatalf("unable to fetch graph: %v", err)
+		}
+
+		err = gossiper.synchronizeWithNode(&syncRequest{
+			node: nodeKeyPub2,
+		})
+		if err != nil {
+			t.Fatalf("unable to sync with node: %v", err)
+		}
+
+		received := sent
+		if compress {
+			if len(sent) < 2 {
+				t.Fatalf("expected multiple batches, got %v",
+					len(sent))
+			}
+
+			received = nil
+			for _, msg := range sent {
+				batch, ok := msg.(*lnwire.CompressedBatch)
+				if !ok {
+					t.Fatalf("expected compressed batch, "+
+						"got %T", msg)
+				}
+
+				msgs, err := batch.Messages()
+				if err != nil {
+					t.Fatalf("unable to decompress "+
+						"batch: %v", err)
+				}
+				received = append(received, msgs...)
+			}
+		}
+
+		if len(received) != len(original) {
+			t.Fatalf("expected %v messages, got %v",
+				len(original), len(received))
+		}
+		for i := range original {
+			var want, got bytes.Buffer
+			_, err := lnwire.WriteMessage(&want, original[i], 0)
+			if err != nil {
+				t.Fatalf("unable to encode message: %v", err)
+			}
+			_, err = lnwire.WriteMessage(&got, received[i], 0)
+			if err != nil {
+				t.Fatalf("unable to encode message: %v", err)
+			}
+			if !bytes.Equal(want.Bytes(), got.Bytes()) {
+				t.Fatalf("message %v doesn't match original",
+					i)
+			}
+		}
+	}
+}
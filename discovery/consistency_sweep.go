@@ -0,0 +1,87 @@
+package discovery
+
+import (
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// sweepConsistency reconciles the auxiliary state of the gossiper with the
+// graph of the router and our view of the chain, which may drift apart over
+// time. It looks for three kinds of inconsistencies:
+//
+//   - premature announcements for heights we've already reached, which
+//     should have been processed once their block arrived.
+//   - waiting proofs for channels whose full proof the router already has,
+//     which will never be matched up.
+//   - orphan updates for channels which are now known to the router.
+//
+// The stale waiting proofs are removed, while the premature announcements
+// and orphan updates are returned, such that they can be re-processed.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) sweepConsistency() ([]*networkMsg, error) {
+	// First, we'll gather all premature announcements which are no longer
+	// premature. The on-disk queue is covered by maturing the announcements
+	// of our current height.
+	var premature []*networkMsg
+	for height, nMsgs := range d.prematureAnnouncements {
+		if height < d.bestHeight {
+			premature = append(premature, nMsgs...)
+			delete(d.prematureAnnouncements, height)
+			d.numPremature -= len(nMsgs)
+		}
+	}
+	premature = append(premature, d.maturePremature(d.bestHeight)...)
+
+	// Next, we'll remove the waiting proofs of channels that have already
+	// been announced. The proofs are gathered before querying the router,
+	// as the graph resides within the same database.
+	var proofs []*channeldb.WaitingProof
+	err := d.waitingProofs.ForAll(func(p *channeldb.WaitingProof) error {
+		proofs = append(proofs, p)
+		return nil
+	})
+	if err != nil && err != channeldb.ErrWaitingProofNotFound {
+		return nil, err
+	}
+
+	var numStaleProofs int
+	for _, proof := range proofs {
+		info, _, _, err := d.cfg.Router.GetChannelByID(
+			proof.ShortChannelID,
+		)
+		if err != nil || info.AuthProof == nil {
+			continue
+		}
+
+		err = d.waitingProofs.Remove(proof.Key())
+		if err != nil && err != channeldb.ErrWaitingProofNotFound {
+			return nil, err
+		}
+		numStaleProofs++
+	}
+
+	// Finally, we'll gather the orphan updates of channels that are now
+	// known to the router.
+	var orphans []*networkMsg
+	if d.orphanUpdates != nil {
+		for _, chanID := range d.orphanUpdates.chanIDs() {
+			shortChanID := lnwire.NewShortChanIDFromInt(chanID)
+			_, _, _, err := d.cfg.Router.GetChannelByID(shortChanID)
+			if err != nil {
+				continue
+			}
+
+			live, expired := d.orphanUpdates.take(chanID, d.now())
+			d.rejectExpiredOrphans(expired)
+			orphans = append(orphans, live...)
+		}
+	}
+
+	log.Infof("Consistency sweep found %v overdue premature "+
+		"announcements, %v stale waiting proofs and %v resolvable "+
+		"orphan updates", len(premature), numStaleProofs,
+		len(orphans))
+
+	return append(premature, orphans...), nil
+}
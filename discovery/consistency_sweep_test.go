@@ -0,0 +1,118 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestConsistencySweep ensures that the consistency sweep reconciles each
+// kind of inconsistency between the gossiper's state and the router.
+func TestConsistencySweep(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 1, func(cfg *Config) {
+		cfg.OrphanUpdateLimit = 10
+		cfg.OrphanUpdateTTL = time.Hour
+	})
+	defer cleanup()
+
+	// We'll start with an announced channel, for which we'll also hold a
+	// half of its proof, as well as an update left behind as an orphan.
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+	chanID := batch.remoteChanAnn.ShortChannelID
+	err = ctx.router.AddEdge(&channeldb.ChannelEdgeInfo{
+		ChannelID: chanID.ToUint64(),
+		NodeKey1:  nodeKeyPub1,
+		NodeKey2:  nodeKeyPub2,
+		AuthProof: &channeldb.ChannelAuthProof{
+			NodeSig1:    batch.remoteChanAnn.NodeSig1,
+			NodeSig2:    batch.remoteChanAnn.NodeSig2,
+			BitcoinSig1: batch.remoteChanAnn.BitcoinSig1,
+			BitcoinSig2: batch.remoteChanAnn.BitcoinSig2,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	batch.remoteProofAnn.ShortChannelID = chanID
+	staleProof := channeldb.NewWaitingProof(true, batch.remoteProofAnn)
+	if err := ctx.gossiper.waitingProofs.Add(staleProof); err != nil {
+		t.Fatalf("unable to add waiting proof: %v", err)
+	}
+
+	// The proof of a channel we don't know of yet should be retained.
+	pendingProofAnn := *batch.localProofAnn
+	pendingProofAnn.ShortChannelID = lnwire.NewShortChanIDFromInt(100)
+	pendingProof := channeldb.NewWaitingProof(false, &pendingProofAnn)
+	if err := ctx.gossiper.waitingProofs.Add(pendingProof); err != nil {
+		t.Fatalf("unable to add waiting proof: %v", err)
+	}
+
+	orphan := &networkMsg{
+		msg:      batch.chanUpdAnn,
+		isRemote: true,
+		peer:     nodeKeyPub2,
+		err:      make(chan error, 1),
+	}
+	ctx.gossiper.orphanUpdates.add(chanID.ToUint64(), orphan, time.Now())
+
+	// We'll also hold a premature announcement for a height we've already
+	// reached, along with one that's still premature.
+	overdue := &networkMsg{
+		msg:      batch.remoteChanAnn,
+		isRemote: true,
+		peer:     nodeKeyPub2,
+		err:      make(chan error, 1),
+	}
+	premature := &networkMsg{
+		msg:      batch.nodeAnn2,
+		isRemote: true,
+		peer:     nodeKeyPub2,
+		err:      make(chan error, 1),
+	}
+	ctx.gossiper.prematureAnnouncements[0] = []*networkMsg{overdue}
+	ctx.gossiper.prematureAnnouncements[10] = []*networkMsg{premature}
+	ctx.gossiper.numPremature = 2
+
+	nMsgs, err := ctx.gossiper.sweepConsistency()
+	if err != nil {
+		t.Fatalf("unable to sweep: %v", err)
+	}
+
+	// The overdue premature announcement and the orphan update should be
+	// returned to be re-processed.
+	if len(nMsgs) != 2 || nMsgs[0] != overdue || nMsgs[1] != orphan {
+		t.Fatalf("expected overdue announcement and orphan update to "+
+			"be re-processed, got %v messages", len(nMsgs))
+	}
+	if _, ok := ctx.gossiper.prematureAnnouncements[0]; ok {
+		t.Fatal("overdue premature announcement wasn't removed")
+	}
+	if _, ok := ctx.gossiper.prematureAnnouncements[10]; !ok {
+		t.Fatal("premature announcement was removed")
+	}
+	if ctx.gossiper.numPremature != 1 {
+		t.Fatalf("expected a single premature announcement, got %v",
+			ctx.gossiper.numPremature)
+	}
+	if len(ctx.gossiper.orphanUpdates.chanIDs()) != 0 {
+		t.Fatal("resolved orphan update wasn't removed")
+	}
+
+	// Only the waiting proof of the announced channel should be removed.
+	_, err = ctx.gossiper.waitingProofs.Get(staleProof.Key())
+	if err != channeldb.ErrWaitingProofNotFound {
+		t.Fatalf("stale waiting proof wasn't removed: %v", err)
+	}
+	_, err = ctx.gossiper.waitingProofs.Get(pendingProof.Key())
+	if err != nil {
+		t.Fatalf("pending waiting proof was removed: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package discovery
+
+import (
+	"math"
+	"sort"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// broadcastBatch broadcasts the passed batch of announcements to all of our
+// connected peers. If a PeerDistance function is configured, then the batch
+// is sent to each peer in turn, starting with the peers closest within the
+// graph to the nodes that originated the announcements, such that the batch
+// reaches the vicinity of its origin before fanning out further. Once
+// broadcast, the batch is also mirrored over the SecondaryBroadcast transport,
+// if configured. As a failed batch is broadcast once more later on, it's only
+// mirrored once it succeeds, such that it's mirrored just once.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) broadcastBatch(msgs ...lnwire.Message) error {
+	if d.cfg.PeerDistance == nil {
+		if err := d.cfg.Broadcast(nil, msgs...); err != nil {
+			return err
+		}
+
+		d.mirrorBroadcast(msgs...)
+		return nil
+	}
+
+	origins := d.announcementOrigins(msgs)
+	peers := d.cfg.ConnectedPeers()
+
+	// The distance of a peer to the batch is its distance to the closest
+	// of the origins. Peers for which no distance is known are sent the
+	// batch last.
+	distances := make(map[*btcec.PublicKey]uint32, len(peers))
+	for _, peer := range peers {
+		distances[peer] = math.MaxUint32
+		for _, origin := range origins {
+			dist, ok := d.cfg.PeerDistance(peer, origin)
+			if ok && dist < distances[peer] {
+				distances[peer] = dist
+			}
+		}
+	}
+
+	sort.SliceStable(peers, func(i, j int) bool {
+		return distances[peers[i]] < distances[peers[j]]
+	})
+
+	// A failure to reach a single peer shouldn't result in the whole
+	// batch being broadcast again, so we'll only log it.
+	for _, peer := range peers {
+		if err := d.sendToPeer(peer, msgs...); err != nil {
+			log.Errorf("unable to send batch announcements to "+
+				"peer %x: %v", peer.SerializeCompressed(), err)
+		}
+	}
+	d.mirrorBroadcast(msgs...)
+
+	return nil
+}
+
+// announcementOrigins returns the identity keys of the nodes that originated
+// the passed announcements. For channel announcements, both nodes of the
+// channel are considered its origin. Announcements whose origin can't be
+// determined are skipped.
+func (d *AuthenticatedGossiper) announcementOrigins(
+	msgs []lnwire.Message) []*btcec.PublicKey {
+
+	var (
+		origins []*btcec.PublicKey
+		seen    = make(map[[33]byte]struct{})
+	)
+	addOrigin := func(node *btcec.PublicKey) {
+		var nodeID [33]byte
+		copy(nodeID[:], node.SerializeCompressed())
+		if _, ok := seen[nodeID]; ok {
+			return
+		}
+
+		seen[nodeID] = struct{}{}
+		origins = append(origins, node)
+	}
+
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case *lnwire.ChannelAnnouncement:
+			addOrigin(msg.NodeID1)
+			addOrigin(msg.NodeID2)
+
+		case *lnwire.ChannelUpdate:
+			info, _, _, err := d.cfg.Router.GetChannelByID(
+				msg.ShortChannelID,
+			)
+			if err != nil {
+				continue
+			}
+
+			if msg.Flags&lnwire.ChanUpdateDirection == 0 {
+				addOrigin(info.NodeKey1)
+			} else {
+				addOrigin(info.NodeKey2)
+			}
+
+		case *lnwire.NodeAnnouncement:
+			addOrigin(msg.NodeID)
+		}
+	}
+
+	return origins
+}
@@ -0,0 +1,168 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestPeerDistanceBroadcast checks that once a PeerDistance function is
+// configured, a batch of new announcements is sent to the peers closest to
+// the origin of the announcements first.
+func TestPeerDistanceBroadcast(t *testing.T) {
+	t.Parallel()
+
+	// We'll connect to three peers: one far from the origin of the
+	// announcement, one close to it, and one of unknown distance.
+	var peers []*btcec.PublicKey
+	for i := 0; i < 3; i++ {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		peers = append(peers, priv.PubKey())
+	}
+	farPeer, closePeer, unknownPeer := peers[0], peers[1], peers[2]
+
+	sends := make(chan *btcec.PublicKey, len(peers))
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.ConnectedPeers = func() []*btcec.PublicKey {
+			return []*btcec.PublicKey{
+				farPeer, unknownPeer, closePeer,
+			}
+		}
+		cfg.PeerDistance = func(peer,
+			node *btcec.PublicKey) (uint32, bool) {
+
+			if !node.IsEqual(nodeKeyPub2) {
+				return 0, false
+			}
+
+			switch {
+			case peer.IsEqual(farPeer):
+				return 3, true
+			case peer.IsEqual(closePeer):
+				return 1, true
+			default:
+				return 0, false
+			}
+		}
+		cfg.SendToPeer = func(target *btcec.PublicKey,
+			_ ...lnwire.Message) error {
+
+			sends <- target
+			return nil
+		}
+	})
+	defer cleanup()
+
+	na, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(na, farPeer):
+		if err != nil {
+			t.Fatalf("can't process remote announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't processed")
+	}
+
+	// The batch should be sent to the close peer first, followed by the
+	// far peer, and finally the peer of unknown distance.
+	expectedOrder := []*btcec.PublicKey{closePeer, farPeer, unknownPeer}
+	for i, expected := range expectedOrder {
+		select {
+		case peer := <-sends:
+			if !peer.IsEqual(expected) {
+				t.Fatalf("expected send #%v to go to peer %x, "+
+					"went to %x", i,
+					expected.SerializeCompressed(),
+					peer.SerializeCompressed())
+			}
+		case <-time.After(2 * trickleDelay):
+			t.Fatalf("batch wasn't sent to peer #%v", i)
+		}
+	}
+
+	// As the batch was sent to each peer directly, it shouldn't have been
+	// broadcast as well.
+	select {
+	case <-ctx.broadcastedMessage:
+		t.Fatal("batch shouldn't have been broadcast")
+	case <-time.After(2 * trickleDelay):
+	}
+}
+
+// TestAnnouncementOrigins checks that the origin of a channel update is the
+// node of the channel in the direction of the update, regardless of any other
+// flags of the update.
+func TestAnnouncementOrigins(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	chanID := lnwire.NewShortChanIDFromInt(1)
+	ctx.router.infos[chanID.ToUint64()] = &channeldb.ChannelEdgeInfo{
+		ChannelID: chanID.ToUint64(),
+		NodeKey1:  nodeKeyPub1,
+		NodeKey2:  nodeKeyPub2,
+	}
+
+	tests := []struct {
+		name   string
+		flags  uint16
+		origin *btcec.PublicKey
+	}{
+		{
+			name:   "first node",
+			flags:  0,
+			origin: nodeKeyPub1,
+		},
+		{
+			name:   "second node",
+			flags:  lnwire.ChanUpdateDirection,
+			origin: nodeKeyPub2,
+		},
+		{
+			name: "disabled first node",
+			flags: lnwire.ChanUpdateDisabled |
+				lnwire.ChanUpdateOptionMaxHtlc,
+			origin: nodeKeyPub1,
+		},
+		{
+			name: "disabled second node",
+			flags: lnwire.ChanUpdateDirection |
+				lnwire.ChanUpdateDisabled |
+				lnwire.ChanUpdateOptionMaxHtlc,
+			origin: nodeKeyPub2,
+		},
+	}
+
+	for _, test := range tests {
+		update := &lnwire.ChannelUpdate{
+			ShortChannelID: chanID,
+			Flags:          test.flags,
+		}
+
+		origins := ctx.gossiper.announcementOrigins(
+			[]lnwire.Message{update},
+		)
+		if len(origins) != 1 {
+			t.Fatalf("%v: expected 1 origin, got %v", test.name,
+				len(origins))
+		}
+		if !origins[0].IsEqual(test.origin) {
+			t.Fatalf("%v: expected origin %x, got %x", test.name,
+				test.origin.SerializeCompressed(),
+				origins[0].SerializeCompressed())
+		}
+	}
+}
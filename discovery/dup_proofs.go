@@ -0,0 +1,38 @@
+package discovery
+
+import (
+	"github.com/viacoin/lnd/channeldb"
+)
+
+// isDuplicateProof returns true if the passed half of a channel proof is
+// already stored as a waiting proof with identical signatures. Peers may
+// resend their half of the proof repeatedly, and such duplicates needn't be
+// stored or sent once more. A stored half with differing signatures isn't
+// considered a duplicate.
+func (d *AuthenticatedGossiper) isDuplicateProof(
+	proof *channeldb.WaitingProof) bool {
+
+	stored, err := d.waitingProofs.Get(proof.Key())
+	if err != nil {
+		return false
+	}
+
+	return sigsEqual(stored.NodeSignature, proof.NodeSignature) &&
+		sigsEqual(stored.BitcoinSignature, proof.BitcoinSignature)
+}
+
+// removeWaitingProofs removes both halves of the channel proof of which the
+// passed proof is one half from the waiting proof store, if stored.
+func (d *AuthenticatedGossiper) removeWaitingProofs(
+	proof *channeldb.WaitingProof) {
+
+	keys := []channeldb.WaitingProofKey{proof.Key(), proof.OppositeKey()}
+	for _, key := range keys {
+		err := d.waitingProofs.Remove(key)
+		if err != nil && err != channeldb.ErrWaitingProofNotFound {
+			log.Errorf("unable to remove waiting proof for "+
+				"short_chan_id=%v: %v",
+				proof.ShortChannelID.ToUint64(), err)
+		}
+	}
+}
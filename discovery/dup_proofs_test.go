@@ -0,0 +1,185 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestProofForAnnouncedChannel ensures that a proof for a channel which has
+// already been announced is acknowledged without being added once more, that
+// any lingering halves of its proof are cleaned up, and that our own half is
+// still sent to the remote peer.
+func TestProofForAnnouncedChannel(t *testing.T) {
+	t.Parallel()
+
+	sentToPeer := make(chan *btcec.PublicKey, 1)
+	ctx, cleanup := newTestCtx(t, proofMatureDelta, func(cfg *Config) {
+		cfg.SendToPeer = func(target *btcec.PublicKey,
+			msg ...lnwire.Message) error {
+
+			sentToPeer <- target
+			return nil
+		}
+	})
+	defer cleanup()
+
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+
+	localKey := batch.nodeAnn1.NodeID
+	remoteKey := batch.nodeAnn2.NodeID
+
+	assertProofSent := func() {
+		select {
+		case target := <-sentToPeer:
+			if !target.IsEqual(remoteKey) {
+				t.Fatalf("proof sent to %x instead of remote "+
+					"peer", target.SerializeCompressed())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("local proof wasn't sent to remote peer")
+		}
+	}
+
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(batch.localChanAnn, localKey)
+	if err != nil {
+		t.Fatalf("unable to process channel announcement: %v", err)
+	}
+
+	// Our half of the proof is left waiting for the remote half, and sent
+	// to the remote peer.
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(batch.localProofAnn, localKey)
+	if err != nil {
+		t.Fatalf("unable to process local proof: %v", err)
+	}
+	assertProofSent()
+
+	// In the meantime, the channel has been announced with a full proof.
+	chanID := batch.localChanAnn.ShortChannelID.ToUint64()
+	ctx.router.infos[chanID].AuthProof = &channeldb.ChannelAuthProof{}
+
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+		batch.remoteProofAnn, remoteKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to process remote proof: %v", err)
+	}
+
+	if ctx.router.addProofCalls != 0 {
+		t.Fatalf("proof was added %v times for announced channel",
+			ctx.router.addProofCalls)
+	}
+
+	var numProofs int
+	err = ctx.gossiper.waitingProofs.ForAll(
+		func(*channeldb.WaitingProof) error {
+			numProofs++
+			return nil
+		},
+	)
+	if err != nil && err != channeldb.ErrWaitingProofNotFound {
+		t.Fatalf("unable to retrieve objects from store: %v", err)
+	}
+	if numProofs != 0 {
+		t.Fatalf("expected empty store, found %v waiting proofs",
+			numProofs)
+	}
+
+	// Should our half of the proof be processed once more, e.g. as the
+	// remote peer reconnected, then it should still be sent to the remote
+	// peer, as it may not have assembled the full proof yet.
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(batch.localProofAnn, localKey)
+	if err != nil {
+		t.Fatalf("unable to process local proof: %v", err)
+	}
+
+	assertProofSent()
+}
+
+// TestDuplicateHalfProof ensures that a half of a channel proof which is
+// received once more is handled idempotently, such that it's neither stored
+// nor sent to the remote peer again.
+func TestDuplicateHalfProof(t *testing.T) {
+	t.Parallel()
+
+	sentToPeer := make(chan lnwire.Message, 10)
+	ctx, cleanup := newTestCtx(t, proofMatureDelta, func(cfg *Config) {
+		cfg.SendToPeer = func(_ *btcec.PublicKey,
+			msgs ...lnwire.Message) error {
+
+			for _, msg := range msgs {
+				sentToPeer <- msg
+			}
+			return nil
+		}
+	})
+	defer cleanup()
+
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+
+	localKey := batch.nodeAnn1.NodeID
+
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(batch.localChanAnn, localKey)
+	if err != nil {
+		t.Fatalf("unable to process :%v", err)
+	}
+
+	// We'll process our half of the proof twice. Both should be accepted,
+	// but the proof should only be sent to the remote peer once.
+	for i := 0; i < 2; i++ {
+		err = <-ctx.gossiper.ProcessLocalAnnouncement(
+			batch.localProofAnn, localKey,
+		)
+		if err != nil {
+			t.Fatalf("unable to process local proof: %v", err)
+		}
+	}
+
+	select {
+	case <-sentToPeer:
+	case <-time.After(time.Second):
+		t.Fatal("local proof wasn't sent to the remote peer")
+	}
+	select {
+	case <-sentToPeer:
+		t.Fatal("duplicate local proof was sent to the remote peer")
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Only a single waiting proof should have been stored.
+	number := 0
+	if err := ctx.gossiper.waitingProofs.ForAll(
+		func(*channeldb.WaitingProof) error {
+			number++
+			return nil
+		},
+	); err != nil {
+		t.Fatalf("unable to retrieve objects from store: %v", err)
+	}
+	if number != 1 {
+		t.Fatalf("expected 1 waiting proof, got %v", number)
+	}
+
+	// The remote half of the proof for a channel we don't know of yet
+	// should also be accepted once more, rather than be rejected for
+	// already being stored.
+	orphanProof := batch.remoteProofAnn
+	orphanProof.ShortChannelID = lnwire.NewShortChanIDFromInt(1)
+	for i := 0; i < 2; i++ {
+		err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+			orphanProof, batch.nodeAnn2.NodeID,
+		)
+		if err != nil {
+			t.Fatalf("unable to process remote proof: %v", err)
+		}
+	}
+}
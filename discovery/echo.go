@@ -0,0 +1,60 @@
+package discovery
+
+import (
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// isEchoedAnnouncement returns true if the passed remote announcement is one
+// of our own channel announcements or updates which a peer has gossiped back
+// to us, and which carries nothing we don't already know. Such an
+// announcement can be ignored without being validated.
+func (d *AuthenticatedGossiper) isEchoedAnnouncement(nMsg *networkMsg) bool {
+	if !d.cfg.DropEchoedAnnouncements || !nMsg.isRemote {
+		return false
+	}
+
+	switch msg := nMsg.msg.(type) {
+	// An announcement of our own channel is an echo if we already know of
+	// the channel along with its full proof. Otherwise, the peer may have
+	// assembled the proof ahead of us.
+	case *lnwire.ChannelAnnouncement:
+		if !msg.NodeID1.IsEqual(d.selfKey) &&
+			!msg.NodeID2.IsEqual(d.selfKey) {
+
+			return false
+		}
+
+		info, _, _, err := d.cfg.Router.GetChannelByID(
+			msg.ShortChannelID,
+		)
+		if err != nil {
+			return false
+		}
+
+		return info.AuthProof != nil
+
+	// An update of our own direction of a channel is an echo if it isn't
+	// more recent than the policy we already know of, as only we are able
+	// to sign a newer one.
+	case *lnwire.ChannelUpdate:
+		info, e1, e2, err := d.cfg.Router.GetChannelByID(
+			msg.ShortChannelID,
+		)
+		if err != nil {
+			return false
+		}
+
+		nodeKey, policy := info.NodeKey1, e1
+		if msg.Flags&lnwire.ChanUpdateDirection != 0 {
+			nodeKey, policy = info.NodeKey2, e2
+		}
+		if !nodeKey.IsEqual(d.selfKey) || policy == nil {
+			return false
+		}
+
+		timestamp := int64(msg.Timestamp)
+		return timestamp <= policy.LastUpdate.Unix()
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestDropEchoedAnnouncements ensures that announcements of our own channels
+// which are gossiped back to us by peers are recognized as echoes, and
+// neither applied nor broadcast once more.
+func TestDropEchoedAnnouncements(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.DropEchoedAnnouncements = true
+	})
+	defer cleanup()
+
+	// The channel announcement is between our node and the remote node,
+	// and the update is for our direction of the channel.
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	processAnns := func() {
+		for _, msg := range []lnwire.Message{ca, ua} {
+			select {
+			case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+				msg, nodeKeyPub2,
+			):
+				if err != nil {
+					t.Fatalf("can't process %T: %v", msg, err)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("%T wasn't processed", msg)
+			}
+		}
+	}
+
+	// The first time we learn of the announcements, they should be
+	// applied and broadcast as usual.
+	processAnns()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ctx.broadcastedMessage:
+		case <-time.After(2 * trickleDelay):
+			t.Fatal("announcement wasn't broadcast")
+		}
+	}
+
+	// Once they're echoed back to us, they should be accepted without
+	// being applied or broadcast again.
+	processAnns()
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("echoed announcement was broadcast: %T", msg)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	numUpdates := len(ctx.router.edges[ua.ShortChannelID.ToUint64()])
+	if numUpdates != 1 {
+		t.Fatalf("expected 1 applied update, got %v", numUpdates)
+	}
+}
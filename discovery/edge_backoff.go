@@ -0,0 +1,100 @@
+package discovery
+
+import "time"
+
+// edgeFailures tracks the consecutive failures to add the channel
+// announcements of a single peer to the graph.
+type edgeFailures struct {
+	// count is the number of consecutive failures.
+	count int
+
+	// cooldownEnd is the time until which all further announcements of
+	// the peer are rejected.
+	cooldownEnd time.Time
+}
+
+// edgeFailureBackoff applies an exponentially growing cooldown to peers whose
+// channel announcements repeatedly fail to be added to the graph for reasons
+// other than being outdated, such as being malformed. This prevents such a
+// peer from having us waste effort on validating its announcements.
+//
+// NOTE: This struct isn't safe for concurrent access.
+type edgeFailureBackoff struct {
+	peers map[[33]byte]*edgeFailures
+}
+
+// newEdgeFailureBackoff returns a new, empty edgeFailureBackoff.
+func newEdgeFailureBackoff() *edgeFailureBackoff {
+	return &edgeFailureBackoff{
+		peers: make(map[[33]byte]*edgeFailures),
+	}
+}
+
+// cooldownEnd returns the end of the cooldown of the peer, and true if the
+// cooldown is still in effect at the passed time.
+func (b *edgeFailureBackoff) cooldownEnd(peer [33]byte,
+	now time.Time) (time.Time, bool) {
+
+	failures, ok := b.peers[peer]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return failures.cooldownEnd, now.Before(failures.cooldownEnd)
+}
+
+// recordFailure records another consecutive failure of the peer, and starts
+// its cooldown at the passed time. The cooldown starts out at the base
+// duration, and doubles with each further consecutive failure up to the
+// passed maximum. The duration of the new cooldown is returned.
+func (b *edgeFailureBackoff) recordFailure(peer [33]byte, now time.Time,
+	base, max time.Duration) time.Duration {
+
+	failures, ok := b.peers[peer]
+	if !ok {
+		failures = &edgeFailures{}
+		b.peers[peer] = failures
+	}
+	failures.count++
+
+	cooldown := base
+	for i := 1; i < failures.count && cooldown < max; i++ {
+		cooldown *= 2
+	}
+	if cooldown > max {
+		cooldown = max
+	}
+
+	failures.cooldownEnd = now.Add(cooldown)
+
+	return cooldown
+}
+
+// reset clears the consecutive failures of the peer, following an
+// announcement of it which was successfully added to the graph.
+func (b *edgeFailureBackoff) reset(peer [33]byte) {
+	delete(b.peers, peer)
+}
+
+// recordEdgeFailure records the failure to add the channel announcement of
+// the passed message to the graph, starting a cooldown for the peer that sent
+// it.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) recordEdgeFailure(nMsg *networkMsg) {
+	if !nMsg.isRemote || nMsg.peer == nil || d.cfg.EdgeFailureBackoff == 0 {
+		return
+	}
+
+	var peer [33]byte
+	copy(peer[:], nMsg.peer.SerializeCompressed())
+
+	cooldown := d.edgeFailures.recordFailure(
+		peer, d.now(), d.cfg.EdgeFailureBackoff,
+		d.cfg.MaxEdgeFailureBackoff,
+	)
+
+	log.Warnf("Channel announcement from peer=%x failed to be added to "+
+		"the graph, rejecting its announcements for %v", peer,
+		cooldown)
+}
@@ -0,0 +1,102 @@
+package discovery
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestEdgeFailureBackoff ensures that a peer whose channel announcements
+// repeatedly fail to be added to the graph has its announcements rejected
+// during an exponentially growing cooldown, which is reset once one of its
+// announcements succeeds.
+func TestEdgeFailureBackoff(t *testing.T) {
+	t.Parallel()
+
+	const backoff = time.Hour
+
+	ctx, cleanup := newTestCtx(t, 100, func(cfg *Config) {
+		cfg.EdgeFailureBackoff = backoff
+		cfg.MaxEdgeFailureBackoff = 4 * backoff
+	})
+	defer cleanup()
+
+	var nowMtx sync.Mutex
+	now := time.Now()
+	ctx.gossiper.now = func() time.Time {
+		nowMtx.Lock()
+		defer nowMtx.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		nowMtx.Lock()
+		now = now.Add(d)
+		nowMtx.Unlock()
+	}
+
+	height := uint32(0)
+	processChanAnn := func(peer *btcec.PublicKey) error {
+		height++
+		ann, err := createRemoteChannelAnnouncement(height)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ann, peer):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("channel announcement wasn't processed")
+		}
+		return nil
+	}
+
+	// The first failing announcement should start a cooldown for the
+	// peer, during which its further announcements are rejected without
+	// being handed to the router.
+	ctx.router.addEdgeFailures = 3
+	if err := processChanAnn(nodeKeyPub1); err == nil {
+		t.Fatal("failing announcement was accepted")
+	}
+	if err := processChanAnn(nodeKeyPub1); err == nil {
+		t.Fatal("announcement accepted during cooldown")
+	}
+	if ctx.router.addEdgeFailures != 2 {
+		t.Fatal("announcement handed to router during cooldown")
+	}
+
+	// Other peers shouldn't be affected by the cooldown.
+	ctx.router.addEdgeFailures = 0
+	if err := processChanAnn(nodeKeyPub2); err != nil {
+		t.Fatalf("announcement of other peer rejected: %v", err)
+	}
+
+	// Once the cooldown has passed, another failure should double it.
+	ctx.router.addEdgeFailures = 1
+	advance(backoff)
+	if err := processChanAnn(nodeKeyPub1); err == nil {
+		t.Fatal("failing announcement was accepted")
+	}
+	advance(backoff)
+	if err := processChanAnn(nodeKeyPub1); err == nil {
+		t.Fatal("announcement accepted during doubled cooldown")
+	}
+
+	// After the doubled cooldown, a successful announcement should reset
+	// the consecutive failures of the peer.
+	advance(backoff)
+	if err := processChanAnn(nodeKeyPub1); err != nil {
+		t.Fatalf("announcement rejected after cooldown: %v", err)
+	}
+
+	ctx.router.addEdgeFailures = 1
+	if err := processChanAnn(nodeKeyPub1); err == nil {
+		t.Fatal("failing announcement was accepted")
+	}
+	advance(backoff)
+	if err := processChanAnn(nodeKeyPub1); err != nil {
+		t.Fatalf("cooldown wasn't reset: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package discovery
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// FeatureMismatchPolicy is the policy which is applied once a node
+// announcement arrives whose features are inconsistent with those of the
+// channels of the node, that is, a channel feature set within the
+// announcement of one of its channels implies a node feature which isn't set
+// within the announcement of the node. Such inconsistencies hint at buggy or
+// misbehaving nodes.
+type FeatureMismatchPolicy uint8
+
+const (
+	// FeatureMismatchIgnore doesn't check the features of node
+	// announcements against those of their channels.
+	FeatureMismatchIgnore FeatureMismatchPolicy = iota
+
+	// FeatureMismatchLog logs any inconsistency between the features of a
+	// node announcement and those of its channels, but still accepts the
+	// node announcement.
+	FeatureMismatchLog
+
+	// FeatureMismatchReject logs and rejects node announcements whose
+	// features are inconsistent with those of their channels.
+	FeatureMismatchReject
+)
+
+// String returns a human readable description of the policy.
+func (p FeatureMismatchPolicy) String() string {
+	switch p {
+	case FeatureMismatchIgnore:
+		return "ignore"
+	case FeatureMismatchLog:
+		return "log"
+	case FeatureMismatchReject:
+		return "reject"
+	default:
+		return "unknown"
+	}
+}
+
+// chanFeatureNodeBits maps each channel feature bit which implies support of
+// a node feature by the nodes of the channel to the bit of that node feature.
+// Channel and node features form separate namespaces, so only the channel
+// features listed here are compared, each against its node counterpart.
+var chanFeatureNodeBits = map[int]int{}
+
+// featureBits returns the set of bits set within the passed feature vector.
+func featureBits(features *lnwire.FeatureVector) map[int]struct{} {
+	bits := make(map[int]struct{})
+	for _, bit := range features.Missing(lnwire.NewFeatureVector(nil)) {
+		bits[bit] = struct{}{}
+	}
+
+	return bits
+}
+
+// findFeatureMismatch returns an error describing the first channel of the
+// announced node which implies a node feature that isn't set within the node
+// announcement, or nil if the features of the node are consistent with those
+// of its channels.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) findFeatureMismatch(
+	msg *lnwire.NodeAnnouncement) error {
+
+	nodeBits := make(map[int]struct{})
+	if msg.Features != nil {
+		nodeBits = featureBits(msg.Features)
+	}
+
+	var mismatch error
+	err := d.cfg.Router.ForEachNodeChannel(msg.NodeID, func(
+		info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		if mismatch != nil || len(info.Features) == 0 {
+			return nil
+		}
+
+		chanFeatures, err := lnwire.NewFeatureVectorFromReader(
+			bytes.NewReader(info.Features),
+		)
+		if err != nil {
+			log.Debugf("Unable to decode features of "+
+				"short_chan_id=%v: %v", info.ChannelID, err)
+			return nil
+		}
+
+		var missing []int
+		for chanBit := range featureBits(chanFeatures) {
+			nodeBit, ok := chanFeatureNodeBits[chanBit]
+			if !ok {
+				continue
+			}
+			if _, ok := nodeBits[nodeBit]; !ok {
+				missing = append(missing, nodeBit)
+			}
+		}
+		if len(missing) != 0 {
+			sort.Ints(missing)
+			mismatch = errors.Errorf("node=%x doesn't advertise "+
+				"node features %v implied by its channel "+
+				"short_chan_id=%v",
+				msg.NodeID.SerializeCompressed(), missing,
+				info.ChannelID)
+		}
+
+		return nil
+	})
+	switch {
+	case err == channeldb.ErrGraphNodeNotFound,
+		err == channeldb.ErrGraphNoEdgesFound:
+
+		return nil
+
+	case err != nil:
+		log.Errorf("Unable to check features of node=%x: %v",
+			msg.NodeID.SerializeCompressed(), err)
+		return nil
+	}
+
+	return mismatch
+}
+
+// checkNodeFeatures applies the configured FeatureMismatchPolicy to a remote
+// node announcement. An error is only returned if the features of the node
+// are inconsistent with those of its channels, and the policy is to reject
+// such announcements.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) checkNodeFeatures(
+	msg *lnwire.NodeAnnouncement) error {
+
+	policy := d.cfg.FeatureMismatchPolicy
+	if policy == FeatureMismatchIgnore {
+		return nil
+	}
+
+	mismatch := d.findFeatureMismatch(msg)
+	if mismatch == nil {
+		return nil
+	}
+
+	log.Warnf("FEATURE MISMATCH detected: %v, applying %v policy",
+		mismatch, policy)
+
+	if policy == FeatureMismatchReject {
+		return mismatch
+	}
+
+	return nil
+}
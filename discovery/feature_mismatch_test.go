@@ -0,0 +1,132 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestFeatureMismatch ensures that node announcements whose features are
+// inconsistent with those of their channels are detected, and handled
+// according to the configured FeatureMismatchPolicy.
+//
+// NOTE: As no channel features implying node features are defined yet, this
+// test defines one within the global mapping, so it isn't run in parallel.
+func TestFeatureMismatch(t *testing.T) {
+	const chanBit, nodeBit = 0, 1
+	chanFeatureNodeBits[chanBit] = nodeBit
+	defer delete(chanFeatureNodeBits, chanBit)
+
+	// We'll add a channel between the first two nodes, which advertises a
+	// channel feature that implies a node feature the node announcements
+	// must advertise.
+	chanFeatures := lnwire.NewFeatureVector([]lnwire.Feature{
+		{Name: "chan", Flag: lnwire.RequiredFlag},
+	})
+	var featureBuf bytes.Buffer
+	if err := chanFeatures.Encode(&featureBuf); err != nil {
+		t.Fatalf("unable to encode features: %v", err)
+	}
+	nodeFeatures, err := lnwire.NewFeatureVectorFromReader(
+		bytes.NewReader([]byte{0x00, 0x01, 0x01 << (2 * nodeBit)}),
+	)
+	if err != nil {
+		t.Fatalf("unable to decode features: %v", err)
+	}
+
+	// createCtx creates a gossiper with the passed policy, which knows of
+	// the channel.
+	createCtx := func(policy FeatureMismatchPolicy) (*testCtx, func()) {
+		ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+			cfg.RejectionLogSize = 10
+			cfg.FeatureMismatchPolicy = policy
+		})
+
+		ctx.router.infos[1] = &channeldb.ChannelEdgeInfo{
+			ChannelID: 1,
+			NodeKey1:  nodeKeyPub1,
+			NodeKey2:  nodeKeyPub2,
+			Features:  featureBuf.Bytes(),
+		}
+
+		return ctx, cleanup
+	}
+
+	// newNodeAnn creates a node announcement of the passed node, which
+	// advertises the passed features.
+	newNodeAnn := func(priv *btcec.PrivateKey,
+		features *lnwire.FeatureVector) *lnwire.NodeAnnouncement {
+
+		na, err := createNodeAnnouncement(priv)
+		if err != nil {
+			t.Fatalf("can't create node announcement: %v", err)
+		}
+		na.Features = features
+
+		signer := mockSigner{priv}
+		na.Signature, err = SignAnnouncement(&signer, priv.PubKey(), na)
+		if err != nil {
+			t.Fatalf("can't sign node announcement: %v", err)
+		}
+
+		return na
+	}
+
+	ctx, cleanup := createCtx(FeatureMismatchReject)
+	defer cleanup()
+
+	// assertRejected asserts that the passed node announcement is
+	// rejected due to a feature mismatch.
+	assertRejected := func(na *lnwire.NodeAnnouncement) {
+		err := <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2)
+		if err == nil {
+			t.Fatal("expected node announcement to be rejected")
+		}
+		records := ctx.gossiper.RecentRejections(1)
+		if len(records) != 1 ||
+			records[0].Reason != RejectFeatureMismatch {
+
+			t.Fatalf("expected %v rejection, got %v",
+				RejectFeatureMismatch, records)
+		}
+		if len(ctx.router.nodes) != 0 {
+			t.Fatal("rejected node was added to the router")
+		}
+	}
+
+	// The announcement of the first node doesn't advertise any features,
+	// so it should be rejected once instructed to. The same holds if it
+	// advertises the bit of the channel feature, as channel and node
+	// features form separate namespaces.
+	na := newNodeAnn(nodeKeyPriv1, lnwire.NewFeatureVector(nil))
+	assertRejected(na)
+	assertRejected(newNodeAnn(nodeKeyPriv1, chanFeatures))
+
+	// The announcement of the second node advertises the implied node
+	// feature, so it should be accepted even if inconsistencies are
+	// rejected.
+	na2 := newNodeAnn(nodeKeyPriv2, nodeFeatures)
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(na2, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("can't process node announcement: %v", err)
+	}
+	if len(ctx.router.nodes) != 1 {
+		t.Fatal("node wasn't added to the router")
+	}
+
+	// Finally, if the inconsistency is only to be logged, then the
+	// announcement of the first node should be accepted.
+	logCtx, logCleanup := createCtx(FeatureMismatchLog)
+	defer logCleanup()
+
+	err = <-logCtx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process node announcement: %v", err)
+	}
+	if len(logCtx.router.nodes) != 1 {
+		t.Fatal("node wasn't added to the router")
+	}
+}
@@ -0,0 +1,138 @@
+package discovery
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// resetRequest is a request to reset the gossip state of a single channel.
+type resetRequest struct {
+	chanID lnwire.ShortChannelID
+
+	errResp chan error
+}
+
+// ResetChannelGossipState forcibly resets the gossip state that we hold for
+// the target channel, in order to recover a channel that is stuck within it.
+// Any premature announcements and orphan updates of the channel are removed
+// and re-processed against the current height, such that they're either
+// applied, or buffered once again if they still can't be. Any waiting proofs
+// of the channel are discarded, such that the proof exchange can start over.
+//
+// NOTE: This is safe to call concurrently with the processing of
+// announcements.
+func (d *AuthenticatedGossiper) ResetChannelGossipState(
+	chanID lnwire.ShortChannelID) error {
+
+	errChan := make(chan error, 1)
+	req := &resetRequest{
+		chanID:  chanID,
+		errResp: errChan,
+	}
+
+	select {
+	case d.resetRequests <- req:
+	case <-d.quit:
+		return ErrShuttingDown
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-d.quit:
+		return ErrShuttingDown
+	}
+}
+
+// clearChannelGossipState removes all premature announcements, orphan updates
+// and waiting proofs of the target channel. The removed announcements are
+// returned, such that they can be re-processed by the caller.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) clearChannelGossipState(
+	chanID lnwire.ShortChannelID) ([]*networkMsg, error) {
+
+	var removed []*networkMsg
+	for height, nMsgs := range d.prematureAnnouncements {
+		var kept []*networkMsg
+		for _, nMsg := range nMsgs {
+			msgChanID, ok := msgShortChanID(nMsg.msg)
+			if ok && msgChanID == chanID {
+				removed = append(removed, nMsg)
+				continue
+			}
+
+			kept = append(kept, nMsg)
+		}
+
+		d.numPremature -= len(nMsgs) - len(kept)
+		if len(kept) == 0 {
+			delete(d.prematureAnnouncements, height)
+		} else {
+			d.prematureAnnouncements[height] = kept
+		}
+	}
+
+	// Announcements persisted within the on-disk queue are removed as
+	// well, as they'd otherwise be replayed once more at their height.
+	if d.prematureQueue != nil {
+		anns, err := d.prematureQueue.Remove(
+			func(ann *channeldb.PrematureAnnouncement) bool {
+				msgChanID, ok := msgShortChanID(ann.Msg)
+				return ok && msgChanID == chanID
+			},
+		)
+		if err != nil {
+			return nil, errors.Errorf("unable to remove premature "+
+				"announcements: %v", err)
+		}
+
+		removed = append(removed, prematureNetworkMsgs(anns)...)
+	}
+	d.updatePrematureDepth()
+
+	if d.orphanUpdates != nil {
+		orphans, expired := d.orphanUpdates.take(
+			chanID.ToUint64(), d.now(),
+		)
+		d.rejectExpiredOrphans(expired)
+
+		removed = append(removed, orphans...)
+	}
+
+	// Both our own and the remote half of the proof are discarded.
+	for _, isRemote := range []bool{false, true} {
+		proof := channeldb.NewWaitingProof(
+			isRemote, &lnwire.AnnounceSignatures{
+				ShortChannelID: chanID,
+			},
+		)
+
+		err := d.waitingProofs.Remove(proof.Key())
+		if err != nil && err != channeldb.ErrWaitingProofNotFound {
+			return nil, errors.Errorf("unable to remove waiting "+
+				"proof: %v", err)
+		}
+	}
+
+	log.Infof("Reset gossip state of short_chan_id=%v, re-processing %v "+
+		"announcements", chanID.ToUint64(), len(removed))
+
+	return removed, nil
+}
+
+// msgShortChanID returns the short channel ID of the channel that the passed
+// message references, if any.
+func msgShortChanID(msg lnwire.Message) (lnwire.ShortChannelID, bool) {
+	switch msg := msg.(type) {
+	case *lnwire.ChannelAnnouncement:
+		return msg.ShortChannelID, true
+	case *lnwire.ChannelUpdate:
+		return msg.ShortChannelID, true
+	case *lnwire.AnnounceSignatures:
+		return msg.ShortChannelID, true
+	default:
+		return lnwire.ShortChannelID{}, false
+	}
+}
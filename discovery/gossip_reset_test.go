@@ -0,0 +1,63 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestResetChannelGossipState ensures that resetting the gossip state of a
+// channel whose announcement is stuck within the premature buffer has the
+// announcement re-processed against the current height.
+func TestResetChannelGossipState(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	// The announcement of a channel confirmed at height 5 is premature,
+	// so it's buffered until that height is reached.
+	const chanHeight = 5
+	ca, err := createRemoteChannelAnnouncement(chanHeight)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	annErr := ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+	select {
+	case err := <-annErr:
+		t.Fatalf("premature announcement wasn't buffered: %v", err)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// If we never learn of that exact height, as the chain tip skipped
+	// past it, then the announcement remains stuck.
+	ctx.notifier.notifyBlock(chainhash.Hash{}, chanHeight+1)
+
+	select {
+	case err := <-annErr:
+		t.Fatalf("announcement unexpectedly processed: %v", err)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Resetting the gossip state of the channel should re-process the
+	// announcement, which is no longer premature.
+	err = ctx.gossiper.ResetChannelGossipState(ca.ShortChannelID)
+	if err != nil {
+		t.Fatalf("unable to reset channel gossip state: %v", err)
+	}
+
+	select {
+	case err := <-annErr:
+		if err != nil {
+			t.Fatalf("announcement rejected after reset: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("announcement wasn't re-processed")
+	}
+
+	if _, ok := ctx.router.infos[ca.ShortChannelID.ToUint64()]; !ok {
+		t.Fatal("channel wasn't added to router")
+	}
+}
@@ -3,6 +3,7 @@ package discovery
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/viacoin/lnd/chainntnfs"
@@ -17,8 +19,17 @@ import (
 	"github.com/viacoin/lnd/lnwallet"
 	"github.com/viacoin/lnd/lnwire"
 	"github.com/viacoin/lnd/routing"
+	viacoinCfg "github.com/viacoin/viad/chaincfg"
 )
 
+// ErrShuttingDown is returned for any announcement handed to the gossiper once
+// it has been signalled to stop.
+var ErrShuttingDown = errors.New("gossiper is shutting down")
+
+// ErrPeerSendQueueFull is returned when messages directed at a peer are
+// dropped, as the peer already has PeerSendQueueSize sends in flight.
+var ErrPeerSendQueueFull = errors.New("peer send queue is full")
+
 // networkMsg couples a routing related wire message with the peer that
 // originally sent it.
 type networkMsg struct {
@@ -27,6 +38,17 @@ type networkMsg struct {
 
 	isRemote bool
 
+	// deferredNodeAnn is set for a node announcement whose validation was
+	// deferred due to LazyNodeAnnValidation, and which is now re-processed
+	// as its node is first used. As it has already passed the rate limits
+	// of node announcements, they're skipped.
+	deferredNodeAnn bool
+
+	// queuedAt is the time at which the message was submitted to the
+	// networkHandler. It's unset for messages which are re-processed,
+	// such as matured premature announcements.
+	queuedAt time.Time
+
 	err chan error
 }
 
@@ -79,6 +101,16 @@ type Config struct {
 	// broadcast.
 	Broadcast func(exclude *btcec.PublicKey, msg ...lnwire.Message) error
 
+	// SecondaryBroadcast is an optional secondary transport, such as a
+	// different overlay network, over which all broadcasts are mirrored
+	// for redundancy in case the primary peer connections are degraded.
+	// Each broadcast is mirrored once it succeeds over the primary
+	// transport. Mirroring is best-effort, and never delays or fails a
+	// broadcast over the primary transport. Broadcasts are mirrored from
+	// a goroutine of their own, which is waited upon when the gossiper is
+	// stopped, so the transport must not block indefinitely.
+	SecondaryBroadcast func(msg ...lnwire.Message) error
+
 	// SendToPeer is a function which allows the service to send a set of
 	// messages to a particular peer identified by the target public key.
 	SendToPeer func(target *btcec.PublicKey, msg ...lnwire.Message) error
@@ -108,6 +140,437 @@ type Config struct {
 	// TODO(roasbeef): extract ann crafting + sign from fundingMgr into
 	// here?
 	AnnSigner lnwallet.MessageSigner
+
+	// VerifyFundingConfirmed is an optional closure which is consulted
+	// before we send our half of a channel announcement proof to the
+	// remote peer. It should return true iff the funding output of the
+	// target channel is still confirmed within the main chain. If the
+	// funding isn't confirmed, then sending of the proof is deferred
+	// until the next block. If nil, then no such check is performed.
+	VerifyFundingConfirmed func(chanID lnwire.ShortChannelID) (bool, error)
+
+	// PeerSendQueueSize is the maximum number of sends directed at a
+	// single peer which may be in flight at once. If non-zero, then
+	// directed sends are dispatched asynchronously, and once a peer has
+	// this many outstanding sends, any further gossip to it is dropped
+	// until the backlog drains. Our own channel announcement proofs are
+	// never dropped. If zero, then no limit is enforced.
+	PeerSendQueueSize int
+
+	// AnnAuditLog is an optional writer to which a line is written for
+	// each network announcement that we reject, detailing the source
+	// peer, the type of the message, and the reason for its rejection.
+	AnnAuditLog io.Writer
+
+	// MaxGraphMemory is the approximate maximum number of bytes that the
+	// channels and nodes learned through gossip may occupy within the
+	// graph. Once exceeded, the least recently updated channels are
+	// evicted from the graph until we're within budget again. If zero,
+	// then no limit is enforced.
+	MaxGraphMemory uint64
+
+	// SignCacheSize is the maximum number of entries within the cache
+	// which maps the signed fields of our own channel updates to their
+	// serialized form. This allows bulk re-signing of updates whose only
+	// change is their timestamp to skip re-serialization. If zero, then
+	// no cache is used.
+	SignCacheSize int
+
+	// SelfBroadcastRate is the maximum number of self-originated
+	// announcements, such as our own channel updates, which are
+	// broadcast per SelfBroadcastInterval. Any excess announcements are
+	// queued and released over time at this rate. Announcements relayed
+	// on behalf of other nodes aren't subject to this limit. If zero,
+	// then no limit is enforced.
+	SelfBroadcastRate int
+
+	// SelfBroadcastInterval is the interval at which up to
+	// SelfBroadcastRate queued self-originated announcements are
+	// broadcast. It must be set if SelfBroadcastRate is non-zero.
+	SelfBroadcastInterval time.Duration
+
+	// RejectionLogSize is the number of the most recent rejected
+	// announcements retained for introspection through
+	// RecentRejections. If zero, then no rejections are retained.
+	RejectionLogSize int
+
+	// RelayChains is the set of chains whose announcements we relay to
+	// the rest of the network. Announcements for any other chain are
+	// still applied to our local graph, but are never re-broadcast. If
+	// empty, then announcements for all known chains are relayed.
+	RelayChains []chainhash.Hash
+
+	// GraphSnapshotPath is the path to an optional graph snapshot, as
+	// written by ExportGraph, which is used to seed the router at startup
+	// if it doesn't know of any channels yet.
+	GraphSnapshotPath string
+
+	// DupChanAnnPolicy is the policy applied once a remote channel
+	// announcement arrives for a known channel, but with a proof that
+	// conflicts with the known one.
+	DupChanAnnPolicy DupChanAnnPolicy
+
+	// ChanIDCollisionPolicy is the policy applied once a remote channel
+	// announcement arrives for a known short channel ID, but with funding
+	// keys that differ from those of the known channel.
+	ChanIDCollisionPolicy ChanIDCollisionPolicy
+
+	// FeatureMismatchPolicy is the policy applied once a remote node
+	// announcement arrives whose features are inconsistent with those of
+	// the channels of the node. By default, the features aren't checked.
+	// As checking them requires a scan of the channels within the graph,
+	// doing so is costly on large graphs.
+	FeatureMismatchPolicy FeatureMismatchPolicy
+
+	// TrackGossipProvenance, if set, has the gossiper record the peers
+	// from which each channel and node was received, such that the
+	// gossip introduced solely by a single peer can later be removed
+	// through PurgePeerGossip. The peer which first delivered each
+	// channel is exposed through AnnouncementProvenance as well. The
+	// sources are only tracked in memory.
+	TrackGossipProvenance bool
+
+	// MaxGossipProvenance is the maximum number of channels, and of nodes,
+	// whose sources are tracked while TrackGossipProvenance is set. Once
+	// reached, the sources of the channel or node recorded the longest ago
+	// are forgotten, exempting it from PurgePeerGossip. It must be set if
+	// TrackGossipProvenance is.
+	MaxGossipProvenance int
+
+	// ReportPeerScore is an optional callback which is used to report
+	// scoring feedback on the announcements delivered by a peer. A
+	// positive delta is reported once a peer delivers a valid
+	// announcement that was new to us, while a negative delta is reported
+	// once a peer delivers an announcement with an invalid signature, or
+	// one that's outdated. The reason briefly describes the cause of the
+	// feedback.
+	ReportPeerScore func(peer *btcec.PublicKey, delta int, reason string)
+
+	// MaxPrematureHeightDelta is the maximum number of blocks beyond our
+	// current chain tip that a remote announcement may claim, for it to
+	// be buffered until we've caught up to that height. Remote
+	// announcements claiming a height further in the future are rejected
+	// outright. If zero, then all premature announcements are buffered.
+	MaxPrematureHeightDelta uint32
+
+	// PrematureQueueSize is the number of bytes of premature
+	// announcements which are persisted within an on-disk queue, such
+	// that they survive a restart. Once the queue is full, the oldest
+	// premature announcements are evicted. If zero, then premature
+	// announcements are only held in memory.
+	PrematureQueueSize uint64
+
+	// DropEchoedAnnouncements, if set, makes the gossiper ignore the
+	// announcements and updates of our own channels which peers gossip
+	// back to us, if they carry nothing we don't already know, rather
+	// than validating them once more.
+	DropEchoedAnnouncements bool
+
+	// PrioritizeLocalAnnouncements, if set, has the announcements
+	// stemming from our own messages, such as freshly signed fee updates,
+	// broadcast ahead of those relayed on behalf of remote peers within
+	// each trickle batch.
+	PrioritizeLocalAnnouncements bool
+
+	// NoRelayGossip, if set, has the gossiper operate in listen-only mode,
+	// in which the announcements received from remote peers are still
+	// applied to the graph once they've been validated, but never relayed
+	// to the rest of the network. Our own announcements are broadcast
+	// either way.
+	NoRelayGossip bool
+
+	// LazyNodeAnnValidation, if set, defers the signature validation of
+	// the node announcements received from remote peers until their node
+	// is first used for path finding, which is signalled through UseNode.
+	// Until then, the announcements are held in memory, and are neither
+	// applied to the graph nor relayed. This lowers the CPU usage of
+	// resource constrained clients, at the cost of the graph lacking the
+	// announced information of nodes which haven't been used yet.
+	LazyNodeAnnValidation bool
+
+	// ResendSharedUpdates, if set, has the latest updates of our own
+	// direction of all channels which we share with a newly connected
+	// peer sent directly to that peer, such that it learns of any policy
+	// changes we've made while it was offline.
+	ResendSharedUpdates bool
+
+	// InitialSyncStrategy is the strategy used to sync the graph with a
+	// peer once it connects to us. By default, the peer is sent a full
+	// dump of the graph.
+	InitialSyncStrategy InitialSyncStrategy
+
+	// InitialSyncHorizon is the period within which announcements must
+	// have been updated to be sent to a connecting peer with the
+	// InitialSyncTimestamp strategy. It must be set if that strategy is
+	// used.
+	InitialSyncHorizon time.Duration
+
+	// MaxSyncMessageSize is the maximum size in bytes of the serialized
+	// form of a single message sent to a peer while syncing the graph
+	// with it. Larger messages are skipped with a warning, as are those
+	// exceeding the maximum message size of the wire protocol regardless
+	// of this option. If zero, then only the latter limit is enforced.
+	MaxSyncMessageSize int
+
+	// MaxWrongChainAnns is the number of announcements targeting a chain
+	// other than ours that a single peer may send us, beyond which the
+	// peer is handed to the WrongChainPeer callback. The count of the
+	// peer is reset once the callback is invoked. If zero, then such
+	// announcements are merely dropped.
+	MaxWrongChainAnns int
+
+	// WrongChainPeer is invoked with a peer which sent us more than
+	// MaxWrongChainAnns announcements targeting a chain other than ours,
+	// along with the number of such announcements, such that the server
+	// may consider disconnecting from or banning the misconfigured peer.
+	// As it's invoked from within the gossiper, it must not block on the
+	// gossiper. It must be set if MaxWrongChainAnns is.
+	WrongChainPeer func(peer *btcec.PublicKey, numAnns int)
+
+	// Checkpoints is an optional list of checkpoints of the active
+	// network, ordered by ascending height. If set, remote channel
+	// announcements at heights covered by a checkpoint are only accepted
+	// if our chain is consistent with that checkpoint, which guards light
+	// clients against a forged chain history.
+	Checkpoints []chaincfg.Checkpoint
+
+	// FetchBlockHash returns the hash of the block at the passed height
+	// within our chain. It must be set if Checkpoints is set.
+	FetchBlockHash func(height uint32) (*chainhash.Hash, error)
+
+	// FetchClosedChannels, if set, returns the short channel IDs of our
+	// own channels which have been closed. Remote announcements of such
+	// channels are rejected, rather than storing the channels once more.
+	// The closed channels are only fetched once as the gossiper starts,
+	// after which any further closures must be reported through
+	// ChannelClosed.
+	FetchClosedChannels func() ([]lnwire.ShortChannelID, error)
+
+	// FetchChanPoint, if set, returns the funding outpoint of the channel
+	// with the passed short channel ID. It's used to re-verify the
+	// channels funded within blocks disconnected by a re-org, which are
+	// only pruned if their funding outpoint is no longer found within the
+	// new main chain.
+	FetchChanPoint func(chanID lnwire.ShortChannelID) (*wire.OutPoint, error)
+
+	// SelfTestInterval is the interval at which the gossiper re-validates
+	// the signatures of a sample of the channel updates it has signed for
+	// our own channels. A signature that no longer verifies is logged as
+	// a critical error. If zero, then no self-test is performed.
+	SelfTestInterval time.Duration
+
+	// MaxTimeLockDelta is the largest CLTV delta that may be set for our
+	// channels through PropagateFeeUpdate. If zero, then only the
+	// minimum delta is enforced.
+	MaxTimeLockDelta uint16
+
+	// ConsistencySweepInterval is the interval at which the gossiper
+	// reconciles its auxiliary state, such as its premature announcements,
+	// waiting proofs and orphan updates, with the graph of the router and
+	// the chain tip. If zero, then no sweep is performed.
+	ConsistencySweepInterval time.Duration
+
+	// SelfTestSampleSize is the maximum number of our own channel updates
+	// re-validated during each self-test. It must be set if
+	// SelfTestInterval is non-zero.
+	SelfTestSampleSize int
+
+	// NodeAnnRateLimit is the maximum number of node announcements for a
+	// single node which are processed on behalf of a single peer per
+	// NodeAnnRateInterval. Any excess announcements are dropped. If zero,
+	// then no limit is enforced.
+	NodeAnnRateLimit int
+
+	// NodeAnnRateInterval is the interval over which NodeAnnRateLimit is
+	// enforced. It must be set if NodeAnnRateLimit is non-zero.
+	NodeAnnRateInterval time.Duration
+
+	// MaxMsgsPerPeerPerSecond is the maximum number of messages of any
+	// type which are processed on behalf of a single peer per second. Any
+	// excess messages are dropped, and reported to ReportPeerScore. If
+	// zero, then no limit is enforced.
+	MaxMsgsPerPeerPerSecond int
+
+	// MaxAnnouncementSize is the maximum size in bytes of the serialized
+	// form of a single announcement received from a remote peer. Larger
+	// announcements are rejected before they're validated. If zero, then
+	// no limit is enforced.
+	MaxAnnouncementSize int
+
+	// NodeAnnMinInterval is the minimum interval between two node
+	// announcements of the same node that are accepted from remote peers,
+	// regardless of the peer which sent them. More frequent announcements
+	// are rejected, even if they're newer. If zero, then no minimum
+	// interval is enforced.
+	NodeAnnMinInterval time.Duration
+
+	// RequestNodeAnn is an optional callback which is used to request the
+	// node announcement of the target node from the peer which sent us a
+	// channel announcement referencing the node, in case we haven't
+	// received an announcement for the node yet. This prevents the node
+	// from remaining a partial node within the graph indefinitely.
+	//
+	// NOTE: This is called from within the networkHandler, so it MUST NOT
+	// block.
+	RequestNodeAnn func(peer, node *btcec.PublicKey) error
+
+	// NodeAnnRequestInterval is the minimum interval between two requests
+	// for the node announcement of the same node, which prevents
+	// announcements of unknown nodes from amplifying into a flood of
+	// requests. It must be set if RequestNodeAnn is set.
+	NodeAnnRequestInterval time.Duration
+
+	// EdgeFailureBackoff is the initial cooldown applied to a peer once a
+	// channel announcement of it fails to be added to the graph for any
+	// reason other than being outdated. During the cooldown, all further
+	// announcements of the peer are rejected early. The cooldown doubles
+	// with each consecutive failure, and is reset once an announcement of
+	// the peer is added successfully. If zero, then no cooldown is
+	// applied.
+	EdgeFailureBackoff time.Duration
+
+	// MaxEdgeFailureBackoff is the maximum cooldown applied to a peer due
+	// to consecutive failures. It must be set if EdgeFailureBackoff is
+	// non-zero.
+	MaxEdgeFailureBackoff time.Duration
+
+	// OrphanUpdateLimit is the maximum number of remote channel updates
+	// referencing an unknown channel which are buffered until the
+	// announcement of their channel arrives, as the announcement may
+	// simply be delivered after the update. If zero, then such updates
+	// are rejected right away.
+	OrphanUpdateLimit int
+
+	// OrphanUpdateTTL is the duration for which each orphan channel update
+	// is buffered. It must be set if OrphanUpdateLimit is non-zero.
+	OrphanUpdateTTL time.Duration
+
+	// MinChannelsToAnnounce is the minimum number of announced channels
+	// that we must have before our own node announcement is broadcast, as
+	// peers would otherwise discard it. Until then, our latest node
+	// announcement is withheld. If zero, then our node announcement is
+	// always broadcast.
+	MinChannelsToAnnounce int
+
+	// IsChannelClosed is an optional callback which reports whether the
+	// funding output of the channel with the passed channel point, which
+	// was confirmed at the passed height, has been spent on-chain. If
+	// set, then channel updates of closed channels are dropped rather
+	// than applied and relayed. As funding outputs are only spent within
+	// new blocks, the result for each channel is only queried once per
+	// block.
+	IsChannelClosed func(chanPoint *wire.OutPoint,
+		heightHint uint32) (bool, error)
+
+	// PruneClosedChannels, if true, has any channel which IsChannelClosed
+	// reports as closed deleted from the graph once an update for it is
+	// dropped.
+	PruneClosedChannels bool
+
+	// AnnouncementPolicy is an optional callback which decides whether
+	// the local channel with the passed channel point should be announced
+	// to the network. Our half of the proof of any channel for which it
+	// returns false is dropped, such that the channel never enters the
+	// proof exchange, and thus remains private.
+	AnnouncementPolicy func(chanPoint wire.OutPoint) bool
+
+	// MaxChannelsPerNode is the maximum number of channels that a single
+	// node may have within the graph. Once a node has this many channels,
+	// any remote announcements of further channels of the node are
+	// rejected, which bounds the impact of a single abusive node on the
+	// graph. Our own node isn't subject to the limit. If zero, then no
+	// limit is enforced.
+	MaxChannelsPerNode int
+
+	// PeerDistance is an optional function which returns the distance,
+	// in hops within the channel graph, between one of our peers and the
+	// target node, along with whether the distance is known. If set, then
+	// each batch of new announcements is sent to the peers closest to the
+	// nodes that originated the announcements first, before fanning out to
+	// more distant peers. If nil, then batches are broadcast to all peers
+	// at once.
+	//
+	// NOTE: This is an experimental propagation strategy.
+	PeerDistance func(peer, node *btcec.PublicKey) (uint32, bool)
+
+	// ConnectedPeers returns the identity keys of all peers that we're
+	// currently connected to. It must be set if PeerDistance is set.
+	ConnectedPeers func() []*btcec.PublicKey
+
+	// OutdatedUpdateGrace, if non-zero, is the maximum age, relative to
+	// the policy we hold for the opposite direction, of a remote channel
+	// update for a direction we hold no policy for yet. Such updates are
+	// applied without being broadcast, while older ones are rejected as
+	// outdated. If zero, then such updates are applied and broadcast
+	// regardless of their age.
+	OutdatedUpdateGrace time.Duration
+
+	// MinPeersForBroadcast is the minimum number of peers that we must be
+	// connected to before our first retransmission of stale channels, and
+	// any batch of announcements, is broadcast, as broadcasts to only a
+	// few peers propagate poorly. Until then, broadcasts are held back.
+	// Once the threshold has been met, broadcasts are no longer held back.
+	// If zero, then broadcasts are never held back.
+	MinPeersForBroadcast int
+
+	// NumConnectedPeers returns the number of peers that we're currently
+	// connected to. It must be set if MinPeersForBroadcast is non-zero.
+	NumConnectedPeers func() int
+
+	// SupportsCompression, if set, returns true if the target peer has
+	// signalled support for compressed message batches. The graph sent
+	// to such peers when synchronizing is compressed into batches of
+	// lnwire.CompressedBatch messages. If nil, then the graph is always
+	// sent uncompressed.
+	SupportsCompression func(peer *btcec.PublicKey) bool
+
+	// RouterRetries is the number of times an announcement is re-applied
+	// to the router after failing with an error which may be transient,
+	// such as a momentary storage failure, before it's dropped. The
+	// setting is shared by all announcements applied to the router. If
+	// zero, then failed announcements are dropped right away.
+	RouterRetries int
+
+	// RouterRetryBackoff is the delay before the first retry of a failed
+	// router operation, which doubles with each further retry.
+	RouterRetryBackoff time.Duration
+
+	// StartupRetries is the number of times that the registration for
+	// block notifications and the query of the current height are retried
+	// when starting the gossiper, such that a transient failure of the
+	// chain backend doesn't prevent the gossiper from starting. If zero,
+	// then a failure aborts the startup right away.
+	StartupRetries int
+
+	// StartupRetryBackoff is the delay before the first retry of a failed
+	// startup operation, which doubles with each further retry.
+	StartupRetryBackoff time.Duration
+
+	// SystemBusy, if non-nil, reports whether the node is currently under
+	// heavy load, such as from forwarding payments. While it returns true,
+	// the periodic retransmission of our stale channels is deferred until
+	// the node is idle again.
+	SystemBusy func() bool
+
+	// VerifyDeterministicSigs, if true, has each update of our own
+	// channels signed twice, and fails the update if the two signatures
+	// differ. As signatures are expected to use deterministic RFC6979
+	// nonces, a mismatch indicates a faulty or malicious AnnSigner, such
+	// as a misbehaving HSM.
+	VerifyDeterministicSigs bool
+
+	// EdgeUpdateBatchSize, if non-zero, has the updated policies of our
+	// own channels written to the graph in batches of up to this size,
+	// each within a single database transaction, if the Router is a
+	// BatchEdgeUpdater. Otherwise, each policy is written within a
+	// transaction of its own.
+	EdgeUpdateBatchSize int
+
+	// SkipAnnValidation disables the validation of the signatures of all
+	// remote node announcements, channel announcements and channel
+	// updates. This is only meant for closed test environments where all
+	// peers are trusted, and is refused on mainnet.
+	SkipAnnValidation bool
 }
 
 // AuthenticatedGossiper is a subsystem which is responsible for receiving
@@ -130,10 +593,28 @@ type AuthenticatedGossiper struct {
 	// was initialized with.
 	cfg *Config
 
+	// mirrorQueue is the queue of broadcasts to be mirrored over the
+	// SecondaryBroadcast transport by the mirrorHandler.
+	mirrorQueue chan []lnwire.Message
+
 	// newBlocks is a channel in which new blocks connected to the end of
 	// the main chain are sent over.
 	newBlocks <-chan *chainntnfs.BlockEpoch
 
+	// staleBlocks is a channel in which blocks disconnected from the end
+	// of the main chain due to a re-org are sent over. It's only set if
+	// the notifier implements the chainntnfs.StaleBlockNotifier
+	// interface.
+	staleBlocks <-chan *chainntnfs.BlockEpoch
+
+	// staleFrom and staleTo are the lowest and highest heights of the
+	// blocks disconnected by a re-org, whose channels have yet to be
+	// re-verified against the new main chain. A zero staleFrom denotes
+	// that there are no such blocks. These fields MUST only be accessed
+	// from within the networkHandler.
+	staleFrom uint32
+	staleTo   uint32
+
 	// prematureAnnouncements maps a block height to a set of network
 	// messages which are "premature" from our PoV. An message is premature
 	// if it claims to be anchored in a block which is beyond the current
@@ -144,6 +625,28 @@ type AuthenticatedGossiper struct {
 	// TODO(roasbeef): limit premature networkMsgs to N
 	prematureAnnouncements map[uint32][]*networkMsg
 
+	// closedChanChecks caches the results of the IsChannelClosed
+	// callback by channel ID. As the funding output of a channel can
+	// only be spent within a new block, the cache is reset once a block
+	// is connected or disconnected.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	closedChanChecks map[uint64]bool
+
+	// numPremature is the total number of network messages within the
+	// prematureAnnouncements, which is kept up to date as messages are
+	// added and removed, such that it doesn't have to be recounted.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	numPremature int
+
+	// prematureQueue is the on-disk queue of premature announcements,
+	// which is used in place of the prematureAnnouncements if
+	// PrematureQueueSize is set.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	prematureQueue *channeldb.PrematureAnnStore
+
 	// waitingProofs is a persistent storage of partial channel proof
 	// announcement messages. We use it to buffer half of the material
 	// needed to reconstruct a full authenticated channel announcement. Once
@@ -165,31 +668,349 @@ type AuthenticatedGossiper struct {
 	// a set of channels is sent over.
 	feeUpdates chan *feeUpdateRequest
 
+	// resetRequests is a channel that requests to reset the gossip state
+	// of a channel are sent over.
+	resetRequests chan *resetRequest
+
+	// purgeRequests is a channel that requests to purge the gossip
+	// introduced by a single peer are sent over.
+	purgeRequests chan *purgeRequest
+
+	// snapshotRequests is a channel that requests for a snapshot of the
+	// internal state of the gossiper are sent over.
+	snapshotRequests chan *snapshotRequest
+
 	// bestHeight is the height of the block at the tip of the main chain
-	// as we know it.
+	// as we know it. It's only written from within the networkHandler, and
+	// MUST be written atomically so that it can be read concurrently
+	// through BestHeight.
 	bestHeight uint32
 
 	// selfKey is the identity public key of the backing Lighting node.
+	//
+	// NOTE: The key can't be rotated while the gossiper is running. Each
+	// channel announcement commits to the identity keys of both nodes of
+	// the channel, and all channel updates are validated against the key
+	// committed to by the announcement. Channel updates re-signed under a
+	// new key would therefore be rejected by the rest of the network, and
+	// a node announcement under the new key would announce a distinct node
+	// without any channels. Migrating to a new identity key requires all
+	// channels to be closed and re-opened under the new key.
 	selfKey *btcec.PublicKey
+
+	// peerSends tracks the number of in flight sends to each peer, keyed
+	// by the peer's compressed public key. It's only used if
+	// PeerSendQueueSize is non-zero.
+	peerSends    map[[33]byte]int
+	peerSendsMtx sync.Mutex
+
+	// pendingNodeAnns holds the remote node announcements whose
+	// validation has been deferred. It's only non-nil if
+	// LazyNodeAnnValidation is set.
+	pendingNodeAnns *pendingNodeAnns
+
+	// graphMemory tracks the approximate memory used by the elements of
+	// the graph that we've learned through gossip. It's only non-nil if
+	// MaxGraphMemory is set.
+	graphMemory *graphMemoryTracker
+
+	// provenance records the peers from which the channels and nodes of
+	// the graph were received. It's only non-nil if TrackGossipProvenance
+	// is set.
+	provenance *gossipProvenance
+
+	// signCache caches the serialized data to be signed of our own
+	// channel updates. It's only non-nil if SignCacheSize is set.
+	signCache *signDataCache
+
+	// selfAnnQueue is the queue of self-originated announcements which
+	// are pending broadcast due to the SelfBroadcastRate limit.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	selfAnnQueue []lnwire.Message
+
+	// retransmitDeferred is true if the last retransmission of our stale
+	// channels was deferred as the system was busy, or as we weren't
+	// connected to enough peers yet.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	retransmitDeferred bool
+
+	// minPeersReached is true once we've been connected to at least
+	// MinPeersForBroadcast peers, after which broadcasts are no longer
+	// held back.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	minPeersReached bool
+
+	// advertisedUpdates maps the short channel IDs of our own channels to
+	// the last channel update that we've broadcast, while stagedUpdates
+	// maps them to the last update we've signed, until it's broadcast.
+	advertisedUpdates map[uint64]*lnwire.ChannelUpdate
+	stagedUpdates     map[uint64]*lnwire.ChannelUpdate
+	advertisedMtx     sync.Mutex
+
+	// rejections retains the most recent rejected announcements. It's
+	// only non-nil if RejectionLogSize is set.
+	rejections *rejectionLog
+
+	// nodeAnnLimiter enforces the NodeAnnRateLimit on the node
+	// announcements received from remote peers.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	nodeAnnLimiter *nodeAnnLimiter
+
+	// peerMsgLimiter enforces the MaxMsgsPerPeerPerSecond on the messages
+	// received from remote peers, before they're handed over to the
+	// networkHandler.
+	peerMsgLimiter *peerMsgLimiter
+
+	// auditMtx serializes the writes to the AnnAuditLog.
+	auditMtx sync.Mutex
+
+	// wrongChainAnns tracks the number of announcements targeting a chain
+	// other than ours received from each peer, which is enforced against
+	// the MaxWrongChainAnns.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	wrongChainAnns map[[33]byte]int
+
+	// checkpointResults caches the result of comparing our chain against
+	// each of the Checkpoints, keyed by the height of the checkpoint, so
+	// that each checkpoint is only verified once rather than for each
+	// announcement. Entries are dropped once a re-org disconnects the
+	// block at their height.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	checkpointResults map[uint32]error
+
+	// closedChans is the set of our own closed channels. It's only
+	// non-nil if FetchClosedChannels is set, in which case it's loaded as
+	// the gossiper starts.
+	closedChans    map[lnwire.ShortChannelID]struct{}
+	closedChansMtx sync.Mutex
+
+	// nodeAnnIntervals enforces the NodeAnnMinInterval. It's only set if
+	// NodeAnnMinInterval is non-zero.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	nodeAnnIntervals *nodeAnnIntervals
+
+	// nodeAnnRequests enforces the NodeAnnRequestInterval on the requests
+	// for the node announcements of unknown nodes.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	nodeAnnRequests *nodeAnnLimiter
+
+	// edgeFailures enforces the EdgeFailureBackoff on peers whose channel
+	// announcements repeatedly fail to be added to the graph.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	edgeFailures *edgeFailureBackoff
+
+	// orphanUpdates buffers the remote channel updates which reference an
+	// unknown channel. It's only non-nil if OrphanUpdateLimit is set.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	orphanUpdates *orphanUpdateBuffer
+
+	// pendingSelfNodeAnn is our latest node announcement, which is
+	// withheld until we have MinChannelsToAnnounce announced channels.
+	//
+	// NOTE: This MUST only be accessed from within the networkHandler.
+	pendingSelfNodeAnn *lnwire.NodeAnnouncement
+
+	// latencies tracks the time taken to process each type of
+	// announcement.
+	latencies *latencyTracker
+
+	// queueWaits tracks the time each type of announcement spent waiting
+	// to be picked up by the networkHandler.
+	queueWaits *latencyTracker
+
+	// numQueued is the number of announcements currently waiting to be
+	// picked up by the networkHandler.
+	//
+	// NOTE: This MUST be used atomically.
+	numQueued int32
+
+	// metrics tracks the counters exposed through the MetricsHandler.
+	metrics *gossipMetrics
+
+	// nodeChanCounts tracks the number of channels of each node within
+	// the graph. It's only used if MaxChannelsPerNode is set, and loaded
+	// lazily from the graph once first needed. From then on, it's updated
+	// as channels are added to the graph, and as they're pruned from it
+	// by either us or the ChannelsPruned notifications.
+	nodeChanCounts nodeChanCounts
+
+	// now returns the current wall clock time, and is used to timestamp
+	// our own channel updates. It's a variable so the clock can be
+	// manipulated within tests.
+	now func() time.Time
+}
+
+// isMainNetChain returns true if the passed chain hash is the genesis hash of
+// the bitcoin or viacoin main network.
+func isMainNetChain(chainHash chainhash.Hash) bool {
+	viaGenesis := viacoinCfg.MainNetParams.GenesisHash
+	return chainHash.IsEqual(chaincfg.MainNetParams.GenesisHash) ||
+		bytes.Equal(chainHash[:], viaGenesis[:])
 }
 
 // New creates a new AuthenticatedGossiper instance, initialized with the
 // passed configuration parameters.
 func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
+	// Before we proceed, we'll ensure that all the required elements of
+	// the config have been populated, as otherwise we'd only find out
+	// once they're first used.
+	switch {
+	case cfg.Router == nil:
+		return nil, errors.New("gossiper config is missing Router")
+	case cfg.Notifier == nil:
+		return nil, errors.New("gossiper config is missing Notifier")
+	case cfg.Broadcast == nil:
+		return nil, errors.New("gossiper config is missing Broadcast")
+	case cfg.SendToPeer == nil:
+		return nil, errors.New("gossiper config is missing SendToPeer")
+	case cfg.DB == nil:
+		return nil, errors.New("gossiper config is missing DB")
+	case cfg.AnnSigner == nil:
+		return nil, errors.New("gossiper config is missing AnnSigner")
+	case cfg.SelfBroadcastRate != 0 && cfg.SelfBroadcastInterval <= 0:
+		return nil, errors.New("gossiper config is missing " +
+			"SelfBroadcastInterval")
+	case cfg.SelfTestInterval != 0 && cfg.SelfTestSampleSize <= 0:
+		return nil, errors.New("gossiper config is missing " +
+			"SelfTestSampleSize")
+	case cfg.NodeAnnRateLimit != 0 && cfg.NodeAnnRateInterval <= 0:
+		return nil, errors.New("gossiper config is missing " +
+			"NodeAnnRateInterval")
+	case cfg.RequestNodeAnn != nil && cfg.NodeAnnRequestInterval <= 0:
+		return nil, errors.New("gossiper config is missing " +
+			"NodeAnnRequestInterval")
+	case cfg.EdgeFailureBackoff != 0 &&
+		cfg.MaxEdgeFailureBackoff < cfg.EdgeFailureBackoff:
+
+		return nil, errors.New("gossiper config is missing " +
+			"MaxEdgeFailureBackoff")
+	case cfg.InitialSyncStrategy == InitialSyncTimestamp &&
+		cfg.InitialSyncHorizon <= 0:
+
+		return nil, errors.New("gossiper config is missing " +
+			"InitialSyncHorizon")
+	case cfg.TrackGossipProvenance && cfg.MaxGossipProvenance <= 0:
+		return nil, errors.New("gossiper config is missing " +
+			"MaxGossipProvenance")
+	case cfg.MaxWrongChainAnns != 0 && cfg.WrongChainPeer == nil:
+		return nil, errors.New("gossiper config is missing " +
+			"WrongChainPeer")
+	case cfg.OrphanUpdateLimit != 0 && cfg.OrphanUpdateTTL <= 0:
+		return nil, errors.New("gossiper config is missing " +
+			"OrphanUpdateTTL")
+	case cfg.PeerDistance != nil && cfg.ConnectedPeers == nil:
+		return nil, errors.New("gossiper config is missing " +
+			"ConnectedPeers")
+	case cfg.MinPeersForBroadcast != 0 && cfg.NumConnectedPeers == nil:
+		return nil, errors.New("gossiper config is missing " +
+			"NumConnectedPeers")
+	case len(cfg.Checkpoints) != 0 && cfg.FetchBlockHash == nil:
+		return nil, errors.New("gossiper config is missing " +
+			"FetchBlockHash")
+	case cfg.SkipAnnValidation && isMainNetChain(cfg.ChainHash):
+		return nil, errors.New("announcement validation can't be " +
+			"skipped on mainnet")
+	}
+
 	storage, err := channeldb.NewWaitingProofStore(cfg.DB)
 	if err != nil {
 		return nil, err
 	}
 
+	var prematureQueue *channeldb.PrematureAnnStore
+	if cfg.PrematureQueueSize != 0 {
+		prematureQueue, err = channeldb.NewPrematureAnnStore(
+			cfg.DB, cfg.PrematureQueueSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var graphMemory *graphMemoryTracker
+	if cfg.MaxGraphMemory != 0 {
+		graphMemory = newGraphMemoryTracker(cfg.MaxGraphMemory)
+	}
+
+	var provenance *gossipProvenance
+	if cfg.TrackGossipProvenance {
+		provenance = newGossipProvenance(cfg.MaxGossipProvenance)
+	}
+
+	var pending *pendingNodeAnns
+	if cfg.LazyNodeAnnValidation {
+		pending = newPendingNodeAnns()
+	}
+
+	var signCache *signDataCache
+	if cfg.SignCacheSize != 0 {
+		signCache = newSignDataCache(cfg.SignCacheSize)
+	}
+
+	var orphanUpdates *orphanUpdateBuffer
+	if cfg.OrphanUpdateLimit != 0 {
+		orphanUpdates = newOrphanUpdateBuffer(
+			cfg.OrphanUpdateLimit, cfg.OrphanUpdateTTL,
+		)
+	}
+
+	var nodeAnnIntervals *nodeAnnIntervals
+	if cfg.NodeAnnMinInterval != 0 {
+		nodeAnnIntervals = newNodeAnnIntervals(cfg.NodeAnnMinInterval)
+	}
+
+	var rejections *rejectionLog
+	if cfg.RejectionLogSize != 0 {
+		rejections = newRejectionLog(cfg.RejectionLogSize)
+	}
+
+	mirrorQueue := make(chan []lnwire.Message, mirrorQueueSize)
+
 	return &AuthenticatedGossiper{
 		selfKey:                selfKey,
 		cfg:                    &cfg,
 		networkMsgs:            make(chan *networkMsg),
 		quit:                   make(chan struct{}),
+		mirrorQueue:            mirrorQueue,
 		syncRequests:           make(chan *syncRequest),
 		feeUpdates:             make(chan *feeUpdateRequest),
+		resetRequests:          make(chan *resetRequest),
+		purgeRequests:          make(chan *purgeRequest),
+		snapshotRequests:       make(chan *snapshotRequest),
+		advertisedUpdates:      make(map[uint64]*lnwire.ChannelUpdate),
+		stagedUpdates:          make(map[uint64]*lnwire.ChannelUpdate),
 		prematureAnnouncements: make(map[uint32][]*networkMsg),
+		prematureQueue:         prematureQueue,
 		waitingProofs:          storage,
+		peerSends:              make(map[[33]byte]int),
+		graphMemory:            graphMemory,
+		provenance:             provenance,
+		pendingNodeAnns:        pending,
+		signCache:              signCache,
+		rejections:             rejections,
+		nodeAnnLimiter:         newNodeAnnLimiter(),
+		peerMsgLimiter:         newPeerMsgLimiter(),
+		wrongChainAnns:         make(map[[33]byte]int),
+		checkpointResults:      make(map[uint32]error),
+		closedChanChecks:       make(map[uint64]bool),
+		nodeAnnIntervals:       nodeAnnIntervals,
+		nodeAnnRequests:        newNodeAnnLimiter(),
+		edgeFailures:           newEdgeFailureBackoff(),
+		orphanUpdates:          orphanUpdates,
+		latencies:              newLatencyTracker(),
+		queueWaits:             newLatencyTracker(),
+		metrics:                newGossipMetrics(),
+		now:                    time.Now,
 	}, nil
 }
 
@@ -212,7 +1033,9 @@ func (d *AuthenticatedGossiper) SynchronizeNode(pub *btcec.PublicKey) {
 // fee update will be applied to all outgoing channels from the source node.
 // Fee updates are done in two stages: first, the AuthenticatedGossiper ensures
 // the updated has been committed by dependant sub-systems, then it signs and
-// broadcasts new updates to the network.
+// broadcasts new updates to the network. If the update can't be applied to
+// some of the channels, such as due to an invalid CLTV delta, then it's still
+// applied to all others, and a *ChanPolicyUpdateError is returned.
 func (d *AuthenticatedGossiper) PropagateFeeUpdate(newSchema routing.FeeSchema,
 	chanPoints ...wire.OutPoint) error {
 
@@ -231,6 +1054,80 @@ func (d *AuthenticatedGossiper) PropagateFeeUpdate(newSchema routing.FeeSchema,
 	}
 }
 
+// ChannelsForNode returns the short channel IDs of all the channels within the
+// graph that the target node is an endpoint of.
+func (d *AuthenticatedGossiper) ChannelsForNode(
+	pub *btcec.PublicKey) ([]lnwire.ShortChannelID, error) {
+
+	var chanIDs []lnwire.ShortChannelID
+	err := d.cfg.Router.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		if info.NodeKey1.IsEqual(pub) || info.NodeKey2.IsEqual(pub) {
+			chanIDs = append(chanIDs,
+				lnwire.NewShortChanIDFromInt(info.ChannelID))
+		}
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return nil, err
+	}
+
+	return chanIDs, nil
+}
+
+// StaleChannels returns the short channel IDs of all third-party channels
+// within the graph for which neither of the directed edges has been updated
+// within the passed threshold, just as the router determines zombie channels.
+// Unlike the router, the channels aren't deleted, leaving it up to the caller
+// to decide which of them to prune.
+func (d *AuthenticatedGossiper) StaleChannels(
+	threshold time.Duration) ([]lnwire.ShortChannelID, error) {
+
+	now := d.now()
+	isStale := func(edge *channeldb.ChannelEdgePolicy) bool {
+		return edge == nil || now.Sub(edge.LastUpdate) >= threshold
+	}
+
+	var chanIDs []lnwire.ShortChannelID
+	err := d.cfg.Router.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		e1, e2 *channeldb.ChannelEdgePolicy) error {
+
+		// Our own channels are kept fresh by our retransmissions, so
+		// we'll only consider those of others.
+		if info.NodeKey1.IsEqual(d.selfKey) ||
+			info.NodeKey2.IsEqual(d.selfKey) {
+
+			return nil
+		}
+
+		if isStale(e1) && isStale(e2) {
+			chanIDs = append(chanIDs,
+				lnwire.NewShortChanIDFromInt(info.ChannelID))
+		}
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return nil, err
+	}
+
+	return chanIDs, nil
+}
+
+// RecentRejections returns up to limit of the most recently rejected network
+// announcements, ordered from newest to oldest. If limit is non-positive,
+// then all retained rejections are returned. If the gossiper wasn't
+// configured to retain rejections, then nil is returned.
+func (d *AuthenticatedGossiper) RecentRejections(limit int) []RejectionRecord {
+	if d.rejections == nil {
+		return nil
+	}
+
+	return d.rejections.recent(limit)
+}
+
 // Start spawns network messages handler goroutine and registers on new block
 // notifications in order to properly handle the premature announcements.
 func (d *AuthenticatedGossiper) Start() error {
@@ -240,20 +1137,76 @@ func (d *AuthenticatedGossiper) Start() error {
 
 	log.Info("Authenticated Gossiper is starting")
 
+	if d.cfg.SkipAnnValidation {
+		log.Warn("!!! SIGNATURE VALIDATION OF REMOTE ANNOUNCEMENTS " +
+			"IS DISABLED, ONLY USE THIS WITH TRUSTED PEERS !!!")
+	}
+
 	// First we register for new notifications of newly discovered blocks.
 	// We do this immediately so we'll later be able to consume any/all
 	// blocks which were discovered.
-	blockEpochs, err := d.cfg.Notifier.RegisterBlockEpochNtfn()
+	var blockEpochs *chainntnfs.BlockEpochEvent
+	err := d.retryStartupOp("Block epoch registration", func() error {
+		var err error
+		blockEpochs, err = d.cfg.Notifier.RegisterBlockEpochNtfn()
+		return err
+	})
 	if err != nil {
 		return err
 	}
 	d.newBlocks = blockEpochs.Epochs
 
-	height, err := d.cfg.Router.CurrentBlockHeight()
+	// If the notifier is able to report re-orgs, then we'll also register
+	// for notifications of disconnected blocks, such that we can prune
+	// any channels whose funding transaction has been re-org'd out.
+	notifier, ok := d.cfg.Notifier.(chainntnfs.StaleBlockNotifier)
+	if ok {
+		var staleBlocks *chainntnfs.BlockEpochEvent
+		registerStaleBlocks := func() error {
+			var err error
+			staleBlocks, err = notifier.RegisterStaleBlockNtfn()
+			return err
+		}
+		err := d.retryStartupOp(
+			"Stale block registration", registerStaleBlocks,
+		)
+		if err != nil {
+			return err
+		}
+		d.staleBlocks = staleBlocks.Epochs
+	}
+
+	var height uint32
+	err = d.retryStartupOp("Block height query", func() error {
+		var err error
+		height, err = d.cfg.Router.CurrentBlockHeight()
+		return err
+	})
 	if err != nil {
 		return err
 	}
-	d.bestHeight = height
+	atomic.StoreUint32(&d.bestHeight, height)
+
+	// If requested, we'll load the set of our closed channels, such that
+	// peers gossiping them back to us can be rejected.
+	if d.cfg.FetchClosedChannels != nil {
+		if err := d.loadClosedChannels(); err != nil {
+			return err
+		}
+	}
+
+	// If we've been provided with a graph snapshot, then we'll seed the
+	// router with it before we start to process any other messages.
+	if d.cfg.GraphSnapshotPath != "" {
+		if err := d.loadGraphSnapshot(d.cfg.GraphSnapshotPath); err != nil {
+			return err
+		}
+	}
+
+	if d.cfg.SecondaryBroadcast != nil {
+		d.wg.Add(1)
+		go d.mirrorHandler()
+	}
 
 	d.wg.Add(1)
 	go d.networkHandler()
@@ -261,6 +1214,12 @@ func (d *AuthenticatedGossiper) Start() error {
 	return nil
 }
 
+// BestHeight returns the height of the block at the tip of the main chain as
+// currently known by the gossiper.
+func (d *AuthenticatedGossiper) BestHeight() uint32 {
+	return atomic.LoadUint32(&d.bestHeight)
+}
+
 // Stop signals any active goroutines for a graceful closure.
 func (d *AuthenticatedGossiper) Stop() {
 	if !atomic.CompareAndSwapUint32(&d.stopped, 0, 1) {
@@ -281,6 +1240,23 @@ func (d *AuthenticatedGossiper) Stop() {
 func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 	src *btcec.PublicKey) chan error {
 
+	return d.processRemoteAnnouncement(msg, 0, src)
+}
+
+// ProcessSizedRemoteAnnouncement is identical to ProcessRemoteAnnouncement,
+// but also takes the size of the announcement as read from the wire, which
+// spares it from being serialized again to enforce the MaxAnnouncementSize.
+func (d *AuthenticatedGossiper) ProcessSizedRemoteAnnouncement(
+	msg lnwire.Message, size int, src *btcec.PublicKey) chan error {
+
+	return d.processRemoteAnnouncement(msg, size, src)
+}
+
+// processRemoteAnnouncement hands the passed remote announcement, of the
+// given size on the wire or zero if unknown, over to the networkHandler.
+func (d *AuthenticatedGossiper) processRemoteAnnouncement(msg lnwire.Message,
+	size int, src *btcec.PublicKey) chan error {
+
 	nMsg := &networkMsg{
 		msg:      msg,
 		isRemote: true,
@@ -288,13 +1264,86 @@ func (d *AuthenticatedGossiper) ProcessRemoteAnnouncement(msg lnwire.Message,
 		err:      make(chan error, 1),
 	}
 
+	// Once we've been signalled to stop, the networkHandler may no longer
+	// be reading new messages, so we'll refuse the announcement right away
+	// rather than racing against its exit.
+	if atomic.LoadUint32(&d.stopped) == 1 {
+		nMsg.err <- ErrShuttingDown
+		return nMsg.err
+	}
+
+	// Before handing the message over to the networkHandler, we'll ensure
+	// that the peer hasn't exceeded its overall share of our processing,
+	// such that excess messages don't hold up the messages of others.
+	if !d.allowPeerMsg(nMsg) {
+		return nMsg.err
+	}
+
+	// We'll also reject any announcement which exceeds our maximum size,
+	// before spending any effort on validating it. This is done once here
+	// rather than within the networkHandler, such that announcements
+	// replayed to it later on aren't measured again.
+	if d.cfg.MaxAnnouncementSize != 0 {
+		if err := d.checkAnnouncementSize(msg, size); err != nil {
+			err := errors.Errorf("rejecting %v: %v",
+				messageSummary(nMsg), err)
+			log.Debug(err)
+			d.recordRejection(nMsg, RejectOversized, err)
+			nMsg.err <- err
+			return nMsg.err
+		}
+	}
+
+	d.enqueueNetworkMsg(nMsg)
+
+	return nMsg.err
+}
+
+// allowPeerMsg returns true if the passed remote message may be processed
+// under the MaxMsgsPerPeerPerSecond. Otherwise, the message is rejected as
+// rate limited, and the peer is reported for flooding us.
+func (d *AuthenticatedGossiper) allowPeerMsg(nMsg *networkMsg) bool {
+	if nMsg.peer == nil || d.cfg.MaxMsgsPerPeerPerSecond == 0 {
+		return true
+	}
+
+	var peer [33]byte
+	copy(peer[:], nMsg.peer.SerializeCompressed())
+
+	allowed := d.peerMsgLimiter.allow(
+		peer, d.now(), d.cfg.MaxMsgsPerPeerPerSecond,
+	)
+	if allowed {
+		return true
+	}
+
+	err := errors.Errorf("dropping %v: peer=%x sent more than %v "+
+		"messages within a second", messageSummary(nMsg), peer,
+		d.cfg.MaxMsgsPerPeerPerSecond)
+	log.Debug(err)
+	d.recordRejection(nMsg, RejectRateLimited, err)
+	d.reportPeerScore(nMsg, floodAnnScore, "message flood")
+	nMsg.err <- err
+
+	return false
+}
+
+// enqueueNetworkMsg hands the passed message over to the networkHandler,
+// blocking until the handler has picked it up. As the handler processes
+// messages one at a time, each message waits for all messages submitted ahead
+// of it, so we'll track the number of waiting messages along with the time
+// they spent waiting.
+func (d *AuthenticatedGossiper) enqueueNetworkMsg(nMsg *networkMsg) {
+	nMsg.queuedAt = time.Now()
+
+	atomic.AddInt32(&d.numQueued, 1)
+	defer atomic.AddInt32(&d.numQueued, -1)
+
 	select {
 	case d.networkMsgs <- nMsg:
 	case <-d.quit:
-		nMsg.err <- errors.New("gossiper has shut down")
+		nMsg.err <- ErrShuttingDown
 	}
-
-	return nMsg.err
 }
 
 // ProcessLocalAnnouncement sends a new remote announcement message along with
@@ -314,12 +1363,16 @@ func (d *AuthenticatedGossiper) ProcessLocalAnnouncement(msg lnwire.Message,
 		err:      make(chan error, 1),
 	}
 
-	select {
-	case d.networkMsgs <- nMsg:
-	case <-d.quit:
-		nMsg.err <- errors.New("gossiper has shut down")
+	// Once we've been signalled to stop, the networkHandler may no longer
+	// be reading new messages, so we'll refuse the announcement right away
+	// rather than racing against its exit.
+	if atomic.LoadUint32(&d.stopped) == 1 {
+		nMsg.err <- ErrShuttingDown
+		return nMsg.err
 	}
 
+	d.enqueueNetworkMsg(nMsg)
+
 	return nMsg.err
 }
 
@@ -338,7 +1391,9 @@ func (d *AuthenticatedGossiper) networkHandler() {
 	//  * buffer recv'd node ann until after chan ann that includes is
 	//    created
 	//    * can use mostly empty struct in db as place holder
-	var announcementBatch []lnwire.Message
+	announcementBatch := &priorityBatch{
+		prioritizeLocal: d.cfg.PrioritizeLocalAnnouncements,
+	}
 
 	retransmitTimer := time.NewTicker(d.cfg.RetransmitDelay)
 	defer retransmitTimer.Stop()
@@ -346,9 +1401,40 @@ func (d *AuthenticatedGossiper) networkHandler() {
 	trickleTimer := time.NewTicker(d.cfg.TrickleDelay)
 	defer trickleTimer.Stop()
 
+	// If our own announcements are rate limited, then we'll release a
+	// portion of them each time this ticker ticks. Otherwise, the channel
+	// remains nil and is never selected.
+	var selfBroadcastTicks <-chan time.Time
+	if d.cfg.SelfBroadcastRate != 0 {
+		selfBroadcastTimer := time.NewTicker(d.cfg.SelfBroadcastInterval)
+		defer selfBroadcastTimer.Stop()
+
+		selfBroadcastTicks = selfBroadcastTimer.C
+	}
+
+	// Similarly, the self-test ticker is only set if we've been
+	// instructed to periodically re-validate our own channel updates.
+	var selfTestTicks <-chan time.Time
+	if d.cfg.SelfTestInterval != 0 {
+		selfTestTimer := time.NewTicker(d.cfg.SelfTestInterval)
+		defer selfTestTimer.Stop()
+
+		selfTestTicks = selfTestTimer.C
+	}
+
+	// The consistency sweep ticker is only set if we've been instructed
+	// to periodically reconcile our state with that of the router.
+	var sweepTicks <-chan time.Time
+	if d.cfg.ConsistencySweepInterval != 0 {
+		sweepTimer := time.NewTicker(d.cfg.ConsistencySweepInterval)
+		defer sweepTimer.Stop()
+
+		sweepTicks = sweepTimer.C
+	}
+
 	// To start, we'll first check to see if there're any stale channels
 	// that we need to re-transmit.
-	if err := d.retransmitStaleChannels(); err != nil {
+	if err := d.retransmitWhenIdle(); err != nil {
 		log.Errorf("unable to rebroadcast stale channels: %v",
 			err)
 	}
@@ -362,7 +1448,9 @@ func (d *AuthenticatedGossiper) networkHandler() {
 			// First, we'll now create new fully signed updates for
 			// the affected channels and also update the underlying
 			// graph with the new state.
-			newChanUpdates, err := d.processFeeChanUpdate(feeUpdate)
+			newChanUpdates, failures, err := d.processFeeChanUpdate(
+				feeUpdate,
+			)
 			if err != nil {
 				log.Errorf("Unable to craft fee updates: %v", err)
 				feeUpdate.errResp <- err
@@ -371,29 +1459,66 @@ func (d *AuthenticatedGossiper) networkHandler() {
 
 			// Finally, with the updates committed, we'll now add
 			// them to the announcement batch to be flushed at the
-			// start of the next epoch.
-			announcementBatch = append(announcementBatch,
-				newChanUpdates...)
+			// start of the next epoch, unless they're subject to
+			// the rate limit of our own announcements.
+			if !d.queueSelfAnnouncements(newChanUpdates...) {
+				announcementBatch.add(false, newChanUpdates...)
+			}
+
+			// The channels the update couldn't be applied to are
+			// reported back to the caller.
+			if len(failures) != 0 {
+				feeUpdate.errResp <- &ChanPolicyUpdateError{
+					Failures: failures,
+				}
+				continue
+			}
 
 			feeUpdate.errResp <- nil
 
+		// A request to reset the gossip state of a stuck channel has
+		// arrived. We'll clear its state, and re-process any of the
+		// announcements that were held back for it.
+		case req := <-d.resetRequests:
+			nMsgs, err := d.clearChannelGossipState(req.chanID)
+			if err != nil {
+				req.errResp <- err
+				continue
+			}
+
+			for _, nMsg := range nMsgs {
+				d.processAndBatch(nMsg, announcementBatch)
+			}
+
+			req.errResp <- nil
+
+		// A request to purge the gossip introduced by a banned peer
+		// has arrived.
+		case req := <-d.purgeRequests:
+			req.errResp <- d.purgePeerGossip(req.peer)
+
+		// A request for a snapshot of our internal state has arrived.
+		// As it's taken from within the networkHandler, no
+		// announcement can be processed while it's being taken.
+		case req := <-d.snapshotRequests:
+			snapshot, err := d.snapshot(announcementBatch.len())
+			if err != nil {
+				req.errResp <- err
+				continue
+			}
+			req.resp <- snapshot
+
 		case announcement := <-d.networkMsgs:
+			d.queueWaits.record(
+				announcement.msg.MsgType(),
+				time.Since(announcement.queuedAt),
+			)
+
 			// Process the network announcement to determine if
 			// this is either a new announcement from our PoV or an
 			// edges to a prior vertex/edge we previously
 			// proceeded.
-			emittedAnnouncements := d.processNetworkAnnouncement(announcement)
-
-			// If the announcement was accepted, then add the
-			// emitted announcements to our announce batch to be
-			// broadcast once the trickle timer ticks gain.
-			if emittedAnnouncements != nil {
-				// TODO(roasbeef): exclude peer that sent
-				announcementBatch = append(
-					announcementBatch,
-					emittedAnnouncements...,
-				)
-			}
+			d.processAndBatch(announcement, announcementBatch)
 
 		// A new block has arrived, so we can re-process the previously
 		// premature announcements.
@@ -404,15 +1529,24 @@ func (d *AuthenticatedGossiper) networkHandler() {
 				return
 			}
 
+			// Channels may have been closed within the block, so
+			// we'll check their funding outputs anew.
+			d.resetClosedChanChecks()
+
 			// Once a new block arrives, we updates our running
 			// track of the height of the chain tip.
 			blockHeight := uint32(newBlock.Height)
-			d.bestHeight = blockHeight
+			atomic.StoreUint32(&d.bestHeight, blockHeight)
+
+			// If the block replaces one disconnected by a re-org,
+			// then we can now tell whether the channels funded
+			// within the disconnected block are still confirmed.
+			d.verifyStaleChannels(blockHeight)
 
 			// Next we check if we have any premature announcements
 			// for this height, if so, then we process them once
 			// more as normal announcements.
-			prematureAnns := d.prematureAnnouncements[uint32(newBlock.Height)]
+			prematureAnns := d.maturePremature(blockHeight)
 			if len(prematureAnns) != 0 {
 				log.Infof("Re-processing %v premature "+
 					"announcements for height %v",
@@ -420,42 +1554,152 @@ func (d *AuthenticatedGossiper) networkHandler() {
 			}
 
 			for _, ann := range prematureAnns {
-				emittedAnnouncements := d.processNetworkAnnouncement(ann)
-				if emittedAnnouncements != nil {
-					announcementBatch = append(
-						announcementBatch,
-						emittedAnnouncements...,
-					)
-				}
+				d.processAndBatch(ann, announcementBatch)
+			}
+			d.updatePrematureDepth()
+
+		// A block has been disconnected from the main chain due to a
+		// re-org, so the channels confirmed within it may no longer
+		// be valid.
+		case staleBlock, ok := <-d.staleBlocks:
+			// If the channel has been closed, then this indicates
+			// the daemon is shutting down, so we exit ourselves.
+			if !ok {
+				return
+			}
+
+			staleHeight := uint32(staleBlock.Height)
+			log.Warnf("Block %v at height %v disconnected from main "+
+				"chain", staleBlock.Hash, staleHeight)
+
+			// The spends of funding outputs within the block have
+			// been undone, so we'll check them anew.
+			d.resetClosedChanChecks()
+
+			// Our view of the chain tip now falls back to the
+			// block preceding the disconnected one.
+			if d.bestHeight >= staleHeight && staleHeight > 0 {
+				atomic.StoreUint32(&d.bestHeight, staleHeight-1)
+			}
+
+			// The channels funded within the block will be
+			// re-verified once the new main chain reaches its
+			// height again.
+			if d.staleFrom == 0 || staleHeight < d.staleFrom {
+				d.staleFrom = staleHeight
+			}
+			if staleHeight > d.staleTo {
+				d.staleTo = staleHeight
 			}
-			delete(d.prematureAnnouncements, blockHeight)
+
+			// Any checkpoints at or above the height will need to
+			// be verified against the new main chain.
+			d.dropCheckpointResults(staleHeight)
 
 		// The trickle timer has ticked, which indicates we should
 		// flush to the network the pending batch of new announcements
 		// we've received since the last trickle tick.
 		case <-trickleTimer.C:
+			// If a retransmission of our stale channels was
+			// deferred due to the system being busy, then we'll
+			// catch up on it once the system is idle again.
+			if d.retransmitDeferred {
+				if err := d.retransmitWhenIdle(); err != nil {
+					log.Errorf("unable to rebroadcast "+
+						"stale channels: %v", err)
+				}
+			}
+
 			// If the current announcements batch is nil, then we
-			// have no further work here.
-			if len(announcementBatch) == 0 {
+			// have no further work here. Similarly, if we aren't
+			// connected to enough peers yet, then we'll hold on
+			// to the batch until we are.
+			if announcementBatch.len() == 0 ||
+				!d.enoughPeersForBroadcast() {
+
 				continue
 			}
 
 			log.Infof("Broadcasting batch of %v new announcements",
-				len(announcementBatch))
+				announcementBatch.len())
 
 			// If we have new things to announce then broadcast
 			// them to all our immediately connected peers.
-			err := d.cfg.Broadcast(nil, announcementBatch...)
-			if err != nil {
+			batch := announcementBatch.messages()
+			if err := d.broadcastBatch(batch...); err != nil {
 				log.Errorf("unable to send batch "+
 					"announcements: %v", err)
 				continue
 			}
+			d.recordAdvertisedUpdates(batch...)
+			d.metrics.recordBatch(announcementBatch.len())
 
 			// If we're able to broadcast the current batch
 			// successfully, then we reset the batch for a new
 			// round of announcements.
-			announcementBatch = nil
+			announcementBatch.reset()
+
+		// The self broadcast timer has ticked, so we'll release the
+		// next portion of our own rate limited announcements.
+		case <-selfBroadcastTicks:
+			if len(d.selfAnnQueue) == 0 ||
+				!d.enoughPeersForBroadcast() {
+
+				continue
+			}
+
+			numAnns := d.cfg.SelfBroadcastRate
+			if numAnns > len(d.selfAnnQueue) {
+				numAnns = len(d.selfAnnQueue)
+			}
+
+			log.Debugf("Broadcasting %v of %v queued self "+
+				"announcements", numAnns, len(d.selfAnnQueue))
+
+			err := d.cfg.Broadcast(nil, d.selfAnnQueue[:numAnns]...)
+			if err != nil {
+				log.Errorf("unable to send self "+
+					"announcements: %v", err)
+				continue
+			}
+			d.mirrorBroadcast(d.selfAnnQueue[:numAnns]...)
+			d.recordAdvertisedUpdates(d.selfAnnQueue[:numAnns]...)
+
+			d.selfAnnQueue = d.selfAnnQueue[numAnns:]
+
+		// The self-test timer has ticked, so we'll re-validate a sample
+		// of our own channel updates to catch any corruption of their
+		// signatures.
+		case <-selfTestTicks:
+			invalid, err := d.selfTestUpdates()
+			if err != nil {
+				log.Errorf("unable to self-test channel "+
+					"updates: %v", err)
+				continue
+			}
+
+			for _, chanID := range invalid {
+				log.Criticalf("Signature of our channel update "+
+					"for short_chan_id=%v doesn't verify "+
+					"against our identity key",
+					chanID.ToUint64())
+			}
+
+		// The consistency sweep timer has ticked, so we'll reconcile
+		// our state with the router, and re-process any announcements
+		// which were left behind.
+		case <-sweepTicks:
+			nMsgs, err := d.sweepConsistency()
+			if err != nil {
+				log.Errorf("unable to sweep gossiper state: %v",
+					err)
+				continue
+			}
+
+			for _, nMsg := range nMsgs {
+				d.processAndBatch(nMsg, announcementBatch)
+			}
+			d.updatePrematureDepth()
 
 		// The retransmission timer has ticked which indicates that we
 		// should check if we need to prune or re-broadcast any of our
@@ -463,7 +1707,7 @@ func (d *AuthenticatedGossiper) networkHandler() {
 		// channel advertisements that have been dropped, or not properly
 		// propagated through the network.
 		case <-retransmitTimer.C:
-			if err := d.retransmitStaleChannels(); err != nil {
+			if err := d.retransmitWhenIdle(); err != nil {
 				log.Errorf("unable to rebroadcast stale "+
 					"channels: %v", err)
 			}
@@ -480,6 +1724,18 @@ func (d *AuthenticatedGossiper) networkHandler() {
 					nodePub, err)
 			}
 
+			// If requested, we'll also resend the latest updates
+			// of the channels we share with the peer, including
+			// those of channels not yet announced.
+			if d.cfg.ResendSharedUpdates {
+				err := d.resendSharedUpdates(syncReq.node)
+				if err != nil {
+					log.Errorf("unable to resend shared "+
+						"channel updates to %x: %v",
+						nodePub, err)
+				}
+			}
+
 		// The gossiper has been signalled to exit, to we exit our
 		// main loop so the wait group can be decremented.
 		case <-d.quit:
@@ -488,18 +1744,69 @@ func (d *AuthenticatedGossiper) networkHandler() {
 	}
 }
 
-// retransmitStaleChannels eaxmines all outgoing channels that the source node
-// is known to maintain to check to see if any of them are "stale". A channel
-// is stale iff, the last timestamp of it's rebroadcast is older then
-// broadcastInterval.
+// processAndBatch processes the passed announcement, and adds the
+// announcements it emits to the passed batch, to be broadcast once the
+// trickle timer ticks again. Emitted announcements stemming from our own
+// local messages may instead be held back by the rate limit of our own
+// announcements, while those of remote peers aren't relayed at all in
+// listen-only mode.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) processAndBatch(nMsg *networkMsg,
+	batch *priorityBatch) {
+
+	emittedAnnouncements := d.processNetworkAnnouncement(nMsg)
+	if emittedAnnouncements == nil {
+		return
+	}
+
+	if !nMsg.isRemote &&
+		d.queueSelfAnnouncements(emittedAnnouncements...) {
+
+		return
+	}
+
+	if !d.shouldRelay(nMsg) {
+		return
+	}
+
+	// TODO(roasbeef): exclude peer that sent
+	batch.add(nMsg.isRemote, emittedAnnouncements...)
+}
+
+// retransmitWhenIdle retransmits our stale channels, unless the system is
+// currently busy, or we aren't connected to enough peers yet. In that case,
+// the retransmission is deferred, and should be re-attempted later on.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) retransmitWhenIdle() error {
+	if d.cfg.SystemBusy != nil && d.cfg.SystemBusy() {
+		if !d.retransmitDeferred {
+			log.Debugf("System busy, deferring retransmission " +
+				"of stale channels")
+		}
+
+		d.retransmitDeferred = true
+		return nil
+	}
+
+	if !d.enoughPeersForBroadcast() {
+		d.retransmitDeferred = true
+		return nil
+	}
+
+	d.retransmitDeferred = false
+	return d.retransmitStaleChannels()
+}
+
+// retransmitStaleChannels eaxmines all outgoing channels that the source node
+// is known to maintain to check to see if any of them are "stale". A channel
+// is stale iff, the last timestamp of it's rebroadcast is older then
+// broadcastInterval.
 func (d *AuthenticatedGossiper) retransmitStaleChannels() error {
 	// Iterate over all of our channels and check if any of them fall
 	// within the prune interval or re-broadcast interval.
-	type updateTuple struct {
-		info *channeldb.ChannelEdgeInfo
-		edge *channeldb.ChannelEdgePolicy
-	}
-	var edgesToUpdate []updateTuple
+	var edgesToUpdate []channelEdge
 	err := d.cfg.Router.ForAllOutgoingChannels(func(
 		info *channeldb.ChannelEdgeInfo,
 		edge *channeldb.ChannelEdgePolicy) error {
@@ -512,7 +1819,7 @@ func (d *AuthenticatedGossiper) retransmitStaleChannels() error {
 		// channel, add the channel to the set of edges we need to
 		// update.
 		if timeElapsed >= broadcastInterval {
-			edgesToUpdate = append(edgesToUpdate, updateTuple{
+			edgesToUpdate = append(edgesToUpdate, channelEdge{
 				info: info,
 				edge: edge,
 			})
@@ -525,20 +1832,19 @@ func (d *AuthenticatedGossiper) retransmitStaleChannels() error {
 			"channels: %v", err)
 	}
 
-	var signedUpdates []lnwire.Message
-	for _, chanToUpdate := range edgesToUpdate {
-		// Re-sign and update the channel on disk and retrieve our
-		// ChannelUpdate to broadcast.
-		chanAnn, chanUpdate, err := d.updateChannel(chanToUpdate.info,
-			chanToUpdate.edge)
-		if err != nil {
-			return fmt.Errorf("unable to update channel: %v", err)
-		}
+	// Re-sign and update the channels on disk and retrieve our
+	// ChannelUpdates to broadcast.
+	chanAnns, chanUpdates, err := d.updateChannels(edgesToUpdate)
+	if err != nil {
+		return fmt.Errorf("unable to update channel: %v", err)
+	}
 
+	var signedUpdates []lnwire.Message
+	for i, chanUpdate := range chanUpdates {
 		// If we have a valid announcement to transmit, then we'll send
 		// that along with the update.
-		if chanAnn != nil {
-			signedUpdates = append(signedUpdates, chanAnn)
+		if chanAnns[i] != nil {
+			signedUpdates = append(signedUpdates, chanAnns[i])
 		}
 
 		signedUpdates = append(signedUpdates, chanUpdate)
@@ -552,23 +1858,52 @@ func (d *AuthenticatedGossiper) retransmitStaleChannels() error {
 
 	log.Infof("Retransmitting %v outgoing channels", len(edgesToUpdate))
 
+	// If our own announcements are rate limited, then they'll be
+	// broadcast once released from the queue.
+	if d.queueSelfAnnouncements(signedUpdates...) {
+		return nil
+	}
+
 	// With all the wire announcements properly crafted, we'll broadcast
 	// our known outgoing channels to all our immediate peers.
 	if err := d.cfg.Broadcast(nil, signedUpdates...); err != nil {
 		return fmt.Errorf("unable to re-broadcast channels: %v", err)
 	}
+	d.mirrorBroadcast(signedUpdates...)
+	d.recordAdvertisedUpdates(signedUpdates...)
 
 	return nil
 }
 
+// queueSelfAnnouncements adds the passed self-originated announcements to the
+// queue of announcements to be released at the configured SelfBroadcastRate.
+// If no such rate limit is configured, then false is returned, and the caller
+// should broadcast the announcements as usual.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) queueSelfAnnouncements(
+	msgs ...lnwire.Message) bool {
+
+	if d.cfg.SelfBroadcastRate == 0 {
+		return false
+	}
+
+	d.selfAnnQueue = append(d.selfAnnQueue, msgs...)
+	return true
+}
+
 // processFeeChanUpdate generates a new set of channel updates with the new fee
 // schema applied for each specified channel identified by its channel point.
 // In the case that no channel points are specified, then the fee update will
 // be applied to all channels. Finally, the backing ChannelGraphSource is
 // updated with the latest information reflecting the applied fee updates.
+// The channels which the update couldn't be applied to are returned along with
+// the reason why, keyed by their channel point.
 //
 // TODO(roasbeef): generalize into generic for any channel update
-func (d *AuthenticatedGossiper) processFeeChanUpdate(feeUpdate *feeUpdateRequest) ([]lnwire.Message, error) {
+func (d *AuthenticatedGossiper) processFeeChanUpdate(
+	feeUpdate *feeUpdateRequest) ([]lnwire.Message,
+	map[wire.OutPoint]error, error) {
 	// First, we'll construct a set of all the channels that need to be
 	// updated.
 	chansToUpdate := make(map[wire.OutPoint]struct{})
@@ -578,7 +1913,8 @@ func (d *AuthenticatedGossiper) processFeeChanUpdate(feeUpdate *feeUpdateRequest
 
 	haveChanFilter := len(chansToUpdate) != 0
 
-	var chanUpdates []lnwire.Message
+	var edgesToUpdate []channelEdge
+	failures := make(map[wire.OutPoint]error)
 
 	// Next, we'll loop over all the outgoing channels the router knows of.
 	// If we have a filter then we'll only collected those channels,
@@ -592,27 +1928,59 @@ func (d *AuthenticatedGossiper) processFeeChanUpdate(feeUpdate *feeUpdateRequest
 			return nil
 		}
 
+		// Before modifying the edge, we'll ensure that the CLTV delta
+		// to be set for it, if any, is within the allowed range.
+		// Otherwise, the channel is skipped.
+		timeLockDelta := feeUpdate.newSchema.TimeLockDelta
+		chanDeltas := feeUpdate.newSchema.ChanTimeLockDeltas
+		if delta, ok := chanDeltas[info.ChannelPoint]; ok {
+			timeLockDelta = delta
+		}
+		if timeLockDelta != 0 {
+			err := d.validateTimeLockDelta(timeLockDelta)
+			if err != nil {
+				failures[info.ChannelPoint] = err
+				return nil
+			}
+			edge.TimeLockDelta = timeLockDelta
+		}
+
 		// Apply the new fee schema to the edge.
 		edge.FeeBaseMSat = feeUpdate.newSchema.BaseFee
 		edge.FeeProportionalMillionths = lnwire.MilliSatoshi(
 			feeUpdate.newSchema.FeeRate,
 		)
 
-		// Re-sign and update the backing ChannelGraphSource, and
-		// retrieve our ChannelUpdate to broadcast.
-		_, chanUpdate, err := d.updateChannel(info, edge)
-		if err != nil {
-			return err
+		// A maximum HTLC is only advertised once one has been set,
+		// after which it's carried along with every later update.
+		if feeUpdate.newSchema.MaxHTLC != 0 {
+			edge.Flags |= lnwire.ChanUpdateOptionMaxHtlc
+			edge.MaxHTLC = feeUpdate.newSchema.MaxHTLC
 		}
 
-		chanUpdates = append(chanUpdates, chanUpdate)
+		edgesToUpdate = append(edgesToUpdate, channelEdge{
+			info: info,
+			edge: edge,
+		})
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return chanUpdates, nil
+	// Re-sign and update the backing ChannelGraphSource, and retrieve our
+	// ChannelUpdates to broadcast.
+	_, updates, err := d.updateChannels(edgesToUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chanUpdates := make([]lnwire.Message, 0, len(updates))
+	for _, chanUpdate := range updates {
+		chanUpdates = append(chanUpdates, chanUpdate)
+	}
+
+	return chanUpdates, failures, nil
 }
 
 // processNetworkAnnouncement processes a new network relate authenticated
@@ -621,12 +1989,90 @@ func (d *AuthenticatedGossiper) processFeeChanUpdate(feeUpdate *feeUpdateRequest
 // or redundant, then nil is returned. Otherwise, the set of announcements will
 // be returned which should be broadcasted to the rest of the network.
 func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []lnwire.Message {
+	// We'll record the time taken to validate and apply the announcement,
+	// regardless of its outcome.
+	start := time.Now()
+	defer func() {
+		d.latencies.record(nMsg.msg.MsgType(), time.Since(start))
+		d.metrics.recordProcessed(nMsg.msg.MsgType())
+		d.updatePrematureDepth()
+	}()
+
+	// Full dumps of the announcement are only logged at the trace level,
+	// as they'd otherwise drown out the rest of the log.
+	log.Tracef("Processing %v: %v", messageSummary(nMsg),
+		newLogClosure(func() string {
+			return spew.Sdump(nMsg.msg)
+		}),
+	)
+
+	// Peers commonly gossip our own announcements back to us, which we
+	// can skip right away rather than validating them once more.
+	if d.isEchoedAnnouncement(nMsg) {
+		log.Debugf("Ignoring %v, which is our own echoed back",
+			messageSummary(nMsg))
+		nMsg.err <- nil
+		return nil
+	}
+
 	isPremature := func(chanID lnwire.ShortChannelID, delta uint32) bool {
 		// TODO(roasbeef) make height delta 6
 		//  * or configurable
 		return chanID.BlockHeight+delta > d.bestHeight
 	}
 
+	// rejectFarPremature rejects the premature announcement if it's
+	// remote, and claims a height too far beyond our chain tip to be
+	// buffered. It returns true if the announcement was rejected.
+	rejectFarPremature := func(chanID lnwire.ShortChannelID,
+		delta uint32) bool {
+
+		maxDelta := d.cfg.MaxPrematureHeightDelta
+		if !nMsg.isRemote || maxDelta == 0 {
+			return false
+		}
+
+		height := chanID.BlockHeight + delta
+		if height <= d.bestHeight+maxDelta {
+			return false
+		}
+
+		err := errors.Errorf("premature announcement for "+
+			"short_chan_id=%v needs height %v, which exceeds our "+
+			"height %v by more than %v blocks", chanID.ToUint64(),
+			height, d.bestHeight, maxDelta)
+		log.Warn(err)
+		d.recordRejection(nMsg, RejectPremature, err)
+		nMsg.err <- err
+
+		return true
+	}
+
+	// If the peer is within a cooldown due to repeatedly sending us
+	// channel announcements which failed to be added to the graph, then
+	// we'll reject its announcements before spending any effort on them.
+	// Announcement signatures are exempt, as they only concern channels
+	// that we're a party of.
+	_, isAnnSigs := nMsg.msg.(*lnwire.AnnounceSignatures)
+	if nMsg.isRemote && nMsg.peer != nil && !isAnnSigs &&
+		d.cfg.EdgeFailureBackoff != 0 {
+
+		var peer [33]byte
+		copy(peer[:], nMsg.peer.SerializeCompressed())
+
+		cooldownEnd, ok := d.edgeFailures.cooldownEnd(peer, d.now())
+		if ok {
+			err := errors.Errorf("dropping %v from peer=%x: peer "+
+				"is in cooldown until %v due to failed "+
+				"channel announcements", nMsg.msg.MsgType(),
+				peer, cooldownEnd)
+			log.Debug(err)
+			d.recordRejection(nMsg, RejectRateLimited, err)
+			nMsg.err <- err
+			return nil
+		}
+	}
+
 	var announcements []lnwire.Message
 
 	switch msg := nMsg.msg.(type) {
@@ -635,11 +2081,78 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 	// information about a node in one of the channels we know about, or a
 	// updating previously advertised information.
 	case *lnwire.NodeAnnouncement:
-		if nMsg.isRemote {
+		// Before spending any effort on validating the announcement,
+		// we'll ensure that the peer hasn't exceeded its rate limit
+		// for announcements of this node.
+		if nMsg.isRemote && nMsg.peer != nil &&
+			d.cfg.NodeAnnRateLimit != 0 && !nMsg.deferredNodeAnn {
+
+			var src nodeAnnSource
+			copy(src.peer[:], nMsg.peer.SerializeCompressed())
+			copy(src.node[:], msg.NodeID.SerializeCompressed())
+
+			allowed := d.nodeAnnLimiter.allow(
+				src, d.now(), d.cfg.NodeAnnRateLimit,
+				d.cfg.NodeAnnRateInterval,
+			)
+			if !allowed {
+				err := errors.Errorf("dropping node announcement "+
+					"for node=%x from peer=%x: more than %v "+
+					"announcements within %v", src.node,
+					src.peer, d.cfg.NodeAnnRateLimit,
+					d.cfg.NodeAnnRateInterval)
+				log.Debug(err)
+				d.recordRejection(nMsg, RejectRateLimited, err)
+				nMsg.err <- err
+				return nil
+			}
+		}
+
+		// Similarly, we'll only accept a node announcement once per
+		// interval for each node, regardless of the peer sending it.
+		var nodeID [33]byte
+		copy(nodeID[:], msg.NodeID.SerializeCompressed())
+		if nMsg.isRemote && d.nodeAnnIntervals != nil &&
+			!nMsg.deferredNodeAnn &&
+			!d.nodeAnnIntervals.allow(nodeID, d.now()) {
+
+			err := errors.Errorf("dropping node announcement for "+
+				"node=%x: already accepted one within %v",
+				nodeID, d.cfg.NodeAnnMinInterval)
+			log.Debug(err)
+			d.recordRejection(nMsg, RejectRateLimited, err)
+			nMsg.err <- err
+			return nil
+		}
+
+		if nMsg.isRemote && !d.cfg.SkipAnnValidation {
+			// If lazy validation is enabled, then the validation
+			// may be deferred until the node is first used.
+			if d.deferNodeAnnValidation(nMsg, msg) {
+				nMsg.err <- nil
+				return nil
+			}
+
 			if err := d.validateNodeAnn(msg); err != nil {
 				err := errors.Errorf("unable to validate "+
 					"node announcement: %v", err)
 				log.Error(err)
+				d.recordRejection(nMsg, RejectInvalidSig, err)
+				nMsg.err <- err
+				return nil
+			}
+		}
+
+		// The features of the node should also be consistent with
+		// those of its channels, which we'll check if instructed to.
+		if nMsg.isRemote {
+			if err := d.checkNodeFeatures(msg); err != nil {
+				err := errors.Errorf("rejecting node "+
+					"announcement: %v", err)
+				log.Debug(err)
+				d.recordRejection(
+					nMsg, RejectFeatureMismatch, err,
+				)
 				nMsg.err <- err
 				return nil
 			}
@@ -655,7 +2168,11 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			Features:             msg.Features,
 		}
 
-		if err := d.cfg.Router.AddNode(node); err != nil {
+		err := d.retryRouterOp(func() error {
+			return d.cfg.Router.AddNode(node)
+		})
+		d.recordNodeProvenance(nMsg, msg, err)
+		if err != nil {
 			if routing.IsError(err, routing.ErrOutdated,
 				routing.ErrIgnored) {
 
@@ -664,14 +2181,43 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 				log.Error(err)
 			}
 
+			d.recordRejection(nMsg, routerRejectionReason(err), err)
 			nMsg.err <- err
 			return nil
 		}
 
+		if nMsg.isRemote && d.nodeAnnIntervals != nil {
+			d.nodeAnnIntervals.accepted(nodeID, d.now())
+		}
+
+		// If we're enforcing a memory budget on the graph, then we'll
+		// account for the newly learned node.
+		if nMsg.isRemote && d.graphMemory != nil {
+			d.graphMemory.trackNode(node)
+			d.enforceGraphMemoryLimit()
+		}
+
+		// Our own node announcement is withheld until we have enough
+		// announced channels for peers to accept it.
+		isSelf := !nMsg.isRemote && msg.NodeID.IsEqual(d.selfKey)
+		if isSelf && !d.canAnnounceSelf() {
+			log.Infof("Withholding our node announcement until we "+
+				"have %v announced channels",
+				d.cfg.MinChannelsToAnnounce)
+
+			d.pendingSelfNodeAnn = msg
+			nMsg.err <- nil
+			return nil
+		}
+		if isSelf {
+			d.pendingSelfNodeAnn = nil
+		}
+
 		// Node announcement was successfully proceeded and know it
 		// might be broadcast to other connected nodes.
 		announcements = append(announcements, msg)
 
+		d.reportPeerScore(nMsg, novelAnnScore, "novel node announcement")
 		nMsg.err <- nil
 		// TODO(roasbeef): get rid of the above
 		return announcements
@@ -687,6 +2233,9 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			log.Error("Ignoring ChannelAnnouncement from "+
 				"chain=%v, gossiper on chain=%v", msg.ChainHash,
 				d.cfg.ChainHash)
+			d.recordRejection(nMsg, RejectWrongChain,
+				errors.Errorf("unknown chain=%v", msg.ChainHash))
+			d.noteWrongChainAnn(nMsg)
 			return nil
 		}
 
@@ -694,31 +2243,105 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		// of the chain tip, then we'll put the announcement in limbo
 		// to be fully verified once we advance forward in the chain.
 		if isPremature(msg.ShortChannelID, 0) {
+			if rejectFarPremature(msg.ShortChannelID, 0) {
+				return nil
+			}
+
 			blockHeight := msg.ShortChannelID.BlockHeight
 			log.Infof("Announcement for chan_id=(%v), is premature: "+
 				"advertises height %v, only height %v is known",
 				msg.ShortChannelID.ToUint64(),
 				msg.ShortChannelID.BlockHeight, d.bestHeight)
 
-			d.prematureAnnouncements[blockHeight] = append(
-				d.prematureAnnouncements[blockHeight],
-				nMsg,
-			)
+			d.retainRejection(nMsg, RejectPremature, errors.Errorf(
+				"premature announcement at height %v, only "+
+					"height %v is known", blockHeight,
+				d.bestHeight))
+			d.deferPremature(blockHeight, nMsg)
+			return nil
+		}
+
+		// If we've been configured with checkpoints, then we'll ensure
+		// that the block of a remote channel is part of a chain that's
+		// consistent with them.
+		if nMsg.isRemote && len(d.cfg.Checkpoints) != 0 {
+			err := d.verifyCheckpoint(msg.ShortChannelID.BlockHeight)
+			if err != nil {
+				err := errors.Errorf("rejecting announcement for "+
+					"short_chan_id=%v: %v",
+					msg.ShortChannelID.ToUint64(), err)
+				log.Error(err)
+				d.recordRejection(nMsg, RejectWrongChain, err)
+				nMsg.err <- err
+				return nil
+			}
+		}
+
+		// A peer may gossip one of our own channels which we've since
+		// closed, which we shouldn't store once more.
+		if nMsg.isRemote && d.isOwnClosedChannel(msg) {
+			err := errors.Errorf("ignoring announcement for our "+
+				"closed channel short_chan_id=%v",
+				msg.ShortChannelID.ToUint64())
+			log.Debug(err)
+			d.recordRejection(nMsg, RejectClosedChannel, err)
+			nMsg.err <- err
 			return nil
 		}
 
+		// If this is a remote channel announcement for a channel that
+		// we already know of, but its funding keys or proof differ
+		// from the known ones, then either of them may be forged, so
+		// we'll resolve the conflict according to the configured
+		// policy.
+		if nMsg.isRemote {
+			known, _, _, err := d.cfg.Router.GetChannelByID(
+				msg.ShortChannelID,
+			)
+			if err == nil {
+				err := d.resolveKnownChanAnn(msg, known)
+				if err != nil {
+					log.Error(err)
+					d.recordRejection(nMsg, RejectConflict, err)
+					nMsg.err <- err
+					return nil
+				}
+			}
+		}
+
+		// If either node of a remote channel announcement already has
+		// the maximum number of channels, then we'll reject it.
+		if nMsg.isRemote {
+			if err := d.checkMaxChannelsPerNode(msg); err != nil {
+				err := errors.Errorf("rejecting channel "+
+					"announcement for short_chan_id=%v: %v",
+					msg.ShortChannelID.ToUint64(), err)
+				log.Warn(err)
+				d.recordRejection(nMsg, RejectOther, err)
+				nMsg.err <- err
+				return nil
+			}
+		}
+
 		// If this is a remote channel announcement, then we'll validate
 		// all the signatures within the proof as it should be well
-		// formed.
+		// formed, unless we've been configured to skip validation.
 		var proof *channeldb.ChannelAuthProof
 		if nMsg.isRemote {
-			if err := d.validateChannelAnn(msg); err != nil {
-				err := errors.Errorf("unable to validate "+
-					"announcement: %v", err)
+			if !d.cfg.SkipAnnValidation {
+				err := d.validateChannelAnn(msg)
+				if err != nil {
+					err := errors.Errorf("unable to "+
+						"validate announcement: %v",
+						err)
 
-				log.Error(err)
-				nMsg.err <- err
-				return nil
+					log.Error(err)
+					d.recordRejection(
+						nMsg, RejectInvalidSig, err,
+					)
+					nMsg.err <- err
+					return nil
+				}
 			}
 
 			// If the proof checks out, then we'll save the proof
@@ -737,6 +2360,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		var featureBuf bytes.Buffer
 		if err := msg.Features.Encode(&featureBuf); err != nil {
 			log.Errorf("unable to encode features: %v", err)
+			d.recordRejection(nMsg, RejectOther, err)
 			nMsg.err <- err
 			return nil
 		}
@@ -756,7 +2380,11 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		// present in this channel are not present in the database, a
 		// partial node will be added to represent each node while we
 		// wait for a node announcement.
-		if err := d.cfg.Router.AddEdge(edge); err != nil {
+		err := d.retryRouterOp(func() error {
+			return d.cfg.Router.AddEdge(edge)
+		})
+		d.recordChanProvenance(nMsg, msg, err)
+		if err != nil {
 			if routing.IsError(err, routing.ErrOutdated,
 				routing.ErrIgnored) {
 
@@ -765,20 +2393,81 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			} else {
 				log.Errorf("Router rejected channel edge: %v",
 					err)
+
+				d.recordEdgeFailure(nMsg)
 			}
 
+			d.recordRejection(nMsg, routerRejectionReason(err), err)
 			nMsg.err <- err
 			return nil
 		}
 
+		// With the edge added, we'll clear any consecutive failures of
+		// the peer that sent it.
+		if nMsg.isRemote && nMsg.peer != nil {
+			var peer [33]byte
+			copy(peer[:], nMsg.peer.SerializeCompressed())
+			d.edgeFailures.reset(peer)
+		}
+
+		// If we're enforcing a memory budget on the graph, then we'll
+		// account for the newly learned channel.
+		if nMsg.isRemote && d.graphMemory != nil {
+			d.graphMemory.trackChannel(edge)
+			d.enforceGraphMemoryLimit()
+		}
+
+		d.countNodeChannels(msg)
+
+		// If we haven't received a node announcement for either of
+		// the nodes of the channel yet, then we'll request it from the
+		// peer that sent us the channel announcement.
+		if nMsg.isRemote && nMsg.peer != nil {
+			d.requestUnknownNodeAnns(nMsg.peer, msg.NodeID1)
+			d.requestUnknownNodeAnns(nMsg.peer, msg.NodeID2)
+		}
+
 		// Channel announcement was successfully proceeded and know it
 		// might be broadcast to other connected nodes if it was
-		// announcement with proof (remote).
-		if proof != nil {
+		// announcement with proof (remote), and we relay the
+		// announcements of its chain.
+		if proof != nil && d.isRelayChain(msg.ChainHash) {
 			announcements = append(announcements, msg)
 		}
 
+		// If this is an announced channel of our own, then we may now
+		// have enough of them to broadcast our node announcement.
+		if proof != nil && (msg.NodeID1.IsEqual(d.selfKey) ||
+			msg.NodeID2.IsEqual(d.selfKey)) {
+
+			announcements = append(announcements,
+				d.releaseSelfNodeAnn()...)
+		}
+
+		d.reportPeerScore(nMsg, novelAnnScore,
+			"novel channel announcement")
 		nMsg.err <- nil
+
+		// Now that the channel is known, we'll process any updates for
+		// it which arrived ahead of its announcement.
+		if d.orphanUpdates != nil {
+			orphans, expired := d.orphanUpdates.take(
+				edge.ChannelID, d.now(),
+			)
+			d.rejectExpiredOrphans(expired)
+
+			if len(orphans) != 0 {
+				log.Debugf("Re-processing %v orphan updates for "+
+					"short_chan_id=%v", len(orphans),
+					edge.ChannelID)
+			}
+
+			for _, orphan := range orphans {
+				announcements = append(announcements,
+					d.processNetworkAnnouncement(orphan)...)
+			}
+		}
+
 		return announcements
 
 	// A new authenticated channel edge update has arrived. This indicates
@@ -791,6 +2480,9 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			log.Error("Ignoring ChannelUpdate from "+
 				"chain=%v, gossiper on chain=%v", msg.ChainHash,
 				d.cfg.ChainHash)
+			d.recordRejection(nMsg, RejectWrongChain,
+				errors.Errorf("unknown chain=%v", msg.ChainHash))
+			d.noteWrongChainAnn(nMsg)
 			return nil
 		}
 
@@ -801,27 +2493,51 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		// of the chain tip, then we'll put the announcement in limbo
 		// to be fully verified once we advance forward in the chain.
 		if isPremature(msg.ShortChannelID, 0) {
+			if rejectFarPremature(msg.ShortChannelID, 0) {
+				return nil
+			}
+
 			log.Infof("Update announcement for "+
 				"short_chan_id(%v), is premature: advertises "+
 				"height %v, only height %v is known",
 				shortChanID, blockHeight, d.bestHeight)
 
-			d.prematureAnnouncements[blockHeight] = append(
-				d.prematureAnnouncements[blockHeight],
-				nMsg,
-			)
+			d.retainRejection(nMsg, RejectPremature, errors.Errorf(
+				"premature update at height %v, only height "+
+					"%v is known", blockHeight, d.bestHeight))
+			d.deferPremature(blockHeight, nMsg)
 			return nil
 		}
 
 		// Get the node pub key as far as we don't have it in channel
 		// update announcement message. We'll need this to properly
 		// verify message signature.
-		chanInfo, _, _, err := d.cfg.Router.GetChannelByID(msg.ShortChannelID)
+		chanInfo, e1, e2, err := d.cfg.Router.GetChannelByID(
+			msg.ShortChannelID,
+		)
 		if err != nil {
+			// The update may have simply been delivered ahead of
+			// the announcement of its channel, so we'll buffer it
+			// for a while if we're able to.
+			if nMsg.isRemote && d.orphanUpdates != nil {
+				buffered, expired := d.orphanUpdates.add(
+					shortChanID, nMsg, d.now(),
+				)
+				d.rejectExpiredOrphans(expired)
+
+				if buffered {
+					log.Debugf("Buffering orphan update "+
+						"for unknown short_chan_id=%v",
+						shortChanID)
+					return nil
+				}
+			}
+
 			err := errors.Errorf("unable to validate "+
 				"channel update short_chan_id=%v: %v",
 				shortChanID, err)
 			log.Error(err)
+			d.recordRejection(nMsg, RejectUnknownChannel, err)
 			nMsg.err <- err
 			return nil
 		}
@@ -829,7 +2545,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		// The flag on the channel update announcement tells us "which"
 		// side of the channels directed edge is being updated.
 		var pubKey *btcec.PublicKey
-		switch msg.Flags {
+		switch msg.Flags & lnwire.ChanUpdateDirection {
 		case 0:
 			pubKey = chanInfo.NodeKey1
 		case 1:
@@ -838,17 +2554,127 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 
 		// Validate the channel announcement with the expected public
 		// key, In the case of an invalid channel , we'll return an
-		// error to the caller and exit early.
-		if err := d.validateChannelUpdateAnn(pubKey, msg); err != nil {
-			rErr := errors.Errorf("unable to validate channel "+
-				"update announcement for short_chan_id=%v: %v",
-				spew.Sdump(msg.ShortChannelID), err)
-
-			log.Error(rErr)
-			nMsg.err <- rErr
+		// error to the caller and exit early. Remote updates are only
+		// exempt if we've been configured to skip their validation.
+		if !(nMsg.isRemote && d.cfg.SkipAnnValidation) {
+			err := d.validateChannelUpdateAnn(pubKey, msg)
+			if err != nil {
+				rErr := errors.Errorf("unable to validate "+
+					"channel update announcement for "+
+					"short_chan_id=%v: %v",
+					spew.Sdump(msg.ShortChannelID), err)
+
+				log.Error(rErr)
+				d.recordRejection(nMsg, RejectInvalidSig, rErr)
+				nMsg.err <- rErr
+				return nil
+			}
+		}
+
+		// If we hold no policy for the direction of a remote update
+		// yet, but the update is older than the policy of the other
+		// direction, then it's only applied if it's within the
+		// OutdatedUpdateGrace, and never relayed.
+		relayUpdate := true
+		if nMsg.isRemote && d.cfg.OutdatedUpdateGrace != 0 {
+			age, outdated := outdatedUpdateAge(msg, e1, e2)
+			switch {
+			case !outdated:
+
+			case age <= d.cfg.OutdatedUpdateGrace:
+				log.Debugf("Accepting update for "+
+					"short_chan_id=%v (flags=%v) %v older "+
+					"than its opposite policy without "+
+					"relaying it", shortChanID, msg.Flags,
+					age)
+				relayUpdate = false
+
+			default:
+				err := errors.Errorf("ignoring update for "+
+					"short_chan_id=%v (flags=%v) %v older "+
+					"than its opposite policy", shortChanID,
+					msg.Flags, age)
+				log.Debug(err)
+				d.recordRejection(nMsg, RejectOutdated, err)
+				nMsg.err <- err
+				return nil
+			}
+		}
+
+		// If the channel has since been closed on-chain, then there's
+		// no point in applying or relaying the update.
+		if d.isChannelClosed(chanInfo) {
+			err := errors.Errorf("ignoring update for closed "+
+				"channel short_chan_id=%v", shortChanID)
+			log.Debug(err)
+			d.recordRejection(nMsg, RejectClosedChannel, err)
+			nMsg.err <- err
+
+			if d.cfg.PruneClosedChannels {
+				err := d.deleteChannel(msg.ShortChannelID)
+				if err != nil {
+					log.Errorf("unable to prune closed "+
+						"short_chan_id=%v: %v",
+						shortChanID, err)
+				}
+			}
+
+			return nil
+		}
+
+		// An HTLC minimum beyond the capacity of the channel would
+		// leave it unroutable in that direction, so we'll reject such
+		// updates. The capacity is only known once the funding output
+		// of the channel has been validated.
+		capacity := lnwire.NewMSatFromSatoshis(chanInfo.Capacity)
+		if capacity != 0 && msg.HtlcMinimumMsat > capacity {
+			err := errors.Errorf("htlc_minimum_msat=%v of update "+
+				"for short_chan_id=%v exceeds channel capacity "+
+				"of %v", msg.HtlcMinimumMsat, shortChanID,
+				capacity)
+			log.Error(err)
+			d.recordRejection(nMsg, RejectInvalidPolicy, err)
+			nMsg.err <- err
+			return nil
+		}
+
+		// Similarly, an advertised HTLC maximum below the minimum or
+		// beyond the capacity of the channel can't be valid.
+		hasMaxHTLC := msg.Flags&lnwire.ChanUpdateOptionMaxHtlc != 0
+		if hasMaxHTLC && (msg.HtlcMaximumMsat < msg.HtlcMinimumMsat ||
+			(capacity != 0 && msg.HtlcMaximumMsat > capacity)) {
+
+			err := errors.Errorf("htlc_maximum_msat=%v of update "+
+				"for short_chan_id=%v is invalid for "+
+				"htlc_minimum_msat=%v and capacity of %v",
+				msg.HtlcMaximumMsat, shortChanID,
+				msg.HtlcMinimumMsat, capacity)
+			log.Error(err)
+			d.recordRejection(nMsg, RejectInvalidPolicy, err)
+			nMsg.err <- err
 			return nil
 		}
 
+		// Any extra data of the update is stored and relayed along
+		// with it, so we'll ensure that the inbound fee it may carry
+		// can be parsed. The inbound fee isn't used for path finding
+		// yet.
+		inboundFee, err := msg.InboundFee()
+		if err != nil {
+			err := errors.Errorf("invalid inbound fee of update "+
+				"for short_chan_id=%v: %v", shortChanID, err)
+			log.Error(err)
+			d.recordRejection(nMsg, RejectInvalidPolicy, err)
+			nMsg.err <- err
+			return nil
+		}
+		if inboundFee != nil {
+			log.Tracef("Update for short_chan_id=%v carries "+
+				"inbound base_fee=%v, fee_rate=%v",
+				shortChanID, inboundFee.BaseFee,
+				inboundFee.FeeRate)
+		}
+
 		update := &channeldb.ChannelEdgePolicy{
 			Signature:                 msg.Signature,
 			ChannelID:                 shortChanID,
@@ -856,29 +2682,54 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			Flags:                     msg.Flags,
 			TimeLockDelta:             msg.TimeLockDelta,
 			MinHTLC:                   msg.HtlcMinimumMsat,
+			MaxHTLC:                   msg.HtlcMaximumMsat,
 			FeeBaseMSat:               lnwire.MilliSatoshi(msg.BaseFee),
 			FeeProportionalMillionths: lnwire.MilliSatoshi(msg.FeeRate),
+			ExtraOpaqueData:           msg.ExtraOpaqueData,
 		}
 
-		if err := d.cfg.Router.UpdateEdge(update); err != nil {
+		err = d.retryRouterOp(func() error {
+			return d.cfg.Router.UpdateEdge(update)
+		})
+		if err != nil {
 			if routing.IsError(err, routing.ErrOutdated, routing.ErrIgnored) {
 				log.Debug(err)
 			} else {
 				log.Error(err)
 			}
 
+			d.recordRejection(nMsg, routerRejectionReason(err), err)
 			nMsg.err <- err
 			return nil
 		}
 
+		// If we're enforcing a memory budget on the graph, then we'll
+		// account for the new policy, which also marks the channel as
+		// recently updated.
+		if nMsg.isRemote && d.graphMemory != nil {
+			d.graphMemory.trackPolicy(update)
+			d.enforceGraphMemoryLimit()
+		}
+
 		// Channel update announcement was successfully processed and
 		// now it can be broadcast to the rest of the network. However,
 		// we'll only broadcast the channel update announcement if it
-		// has an attached authentication proof.
-		if chanInfo.AuthProof != nil {
+		// has an attached authentication proof, and we relay the
+		// announcements of its chain.
+		if relayUpdate && chanInfo.AuthProof != nil &&
+			d.isRelayChain(msg.ChainHash) {
+
 			announcements = append(announcements, msg)
 		}
 
+		// Local channel updates are always of our own channels, so
+		// we'll remember this one to be recorded as our latest
+		// advertised update once it's broadcast.
+		if !nMsg.isRemote {
+			d.stageAdvertisedUpdate(msg)
+		}
+
+		d.reportPeerScore(nMsg, novelAnnScore, "novel channel update")
 		nMsg.err <- nil
 		return announcements
 
@@ -894,7 +2745,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			prefix = "remote"
 		}
 
-		log.Infof("Received new channel announcement: %v", spew.Sdump(msg))
+		log.Infof("Received new %v", messageSummary(nMsg))
 
 		// By the specification, channel announcement proofs should be
 		// sent after some number of confirmations after channel was
@@ -903,10 +2754,16 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		// expected announcement height.  This allows us to be tolerant
 		// to other clients if this constraint was changed.
 		if isPremature(msg.ShortChannelID, d.cfg.ProofMatureDelta) {
-			d.prematureAnnouncements[needBlockHeight] = append(
-				d.prematureAnnouncements[needBlockHeight],
-				nMsg,
-			)
+			if rejectFarPremature(msg.ShortChannelID,
+				d.cfg.ProofMatureDelta) {
+
+				return nil
+			}
+
+			d.retainRejection(nMsg, RejectPremature, errors.Errorf(
+				"premature proof, needs height %v, only height "+
+					"%v is known", needBlockHeight, d.bestHeight))
+			d.deferPremature(needBlockHeight, nMsg)
 			log.Infof("Premature proof announcement, "+
 				"current block height lower than needed: %v <"+
 				" %v, add announcement to reprocessing batch",
@@ -914,6 +2771,27 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			return nil
 		}
 
+		// If this is our own half of the proof, and we've been
+		// instructed to do so, then we'll ensure that the funding
+		// transaction is still confirmed before we hand the proof over
+		// to the remote peer. Otherwise, a re-org may have removed the
+		// funding transaction, so we'll defer the proof until the next
+		// block to give the chain a chance to settle.
+		if !nMsg.isRemote && d.cfg.VerifyFundingConfirmed != nil {
+			confirmed, err := d.cfg.VerifyFundingConfirmed(
+				msg.ShortChannelID,
+			)
+			if err != nil || !confirmed {
+				retryHeight := d.bestHeight + 1
+				d.deferPremature(retryHeight, nMsg)
+				log.Warnf("Funding for short_chan_id=%v isn't "+
+					"confirmed (err=%v), deferring local proof "+
+					"until height %v", shortChanID, err,
+					retryHeight)
+				return nil
+			}
+		}
+
 		// Ensure that we know of a channel with the target channel ID
 		// before proceeding further.
 		chanInfo, e1, e2, err := d.cfg.Router.GetChannelByID(msg.ShortChannelID)
@@ -921,11 +2799,19 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			// TODO(andrew.shvv) this is dangerous because remote
 			// node might rewrite the waiting proof.
 			proof := channeldb.NewWaitingProof(nMsg.isRemote, msg)
+			if d.isDuplicateProof(proof) {
+				log.Debugf("Ignoring duplicate orphan %v "+
+					"proof for short_chan_id=%v", prefix,
+					shortChanID)
+				nMsg.err <- nil
+				return nil
+			}
 			if err := d.waitingProofs.Add(proof); err != nil {
 				err := errors.Errorf("unable to store "+
 					"the proof for short_chan_id=%v: %v",
 					shortChanID, err)
 				log.Error(err)
+				d.recordRejection(nMsg, RejectOther, err)
 				nMsg.err <- err
 				return nil
 			}
@@ -937,6 +2823,24 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			return nil
 		}
 
+		// We'll first ensure that our policy allows the channel to be
+		// announced. Otherwise, either half of its proof is dropped,
+		// along with any half stored before the channel was known, as
+		// the proof would never be completed.
+		if d.cfg.AnnouncementPolicy != nil &&
+			!d.cfg.AnnouncementPolicy(chanInfo.ChannelPoint) {
+
+			log.Infof("Announcement policy keeps short_chan_id=%v "+
+				"private, dropping %v proof", shortChanID,
+				prefix)
+
+			proof := channeldb.NewWaitingProof(nMsg.isRemote, msg)
+			d.removeWaitingProofs(proof)
+
+			nMsg.err <- nil
+			return nil
+		}
+
 		isFirstNode := bytes.Equal(nMsg.peer.SerializeCompressed(),
 			chanInfo.NodeKey1.SerializeCompressed())
 		isSecondNode := bytes.Equal(nMsg.peer.SerializeCompressed(),
@@ -949,32 +2853,77 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 				"belongs to the peer which sent the proof, "+
 				"short_chan_id=%v", shortChanID)
 			log.Error(err)
+			d.recordRejection(nMsg, RejectOther, err)
 			nMsg.err <- err
 			return nil
 		}
 
-		// Check that we received the opposite proof. If so, then we're
-		// now able to construct the full proof, and create the channel
-		// announcement. If we didn't receive the opposite half of the
-		// proof than we should store it this one, and wait for
-		// opposite to be received.
-		proof := channeldb.NewWaitingProof(nMsg.isRemote, msg)
-		oppositeProof, err := d.waitingProofs.Get(proof.OppositeKey())
-		if err != nil && err != channeldb.ErrWaitingProofNotFound {
-			err := errors.Errorf("unable to get "+
-				"the opposite proof for short_chan_id=%v: %v",
-				shortChanID, err)
-			log.Error(err)
-			nMsg.err <- err
+		// The halves of the proof are exchanged with the other party
+		// of the channel.
+		remotePeer := chanInfo.NodeKey1
+		if isFirstNode {
+			remotePeer = chanInfo.NodeKey2
+		}
+
+		// If the channel has already been announced, then there's no
+		// need to assemble its proof once more. We'll acknowledge the
+		// proof, and clean up any of its halves left behind.
+		if chanInfo.AuthProof != nil {
+			log.Debugf("Ignoring proof for already announced "+
+				"short_chan_id=%v", shortChanID)
+
+			proof := channeldb.NewWaitingProof(nMsg.isRemote, msg)
+			d.removeWaitingProofs(proof)
+
+			// The remote peer may not have received our half of
+			// the proof though, so we'll still send it, allowing
+			// the remote peer to assemble the full proof as well.
+			if !nMsg.isRemote {
+				d.sendLocalProof(remotePeer, msg)
+			}
+
+			nMsg.err <- nil
+			return nil
+		}
+
+		// NOTE: As all announcements are processed within the
+		// networkHandler, two halves of the same proof are never
+		// matched up concurrently.
+
+		// Check that we received the opposite proof. If so, then we're
+		// now able to construct the full proof, and create the channel
+		// announcement. If we didn't receive the opposite half of the
+		// proof than we should store it this one, and wait for
+		// opposite to be received.
+		proof := channeldb.NewWaitingProof(nMsg.isRemote, msg)
+		oppositeProof, err := d.waitingProofs.Get(proof.OppositeKey())
+		if err != nil && err != channeldb.ErrWaitingProofNotFound {
+			err := errors.Errorf("unable to get "+
+				"the opposite proof for short_chan_id=%v: %v",
+				shortChanID, err)
+			log.Error(err)
+			d.recordRejection(nMsg, RejectOther, err)
+			nMsg.err <- err
 			return nil
 		}
 
 		if err == channeldb.ErrWaitingProofNotFound {
+			// If we've already stored this exact half of the
+			// proof, then it has already been handled, so there's
+			// no need to store or send it once more.
+			if d.isDuplicateProof(proof) {
+				log.Debugf("Ignoring duplicate %v proof for "+
+					"short_chan_id=%v", prefix, shortChanID)
+				nMsg.err <- nil
+				return nil
+			}
+
 			if err := d.waitingProofs.Add(proof); err != nil {
 				err := errors.Errorf("unable to store "+
 					"the proof for short_chan_id=%v: %v",
 					shortChanID, err)
 				log.Error(err)
+				d.recordRejection(nMsg, RejectOther, err)
 				nMsg.err <- err
 				return nil
 			}
@@ -984,26 +2933,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			// so they can also reconstruct the full channel
 			// announcement.
 			if !nMsg.isRemote {
-				// Check that first node of the channel info
-				// corresponds to us.
-				var remotePeer *btcec.PublicKey
-				if isFirstNode {
-					remotePeer = chanInfo.NodeKey2
-				} else {
-					remotePeer = chanInfo.NodeKey1
-				}
-
-				err := d.cfg.SendToPeer(remotePeer, msg)
-				if err != nil {
-					log.Errorf("unable to send "+
-						"announcement message to peer: %x",
-						remotePeer.SerializeCompressed())
-				}
-
-				log.Infof("Sent channel announcement proof "+
-					"for short_chan_id=%v to remote peer: "+
-					"%x", shortChanID,
-					remotePeer.SerializeCompressed())
+				d.sendLocalProof(remotePeer, msg)
 			}
 
 			log.Infof("1/2 of channel ann proof received for "+
@@ -1039,6 +2969,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 				shortChanID, err)
 
 			log.Error(err)
+			d.recordRejection(nMsg, RejectInvalidSig, err)
 			nMsg.err <- err
 			return nil
 		}
@@ -1055,6 +2986,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			err := errors.Errorf("unable add proof to the "+
 				"channel chanID=%v: %v", msg.ChannelID, err)
 			log.Error(err)
+			d.recordRejection(nMsg, RejectOther, err)
 			nMsg.err <- err
 			return nil
 		}
@@ -1063,6 +2995,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			err := errors.Errorf("unable remove opposite proof "+
 				"for the channel with chanID=%v: %v", msg.ChannelID, err)
 			log.Error(err)
+			d.recordRejection(nMsg, RejectOther, err)
 			nMsg.err <- err
 			return nil
 		}
@@ -1074,15 +3007,22 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 			shortChanID)
 
 		// Assemble the necessary announcements to add to the next
-		// broadcasting batch.
-		announcements = append(announcements, chanAnn)
-		if e1Ann != nil {
-			announcements = append(announcements, e1Ann)
-		}
-		if e2Ann != nil {
-			announcements = append(announcements, e2Ann)
+		// broadcasting batch, unless we don't relay the announcements
+		// of the channel's chain.
+		if d.isRelayChain(chanInfo.ChainHash) {
+			announcements = append(announcements, chanAnn)
+			if e1Ann != nil {
+				announcements = append(announcements, e1Ann)
+			}
+			if e2Ann != nil {
+				announcements = append(announcements, e2Ann)
+			}
 		}
 
+		// With the channel announced, we may now have enough announced
+		// channels to broadcast our node announcement.
+		announcements = append(announcements, d.releaseSelfNodeAnn()...)
+
 		// If this a local announcement, then we'll send it to the
 		// remote side so they can reconstruct the full channel
 		// announcement proof.
@@ -1094,7 +3034,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 				remotePeer = chanInfo.NodeKey1
 			}
 
-			if err = d.cfg.SendToPeer(remotePeer, msg); err != nil {
+			if err = d.sendToPeer(remotePeer, msg); err != nil {
 				log.Errorf("unable to send announcement "+
 					"message to peer: %x",
 					remotePeer.SerializeCompressed())
@@ -1105,11 +3045,314 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []l
 		return announcements
 
 	default:
-		nMsg.err <- errors.New("wrong type of the announcement")
+		err := errors.New("wrong type of the announcement")
+		d.recordRejection(nMsg, RejectOther, err)
+		nMsg.err <- err
 		return nil
 	}
 }
 
+// isRelayChain returns true if announcements for the passed chain should be
+// relayed to the rest of the network.
+func (d *AuthenticatedGossiper) isRelayChain(chainHash chainhash.Hash) bool {
+	if len(d.cfg.RelayChains) == 0 {
+		return true
+	}
+
+	for _, relayChain := range d.cfg.RelayChains {
+		if relayChain == chainHash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifyStaleChannels re-verifies the channels funded within the blocks
+// disconnected by a re-org, up to the passed height of a block which has been
+// connected to the new main chain. The remaining disconnected blocks are
+// re-verified once the new main chain reaches their height.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) verifyStaleChannels(height uint32) {
+	if d.staleFrom == 0 || height < d.staleFrom {
+		return
+	}
+
+	verifyTo := height
+	if verifyTo > d.staleTo {
+		verifyTo = d.staleTo
+	}
+
+	// Without a way to fetch the funding outpoints from the new main
+	// chain, we can't tell whether the channels are still confirmed, so
+	// we'll keep them.
+	if d.cfg.FetchChanPoint != nil {
+		err := d.pruneStaleChannels(d.staleFrom, verifyTo)
+		if err != nil {
+			log.Errorf("unable to prune channels of stale blocks "+
+				"at heights %v to %v: %v", d.staleFrom,
+				verifyTo, err)
+		}
+	}
+
+	d.staleFrom = verifyTo + 1
+	if d.staleFrom > d.staleTo {
+		d.staleFrom, d.staleTo = 0, 0
+	}
+}
+
+// pruneStaleChannels removes the channels from the graph whose short channel
+// ID places their funding transaction within the passed range of heights of
+// blocks that have been disconnected from the main chain, and whose funding
+// outpoint isn't found at the same location within the new main chain. Our
+// own channels are never pruned, and channels whose funding outpoint can't be
+// fetched are kept.
+func (d *AuthenticatedGossiper) pruneStaleChannels(fromHeight,
+	toHeight uint32) error {
+
+	var staleChans []*channeldb.ChannelEdgeInfo
+	err := d.cfg.Router.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		chanID := lnwire.NewShortChanIDFromInt(info.ChannelID)
+		if chanID.BlockHeight >= fromHeight &&
+			chanID.BlockHeight <= toHeight {
+
+			staleChans = append(staleChans, info)
+		}
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return err
+	}
+
+	for _, info := range staleChans {
+		chanID := lnwire.NewShortChanIDFromInt(info.ChannelID)
+
+		// Our own channels are tracked by the funding manager and
+		// the chain watchers, so we'll leave them be.
+		if d.isSelfChannel(info) {
+			continue
+		}
+
+		chanPoint, err := d.cfg.FetchChanPoint(chanID)
+		if err != nil {
+			log.Errorf("unable to re-verify short_chan_id=%v after "+
+				"re-org: %v", chanID.ToUint64(), err)
+			continue
+		}
+		if *chanPoint == info.ChannelPoint {
+			continue
+		}
+
+		log.Infof("Pruning short_chan_id=%v as its funding transaction "+
+			"is no longer confirmed at its location",
+			chanID.ToUint64())
+
+		if err := d.deleteChannel(chanID); err != nil {
+			log.Errorf("unable to prune short_chan_id=%v: %v",
+				chanID.ToUint64(), err)
+		}
+	}
+
+	return nil
+}
+
+// isChannelClosed returns true if the IsChannelClosed callback reports the
+// funding output of the channel as spent. Failures to determine the state of
+// the channel are logged, and the channel is assumed to be open. The result
+// is cached until the next block.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) isChannelClosed(
+	info *channeldb.ChannelEdgeInfo) bool {
+
+	if d.cfg.IsChannelClosed == nil {
+		return false
+	}
+
+	if closed, ok := d.closedChanChecks[info.ChannelID]; ok {
+		return closed
+	}
+
+	chanID := lnwire.NewShortChanIDFromInt(info.ChannelID)
+	closed, err := d.cfg.IsChannelClosed(
+		&info.ChannelPoint, chanID.BlockHeight,
+	)
+	if err != nil {
+		log.Errorf("unable to determine whether short_chan_id=%v is "+
+			"closed: %v", info.ChannelID, err)
+		return false
+	}
+	d.closedChanChecks[info.ChannelID] = closed
+
+	return closed
+}
+
+// resetClosedChanChecks drops the cached results of the IsChannelClosed
+// callback, such that the channels are checked anew.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) resetClosedChanChecks() {
+	if len(d.closedChanChecks) != 0 {
+		d.closedChanChecks = make(map[uint64]bool)
+	}
+}
+
+// deleteChannel removes the target channel from the graph, and stops
+// accounting for it within the graph memory budget.
+func (d *AuthenticatedGossiper) deleteChannel(chanID lnwire.ShortChannelID) error {
+	if err := d.cfg.Router.DeleteEdge(chanID); err != nil {
+		return err
+	}
+
+	if d.graphMemory != nil {
+		d.graphMemory.untrackChannel(chanID.ToUint64())
+	}
+	if d.provenance != nil {
+		d.provenance.removeChannel(chanID.ToUint64())
+	}
+	d.nodeChanCounts.remove(chanID.ToUint64())
+
+	return nil
+}
+
+// enforceGraphMemoryLimit evicts the least recently updated channels learned
+// through gossip from the graph until the graph is within its memory budget.
+// The nodes left without channels by the eviction are evicted as well.
+func (d *AuthenticatedGossiper) enforceGraphMemoryLimit() {
+	chanIDs, nodes := d.graphMemory.evictionCandidates()
+	for _, chanID := range chanIDs {
+		shortChanID := lnwire.NewShortChanIDFromInt(chanID)
+
+		log.Debugf("Graph memory budget of %v bytes exceeded, evicting "+
+			"short_chan_id=%v", d.cfg.MaxGraphMemory, chanID)
+
+		if err := d.cfg.Router.DeleteEdge(shortChanID); err != nil {
+			log.Errorf("unable to evict short_chan_id=%v: %v",
+				chanID, err)
+		}
+
+		d.nodeChanCounts.remove(chanID)
+	}
+
+	for _, node := range nodes {
+		pub, err := btcec.ParsePubKey(node[:], btcec.S256())
+		if err != nil {
+			log.Errorf("unable to parse node key %x: %v", node[:],
+				err)
+			continue
+		}
+		if pub.IsEqual(d.selfKey) {
+			continue
+		}
+
+		log.Debugf("Graph memory budget of %v bytes exceeded, evicting "+
+			"node=%x", d.cfg.MaxGraphMemory, node[:])
+
+		err = d.cfg.Router.DeleteNode(pub)
+		if err != nil && err != channeldb.ErrGraphNodeNotFound {
+			log.Errorf("unable to evict node=%x: %v", node[:], err)
+		}
+	}
+}
+
+// recordRejection retains the rejection of the passed network announcement
+// for introspection, writes a line detailing it to the audit log if one is
+// configured, and reports a score for the sending peer if the rejection
+// indicates misbehavior.
+func (d *AuthenticatedGossiper) recordRejection(nMsg *networkMsg,
+	reason RejectionReason, err error) {
+
+	d.retainRejection(nMsg, reason, err)
+	if nMsg.msg != nil {
+		d.metrics.recordRejected(nMsg.msg.MsgType())
+	}
+	d.auditRejection(nMsg, err)
+	d.reportRejectionScore(nMsg, reason)
+}
+
+// retainRejection adds a record of the rejection of the passed network
+// announcement to the rejection log, if one is configured.
+func (d *AuthenticatedGossiper) retainRejection(nMsg *networkMsg,
+	reason RejectionReason, err error) {
+
+	if d.rejections == nil {
+		return
+	}
+
+	record := RejectionRecord{
+		Timestamp: time.Now(),
+		Reason:    reason,
+		Err:       err,
+	}
+	if nMsg.peer != nil {
+		copy(record.Peer[:], nMsg.peer.SerializeCompressed())
+	}
+	if nMsg.msg != nil {
+		record.MsgType = nMsg.msg.MsgType()
+	}
+
+	switch msg := nMsg.msg.(type) {
+	case *lnwire.NodeAnnouncement:
+		copy(record.NodeID[:], msg.NodeID.SerializeCompressed())
+	case *lnwire.ChannelAnnouncement:
+		record.ShortChannelID = msg.ShortChannelID
+	case *lnwire.ChannelUpdate:
+		record.ShortChannelID = msg.ShortChannelID
+	case *lnwire.AnnounceSignatures:
+		record.ShortChannelID = msg.ShortChannelID
+	}
+
+	d.rejections.add(record)
+}
+
+// auditRejection writes a line detailing the rejection of the passed network
+// message to the announcement audit log, if one is configured.
+func (d *AuthenticatedGossiper) auditRejection(nMsg *networkMsg, reason error) {
+	if d.cfg.AnnAuditLog == nil {
+		return
+	}
+
+	// Only the channel related messages carry a short channel ID, so for
+	// all other messages we'll leave it blank.
+	var shortChanID string
+	switch msg := nMsg.msg.(type) {
+	case *lnwire.ChannelAnnouncement:
+		shortChanID = fmt.Sprintf("%v", msg.ShortChannelID.ToUint64())
+	case *lnwire.ChannelUpdate:
+		shortChanID = fmt.Sprintf("%v", msg.ShortChannelID.ToUint64())
+	case *lnwire.AnnounceSignatures:
+		shortChanID = fmt.Sprintf("%v", msg.ShortChannelID.ToUint64())
+	}
+
+	var peer []byte
+	if nMsg.peer != nil {
+		peer = nMsg.peer.SerializeCompressed()
+	}
+
+	var msgType lnwire.MessageType
+	if nMsg.msg != nil {
+		msgType = nMsg.msg.MsgType()
+	}
+
+	// Rejections may be recorded before messages reach the
+	// networkHandler, so we'll serialize the writes to the audit log.
+	d.auditMtx.Lock()
+	defer d.auditMtx.Unlock()
+
+	_, err := fmt.Fprintf(d.cfg.AnnAuditLog, "%v peer=%x remote=%v "+
+		"msg_type=%v short_chan_id=%v reason=%q\n",
+		time.Now().UTC().Format(time.RFC3339), peer, nMsg.isRemote,
+		msgType, shortChanID, reason.Error())
+	if err != nil {
+		log.Errorf("unable to write to announcement audit log: %v",
+			err)
+	}
+}
+
 // synchronizeWithNode attempts to synchronize the target node in the syncReq
 // to the latest channel graph state. In order to accomplish this, (currently)
 // the entire network graph is read from disk, then serialized to the format
@@ -1121,8 +3364,66 @@ func (d *AuthenticatedGossiper) synchronizeWithNode(syncReq *syncRequest) error
 	// TODO(roasbeef): need to also store sig data in db
 	//  * will be nice when we switch to pairing sigs would only need one ^_^
 
+	horizon := d.initialSyncHorizon()
+	announceMessages, numNodes, numEdges, err :=
+		d.fetchGraphAnnouncementsSince(horizon)
+	if err != nil {
+		log.Errorf("unable to sync infos with peer: %v", err)
+		return err
+	}
+
+	log.Infof("Syncing channel graph state with %x using %v strategy, "+
+		"sending %v vertexes and %v edges",
+		targetNode.SerializeCompressed(), d.cfg.InitialSyncStrategy,
+		numNodes, numEdges)
+
+	// Any single message that the peer wouldn't accept would cause it to
+	// disconnect mid-sync, so we'll leave such messages out.
+	announceMessages = d.dropOversizedMessages(
+		targetNode, announceMessages,
+	)
+
+	// If the peer is able to receive compressed batches, then we'll
+	// compress the announcements to save on bandwidth.
+	if d.cfg.SupportsCompression != nil &&
+		d.cfg.SupportsCompression(targetNode) {
+
+		announceMessages, err = compressMessages(announceMessages)
+		if err != nil {
+			log.Errorf("unable to compress graph for peer: %v", err)
+			return err
+		}
+	}
+
+	// With all the announcement messages gathered, send them all in a
+	// single batch to the target peer.
+	return d.sendToPeer(targetNode, announceMessages...)
+}
+
+// fetchGraphAnnouncements re-creates the authenticated announcements of all
+// the channels and nodes within the graph, returning them along with the
+// number of nodes and edges they describe. Channel announcements precede the
+// updates of the channel, and all channels precede the nodes.
+func (d *AuthenticatedGossiper) fetchGraphAnnouncements() ([]lnwire.Message,
+	uint32, uint32, error) {
+
+	return d.fetchGraphAnnouncementsSince(time.Time{})
+}
+
+// fetchGraphAnnouncementsSince re-creates the authenticated announcements
+// within the graph, as with fetchGraphAnnouncements, but only those of the
+// updates and nodes which were updated at or after the passed horizon. A
+// channel announcement is included if either of its updates is. A zero
+// horizon includes all announcements.
+func (d *AuthenticatedGossiper) fetchGraphAnnouncementsSince(
+	horizon time.Time) ([]lnwire.Message, uint32, uint32, error) {
+
+	isRecent := func(lastUpdate time.Time) bool {
+		return horizon.IsZero() || !lastUpdate.Before(horizon)
+	}
+
 	// We'll collate all the gathered routing messages into a single slice
-	// containing all the messages to be sent to the target peer.
+	// containing all the messages.
 	var announceMessages []lnwire.Message
 
 	// As peers are expecting channel announcements before node
@@ -1132,6 +3433,18 @@ func (d *AuthenticatedGossiper) synchronizeWithNode(syncReq *syncRequest) error
 	var numEdges uint32
 	if err := d.cfg.Router.ForEachChannel(func(chanInfo *channeldb.ChannelEdgeInfo,
 		e1, e2 *channeldb.ChannelEdgePolicy) error {
+		// Any updates that predate the horizon are left out, along
+		// with the channel itself if both of its updates are.
+		if e1 != nil && !isRecent(e1.LastUpdate) {
+			e1 = nil
+		}
+		if e2 != nil && !isRecent(e2.LastUpdate) {
+			e2 = nil
+		}
+		if !horizon.IsZero() && e1 == nil && e2 == nil {
+			return nil
+		}
+
 		// First, using the parameters of the channel, along with the
 		// channel authentication proof, we'll create re-create the
 		// original authenticated channel announcement.
@@ -1152,8 +3465,7 @@ func (d *AuthenticatedGossiper) synchronizeWithNode(syncReq *syncRequest) error
 
 		return nil
 	}); err != nil && err != channeldb.ErrGraphNoEdgesFound {
-		log.Errorf("unable to sync infos with peer: %v", err)
-		return err
+		return nil, 0, 0, err
 	}
 
 	// Run through all the vertexes in the graph, retrieving the data for
@@ -1161,8 +3473,8 @@ func (d *AuthenticatedGossiper) synchronizeWithNode(syncReq *syncRequest) error
 	var numNodes uint32
 	if err := d.cfg.Router.ForEachNode(func(node *channeldb.LightningNode) error {
 		// If this is a node we never received a node announcement for,
-		// we skip it.
-		if !node.HaveNodeAnnouncement {
+		// or one that predates the horizon, we skip it.
+		if !node.HaveNodeAnnouncement || !isRecent(node.LastUpdate) {
 			return nil
 		}
 
@@ -1184,16 +3496,128 @@ func (d *AuthenticatedGossiper) synchronizeWithNode(syncReq *syncRequest) error
 
 		return nil
 	}); err != nil {
-		return err
+		return nil, 0, 0, err
 	}
 
-	log.Infof("Syncing channel graph state with %x, sending %v "+
-		"vertexes and %v edges", targetNode.SerializeCompressed(),
-		numNodes, numEdges)
+	return announceMessages, numNodes, numEdges, nil
+}
 
-	// With all the announcement messages gathered, send them all in a
-	// single batch to the target peer.
-	return d.cfg.SendToPeer(targetNode, announceMessages...)
+// sendLocalProof sends our half of the proof of a channel to the remote party
+// of the channel, so that they can reconstruct the full channel announcement.
+func (d *AuthenticatedGossiper) sendLocalProof(remotePeer *btcec.PublicKey,
+	msg *lnwire.AnnounceSignatures) {
+
+	err := d.sendToPeer(remotePeer, msg)
+	if err != nil {
+		log.Errorf("unable to send announcement message to peer: %x",
+			remotePeer.SerializeCompressed())
+		return
+	}
+
+	log.Infof("Sent channel announcement proof for short_chan_id=%v to "+
+		"remote peer: %x", msg.ShortChannelID.ToUint64(),
+		remotePeer.SerializeCompressed())
+}
+
+// sendToPeer sends the set of messages to the target peer. If a
+// PeerSendQueueSize is configured, then the send is dispatched within a new
+// goroutine, unless the target peer already has PeerSendQueueSize sends in
+// flight, in which case the messages are dropped and ErrPeerSendQueueFull is
+// returned. Dropped messages aren't re-sent, so our own proof halves are
+// exempt from the limit, as the remote party can't otherwise complete the
+// channel announcement. As the callers run within the networkHandler and
+// can't wait on a dispatched send, its failure is logged instead.
+//
+// NOTE: Broadcasts aren't subject to the limit, as they have no single target
+// peer. They're enqueued by the server to each peer concurrently.
+func (d *AuthenticatedGossiper) sendToPeer(target *btcec.PublicKey,
+	msgs ...lnwire.Message) error {
+
+	if d.cfg.PeerSendQueueSize == 0 {
+		return d.cfg.SendToPeer(target, msgs...)
+	}
+
+	var peerKey [33]byte
+	copy(peerKey[:], target.SerializeCompressed())
+
+	d.peerSendsMtx.Lock()
+	if d.peerSends[peerKey] >= d.cfg.PeerSendQueueSize &&
+		!containsProof(msgs) {
+
+		d.peerSendsMtx.Unlock()
+
+		log.Warnf("Send queue for peer %x is full (%v in flight), "+
+			"dropping %v messages", peerKey[:],
+			d.cfg.PeerSendQueueSize, len(msgs))
+		return ErrPeerSendQueueFull
+	}
+	d.peerSends[peerKey]++
+	d.peerSendsMtx.Unlock()
+
+	// NOTE: We don't track this goroutine within the gossiper's wait
+	// group, as a stalled peer would otherwise prevent us from shutting
+	// down.
+	go func() {
+		if err := d.cfg.SendToPeer(target, msgs...); err != nil {
+			log.Errorf("unable to send %v messages to peer %x: %v",
+				len(msgs), peerKey[:], err)
+		}
+
+		d.peerSendsMtx.Lock()
+		d.peerSends[peerKey]--
+		if d.peerSends[peerKey] == 0 {
+			delete(d.peerSends, peerKey)
+		}
+		d.peerSendsMtx.Unlock()
+	}()
+
+	return nil
+}
+
+// containsProof returns true if any of the passed messages is a channel
+// announcement proof.
+func containsProof(msgs []lnwire.Message) bool {
+	for _, msg := range msgs {
+		if _, ok := msg.(*lnwire.AnnounceSignatures); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// channelUpdateData returns the serialized data to be signed of the passed
+// channel update. If a sign cache is configured, then the data is drawn from
+// the cache.
+func (d *AuthenticatedGossiper) channelUpdateData(
+	update *lnwire.ChannelUpdate) ([]byte, error) {
+
+	var (
+		data []byte
+		err  error
+	)
+	if d.signCache == nil {
+		data, err = update.DataToSign()
+	} else {
+		data, err = d.signCache.dataToSign(update)
+	}
+	if err != nil {
+		return nil, errors.Errorf("unable to get data to sign: %v", err)
+	}
+
+	return data, nil
+}
+
+// signChannelUpdate signs the passed channel update with our identity key.
+func (d *AuthenticatedGossiper) signChannelUpdate(
+	update *lnwire.ChannelUpdate) (*btcec.Signature, error) {
+
+	data, err := d.channelUpdateData(update)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.cfg.AnnSigner.SignMessage(d.selfKey, data)
 }
 
 // updateChannel creates a new fully signed update for the channel, and updates
@@ -1201,26 +3625,108 @@ func (d *AuthenticatedGossiper) synchronizeWithNode(syncReq *syncRequest) error
 func (d *AuthenticatedGossiper) updateChannel(info *channeldb.ChannelEdgeInfo,
 	edge *channeldb.ChannelEdgePolicy) (*lnwire.ChannelAnnouncement, *lnwire.ChannelUpdate, error) {
 
-	edge.LastUpdate = time.Now()
-	chanUpdate := &lnwire.ChannelUpdate{
-		Signature:       edge.Signature,
-		ChainHash:       info.ChainHash,
-		ShortChannelID:  lnwire.NewShortChanIDFromInt(edge.ChannelID),
-		Timestamp:       uint32(edge.LastUpdate.Unix()),
-		Flags:           edge.Flags,
-		TimeLockDelta:   edge.TimeLockDelta,
-		HtlcMinimumMsat: edge.MinHTLC,
-		BaseFee:         uint32(edge.FeeBaseMSat),
-		FeeRate:         uint32(edge.FeeProportionalMillionths),
+	chanUpdate, sig, err := d.newSignedChannelUpdate(info, edge)
+	if err != nil {
+		return nil, nil, err
 	}
 
+	chanAnn, err := d.applyChannelUpdate(info, edge, chanUpdate, sig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return chanAnn, chanUpdate, nil
+}
+
+// newSignedChannelUpdate bumps the timestamp of the passed edge, and returns a
+// new channel update reflecting the edge, along with our signature over it.
+// The signature isn't set within the update, nor is the edge written to the
+// graph.
+func (d *AuthenticatedGossiper) newSignedChannelUpdate(
+	info *channeldb.ChannelEdgeInfo, edge *channeldb.ChannelEdgePolicy) (
+	*lnwire.ChannelUpdate, *btcec.Signature, error) {
+
+	chanUpdate := d.newChannelUpdate(info, edge)
+
 	// With the update applied, we'll generate a new signature over a
 	// digest of the channel announcement itself.
-	sig, err := SignAnnouncement(d.cfg.AnnSigner, d.selfKey, chanUpdate)
+	sig, err := d.signChannelUpdate(chanUpdate)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// If instructed to, we'll sign the update once more to ensure that
+	// our signer produces deterministic signatures.
+	if d.cfg.VerifyDeterministicSigs {
+		sig2, err := d.signChannelUpdate(chanUpdate)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !bytes.Equal(sig.Serialize(), sig2.Serialize()) {
+			return nil, nil, fmt.Errorf("signer produced "+
+				"non-deterministic signatures for update of "+
+				"short_chan_id=%v", edge.ChannelID)
+		}
+	}
+
+	return chanUpdate, sig, nil
+}
+
+// newChannelUpdate bumps the timestamp of the passed edge, and returns a new,
+// yet unsigned, channel update reflecting the edge.
+func (d *AuthenticatedGossiper) newChannelUpdate(
+	info *channeldb.ChannelEdgeInfo,
+	edge *channeldb.ChannelEdgePolicy) *lnwire.ChannelUpdate {
+
+	// We'll ensure that the timestamp of the new update is strictly
+	// greater than that of the update we last emitted for this channel.
+	// Otherwise, if the wall clock jumped backwards, our peers would
+	// reject the new update as being outdated. As timestamps only have a
+	// resolution of a second, we'll bump the prior timestamp by a second.
+	timestamp := d.now()
+	if timestamp.Unix() <= edge.LastUpdate.Unix() {
+		log.Warnf("Clock skew detected for short_chan_id=%v: wall "+
+			"clock %v isn't after last update %v, bumping timestamp",
+			edge.ChannelID, timestamp, edge.LastUpdate)
+
+		timestamp = time.Unix(edge.LastUpdate.Unix()+1, 0)
+	}
+
+	edge.LastUpdate = timestamp
+	return ChanUpdateFromPolicy(info.ChainHash, edge)
+}
+
+// applyChannelUpdate sets the passed signature of the channel update in
+// place, and writes the updated edge to the graph. The original channel
+// announcement is returned along with it, if we have a full announcement for
+// the channel.
+func (d *AuthenticatedGossiper) applyChannelUpdate(
+	info *channeldb.ChannelEdgeInfo, edge *channeldb.ChannelEdgePolicy,
+	chanUpdate *lnwire.ChannelUpdate,
+	sig *btcec.Signature) (*lnwire.ChannelAnnouncement, error) {
+
+	err := d.finalizeChannelUpdate(edge, chanUpdate, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, we'll write the new edge policy to disk.
+	if err := d.cfg.Router.UpdateEdge(edge); err != nil {
+		return nil, err
+	}
+	d.stageAdvertisedUpdate(chanUpdate)
+
+	return newChanAnnFromInfo(info), nil
+}
+
+// finalizeChannelUpdate sets the passed signature of the channel update in
+// place, and verifies it, such that the edge is ready to be written to the
+// graph.
+func (d *AuthenticatedGossiper) finalizeChannelUpdate(
+	edge *channeldb.ChannelEdgePolicy, chanUpdate *lnwire.ChannelUpdate,
+	sig *btcec.Signature) error {
+
 	// Next, we'll set the new signature in place, and update the reference
 	// in the backing slice.
 	edge.Signature = sig
@@ -1228,21 +3734,23 @@ func (d *AuthenticatedGossiper) updateChannel(info *channeldb.ChannelEdgeInfo,
 
 	// To ensure that our signature is valid, we'll verify it ourself
 	// before committing it to the slice returned.
-	err = d.validateChannelUpdateAnn(d.selfKey, chanUpdate)
+	err := d.validateChannelUpdateAnn(d.selfKey, chanUpdate)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generated invalid channel "+
-			"update sig: %v", err)
+		return fmt.Errorf("generated invalid channel update sig: %v",
+			err)
 	}
 
-	// Finally, we'll write the new edge policy to disk.
 	edge.Node.PubKey.Curve = nil
-	if err := d.cfg.Router.UpdateEdge(edge); err != nil {
-		return nil, nil, err
-	}
+	return nil
+}
+
+// newChanAnnFromInfo creates the original channel announcement of the passed
+// channel, such that it can be broadcast along side an update of the channel
+// (if necessary). If we don't have a full channel announcement for the
+// channel, then nil is returned.
+func newChanAnnFromInfo(
+	info *channeldb.ChannelEdgeInfo) *lnwire.ChannelAnnouncement {
 
-	// We'll also create the original channel announcement so the two can
-	// be broadcast along side each other (if necessary), but only if we
-	// have a full channel announcement for this channel.
 	var chanAnn *lnwire.ChannelAnnouncement
 	if info.AuthProof != nil {
 		chanID := lnwire.NewShortChanIDFromInt(info.ChannelID)
@@ -1261,5 +3769,5 @@ func (d *AuthenticatedGossiper) updateChannel(info *channeldb.ChannelEdgeInfo,
 		}
 	}
 
-	return chanAnn, chanUpdate, err
+	return chanAnn
 }
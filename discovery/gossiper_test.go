@@ -1,10 +1,13 @@
 package discovery
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	prand "math/rand"
 
@@ -19,8 +22,10 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
 	"github.com/viacoin/lnd/chainntnfs"
 	"github.com/viacoin/lnd/channeldb"
 	"github.com/viacoin/lnd/lnwire"
@@ -110,6 +115,21 @@ type mockGraphSource struct {
 	infos      map[uint64]*channeldb.ChannelEdgeInfo
 	edges      map[uint64][]*channeldb.ChannelEdgePolicy
 	bestHeight uint32
+
+	// addNodeFailures is the number of upcoming calls to AddNode which
+	// fail before nodes are added again.
+	addNodeFailures int
+
+	// addEdgeFailures is the number of upcoming calls to AddEdge which
+	// fail before edges are added again.
+	addEdgeFailures int
+
+	// updateEdgeFailures is the number of upcoming calls to UpdateEdge
+	// which fail before policies are updated again.
+	updateEdgeFailures int
+
+	// addProofCalls is the number of calls to AddProof.
+	addProofCalls int
 }
 
 func newMockRouter(height uint32) *mockGraphSource {
@@ -123,11 +143,21 @@ func newMockRouter(height uint32) *mockGraphSource {
 var _ routing.ChannelGraphSource = (*mockGraphSource)(nil)
 
 func (r *mockGraphSource) AddNode(node *channeldb.LightningNode) error {
+	if r.addNodeFailures > 0 {
+		r.addNodeFailures--
+		return errors.New("transient failure")
+	}
+
 	r.nodes = append(r.nodes, node)
 	return nil
 }
 
 func (r *mockGraphSource) AddEdge(info *channeldb.ChannelEdgeInfo) error {
+	if r.addEdgeFailures > 0 {
+		r.addEdgeFailures--
+		return errors.New("malformed edge")
+	}
+
 	if _, ok := r.infos[info.ChannelID]; ok {
 		return errors.New("info already exist")
 	}
@@ -136,6 +166,11 @@ func (r *mockGraphSource) AddEdge(info *channeldb.ChannelEdgeInfo) error {
 }
 
 func (r *mockGraphSource) UpdateEdge(edge *channeldb.ChannelEdgePolicy) error {
+	if r.updateEdgeFailures > 0 {
+		r.updateEdgeFailures--
+		return errors.New("transient failure")
+	}
+
 	r.edges[edge.ChannelID] = append(
 		r.edges[edge.ChannelID],
 		edge,
@@ -143,6 +178,27 @@ func (r *mockGraphSource) UpdateEdge(edge *channeldb.ChannelEdgePolicy) error {
 	return nil
 }
 
+func (r *mockGraphSource) DeleteEdge(chanID lnwire.ShortChannelID) error {
+	if _, ok := r.infos[chanID.ToUint64()]; !ok {
+		return errors.New("can't find channel info")
+	}
+
+	delete(r.infos, chanID.ToUint64())
+	delete(r.edges, chanID.ToUint64())
+	return nil
+}
+
+func (r *mockGraphSource) DeleteNode(pub *btcec.PublicKey) error {
+	for i, node := range r.nodes {
+		if node.PubKey.IsEqual(pub) {
+			r.nodes = append(r.nodes[:i], r.nodes[i+1:]...)
+			return nil
+		}
+	}
+
+	return channeldb.ErrGraphNodeNotFound
+}
+
 func (r *mockGraphSource) SelfEdges() ([]*channeldb.ChannelEdgePolicy, error) {
 	return nil, nil
 }
@@ -153,20 +209,94 @@ func (r *mockGraphSource) CurrentBlockHeight() (uint32, error) {
 
 func (r *mockGraphSource) AddProof(chanID lnwire.ShortChannelID,
 	proof *channeldb.ChannelAuthProof) error {
+	r.addProofCalls++
 	return nil
 }
 
-func (r *mockGraphSource) ForEachNode(func(node *channeldb.LightningNode) error) error {
+func (r *mockGraphSource) ForEachNode(cb func(node *channeldb.LightningNode) error) error {
+	for _, node := range r.nodes {
+		if err := cb(node); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (r *mockGraphSource) FetchLightningNode(pub *btcec.PublicKey) (
+	*channeldb.LightningNode, error) {
+
+	for _, node := range r.nodes {
+		if node.PubKey.IsEqual(pub) {
+			return node, nil
+		}
+	}
+
+	return nil, channeldb.ErrGraphNodeNotFound
+}
+
 func (r *mockGraphSource) ForAllOutgoingChannels(cb func(i *channeldb.ChannelEdgeInfo,
 	c *channeldb.ChannelEdgePolicy) error) error {
+
+	for chanID, info := range r.infos {
+		edges := r.edges[chanID]
+		if len(edges) == 0 {
+			continue
+		}
+
+		if err := cb(info, edges[len(edges)-1]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (r *mockGraphSource) ForEachChannel(func(chanInfo *channeldb.ChannelEdgeInfo,
+func (r *mockGraphSource) ForEachChannel(cb func(chanInfo *channeldb.ChannelEdgeInfo,
 	e1, e2 *channeldb.ChannelEdgePolicy) error) error {
+
+	for chanID, info := range r.infos {
+		var e1, e2 *channeldb.ChannelEdgePolicy
+		edges := r.edges[chanID]
+		if len(edges) > 0 {
+			e1 = edges[0]
+		}
+		if len(edges) > 1 {
+			e2 = edges[1]
+		}
+
+		if err := cb(info, e1, e2); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *mockGraphSource) ForEachNodeChannel(node *btcec.PublicKey,
+	cb func(chanInfo *channeldb.ChannelEdgeInfo,
+		outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error) error {
+
+	for chanID, info := range r.infos {
+		if !info.NodeKey1.IsEqual(node) && !info.NodeKey2.IsEqual(node) {
+			continue
+		}
+
+		var e1, e2 *channeldb.ChannelEdgePolicy
+		edges := r.edges[chanID]
+		if len(edges) > 0 {
+			e1 = edges[0]
+		}
+		if len(edges) > 1 {
+			e2 = edges[1]
+		}
+		if info.NodeKey2.IsEqual(node) {
+			e1, e2 = e2, e1
+		}
+
+		if err := cb(info, e1, e2); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -195,6 +325,15 @@ func (r *mockGraphSource) GetChannelByID(chanID lnwire.ShortChannelID) (
 type mockNotifier struct {
 	clientCounter uint32
 	epochClients  map[uint32]chan *chainntnfs.BlockEpoch
+	staleClients  map[uint32]chan *chainntnfs.BlockEpoch
+
+	// registerFailures is the number of upcoming block epoch
+	// registrations which fail before they succeed again.
+	registerFailures int
+
+	// staleRegisterFailures is the number of upcoming stale block
+	// registrations which fail before they succeed again.
+	staleRegisterFailures int
 
 	sync.RWMutex
 }
@@ -202,9 +341,12 @@ type mockNotifier struct {
 func newMockNotifier() *mockNotifier {
 	return &mockNotifier{
 		epochClients: make(map[uint32]chan *chainntnfs.BlockEpoch),
+		staleClients: make(map[uint32]chan *chainntnfs.BlockEpoch),
 	}
 }
 
+var _ chainntnfs.StaleBlockNotifier = (*mockNotifier)(nil)
+
 func (m *mockNotifier) RegisterConfirmationsNtfn(txid *chainhash.Hash,
 	numConfs, _ uint32) (*chainntnfs.ConfirmationEvent, error) {
 
@@ -228,8 +370,13 @@ func (m *mockNotifier) notifyBlock(hash chainhash.Hash, height uint32) {
 }
 
 func (m *mockNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent, error) {
-	m.RLock()
-	defer m.RUnlock()
+	m.Lock()
+	defer m.Unlock()
+
+	if m.registerFailures > 0 {
+		m.registerFailures--
+		return nil, errors.New("backend unavailable")
+	}
 
 	epochChan := make(chan *chainntnfs.BlockEpoch)
 	clientID := m.clientCounter
@@ -242,6 +389,38 @@ func (m *mockNotifier) RegisterBlockEpochNtfn() (*chainntnfs.BlockEpochEvent, er
 	}, nil
 }
 
+func (m *mockNotifier) notifyStaleBlock(hash chainhash.Hash, height uint32) {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, client := range m.staleClients {
+		client <- &chainntnfs.BlockEpoch{
+			Height: int32(height),
+			Hash:   &hash,
+		}
+	}
+}
+
+func (m *mockNotifier) RegisterStaleBlockNtfn() (*chainntnfs.BlockEpochEvent, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.staleRegisterFailures > 0 {
+		m.staleRegisterFailures--
+		return nil, errors.New("backend unavailable")
+	}
+
+	staleChan := make(chan *chainntnfs.BlockEpoch)
+	clientID := m.clientCounter
+	m.clientCounter++
+	m.staleClients[clientID] = staleChan
+
+	return &chainntnfs.BlockEpochEvent{
+		Epochs: staleChan,
+		Cancel: func() {},
+	}, nil
+}
+
 func (m *mockNotifier) Start() error {
 	return nil
 }
@@ -404,6 +583,43 @@ func createRemoteChannelAnnouncement(blockHeight uint32) (*lnwire.ChannelAnnounc
 	return a, nil
 }
 
+// createChannelAnnouncementBetween creates a channel announcement at the
+// passed height for a channel between the two passed nodes.
+func createChannelAnnouncementBetween(blockHeight uint32, node1,
+	node2 *btcec.PrivateKey) (*lnwire.ChannelAnnouncement, error) {
+
+	a := &lnwire.ChannelAnnouncement{
+		ShortChannelID: lnwire.ShortChannelID{
+			BlockHeight: blockHeight,
+		},
+		NodeID1:     node1.PubKey(),
+		NodeID2:     node2.PubKey(),
+		BitcoinKey1: bitcoinKeyPub1,
+		BitcoinKey2: bitcoinKeyPub2,
+		Features:    testFeatures,
+	}
+
+	sigs := []struct {
+		sig  **btcec.Signature
+		priv *btcec.PrivateKey
+	}{
+		{&a.NodeSig1, node1},
+		{&a.NodeSig2, node2},
+		{&a.BitcoinSig1, bitcoinKeyPriv1},
+		{&a.BitcoinSig2, bitcoinKeyPriv2},
+	}
+	for _, s := range sigs {
+		signer := mockSigner{s.priv}
+		sig, err := SignAnnouncement(&signer, s.priv.PubKey(), a)
+		if err != nil {
+			return nil, err
+		}
+		*s.sig = sig
+	}
+
+	return a, nil
+}
+
 type testCtx struct {
 	gossiper           *AuthenticatedGossiper
 	router             *mockGraphSource
@@ -411,7 +627,13 @@ type testCtx struct {
 	broadcastedMessage chan lnwire.Message
 }
 
-func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
+// testCtxOption modifies the config of the gossiper created by createTestCtx
+// before it's started.
+type testCtxOption func(*Config)
+
+func createTestCtx(startHeight uint32,
+	opts ...testCtxOption) (*testCtx, func(), error) {
+
 	// Next we'll initialize an instance of the channel router with mock
 	// versions of the chain and channel notifier. As we don't need to test
 	// any p2p functionality, the peer send and switch send,
@@ -425,7 +647,7 @@ func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
 	}
 
 	broadcastedMessage := make(chan lnwire.Message, 10)
-	gossiper, err := New(Config{
+	cfg := Config{
 		Notifier: notifier,
 		Broadcast: func(_ *btcec.PublicKey, msgs ...lnwire.Message) error {
 			for _, msg := range msgs {
@@ -441,7 +663,13 @@ func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
 		RetransmitDelay:  retransmitDelay,
 		ProofMatureDelta: proofMatureDelta,
 		DB:               db,
-	}, nodeKeyPub1)
+		AnnSigner:        &mockSigner{nodeKeyPriv1},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	gossiper, err := New(cfg, nodeKeyPub1)
 	if err != nil {
 		cleanUpDb()
 		return nil, nil, fmt.Errorf("unable to create router %v", err)
@@ -464,6 +692,19 @@ func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
 	}, cleanUp, nil
 }
 
+// newTestCtx creates a new test context through createTestCtx, failing the
+// test if it can't be created.
+func newTestCtx(t *testing.T, startHeight uint32,
+	opts ...testCtxOption) (*testCtx, func()) {
+
+	ctx, cleanup, err := createTestCtx(startHeight, opts...)
+	if err != nil {
+		t.Fatalf("can't create context: %v", err)
+	}
+
+	return ctx, cleanup
+}
+
 // TestProcessAnnouncement checks that mature announcements are propagated to
 // the router subsystem.
 func TestProcessAnnouncement(t *testing.T) {
@@ -837,3 +1078,1503 @@ func TestOrphanSignatureAnnouncement(t *testing.T) {
 		t.Fatal("wrong number of objects in storage")
 	}
 }
+
+// TestLocalProofDeferredUntilFundingConfirmed ensures that if the funding
+// transaction of a channel can't be verified as confirmed, then our half of
+// the channel proof isn't sent to the remote peer until it can be.
+func TestLocalProofDeferredUntilFundingConfirmed(t *testing.T) {
+	t.Parallel()
+
+	// We'll start out with the funding transaction reported as
+	// unconfirmed, and also intercept all messages sent directly to the
+	// remote peer.
+	var fundingConfirmed uint32
+	sentToPeer := make(chan lnwire.Message, 10)
+	ctx, cleanup := newTestCtx(t, proofMatureDelta, func(cfg *Config) {
+		cfg.VerifyFundingConfirmed = func(
+			lnwire.ShortChannelID) (bool, error) {
+
+			return atomic.LoadUint32(&fundingConfirmed) == 1, nil
+		}
+		cfg.SendToPeer = func(_ *btcec.PublicKey,
+			msgs ...lnwire.Message) error {
+
+			for _, msg := range msgs {
+				sentToPeer <- msg
+			}
+			return nil
+		}
+	})
+	defer cleanup()
+
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+
+	localKey := batch.nodeAnn1.NodeID
+
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(batch.localChanAnn, localKey)
+	if err != nil {
+		t.Fatalf("unable to process :%v", err)
+	}
+
+	// As the funding isn't confirmed, the local proof should neither be
+	// processed nor sent to the remote peer.
+	errChan := ctx.gossiper.ProcessLocalAnnouncement(
+		batch.localProofAnn, localKey,
+	)
+	select {
+	case <-errChan:
+		t.Fatal("local proof was processed")
+	case <-time.After(2 * trickleDelay):
+	}
+
+	select {
+	case <-sentToPeer:
+		t.Fatal("local proof was sent to the remote peer")
+	default:
+	}
+
+	// Once the funding is confirmed and a new block arrives, the proof
+	// should be re-processed and sent over to the remote peer.
+	atomic.StoreUint32(&fundingConfirmed, 1)
+
+	newBlock := &wire.MsgBlock{}
+	ctx.notifier.notifyBlock(newBlock.Header.BlockHash(), 1)
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("unable to process local proof: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("local proof wasn't re-processed")
+	}
+
+	select {
+	case msg := <-sentToPeer:
+		if _, ok := msg.(*lnwire.AnnounceSignatures); !ok {
+			t.Fatalf("expected AnnounceSignatures, got %T", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("local proof wasn't sent to the remote peer")
+	}
+}
+
+// TestPeerSendQueueSize ensures that once a stalled peer has reached its
+// limit of in flight sends, any further gossip to it is dropped, while other
+// peers continue to receive gossip as normal.
+func TestPeerSendQueueSize(t *testing.T) {
+	t.Parallel()
+
+	const queueSize = 2
+
+	// We'll stall all sends to the first peer, while sends to the second
+	// peer complete immediately.
+	stalledPeer := nodeKeyPub1
+	healthyPeer := nodeKeyPub2
+
+	stall := make(chan struct{})
+	defer close(stall)
+
+	stalledSends := make(chan struct{}, 10)
+	healthySends := make(chan struct{}, 10)
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.PeerSendQueueSize = queueSize
+		cfg.SendToPeer = func(target *btcec.PublicKey,
+			_ ...lnwire.Message) error {
+
+			if target.IsEqual(stalledPeer) {
+				stalledSends <- struct{}{}
+				<-stall
+				return nil
+			}
+
+			healthySends <- struct{}{}
+			return nil
+		}
+	})
+	defer cleanup()
+
+	// Request more syncs with the stalled peer than its queue permits.
+	for i := 0; i < queueSize+2; i++ {
+		ctx.gossiper.SynchronizeNode(stalledPeer)
+	}
+
+	for i := 0; i < queueSize; i++ {
+		select {
+		case <-stalledSends:
+		case <-time.After(time.Second):
+			t.Fatal("send to stalled peer wasn't attempted")
+		}
+	}
+
+	// As the queue of the stalled peer is full, the remaining syncs
+	// should have been dropped.
+	select {
+	case <-stalledSends:
+		t.Fatal("send to stalled peer exceeded queue size")
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// The healthy peer should be unaffected by the stalled one.
+	ctx.gossiper.SynchronizeNode(healthyPeer)
+	select {
+	case <-healthySends:
+	case <-time.After(time.Second):
+		t.Fatal("send to healthy peer wasn't attempted")
+	}
+}
+
+// TestPeerSendQueueProof ensures that once a stalled peer has reached its
+// limit of in flight sends, further gossip to it is rejected with
+// ErrPeerSendQueueFull, while our half of a channel announcement proof is
+// still sent.
+func TestPeerSendQueueProof(t *testing.T) {
+	t.Parallel()
+
+	const queueSize = 2
+
+	stall := make(chan struct{})
+	defer close(stall)
+
+	sends := make(chan lnwire.Message, 10)
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.PeerSendQueueSize = queueSize
+		cfg.SendToPeer = func(_ *btcec.PublicKey,
+			msgs ...lnwire.Message) error {
+
+			sends <- msgs[0]
+			<-stall
+			return nil
+		}
+	})
+	defer cleanup()
+
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+
+	for i := 0; i < queueSize; i++ {
+		err := ctx.gossiper.sendToPeer(nodeKeyPub1, batch.nodeAnn1)
+		if err != nil {
+			t.Fatalf("unable to send to peer: %v", err)
+		}
+	}
+
+	err = ctx.gossiper.sendToPeer(nodeKeyPub1, batch.nodeAnn1)
+	if err != ErrPeerSendQueueFull {
+		t.Fatalf("expected ErrPeerSendQueueFull, got %v", err)
+	}
+
+	err = ctx.gossiper.sendToPeer(nodeKeyPub1, batch.localProofAnn)
+	if err != nil {
+		t.Fatalf("unable to send proof to peer: %v", err)
+	}
+
+	// Both sends within the queue as well as the proof should have been
+	// attempted.
+	var proofSent bool
+	for i := 0; i < queueSize+1; i++ {
+		select {
+		case msg := <-sends:
+			if msg == batch.localProofAnn {
+				proofSent = true
+			}
+		case <-time.After(time.Second):
+			t.Fatal("send to stalled peer wasn't attempted")
+		}
+	}
+	if !proofSent {
+		t.Fatal("proof wasn't sent to the stalled peer")
+	}
+}
+
+// TestChannelUpdateMonotonicTimestamp ensures that the timestamps of our own
+// channel updates always increase, even if the wall clock jumps backwards.
+func TestChannelUpdateMonotonicTimestamp(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	selfPub, err := btcec.ParsePubKey(
+		nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	info := &channeldb.ChannelEdgeInfo{
+		ChannelID: 1,
+		NodeKey1:  nodeKeyPub1,
+		NodeKey2:  nodeKeyPub2,
+	}
+	edge := &channeldb.ChannelEdgePolicy{
+		ChannelID: 1,
+		Node: &channeldb.LightningNode{
+			PubKey: selfPub,
+		},
+	}
+
+	// First, we'll sign an update with the clock set to some point in
+	// time, which should carry that exact timestamp.
+	startTime := time.Unix(1500000000, 0)
+	ctx.gossiper.now = func() time.Time {
+		return startTime
+	}
+
+	_, firstUpdate, err := ctx.gossiper.updateChannel(info, edge)
+	if err != nil {
+		t.Fatalf("unable to update channel: %v", err)
+	}
+	if firstUpdate.Timestamp != uint32(startTime.Unix()) {
+		t.Fatalf("expected timestamp %v, got %v", startTime.Unix(),
+			firstUpdate.Timestamp)
+	}
+
+	// Next, we'll simulate the clock jumping backwards by an hour. The
+	// following update should still carry a greater timestamp than the
+	// first.
+	ctx.gossiper.now = func() time.Time {
+		return startTime.Add(-time.Hour)
+	}
+
+	_, secondUpdate, err := ctx.gossiper.updateChannel(info, edge)
+	if err != nil {
+		t.Fatalf("unable to update channel: %v", err)
+	}
+	if secondUpdate.Timestamp <= firstUpdate.Timestamp {
+		t.Fatalf("timestamp didn't increase: %v <= %v",
+			secondUpdate.Timestamp, firstUpdate.Timestamp)
+	}
+
+	// Signing yet another update within the same second should also
+	// result in an increased timestamp.
+	_, thirdUpdate, err := ctx.gossiper.updateChannel(info, edge)
+	if err != nil {
+		t.Fatalf("unable to update channel: %v", err)
+	}
+	if thirdUpdate.Timestamp <= secondUpdate.Timestamp {
+		t.Fatalf("timestamp didn't increase: %v <= %v",
+			thirdUpdate.Timestamp, secondUpdate.Timestamp)
+	}
+}
+
+// TestNewMissingConfig ensures that the gossiper can't be created if any of
+// the required elements of its config are missing.
+func TestNewMissingConfig(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUpDb, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+	defer cleanUpDb()
+
+	validConfig := func() Config {
+		return Config{
+			Router:   newMockRouter(0),
+			Notifier: newMockNotifier(),
+			Broadcast: func(_ *btcec.PublicKey,
+				_ ...lnwire.Message) error {
+
+				return nil
+			},
+			SendToPeer: func(_ *btcec.PublicKey,
+				_ ...lnwire.Message) error {
+
+				return nil
+			},
+			DB:        db,
+			AnnSigner: &mockSigner{nodeKeyPriv1},
+		}
+	}
+
+	tests := []struct {
+		field  string
+		modify func(*Config)
+	}{
+		{"Router", func(c *Config) { c.Router = nil }},
+		{"Notifier", func(c *Config) { c.Notifier = nil }},
+		{"Broadcast", func(c *Config) { c.Broadcast = nil }},
+		{"SendToPeer", func(c *Config) { c.SendToPeer = nil }},
+		{"DB", func(c *Config) { c.DB = nil }},
+		{"AnnSigner", func(c *Config) { c.AnnSigner = nil }},
+		{"SelfBroadcastInterval", func(c *Config) { c.SelfBroadcastRate = 1 }},
+		{"SelfTestSampleSize", func(c *Config) {
+			c.SelfTestInterval = time.Minute
+		}},
+		{"NodeAnnRateInterval", func(c *Config) {
+			c.NodeAnnRateLimit = 1
+		}},
+		{"NodeAnnRequestInterval", func(c *Config) {
+			c.RequestNodeAnn = func(_, _ *btcec.PublicKey) error {
+				return nil
+			}
+		}},
+		{"MaxEdgeFailureBackoff", func(c *Config) {
+			c.EdgeFailureBackoff = time.Minute
+		}},
+		{"OrphanUpdateTTL", func(c *Config) {
+			c.OrphanUpdateLimit = 1
+		}},
+		{"FetchBlockHash", func(c *Config) {
+			c.Checkpoints = []chaincfg.Checkpoint{{Height: 1}}
+		}},
+		{"ConnectedPeers", func(c *Config) {
+			c.PeerDistance = func(_, _ *btcec.PublicKey) (uint32, bool) {
+				return 0, false
+			}
+		}},
+		{"NumConnectedPeers", func(c *Config) {
+			c.MinPeersForBroadcast = 2
+		}},
+	}
+
+	for _, test := range tests {
+		cfg := validConfig()
+		test.modify(&cfg)
+
+		_, err := New(cfg, nodeKeyPub1)
+		if err == nil {
+			t.Fatalf("gossiper created without %v", test.field)
+		}
+		if !strings.Contains(err.Error(), test.field) {
+			t.Fatalf("expected error for missing %v, got: %v",
+				test.field, err)
+		}
+	}
+
+	if _, err := New(validConfig(), nodeKeyPub1); err != nil {
+		t.Fatalf("unable to create gossiper with valid config: %v", err)
+	}
+}
+
+// TestChannelsForNode ensures that the gossiper returns exactly the set of
+// channels which the target node is an endpoint of.
+func TestChannelsForNode(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	nodeKeyPriv3, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	nodeKeyPub3 := nodeKeyPriv3.PubKey()
+
+	// We'll create a graph in which the first node has a channel with
+	// each of the other two nodes, while the second and third nodes also
+	// share a channel.
+	addChannel := func(chanID uint64, node1, node2 *btcec.PublicKey) {
+		ctx.router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+			ChannelID: chanID,
+			NodeKey1:  node1,
+			NodeKey2:  node2,
+		}
+	}
+	addChannel(1, nodeKeyPub1, nodeKeyPub2)
+	addChannel(2, nodeKeyPub3, nodeKeyPub1)
+	addChannel(3, nodeKeyPub2, nodeKeyPub3)
+
+	chanIDs, err := ctx.gossiper.ChannelsForNode(nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("unable to query channels for node: %v", err)
+	}
+
+	if len(chanIDs) != 2 {
+		t.Fatalf("expected 2 channels, got %v", len(chanIDs))
+	}
+	found := make(map[uint64]bool)
+	for _, chanID := range chanIDs {
+		found[chanID.ToUint64()] = true
+	}
+	if !found[1] || !found[2] {
+		t.Fatalf("expected channels 1 and 2, got %v", chanIDs)
+	}
+}
+
+// TestSelfBroadcastRateLimit ensures that a burst of our own fee updates is
+// released to the network at the configured rate.
+func TestSelfBroadcastRateLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	// We'll replace the gossiper of the context with one which releases
+	// at most two of our own announcements per interval.
+	const (
+		selfBroadcastRate     = 2
+		selfBroadcastInterval = time.Millisecond * 500
+	)
+	ctx.gossiper.Stop()
+
+	cfg := *ctx.gossiper.cfg
+	cfg.SelfBroadcastRate = selfBroadcastRate
+	cfg.SelfBroadcastInterval = selfBroadcastInterval
+
+	gossiper, err := New(cfg, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("unable to create gossiper: %v", err)
+	}
+
+	// Before starting the gossiper, we'll populate the graph with a set
+	// of our own channels.
+	const numChans = 5
+	var chanPoints []wire.OutPoint
+	for i := uint64(1); i <= numChans; i++ {
+		selfPub, err := btcec.ParsePubKey(
+			nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+		)
+		if err != nil {
+			t.Fatalf("unable to parse pubkey: %v", err)
+		}
+
+		chanPoint := wire.OutPoint{Index: uint32(i)}
+		chanPoints = append(chanPoints, chanPoint)
+
+		ctx.router.infos[i] = &channeldb.ChannelEdgeInfo{
+			ChannelID:    i,
+			ChannelPoint: chanPoint,
+			NodeKey1:     nodeKeyPub1,
+			NodeKey2:     nodeKeyPub2,
+		}
+		ctx.router.edges[i] = []*channeldb.ChannelEdgePolicy{{
+			ChannelID:  i,
+			LastUpdate: time.Now(),
+			Node: &channeldb.LightningNode{
+				PubKey: selfPub,
+			},
+		}}
+	}
+
+	if err := gossiper.Start(); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+	defer gossiper.Stop()
+
+	// We'll now issue a burst of fee updates, one for each channel.
+	for i, chanPoint := range chanPoints {
+		feeSchema := routing.FeeSchema{
+			BaseFee: lnwire.MilliSatoshi(1000 + i),
+			FeeRate: 1,
+		}
+		err := gossiper.PropagateFeeUpdate(feeSchema, chanPoint)
+		if err != nil {
+			t.Fatalf("unable to propagate fee update: %v", err)
+		}
+	}
+
+	// The resulting channel updates should be released in groups of at
+	// most selfBroadcastRate, with each group being an interval apart.
+	var arrivals []time.Time
+	for i := 0; i < numChans; i++ {
+		select {
+		case msg := <-ctx.broadcastedMessage:
+			if _, ok := msg.(*lnwire.ChannelUpdate); !ok {
+				t.Fatalf("expected channel update, got %T", msg)
+			}
+			arrivals = append(arrivals, time.Now())
+
+		case <-time.After(selfBroadcastInterval * numChans):
+			t.Fatalf("only %v of %v updates were broadcast", i,
+				numChans)
+		}
+	}
+
+	for i := selfBroadcastRate; i < numChans; i++ {
+		elapsed := arrivals[i].Sub(arrivals[i-selfBroadcastRate])
+		if elapsed < selfBroadcastInterval/2 {
+			t.Fatalf("update %v was broadcast %v after update %v, "+
+				"exceeding the rate limit", i, elapsed,
+				i-selfBroadcastRate)
+		}
+	}
+
+	// No further updates should have been broadcast.
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("unexpected broadcast of %T", msg)
+	case <-time.After(selfBroadcastInterval * 2):
+	}
+}
+
+// TestStaleBlockPrunesChannels ensures that once a block disconnected from the
+// main chain has been replaced, the channels funded within it whose funding
+// outpoint is no longer found within the new main chain are pruned from the
+// graph, while our own channels, and those which can't be re-verified, are
+// kept.
+func TestStaleBlockPrunesChannels(t *testing.T) {
+	t.Parallel()
+
+	const startHeight = 100
+
+	// We'll have one channel funded well before the tip, and several
+	// funded within the block at the tip, one of which is our own.
+	oldChanID := lnwire.ShortChannelID{BlockHeight: startHeight - 10}
+	staleChanID := lnwire.ShortChannelID{BlockHeight: startHeight}
+	reconfChanID := lnwire.ShortChannelID{
+		BlockHeight: startHeight,
+		TxIndex:     1,
+	}
+	unknownChanID := lnwire.ShortChannelID{
+		BlockHeight: startHeight,
+		TxIndex:     2,
+	}
+	ownChanID := lnwire.ShortChannelID{
+		BlockHeight: startHeight,
+		TxIndex:     3,
+	}
+
+	// Within the new main chain, only one of the channels funded within
+	// the block at the tip is still found at the same location.
+	ctx, cleanup := newTestCtx(t, startHeight, func(cfg *Config) {
+		cfg.FetchChanPoint = func(
+			chanID lnwire.ShortChannelID) (*wire.OutPoint, error) {
+
+			switch chanID {
+			case reconfChanID:
+				return &wire.OutPoint{Index: 1}, nil
+			case unknownChanID:
+				return nil, errors.New("block unavailable")
+			default:
+				return &wire.OutPoint{Index: 100}, nil
+			}
+		}
+	})
+	defer cleanup()
+
+	remotePriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("can't create private key: %v", err)
+	}
+
+	addChannel := func(chanID lnwire.ShortChannelID,
+		node1 *btcec.PublicKey) {
+
+		ctx.router.infos[chanID.ToUint64()] = &channeldb.ChannelEdgeInfo{
+			ChannelID: chanID.ToUint64(),
+			NodeKey1:  node1,
+			NodeKey2:  remotePriv.PubKey(),
+			ChannelPoint: wire.OutPoint{
+				Index: uint32(chanID.TxIndex),
+			},
+		}
+	}
+	for _, chanID := range []lnwire.ShortChannelID{
+		oldChanID, staleChanID, reconfChanID, unknownChanID,
+	} {
+		addChannel(chanID, nodeKeyPub2)
+	}
+	addChannel(ownChanID, nodeKeyPub1)
+
+	// As messages are processed in order, once a subsequent message has
+	// been processed, any prior notification will have been handled.
+	waitProcessed := func() {
+		select {
+		case <-ctx.gossiper.ProcessRemoteAnnouncement(
+			lnwire.NewPing(0), nodeKeyPub2):
+		case <-time.After(time.Second):
+			t.Fatal("message wasn't processed")
+		}
+	}
+
+	// Next, we'll re-org out the block at the tip. No channels should be
+	// pruned until the new main chain reaches the same height.
+	ctx.notifier.notifyStaleBlock(chainhash.Hash{}, startHeight)
+	waitProcessed()
+
+	if ctx.gossiper.BestHeight() != startHeight-1 {
+		t.Fatalf("expected best height %v, got %v", startHeight-1,
+			ctx.gossiper.BestHeight())
+	}
+	if _, ok := ctx.router.infos[staleChanID.ToUint64()]; !ok {
+		t.Fatal("channel pruned before the stale block was replaced")
+	}
+
+	ctx.notifier.notifyBlock(chainhash.Hash{1}, startHeight)
+	waitProcessed()
+
+	if _, ok := ctx.router.infos[staleChanID.ToUint64()]; ok {
+		t.Fatal("channel no longer confirmed wasn't pruned")
+	}
+	for _, chanID := range []lnwire.ShortChannelID{
+		oldChanID, reconfChanID, unknownChanID, ownChanID,
+	} {
+		if _, ok := ctx.router.infos[chanID.ToUint64()]; !ok {
+			t.Fatalf("short_chan_id=%v was pruned",
+				chanID.ToUint64())
+		}
+	}
+}
+
+// TestRelayChains ensures that announcements for a chain which isn't within
+// the set of relayed chains are applied to the local graph, but aren't
+// broadcast.
+func TestRelayChains(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		// We'll only relay the announcements of some other chain.
+		cfg.RelayChains = []chainhash.Hash{{0x01}}
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	// Both announcements should've been applied to the local graph.
+	if len(ctx.router.infos) != 1 {
+		t.Fatalf("edge wasn't added to router")
+	}
+	if len(ctx.router.edges) != 1 {
+		t.Fatalf("edge update wasn't added to router")
+	}
+
+	// However, neither of them should be broadcast.
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("announcement %T of non-relayed chain was broadcast",
+			msg)
+	case <-time.After(2 * trickleDelay):
+	}
+}
+
+// TestMaxPrematureHeightDelta tests that remote announcements claiming a
+// height too far beyond our chain tip are rejected immediately, rather than
+// being buffered like near-future announcements.
+func TestMaxPrematureHeightDelta(t *testing.T) {
+	t.Parallel()
+
+	const maxDelta = 10
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.MaxPrematureHeightDelta = maxDelta
+	})
+	defer cleanup()
+
+	// A channel announcement within the max delta should be buffered
+	// until we've caught up to its height, so no response is sent.
+	nearCa, err := createRemoteChannelAnnouncement(maxDelta)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case <-ctx.gossiper.ProcessRemoteAnnouncement(nearCa, nodeKeyPub2):
+		t.Fatal("near-future announcement wasn't buffered")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A channel announcement beyond the max delta should be rejected
+	// right away.
+	farCa, err := createRemoteChannelAnnouncement(maxDelta + 1)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(farCa, nodeKeyPub2):
+		if err == nil {
+			t.Fatal("far-future announcement was accepted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("far-future announcement wasn't rejected")
+	}
+
+	if len(ctx.gossiper.prematureAnnouncements[maxDelta]) != 1 {
+		t.Fatal("near-future announcement wasn't buffered")
+	}
+	if len(ctx.gossiper.prematureAnnouncements[maxDelta+1]) != 0 {
+		t.Fatal("far-future announcement was buffered")
+	}
+	if len(ctx.router.infos) != 0 {
+		t.Fatal("edge was added to router")
+	}
+}
+
+// TestRetransmitDeferredWhenBusy ensures that the retransmission of our stale
+// channels is deferred while the system reports to be busy, and caught up on
+// once it's idle again.
+func TestRetransmitDeferredWhenBusy(t *testing.T) {
+	t.Parallel()
+
+	var busy uint32
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.SystemBusy = func() bool {
+			return atomic.LoadUint32(&busy) == 1
+		}
+	})
+	defer cleanup()
+
+	// We'll add a single channel of our own whose last update is well
+	// beyond the re-broadcast interval, making it stale.
+	nodePub := *nodeKeyPub1
+	ctx.router.infos[1] = &channeldb.ChannelEdgeInfo{
+		ChannelID: 1,
+		NodeKey1:  nodeKeyPub1,
+		NodeKey2:  nodeKeyPub2,
+	}
+	ctx.router.edges[1] = []*channeldb.ChannelEdgePolicy{{
+		ChannelID:  1,
+		LastUpdate: time.Now().Add(-48 * time.Hour),
+		Node: &channeldb.LightningNode{
+			PubKey: &nodePub,
+		},
+	}}
+
+	// While the system is busy, the retransmission should be deferred.
+	atomic.StoreUint32(&busy, 1)
+	if err := ctx.gossiper.retransmitWhenIdle(); err != nil {
+		t.Fatalf("unable to retransmit stale channels: %v", err)
+	}
+	if !ctx.gossiper.retransmitDeferred {
+		t.Fatal("retransmission wasn't deferred")
+	}
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("channel retransmitted while busy: %T", msg)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Once the system is idle again, the deferred retransmission should
+	// take place.
+	atomic.StoreUint32(&busy, 0)
+	if err := ctx.gossiper.retransmitWhenIdle(); err != nil {
+		t.Fatalf("unable to retransmit stale channels: %v", err)
+	}
+	if ctx.gossiper.retransmitDeferred {
+		t.Fatal("retransmission still deferred")
+	}
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		update, ok := msg.(*lnwire.ChannelUpdate)
+		if !ok {
+			t.Fatalf("expected channel update, got %T", msg)
+		}
+		if update.ShortChannelID.ToUint64() != 1 {
+			t.Fatalf("wrong channel retransmitted: %v",
+				update.ShortChannelID)
+		}
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("stale channel wasn't retransmitted")
+	}
+}
+
+// TestProcessAnnouncementAfterStop ensures that announcements handed to the
+// gossiper once it has been stopped are refused right away with
+// ErrShuttingDown.
+func TestProcessAnnouncementAfterStop(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	ctx.gossiper.Stop()
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2):
+		if err != ErrShuttingDown {
+			t.Fatalf("expected ErrShuttingDown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("remote announcement wasn't refused")
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessLocalAnnouncement(na, nodeKeyPub1):
+		if err != ErrShuttingDown {
+			t.Fatalf("expected ErrShuttingDown, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("local announcement wasn't refused")
+	}
+
+	if len(ctx.router.nodes) != 0 {
+		t.Fatal("announcement processed after stop")
+	}
+}
+
+// TestStaleChannels ensures that exactly the third-party channels for which
+// neither of the edges has been updated within the threshold are reported as
+// stale.
+func TestStaleChannels(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	now := time.Now()
+	ctx.gossiper.now = func() time.Time {
+		return now
+	}
+
+	nodeKeyPriv3, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	nodeKeyPub3 := nodeKeyPriv3.PubKey()
+
+	const threshold = 14 * 24 * time.Hour
+	fresh := now.Add(-time.Hour)
+	stale := now.Add(-threshold)
+
+	addChannel := func(chanID uint64, node1, node2 *btcec.PublicKey,
+		updates ...time.Time) {
+
+		ctx.router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+			ChannelID: chanID,
+			NodeKey1:  node1,
+			NodeKey2:  node2,
+		}
+		for _, update := range updates {
+			ctx.router.edges[chanID] = append(
+				ctx.router.edges[chanID],
+				&channeldb.ChannelEdgePolicy{
+					ChannelID:  chanID,
+					LastUpdate: update,
+				},
+			)
+		}
+	}
+
+	// Only the channels whose edges are all stale or missing should be
+	// reported, while our own channels are never reported.
+	addChannel(1, nodeKeyPub2, nodeKeyPub3, stale, stale)
+	addChannel(2, nodeKeyPub2, nodeKeyPub3, fresh, stale)
+	addChannel(3, nodeKeyPub2, nodeKeyPub3)
+	addChannel(4, nodeKeyPub1, nodeKeyPub2, stale, stale)
+	addChannel(5, nodeKeyPub3, nodeKeyPub2, fresh, fresh)
+	addChannel(6, nodeKeyPub3, nodeKeyPub2, stale)
+
+	chanIDs, err := ctx.gossiper.StaleChannels(threshold)
+	if err != nil {
+		t.Fatalf("unable to query stale channels: %v", err)
+	}
+
+	found := make(map[uint64]bool)
+	for _, chanID := range chanIDs {
+		found[chanID.ToUint64()] = true
+	}
+	if len(chanIDs) != 3 || !found[1] || !found[3] || !found[6] {
+		t.Fatalf("expected channels 1, 3 and 6, got %v", chanIDs)
+	}
+
+	// None of the channels should have been deleted.
+	if len(ctx.router.infos) != 6 {
+		t.Fatalf("expected 6 channels, got %v", len(ctx.router.infos))
+	}
+}
+
+// TestClosedChannelUpdate ensures that updates of channels which have been
+// closed on-chain are dropped, and that the channel is pruned if requested.
+// The state of each channel should only be queried once per block.
+func TestClosedChannelUpdate(t *testing.T) {
+	t.Parallel()
+
+	var closed, numQueries uint32
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.IsChannelClosed = func(_ *wire.OutPoint,
+			_ uint32) (bool, error) {
+
+			atomic.AddUint32(&numQueries, 1)
+			return atomic.LoadUint32(&closed) == 1, nil
+		}
+		cfg.PruneClosedChannels = true
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process channel announcement: %v", err)
+	}
+
+	processUpdate := func(timestamp uint32) error {
+		ua, err := createUpdateAnnouncement(0)
+		if err != nil {
+			t.Fatalf("can't create update announcement: %v", err)
+		}
+
+		ua.Timestamp = timestamp
+		signer := mockSigner{nodeKeyPriv1}
+		ua.Signature, err = SignAnnouncement(&signer, nodeKeyPub1, ua)
+		if err != nil {
+			t.Fatalf("can't sign update announcement: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			ua, nodeKeyPub2,
+		):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("update wasn't processed")
+		}
+		return nil
+	}
+
+	// While the channel is open, its updates should be applied. The
+	// state of the channel should only be queried for the first one.
+	for i := uint32(0); i < 2; i++ {
+		if err := processUpdate(1 + i); err != nil {
+			t.Fatalf("update of open channel rejected: %v", err)
+		}
+	}
+	if n := atomic.LoadUint32(&numQueries); n != 1 {
+		t.Fatalf("expected channel state to be queried once, got %v",
+			n)
+	}
+
+	chanID := ca.ShortChannelID.ToUint64()
+	if len(ctx.router.edges[chanID]) != 2 {
+		t.Fatal("updates of open channel weren't applied")
+	}
+
+	// Once the channel is closed within a new block, further updates
+	// should be dropped, and the channel pruned from the graph.
+	atomic.StoreUint32(&closed, 1)
+	ctx.notifier.notifyBlock(chainhash.Hash{}, 1)
+	for i := 0; ctx.gossiper.BestHeight() != 1; i++ {
+		if i == 100 {
+			t.Fatal("new block wasn't processed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := processUpdate(3); err == nil {
+		t.Fatal("update of closed channel applied")
+	}
+
+	if _, ok := ctx.router.infos[chanID]; ok {
+		t.Fatal("closed channel wasn't pruned")
+	}
+}
+
+// TestAnnouncementPolicy ensures that our half of the proof of a channel
+// which the announcement policy keeps private is never sent to the remote
+// peer, and that neither half of its proof is left stored.
+func TestAnnouncementPolicy(t *testing.T) {
+	t.Parallel()
+
+	sentMsgs := make(chan lnwire.Message, 10)
+	ctx, cleanup := newTestCtx(t, proofMatureDelta, func(cfg *Config) {
+		cfg.SendToPeer = func(_ *btcec.PublicKey,
+			msgs ...lnwire.Message) error {
+
+			for _, msg := range msgs {
+				sentMsgs <- msg
+			}
+			return nil
+		}
+		cfg.AnnouncementPolicy = func(_ wire.OutPoint) bool {
+			return false
+		}
+	})
+	defer cleanup()
+
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+	localKey := batch.nodeAnn1.NodeID
+	remoteKey := batch.nodeAnn2.NodeID
+
+	countProofs := func() int {
+		number := 0
+		if err := ctx.gossiper.waitingProofs.ForAll(
+			func(*channeldb.WaitingProof) error {
+				number++
+				return nil
+			},
+		); err != nil && err != channeldb.ErrWaitingProofNotFound {
+			t.Fatalf("unable to retrieve objects from store: %v",
+				err)
+		}
+		return number
+	}
+
+	// The remote half of the proof arrives before the channel is known,
+	// so it's stored until the channel is.
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+		batch.remoteProofAnn, remoteKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to process remote proof: %v", err)
+	}
+	if countProofs() != 1 {
+		t.Fatal("orphan remote proof wasn't stored")
+	}
+
+	msgs := []lnwire.Message{
+		batch.localChanAnn, batch.chanUpdAnn, batch.localProofAnn,
+	}
+	for _, msg := range msgs {
+		err = <-ctx.gossiper.ProcessLocalAnnouncement(msg, localKey)
+		if err != nil {
+			t.Fatalf("unable to process %T: %v", msg, err)
+		}
+	}
+
+	select {
+	case msg := <-sentMsgs:
+		t.Fatalf("private channel sent %T to remote peer", msg)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Neither half of the proof of the private channel should be stored,
+	// as the stored remote half is removed once our half is dropped.
+	if countProofs() != 0 {
+		t.Fatal("proof of private channel was stored")
+	}
+
+	// A remote half arriving once the channel is known should be dropped
+	// as well, rather than stored forever.
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+		batch.remoteProofAnn, remoteKey,
+	)
+	if err != nil {
+		t.Fatalf("unable to process remote proof: %v", err)
+	}
+	if countProofs() != 0 {
+		t.Fatal("remote proof of private channel was stored")
+	}
+}
+
+// TestHtlcMinimumExceedsCapacity ensures that channel updates advertising an
+// HTLC minimum beyond the capacity of their channel are rejected.
+func TestHtlcMinimumExceedsCapacity(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("can't process channel announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel announcement wasn't processed")
+	}
+
+	// The mock router doesn't validate the funding output, so we'll set
+	// the capacity of the channel ourselves.
+	const capacity = btcutil.Amount(100000)
+	ctx.router.infos[ca.ShortChannelID.ToUint64()].Capacity = capacity
+
+	processUpdate := func(minHTLC lnwire.MilliSatoshi) error {
+		ua, err := createUpdateAnnouncement(0)
+		if err != nil {
+			t.Fatalf("can't create update announcement: %v", err)
+		}
+		ua.HtlcMinimumMsat = minHTLC
+
+		signer := mockSigner{nodeKeyPriv1}
+		ua.Signature, err = SignAnnouncement(&signer, nodeKeyPub1, ua)
+		if err != nil {
+			t.Fatalf("unable to sign update: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			ua, nodeKeyPub2,
+		):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("update wasn't processed")
+		}
+
+		return nil
+	}
+
+	maxMinHTLC := lnwire.NewMSatFromSatoshis(capacity)
+	if err := processUpdate(maxMinHTLC + 1); err == nil {
+		t.Fatal("update with htlc minimum beyond capacity was accepted")
+	}
+	if err := processUpdate(maxMinHTLC); err != nil {
+		t.Fatalf("update with htlc minimum at capacity was "+
+			"rejected: %v", err)
+	}
+}
+
+// TestConcurrentProofHalves ensures that if both halves of a channel's
+// announcement proof are submitted concurrently, the full proof is
+// reconstructed exactly once and no waiting proof is left behind, as the
+// halves are processed one after the other by the networkHandler.
+func TestConcurrentProofHalves(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, uint32(proofMatureDelta))
+	defer cleanup()
+
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+
+	localKey := batch.nodeAnn1.NodeID
+	remoteKey := batch.nodeAnn2.NodeID
+
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(batch.localChanAnn, localKey)
+	if err != nil {
+		t.Fatalf("unable to process channel announcement: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(batch.chanUpdAnn, localKey)
+	if err != nil {
+		t.Fatalf("unable to process channel update: %v", err)
+	}
+
+	// Submit both halves of the proof at once.
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs <- <-ctx.gossiper.ProcessLocalAnnouncement(
+			batch.localProofAnn, localKey,
+		)
+	}()
+	go func() {
+		defer wg.Done()
+		errs <- <-ctx.gossiper.ProcessRemoteAnnouncement(
+			batch.remoteProofAnn, remoteKey,
+		)
+	}()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unable to process proof: %v", err)
+		}
+	}
+
+	// The full proof should be reconstructed exactly once, resulting in
+	// a single broadcast channel announcement.
+	var numChanAnns int
+out:
+	for {
+		select {
+		case msg := <-ctx.broadcastedMessage:
+			if _, ok := msg.(*lnwire.ChannelAnnouncement); ok {
+				numChanAnns++
+			}
+
+		case <-time.After(2 * trickleDelay):
+			break out
+		}
+	}
+	if numChanAnns != 1 {
+		t.Fatalf("expected 1 channel announcement to be broadcast, "+
+			"got %v", numChanAnns)
+	}
+
+	var numProofs int
+	err = ctx.gossiper.waitingProofs.ForAll(
+		func(*channeldb.WaitingProof) error {
+			numProofs++
+			return nil
+		},
+	)
+	if err != nil && err != channeldb.ErrWaitingProofNotFound {
+		t.Fatalf("unable to retrieve objects from store: %v", err)
+	}
+	if numProofs != 0 {
+		t.Fatalf("expected empty store, found %v waiting proofs",
+			numProofs)
+	}
+}
+
+// TestFeeUpdateMaxHTLC ensures that a maximum HTLC set through a fee update is
+// advertised within the broadcast channel update, and covered by its
+// signature.
+func TestFeeUpdateMaxHTLC(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	selfPub, err := btcec.ParsePubKey(
+		nodeKeyPub1.SerializeCompressed(), btcec.S256(),
+	)
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	ctx.router.infos[1] = &channeldb.ChannelEdgeInfo{
+		ChannelID:    1,
+		ChannelPoint: wire.OutPoint{Index: 1},
+		NodeKey1:     nodeKeyPub1,
+		NodeKey2:     nodeKeyPub2,
+	}
+	ctx.router.edges[1] = []*channeldb.ChannelEdgePolicy{{
+		ChannelID:  1,
+		LastUpdate: time.Now(),
+		Node: &channeldb.LightningNode{
+			PubKey: selfPub,
+		},
+	}}
+
+	feeSchema := routing.FeeSchema{
+		BaseFee: 1000,
+		FeeRate: 1,
+		MaxHTLC: 500000,
+	}
+	if err := ctx.gossiper.PropagateFeeUpdate(feeSchema); err != nil {
+		t.Fatalf("unable to propagate fee update: %v", err)
+	}
+
+	var update *lnwire.ChannelUpdate
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		var ok bool
+		update, ok = msg.(*lnwire.ChannelUpdate)
+		if !ok {
+			t.Fatalf("expected channel update, got %T", msg)
+		}
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("channel update wasn't broadcast")
+	}
+
+	if update.Flags&lnwire.ChanUpdateOptionMaxHtlc == 0 {
+		t.Fatalf("update doesn't signal a maximum HTLC, flags=%v",
+			update.Flags)
+	}
+	if update.HtlcMaximumMsat != feeSchema.MaxHTLC {
+		t.Fatalf("expected maximum HTLC of %v, got %v",
+			feeSchema.MaxHTLC, update.HtlcMaximumMsat)
+	}
+
+	// The maximum HTLC should survive a round trip over the wire, and
+	// remain covered by the signature of the update.
+	var b bytes.Buffer
+	if _, err := lnwire.WriteMessage(&b, update, 0); err != nil {
+		t.Fatalf("unable to encode update: %v", err)
+	}
+	msg, err := lnwire.ReadMessage(&b, 0)
+	if err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+	decoded := msg.(*lnwire.ChannelUpdate)
+	if decoded.HtlcMaximumMsat != feeSchema.MaxHTLC {
+		t.Fatalf("expected decoded maximum HTLC of %v, got %v",
+			feeSchema.MaxHTLC, decoded.HtlcMaximumMsat)
+	}
+	err = ctx.gossiper.validateChannelUpdateAnn(nodeKeyPub1, decoded)
+	if err != nil {
+		t.Fatalf("invalid signature for decoded update: %v", err)
+	}
+
+	// Tampering with the maximum HTLC should invalidate the signature.
+	decoded.HtlcMaximumMsat++
+	err = ctx.gossiper.validateChannelUpdateAnn(nodeKeyPub1, decoded)
+	if err == nil {
+		t.Fatal("expected tampered update to fail validation")
+	}
+
+	edges := ctx.router.edges[1]
+	if policy := edges[len(edges)-1]; policy.MaxHTLC != feeSchema.MaxHTLC {
+		t.Fatalf("expected stored maximum HTLC of %v, got %v",
+			feeSchema.MaxHTLC, policy.MaxHTLC)
+	}
+}
+
+// TestBestHeight ensures that the best height exposed by the gossiper tracks
+// the blocks it's notified of.
+func TestBestHeight(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, proofMatureDelta)
+	defer cleanup()
+
+	if height := ctx.gossiper.BestHeight(); height != proofMatureDelta {
+		t.Fatalf("expected best height of %v, got %v",
+			proofMatureDelta, height)
+	}
+
+	// Notify the gossiper of a new block, after which its best height
+	// should be updated to reflect it.
+	newHeight := proofMatureDelta + 1
+	newBlock := &wire.MsgBlock{}
+	ctx.notifier.notifyBlock(newBlock.Header.BlockHash(), newHeight)
+
+	timeout := time.After(2 * trickleDelay)
+	for ctx.gossiper.BestHeight() != newHeight {
+		select {
+		case <-timeout:
+			t.Fatalf("expected best height of %v, got %v",
+				newHeight, ctx.gossiper.BestHeight())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// TestInboundFeeRoundTrip checks that the inbound fee carried within the
+// extra data of a channel update survives its ingest, and is re-emitted along
+// with the update, such that its signature remains valid.
+func TestInboundFeeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+	select {
+	case <-ctx.broadcastedMessage:
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("announcement wasn't broadcast")
+	}
+
+	// We'll create an update carrying an inbound fee, and pass it through
+	// the wire encoding, just as if it was received from a peer.
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	inboundFee := lnwire.InboundFee{BaseFee: -500, FeeRate: -100}
+	if err := ua.SetInboundFee(inboundFee); err != nil {
+		t.Fatalf("unable to set inbound fee: %v", err)
+	}
+	signer := mockSigner{nodeKeyPriv1}
+	ua.Signature, err = SignAnnouncement(&signer, nodeKeyPub1, ua)
+	if err != nil {
+		t.Fatalf("unable to sign update: %v", err)
+	}
+
+	var b bytes.Buffer
+	if _, err := lnwire.WriteMessage(&b, ua, 0); err != nil {
+		t.Fatalf("unable to encode update: %v", err)
+	}
+	rawUpdate := append([]byte(nil), b.Bytes()...)
+	msg, err := lnwire.ReadMessage(&b, 0)
+	if err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(msg, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	// The update should be relayed along with its inbound fee.
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		relayed, ok := msg.(*lnwire.ChannelUpdate)
+		if !ok {
+			t.Fatalf("expected channel update, got %T", msg)
+		}
+		fee, err := relayed.InboundFee()
+		if err != nil {
+			t.Fatalf("unable to parse inbound fee: %v", err)
+		}
+		if fee == nil || *fee != inboundFee {
+			t.Fatalf("expected inbound fee %v, got %v",
+				inboundFee, fee)
+		}
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("update wasn't broadcast")
+	}
+
+	// The extra data should've been stored along with the policy.
+	chanID := ua.ShortChannelID.ToUint64()
+	if len(ctx.router.edges[chanID]) != 1 {
+		t.Fatalf("expected 1 policy, got %v",
+			len(ctx.router.edges[chanID]))
+	}
+	policy := ctx.router.edges[chanID][0]
+	if !bytes.Equal(policy.ExtraOpaqueData, ua.ExtraOpaqueData) {
+		t.Fatalf("expected extra data %x, got %x", ua.ExtraOpaqueData,
+			policy.ExtraOpaqueData)
+	}
+
+	// Re-creating the update from the graph, such as during a sync with a
+	// peer, should yield the very same update, with a valid signature.
+	announcements, _, _, err := ctx.gossiper.fetchGraphAnnouncements()
+	if err != nil {
+		t.Fatalf("unable to fetch graph announcements: %v", err)
+	}
+	var reEmitted *lnwire.ChannelUpdate
+	for _, msg := range announcements {
+		if update, ok := msg.(*lnwire.ChannelUpdate); ok {
+			reEmitted = update
+		}
+	}
+	if reEmitted == nil {
+		t.Fatalf("update wasn't re-emitted")
+	}
+
+	err = ctx.gossiper.validateChannelUpdateAnn(nodeKeyPub1, reEmitted)
+	if err != nil {
+		t.Fatalf("re-emitted update has invalid signature: %v", err)
+	}
+
+	b.Reset()
+	if _, err := lnwire.WriteMessage(&b, reEmitted, 0); err != nil {
+		t.Fatalf("unable to encode update: %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), rawUpdate) {
+		t.Fatalf("re-emitted update doesn't match original: %x vs %x",
+			b.Bytes(), rawUpdate)
+	}
+
+	// Finally, an update carrying a malformed inbound fee should be
+	// rejected.
+	ua.Timestamp++
+	ua.ExtraOpaqueData = []byte{0xfd, 0xd9, 0x03, 0x01, 0x00}
+	ua.Signature, err = SignAnnouncement(&signer, nodeKeyPub1, ua)
+	if err != nil {
+		t.Fatalf("unable to sign update: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub2)
+	if err == nil {
+		t.Fatalf("expected update with malformed inbound fee to be " +
+			"rejected")
+	}
+	if len(ctx.router.edges[chanID]) != 1 {
+		t.Fatalf("malformed update was added to the router")
+	}
+}
@@ -0,0 +1,233 @@
+package discovery
+
+import (
+	"container/list"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+const (
+	// chanInfoMemory is the approximate number of bytes required to store
+	// a single channel edge info along with its authentication proof:
+	// the channel ID, chain hash, four public keys, four signatures, the
+	// channel point and the capacity.
+	chanInfoMemory = 8 + 32 + 4*33 + 4*73 + 36 + 8
+
+	// chanPolicyMemory is the approximate number of bytes required to
+	// store a single directed edge policy of a channel.
+	chanPolicyMemory = 73 + 8 + 8 + 1 + 2 + 8 + 8 + 8
+
+	// nodeMemory is the approximate number of bytes required to store a
+	// single node, excluding its alias and addresses: the public key,
+	// signature, and timestamp.
+	nodeMemory = 33 + 73 + 8
+)
+
+// chanMemoryEntry is the element stored within the LRU list of the
+// graphMemoryTracker for each tracked channel.
+type chanMemoryEntry struct {
+	chanID uint64
+
+	// nodes are the compressed public keys of the nodes of the channel.
+	nodes [2][33]byte
+
+	infoSize    uint64
+	policySizes [2]uint64
+}
+
+// size returns the total number of bytes accounted to the channel.
+func (c *chanMemoryEntry) size() uint64 {
+	return c.infoSize + c.policySizes[0] + c.policySizes[1]
+}
+
+// nodeMemoryEntry is the entry stored within the graphMemoryTracker for each
+// node of a tracked channel.
+type nodeMemoryEntry struct {
+	// size is the number of bytes accounted to the node.
+	size uint64
+
+	// numChans is the number of tracked channels of the node. Once it
+	// drops to zero, the node is no longer tracked.
+	numChans int
+}
+
+// graphMemoryTracker tracks the approximate memory used by the elements of
+// the channel graph fed by the gossiper. Channels are kept in least recently
+// updated order, such that once the tracked memory exceeds the budget, the
+// stalest channels can be evicted from the graph.
+//
+// NOTE: This struct isn't safe for concurrent access.
+type graphMemoryTracker struct {
+	// maxBytes is the memory budget of the graph.
+	maxBytes uint64
+
+	// totalBytes is the total memory accounted to all tracked elements.
+	totalBytes uint64
+
+	// chans maps a channel ID to its element within the lru list.
+	chans map[uint64]*list.Element
+
+	// lru is the list of tracked channels, with the most recently updated
+	// channel at the front.
+	lru *list.List
+
+	// nodes maps the compressed public key of each node of a tracked
+	// channel to its entry.
+	nodes map[[33]byte]*nodeMemoryEntry
+}
+
+// newGraphMemoryTracker returns a new graphMemoryTracker which enforces the
+// passed memory budget.
+func newGraphMemoryTracker(maxBytes uint64) *graphMemoryTracker {
+	return &graphMemoryTracker{
+		maxBytes: maxBytes,
+		chans:    make(map[uint64]*list.Element),
+		lru:      list.New(),
+		nodes:    make(map[[33]byte]*nodeMemoryEntry),
+	}
+}
+
+// trackChannel accounts the memory of a newly added channel, marking it as
+// the most recently updated channel.
+func (g *graphMemoryTracker) trackChannel(info *channeldb.ChannelEdgeInfo) {
+	if _, ok := g.chans[info.ChannelID]; ok {
+		return
+	}
+
+	entry := &chanMemoryEntry{
+		chanID:   info.ChannelID,
+		infoSize: chanInfoMemory + uint64(len(info.Features)),
+	}
+	copy(entry.nodes[0][:], info.NodeKey1.SerializeCompressed())
+	copy(entry.nodes[1][:], info.NodeKey2.SerializeCompressed())
+
+	for _, pub := range entry.nodes {
+		node, ok := g.nodes[pub]
+		if !ok {
+			node = &nodeMemoryEntry{}
+			g.nodes[pub] = node
+		}
+		node.numChans++
+	}
+
+	g.chans[info.ChannelID] = g.lru.PushFront(entry)
+	g.totalBytes += entry.size()
+}
+
+// trackPolicy accounts the memory of a new directed edge policy of a tracked
+// channel, replacing the prior policy in that direction, and marks the
+// channel as the most recently updated channel.
+func (g *graphMemoryTracker) trackPolicy(policy *channeldb.ChannelEdgePolicy) {
+	elem, ok := g.chans[policy.ChannelID]
+	if !ok {
+		return
+	}
+
+	entry := elem.Value.(*chanMemoryEntry)
+	direction := policy.Flags & lnwire.ChanUpdateDirection
+
+	g.totalBytes -= entry.policySizes[direction]
+	entry.policySizes[direction] = chanPolicyMemory
+	g.totalBytes += entry.policySizes[direction]
+
+	g.lru.MoveToFront(elem)
+}
+
+// trackNode accounts the memory of a node of a tracked channel, replacing
+// any prior accounting for the same node. Nodes without tracked channels
+// aren't tracked.
+func (g *graphMemoryTracker) trackNode(node *channeldb.LightningNode) {
+	var pub [33]byte
+	copy(pub[:], node.PubKey.SerializeCompressed())
+
+	entry, ok := g.nodes[pub]
+	if !ok {
+		return
+	}
+
+	size := uint64(nodeMemory + len(node.Alias))
+	for _, addr := range node.Addresses {
+		size += uint64(len(addr.String()))
+	}
+
+	g.totalBytes -= entry.size
+	entry.size = size
+	g.totalBytes += size
+}
+
+// untrackNode removes a node which has been deleted from the graph from the
+// tracker.
+func (g *graphMemoryTracker) untrackNode(pub [33]byte) {
+	entry, ok := g.nodes[pub]
+	if !ok {
+		return
+	}
+
+	delete(g.nodes, pub)
+	g.totalBytes -= entry.size
+}
+
+// untrackChannel removes a channel which has been deleted from the graph from
+// the tracker, along with those of its nodes left without tracked channels.
+// The public keys of these nodes are returned.
+func (g *graphMemoryTracker) untrackChannel(chanID uint64) [][33]byte {
+	elem, ok := g.chans[chanID]
+	if !ok {
+		return nil
+	}
+
+	return g.removeChannel(elem)
+}
+
+// removeChannel removes the channel of the passed element of the lru list
+// from the tracker, along with those of its nodes left without tracked
+// channels. The public keys of these nodes are returned.
+func (g *graphMemoryTracker) removeChannel(elem *list.Element) [][33]byte {
+	entry := g.lru.Remove(elem).(*chanMemoryEntry)
+	delete(g.chans, entry.chanID)
+	g.totalBytes -= entry.size()
+
+	var orphaned [][33]byte
+	for _, pub := range entry.nodes {
+		node, ok := g.nodes[pub]
+		if !ok {
+			continue
+		}
+
+		node.numChans--
+		if node.numChans > 0 {
+			continue
+		}
+
+		g.untrackNode(pub)
+		orphaned = append(orphaned, pub)
+	}
+
+	return orphaned
+}
+
+// evictionCandidates removes the least recently updated channels from the
+// tracker until the tracked memory is within budget, returning the IDs of
+// the removed channels so they can be evicted from the graph. The public keys
+// of the nodes left without tracked channels by the eviction are returned as
+// well, as they're removed from the tracker too.
+func (g *graphMemoryTracker) evictionCandidates() ([]uint64, [][33]byte) {
+	var (
+		evicted  []uint64
+		orphaned [][33]byte
+	)
+	for g.totalBytes > g.maxBytes {
+		elem := g.lru.Back()
+		if elem == nil {
+			break
+		}
+
+		chanID := elem.Value.(*chanMemoryEntry).chanID
+		orphaned = append(orphaned, g.removeChannel(elem)...)
+
+		evicted = append(evicted, chanID)
+	}
+
+	return evicted, orphaned
+}
@@ -0,0 +1,148 @@
+package discovery
+
+import (
+	"math"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestGraphMemoryEviction ensures that once the memory budget of the graph is
+// exceeded, the least recently updated channels are evicted.
+func TestGraphMemoryEviction(t *testing.T) {
+	t.Parallel()
+
+	// We'll set a budget which can hold two channels, but not three.
+	const maxGraphMemory = 2 * (chanInfoMemory + 16)
+
+	ctx, cleanup := newTestCtx(t, 3, func(cfg *Config) {
+		cfg.MaxGraphMemory = maxGraphMemory
+	})
+	defer cleanup()
+
+	remoteKey := nodeKeyPub2
+
+	var chanIDs []lnwire.ShortChannelID
+	for height := uint32(1); height <= 3; height++ {
+		ca, err := createRemoteChannelAnnouncement(height)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, remoteKey)
+		if err != nil {
+			t.Fatalf("can't process remote announcement: %v", err)
+		}
+
+		chanIDs = append(chanIDs, ca.ShortChannelID)
+	}
+
+	// The first, and therefore oldest, channel should have been evicted,
+	// while the two most recent channels should remain.
+	if _, _, _, err := ctx.router.GetChannelByID(chanIDs[0]); err == nil {
+		t.Fatal("oldest channel wasn't evicted")
+	}
+	for _, chanID := range chanIDs[1:] {
+		if _, _, _, err := ctx.router.GetChannelByID(chanID); err != nil {
+			t.Fatalf("channel %v was evicted: %v", chanID, err)
+		}
+	}
+}
+
+// TestGraphMemoryTrackerNodes ensures that the graphMemoryTracker accounts
+// for the nodes of tracked channels only, and stops accounting for them once
+// they're left without tracked channels.
+func TestGraphMemoryTrackerNodes(t *testing.T) {
+	t.Parallel()
+
+	var keys [3]*btcec.PublicKey
+	for i := range keys {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		keys[i] = priv.PubKey()
+	}
+
+	tracker := newGraphMemoryTracker(math.MaxUint64)
+
+	// We'll track two channels, both of which are connected to the first
+	// node.
+	tracker.trackChannel(&channeldb.ChannelEdgeInfo{
+		ChannelID: 1,
+		NodeKey1:  keys[0],
+		NodeKey2:  keys[1],
+	})
+	tracker.trackChannel(&channeldb.ChannelEdgeInfo{
+		ChannelID: 2,
+		NodeKey1:  keys[0],
+		NodeKey2:  keys[2],
+	})
+
+	// Both directions of the first channel should be accounted for,
+	// regardless of the other flags of their policies.
+	tracker.trackPolicy(&channeldb.ChannelEdgePolicy{
+		ChannelID: 1,
+		Flags:     lnwire.ChanUpdateDisabled,
+	})
+	tracker.trackPolicy(&channeldb.ChannelEdgePolicy{
+		ChannelID: 1,
+		Flags: lnwire.ChanUpdateDisabled |
+			lnwire.ChanUpdateDirection,
+	})
+
+	// The nodes of the channels should be accounted for, while a node
+	// without tracked channels shouldn't be.
+	unknownPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	for _, key := range append(keys[:], unknownPriv.PubKey()) {
+		tracker.trackNode(&channeldb.LightningNode{PubKey: key})
+	}
+
+	expectedBytes := uint64(2*chanInfoMemory + 2*chanPolicyMemory +
+		3*nodeMemory)
+	if tracker.totalBytes != expectedBytes {
+		t.Fatalf("expected %v tracked bytes, got %v", expectedBytes,
+			tracker.totalBytes)
+	}
+
+	var pubs [3][33]byte
+	for i, key := range keys {
+		copy(pubs[i][:], key.SerializeCompressed())
+	}
+
+	// Once the first channel is removed, only its second node should be
+	// left without channels.
+	orphaned := tracker.untrackChannel(1)
+	if len(orphaned) != 1 || orphaned[0] != pubs[1] {
+		t.Fatalf("expected node %x to be orphaned, got %x", pubs[1],
+			orphaned)
+	}
+	expectedBytes = chanInfoMemory + 2*nodeMemory
+	if tracker.totalBytes != expectedBytes {
+		t.Fatalf("expected %v tracked bytes, got %v", expectedBytes,
+			tracker.totalBytes)
+	}
+
+	// Evicting the remaining channel should leave both of its nodes
+	// without channels, and nothing tracked.
+	tracker.maxBytes = 0
+	chanIDs, orphaned := tracker.evictionCandidates()
+	if len(chanIDs) != 1 || chanIDs[0] != 2 {
+		t.Fatalf("expected channel 2 to be evicted, got %v", chanIDs)
+	}
+	if len(orphaned) != 2 || orphaned[0] != pubs[0] ||
+		orphaned[1] != pubs[2] {
+
+		t.Fatalf("expected nodes %x and %x to be orphaned, got %x",
+			pubs[0], pubs[2], orphaned)
+	}
+	if tracker.totalBytes != 0 || len(tracker.nodes) != 0 {
+		t.Fatalf("expected nothing to be tracked, got %v bytes and "+
+			"%v nodes", tracker.totalBytes, len(tracker.nodes))
+	}
+}
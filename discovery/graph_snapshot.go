@@ -0,0 +1,269 @@
+package discovery
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// graphSnapshotMagic prefixes each graph snapshot, followed by the version of
+// its format. Snapshots written before they were versioned consist of bare
+// wire messages, which never start with the magic, as it isn't a known
+// message type.
+var graphSnapshotMagic = [4]byte{0xff, 'g', 's', 'n'}
+
+const (
+	// graphSnapshotLegacy is the format of the unversioned snapshots, in
+	// which each announcement is serialized as a bare wire message.
+	graphSnapshotLegacy byte = 0
+
+	// graphSnapshotVersion is the current format of snapshots, in which
+	// each announcement is serialized as a wire message prefixed by its
+	// length. The length prefix is needed as channel updates may carry
+	// extra data of an arbitrary length at their end.
+	graphSnapshotVersion byte = 1
+)
+
+const (
+	// legacyChanUpdateFlagsEnd is the offset within a serialized channel
+	// update, including its message type, at which its flags end.
+	legacyChanUpdateFlagsEnd = 2 + 64 + 32 + 8 + 4 + 2
+
+	// legacyChanUpdateSize is the size of a serialized channel update
+	// within a legacy snapshot, including its message type, if it doesn't
+	// carry the maximum HTLC. Legacy snapshots predate the extra data of
+	// channel updates, so their updates end right after their known
+	// fields.
+	legacyChanUpdateSize = legacyChanUpdateFlagsEnd + 2 + 8 + 4 + 4
+)
+
+// ExportGraph writes a snapshot of the graph to the passed writer, which can
+// later be used to seed the router of a fresh node through the
+// GraphSnapshotPath config option. The snapshot consists of a versioned
+// header, followed by the authenticated announcements of all channels and
+// nodes within the graph.
+func (d *AuthenticatedGossiper) ExportGraph(w io.Writer) error {
+	announcements, numNodes, numEdges, err := d.fetchGraphAnnouncements()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(graphSnapshotMagic[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{graphSnapshotVersion}); err != nil {
+		return err
+	}
+
+	for _, msg := range announcements {
+		if err := writeSnapshotMsg(w, msg); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Exported graph snapshot of %v vertexes and %v edges",
+		numNodes, numEdges)
+
+	return nil
+}
+
+// writeSnapshotMsg writes the passed announcement to a graph snapshot of the
+// current version, prefixed by its length.
+func writeSnapshotMsg(w io.Writer, msg lnwire.Message) error {
+	var b bytes.Buffer
+	if _, err := lnwire.WriteMessage(&b, msg, 0); err != nil {
+		return err
+	}
+
+	var l [2]byte
+	binary.BigEndian.PutUint16(l[:], uint16(b.Len()))
+	if _, err := w.Write(l[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// readSnapshotVersion reads the header of a graph snapshot, returning the
+// version of its format. If the snapshot has no header, then it's a legacy
+// snapshot, in which case nothing is consumed from the reader.
+func readSnapshotVersion(r *bufio.Reader) (byte, error) {
+	magic, err := r.Peek(len(graphSnapshotMagic))
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if !bytes.Equal(magic, graphSnapshotMagic[:]) {
+		return graphSnapshotLegacy, nil
+	}
+
+	if _, err := r.Discard(len(graphSnapshotMagic)); err != nil {
+		return 0, err
+	}
+	version, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if version != graphSnapshotVersion {
+		return 0, errors.Errorf("unknown graph snapshot version %v",
+			version)
+	}
+
+	return version, nil
+}
+
+// peekSnapshot returns the next n bytes of a graph snapshot without consuming
+// them. io.EOF is only returned if the end of the snapshot has already been
+// reached, while io.ErrUnexpectedEOF is returned if it's reached within the
+// n bytes.
+func peekSnapshot(r *bufio.Reader, n int) ([]byte, error) {
+	b, err := r.Peek(n)
+	switch {
+	case err == io.EOF && len(b) > 0:
+		return nil, io.ErrUnexpectedEOF
+	case err != nil:
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// readSnapshotMsg reads the next announcement from a graph snapshot of the
+// passed version. io.EOF is returned once the end of the snapshot has been
+// reached.
+func readSnapshotMsg(r *bufio.Reader, version byte) (lnwire.Message, error) {
+	var raw []byte
+	switch version {
+	case graphSnapshotLegacy:
+		// All announcements but channel updates are delimited by
+		// their own fields, so they can be read as is.
+		msgType, err := peekSnapshot(r, 2)
+		if err != nil {
+			return nil, err
+		}
+		if lnwire.MessageType(binary.BigEndian.Uint16(msgType)) !=
+			lnwire.MsgChannelUpdate {
+
+			return lnwire.ReadMessage(r, 0)
+		}
+
+		// A channel update carries its maximum HTLC only if
+		// signalled within its flags.
+		header, err := peekSnapshot(r, legacyChanUpdateFlagsEnd)
+		if err != nil {
+			return nil, err
+		}
+		flags := binary.BigEndian.Uint16(header[len(header)-2:])
+
+		size := legacyChanUpdateSize
+		if flags&lnwire.ChanUpdateOptionMaxHtlc != 0 {
+			size += 8
+		}
+		raw = make([]byte, size)
+
+	default:
+		var l [2]byte
+		if _, err := io.ReadFull(r, l[:]); err != nil {
+			return nil, err
+		}
+		raw = make([]byte, binary.BigEndian.Uint16(l[:]))
+	}
+
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, err
+	}
+
+	return lnwire.ReadMessage(bytes.NewReader(raw), 0)
+}
+
+// loadGraphSnapshot seeds the router with the announcements of the graph
+// snapshot at the passed path, but only if the router doesn't know of any
+// channels yet. Each announcement is processed just as if it was received
+// from a remote peer, so any announcements which fail validation are skipped.
+//
+// NOTE: This MUST be called before the networkHandler is started.
+func (d *AuthenticatedGossiper) loadGraphSnapshot(path string) error {
+	// We'll only seed the router if it's empty, as otherwise we'd replace
+	// our existing view of the graph with a possibly outdated one.
+	errNotEmpty := errors.New("graph not empty")
+	err := d.cfg.Router.ForEachChannel(func(_ *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		return errNotEmpty
+	})
+	switch {
+	case err == errNotEmpty:
+		log.Infof("Graph isn't empty, skipping graph snapshot %v", path)
+		return nil
+
+	case err != nil && err != channeldb.ErrGraphNoEdgesFound:
+		return err
+	}
+
+	snapshot, err := os.Open(path)
+	if err != nil {
+		return errors.Errorf("unable to open graph snapshot: %v", err)
+	}
+	defer snapshot.Close()
+
+	r := bufio.NewReader(snapshot)
+	version, err := readSnapshotVersion(r)
+	if err != nil {
+		return errors.Errorf("unable to read graph snapshot: %v", err)
+	}
+
+	var numLoaded, numSkipped uint32
+	for {
+		msg, err := readSnapshotMsg(r, version)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Errorf("unable to read graph snapshot: %v",
+				err)
+		}
+
+		// A snapshot should only consist of channel and node
+		// announcements, so we'll skip anything else.
+		switch msg.(type) {
+		case *lnwire.ChannelAnnouncement, *lnwire.ChannelUpdate,
+			*lnwire.NodeAnnouncement:
+
+		default:
+			numSkipped++
+			continue
+		}
+
+		nMsg := &networkMsg{
+			msg:      msg,
+			isRemote: true,
+			err:      make(chan error, 1),
+		}
+		d.processNetworkAnnouncement(nMsg)
+
+		// Announcements which aren't applied to the graph right away,
+		// such as those that are premature, don't send a response, so
+		// we'll count them as skipped as well.
+		select {
+		case err := <-nMsg.err:
+			if err != nil {
+				numSkipped++
+				continue
+			}
+			numLoaded++
+
+		default:
+			numSkipped++
+		}
+	}
+
+	log.Infof("Loaded %v announcements from graph snapshot %v, skipped "+
+		"%v invalid announcements", numLoaded, path, numSkipped)
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package discovery
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestGraphSnapshot ensures that a graph snapshot exported by one gossiper can
+// be used to seed the empty router of another, skipping any invalid
+// announcements within the snapshot. Legacy snapshots, which predate the
+// versioning of snapshots, should be loaded as well.
+func TestGraphSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	// First, we'll populate the graph of the exporting gossiper with a
+	// channel, an update for it, and a node.
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	for _, msg := range []lnwire.Message{ca, ua, na} {
+		err := <-ctx.gossiper.ProcessRemoteAnnouncement(msg, nodeKeyPub2)
+		if err != nil {
+			t.Fatalf("can't process remote announcement: %v", err)
+		}
+	}
+
+	// Each snapshot will also end in a node announcement which has been
+	// modified after being signed, which should be skipped when loading
+	// the snapshot.
+	invalidNa, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	invalidNa.Timestamp++
+
+	assertSnapshotLoaded := func(writeSnapshot func(io.Writer) error) {
+		snapshotFile, err := ioutil.TempFile("", "graphsnapshot")
+		if err != nil {
+			t.Fatalf("unable to create snapshot file: %v", err)
+		}
+		defer os.Remove(snapshotFile.Name())
+
+		if err := writeSnapshot(snapshotFile); err != nil {
+			t.Fatalf("unable to write snapshot: %v", err)
+		}
+		snapshotFile.Close()
+
+		// Next, we'll create a gossiper with an empty graph, which is
+		// seeded with the snapshot once started.
+		emptyCtx, emptyCleanup := newTestCtx(t, 0)
+		defer emptyCleanup()
+		emptyCtx.gossiper.Stop()
+
+		cfg := *emptyCtx.gossiper.cfg
+		cfg.GraphSnapshotPath = snapshotFile.Name()
+
+		gossiper, err := New(cfg, nodeKeyPub1)
+		if err != nil {
+			t.Fatalf("unable to create gossiper: %v", err)
+		}
+		if err := gossiper.Start(); err != nil {
+			t.Fatalf("unable to start gossiper: %v", err)
+		}
+		defer gossiper.Stop()
+
+		// The valid channel, update and node should now be known,
+		// while the invalid node announcement should have been
+		// skipped.
+		chanID := ca.ShortChannelID.ToUint64()
+		if _, ok := emptyCtx.router.infos[chanID]; !ok {
+			t.Fatal("channel wasn't loaded from snapshot")
+		}
+		if len(emptyCtx.router.edges[chanID]) != 1 {
+			t.Fatal("channel update wasn't loaded from snapshot")
+		}
+		if len(emptyCtx.router.nodes) != 1 {
+			t.Fatalf("expected 1 node to be loaded from snapshot, "+
+				"got %v", len(emptyCtx.router.nodes))
+		}
+		if !emptyCtx.router.nodes[0].PubKey.IsEqual(nodeKeyPub1) {
+			t.Fatal("wrong node loaded from snapshot")
+		}
+	}
+
+	assertSnapshotLoaded(func(w io.Writer) error {
+		if err := ctx.gossiper.ExportGraph(w); err != nil {
+			return err
+		}
+		return writeSnapshotMsg(w, invalidNa)
+	})
+
+	// A legacy snapshot consists of bare wire messages, without any
+	// header.
+	assertSnapshotLoaded(func(w io.Writer) error {
+		for _, msg := range []lnwire.Message{ca, ua, na, invalidNa} {
+			if _, err := lnwire.WriteMessage(w, msg, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
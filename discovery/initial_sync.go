@@ -0,0 +1,44 @@
+package discovery
+
+import "time"
+
+// InitialSyncStrategy is the strategy used to synchronize the graph with a
+// peer once it connects to us, trading the completeness of the sync against
+// the bandwidth it takes.
+type InitialSyncStrategy uint8
+
+const (
+	// InitialSyncFull sends the peer a full dump of all announcements
+	// within the graph.
+	InitialSyncFull InitialSyncStrategy = iota
+
+	// InitialSyncTimestamp only sends the peer the announcements within
+	// the graph which have been updated within the InitialSyncHorizon.
+	// Channel announcements are sent if either of the channel's updates
+	// is sent.
+	InitialSyncTimestamp
+)
+
+// String returns a human readable description of the strategy.
+func (s InitialSyncStrategy) String() string {
+	switch s {
+	case InitialSyncFull:
+		return "full"
+	case InitialSyncTimestamp:
+		return "timestamp"
+	default:
+		return "unknown"
+	}
+}
+
+// initialSyncHorizon returns the time from which on the announcements within
+// the graph are sent to a connecting peer, according to the configured
+// InitialSyncStrategy. A zero time is returned if all announcements are to be
+// sent.
+func (d *AuthenticatedGossiper) initialSyncHorizon() time.Time {
+	if d.cfg.InitialSyncStrategy != InitialSyncTimestamp {
+		return time.Time{}
+	}
+
+	return d.now().Add(-d.cfg.InitialSyncHorizon)
+}
@@ -0,0 +1,144 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestInitialSyncStrategy ensures that a connecting peer is sent the
+// announcements within the graph according to the configured
+// InitialSyncStrategy.
+func TestInitialSyncStrategy(t *testing.T) {
+	t.Parallel()
+
+	const horizon = 24 * time.Hour
+	now := time.Now()
+
+	// We'll populate the graph with a channel and node that were updated
+	// within the horizon, and another channel and node that weren't.
+	router := newMockRouter(0)
+	lastUpdates := []time.Time{now.Add(-time.Hour), now.Add(-2 * horizon)}
+	for i, lastUpdate := range lastUpdates {
+		var keys [4]*btcec.PublicKey
+		for j := range keys {
+			priv, err := btcec.NewPrivateKey(btcec.S256())
+			if err != nil {
+				t.Fatalf("unable to generate key: %v", err)
+			}
+			keys[j] = priv.PubKey()
+		}
+
+		chanID := uint64(i)
+		router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+			ChannelID:   chanID,
+			NodeKey1:    keys[0],
+			NodeKey2:    keys[1],
+			BitcoinKey1: keys[2],
+			BitcoinKey2: keys[3],
+			AuthProof: &channeldb.ChannelAuthProof{
+				NodeSig1:    testSig,
+				NodeSig2:    testSig,
+				BitcoinSig1: testSig,
+				BitcoinSig2: testSig,
+			},
+		}
+		router.edges[chanID] = []*channeldb.ChannelEdgePolicy{{
+			Signature:  testSig,
+			ChannelID:  chanID,
+			LastUpdate: lastUpdate,
+		}}
+		router.nodes = append(router.nodes, &channeldb.LightningNode{
+			HaveNodeAnnouncement: true,
+			LastUpdate:           lastUpdate,
+			PubKey:               keys[0],
+			AuthSig:              testSig,
+			Features:             testFeatures,
+		})
+	}
+
+	tests := []struct {
+		strategy InitialSyncStrategy
+
+		// numChans is the number of channels, starting at the first
+		// one, whose announcements should be sent.
+		numChans int
+	}{
+		{
+			strategy: InitialSyncFull,
+			numChans: 2,
+		},
+		{
+			strategy: InitialSyncTimestamp,
+			numChans: 1,
+		},
+	}
+
+	for _, test := range tests {
+		var sent []lnwire.Message
+		gossiper := &AuthenticatedGossiper{
+			cfg: &Config{
+				Router: router,
+				SendToPeer: func(_ *btcec.PublicKey,
+					msgs ...lnwire.Message) error {
+
+					sent = append(sent, msgs...)
+					return nil
+				},
+				InitialSyncStrategy: test.strategy,
+				InitialSyncHorizon:  horizon,
+			},
+			now: func() time.Time {
+				return now
+			},
+		}
+
+		err := gossiper.synchronizeWithNode(&syncRequest{
+			node: nodeKeyPub2,
+		})
+		if err != nil {
+			t.Fatalf("%v: unable to sync with node: %v",
+				test.strategy, err)
+		}
+
+		// Each channel should be sent along with its update, after
+		// which the node announcements follow.
+		if len(sent) != 3*test.numChans {
+			t.Fatalf("%v: expected %v messages, got %v",
+				test.strategy, 3*test.numChans, len(sent))
+		}
+
+		sentChans := make(map[uint64]struct{})
+		sentNodes := make(map[[33]byte]struct{})
+		for _, msg := range sent {
+			switch msg := msg.(type) {
+			case *lnwire.ChannelAnnouncement:
+				chanID := msg.ShortChannelID.ToUint64()
+				sentChans[chanID] = struct{}{}
+
+			case *lnwire.NodeAnnouncement:
+				var node [33]byte
+				copy(node[:], msg.NodeID.SerializeCompressed())
+				sentNodes[node] = struct{}{}
+			}
+		}
+
+		for i := 0; i < test.numChans; i++ {
+			if _, ok := sentChans[uint64(i)]; !ok {
+				t.Fatalf("%v: channel %v wasn't sent",
+					test.strategy, i)
+			}
+
+			var node [33]byte
+			pub := router.nodes[i].PubKey
+			copy(node[:], pub.SerializeCompressed())
+			if _, ok := sentNodes[node]; !ok {
+				t.Fatalf("%v: node %x wasn't sent",
+					test.strategy, node)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package discovery
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// latencyBuckets are the upper bounds of the buckets of each
+// LatencyHistogram. Latencies beyond the last bound are counted within an
+// additional overflow bucket.
+var latencyBuckets = []time.Duration{
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// LatencyHistogram is a histogram of the latencies of processing a single
+// type of announcement.
+type LatencyHistogram struct {
+	// Buckets are the upper bounds of the buckets of the histogram.
+	Buckets []time.Duration
+
+	// Counts is the number of samples within each bucket, where the
+	// sample falls into the first bucket whose bound it doesn't exceed.
+	// It has an additional final entry counting the samples beyond the
+	// last bound.
+	Counts []uint64
+
+	// NumSamples is the total number of samples.
+	NumSamples uint64
+
+	// Total is the sum of all samples.
+	Total time.Duration
+}
+
+// newLatencyHistogram returns a new, empty LatencyHistogram.
+func newLatencyHistogram() *LatencyHistogram {
+	return &LatencyHistogram{
+		Buckets: latencyBuckets,
+		Counts:  make([]uint64, len(latencyBuckets)+1),
+	}
+}
+
+// record adds a single sample to the histogram.
+func (h *LatencyHistogram) record(latency time.Duration) {
+	bucket := len(h.Buckets)
+	for i, bound := range h.Buckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+
+	h.Counts[bucket]++
+	h.NumSamples++
+	h.Total += latency
+}
+
+// copy returns a deep copy of the histogram.
+func (h *LatencyHistogram) copy() LatencyHistogram {
+	c := *h
+	c.Counts = make([]uint64, len(h.Counts))
+	copy(c.Counts, h.Counts)
+
+	return c
+}
+
+// Stats is a snapshot of the statistics of the gossiper.
+type Stats struct {
+	// ProcessingLatency maps the type of each processed announcement to
+	// the histogram of the time taken to validate and apply
+	// announcements of that type.
+	ProcessingLatency map[lnwire.MessageType]LatencyHistogram
+
+	// QueueWait maps the type of each processed announcement to the
+	// histogram of the time announcements of that type spent waiting for
+	// the announcements submitted ahead of them to be processed.
+	QueueWait map[lnwire.MessageType]LatencyHistogram
+
+	// NumQueued is the number of announcements currently waiting to be
+	// processed.
+	NumQueued int
+}
+
+// latencyTracker tracks the processing latency of each type of announcement.
+type latencyTracker struct {
+	histograms map[lnwire.MessageType]*LatencyHistogram
+
+	sync.Mutex
+}
+
+// newLatencyTracker returns a new, empty latencyTracker.
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{
+		histograms: make(map[lnwire.MessageType]*LatencyHistogram),
+	}
+}
+
+// record adds a latency sample for the passed type of announcement.
+func (l *latencyTracker) record(msgType lnwire.MessageType,
+	latency time.Duration) {
+
+	l.Lock()
+	defer l.Unlock()
+
+	histogram, ok := l.histograms[msgType]
+	if !ok {
+		histogram = newLatencyHistogram()
+		l.histograms[msgType] = histogram
+	}
+	histogram.record(latency)
+}
+
+// snapshot returns a copy of the histograms of all types of announcements.
+func (l *latencyTracker) snapshot() map[lnwire.MessageType]LatencyHistogram {
+	l.Lock()
+	defer l.Unlock()
+
+	histograms := make(
+		map[lnwire.MessageType]LatencyHistogram, len(l.histograms),
+	)
+	for msgType, histogram := range l.histograms {
+		histograms[msgType] = histogram.copy()
+	}
+
+	return histograms
+}
+
+// Stats returns a snapshot of the statistics of the gossiper.
+func (d *AuthenticatedGossiper) Stats() Stats {
+	return Stats{
+		ProcessingLatency: d.latencies.snapshot(),
+		QueueWait:         d.queueWaits.snapshot(),
+		NumQueued:         int(atomic.LoadInt32(&d.numQueued)),
+	}
+}
@@ -0,0 +1,159 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestProcessingLatencyStats ensures that the processing latency of each type
+// of announcement is recorded within the stats of the gossiper.
+func TestProcessingLatencyStats(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	msgs := []lnwire.Message{ca, ua, na}
+	for _, msg := range msgs {
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			msg, nodeKeyPub2,
+		):
+			if err != nil {
+				t.Fatalf("can't process %T: %v", msg, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%T wasn't processed", msg)
+		}
+	}
+
+	stats := ctx.gossiper.Stats()
+	for _, msg := range msgs {
+		histogram, ok := stats.ProcessingLatency[msg.MsgType()]
+		if !ok || histogram.NumSamples == 0 {
+			t.Fatalf("no latency recorded for %v", msg.MsgType())
+		}
+
+		var numSamples uint64
+		for _, count := range histogram.Counts {
+			numSamples += count
+		}
+		if numSamples != histogram.NumSamples {
+			t.Fatalf("bucket counts of %v sum up to %v, expected "+
+				"%v", msg.MsgType(), numSamples,
+				histogram.NumSamples)
+		}
+	}
+}
+
+// TestQueueWaitStats ensures that the time announcements spend waiting for
+// the announcements submitted ahead of them to be processed is recorded
+// within the stats of the gossiper, along with the number of waiting
+// announcements.
+func TestQueueWaitStats(t *testing.T) {
+	t.Parallel()
+
+	// We'll stall the processing of the channel update, such that the
+	// node announcement submitted after it has to wait for it. Only
+	// channel updates are checked against the chain for closure.
+	stalled := make(chan struct{})
+	release := make(chan struct{})
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.IsChannelClosed = func(_ *wire.OutPoint,
+			_ uint32) (bool, error) {
+
+			close(stalled)
+			<-release
+			return false, nil
+		}
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("can't process channel announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel announcement wasn't processed")
+	}
+
+	updateErr := ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub2)
+	select {
+	case <-stalled:
+	case <-time.After(time.Second):
+		t.Fatal("channel update wasn't processed")
+	}
+
+	nodeErr := make(chan chan error, 1)
+	go func() {
+		nodeErr <- ctx.gossiper.ProcessRemoteAnnouncement(
+			na, nodeKeyPub2,
+		)
+	}()
+
+	// The node announcement should be reported as waiting until the
+	// channel update has been processed.
+	const waitTime = 50 * time.Millisecond
+	time.Sleep(waitTime)
+	if numQueued := ctx.gossiper.Stats().NumQueued; numQueued != 1 {
+		t.Fatalf("expected 1 queued announcement, got %v", numQueued)
+	}
+	close(release)
+
+	for _, errChan := range []chan error{updateErr, <-nodeErr} {
+		select {
+		case err := <-errChan:
+			if err != nil {
+				t.Fatalf("can't process announcement: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("announcement wasn't processed")
+		}
+	}
+
+	stats := ctx.gossiper.Stats()
+	if stats.NumQueued != 0 {
+		t.Fatalf("expected no queued announcements, got %v",
+			stats.NumQueued)
+	}
+
+	histogram, ok := stats.QueueWait[na.MsgType()]
+	if !ok || histogram.NumSamples != 1 {
+		t.Fatalf("expected 1 queue wait sample for %v, got %v",
+			na.MsgType(), histogram.NumSamples)
+	}
+	if histogram.Total < waitTime {
+		t.Fatalf("expected queue wait of at least %v, got %v",
+			waitTime, histogram.Total)
+	}
+}
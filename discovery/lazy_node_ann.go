@@ -0,0 +1,140 @@
+package discovery
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// pendingNodeAnns holds the remote node announcements whose validation has
+// been deferred until their node is first used for path finding. Only the
+// most recent announcement of each node is retained.
+type pendingNodeAnns struct {
+	// anns maps the compressed public key of each node to its pending
+	// announcement.
+	anns map[[33]byte]*networkMsg
+
+	sync.Mutex
+}
+
+// newPendingNodeAnns returns a new pendingNodeAnns without any announcements.
+func newPendingNodeAnns() *pendingNodeAnns {
+	return &pendingNodeAnns{
+		anns: make(map[[33]byte]*networkMsg),
+	}
+}
+
+// add retains the passed node announcement, unless a more recent one of the
+// same node is already pending.
+func (p *pendingNodeAnns) add(nMsg *networkMsg) {
+	msg := nMsg.msg.(*lnwire.NodeAnnouncement)
+
+	var node [33]byte
+	copy(node[:], msg.NodeID.SerializeCompressed())
+
+	p.Lock()
+	defer p.Unlock()
+
+	if pending, ok := p.anns[node]; ok {
+		pendingMsg := pending.msg.(*lnwire.NodeAnnouncement)
+		if pendingMsg.Timestamp >= msg.Timestamp {
+			return
+		}
+	}
+
+	p.anns[node] = nMsg
+}
+
+// take removes and returns the pending announcement of the passed node, if
+// any.
+func (p *pendingNodeAnns) take(node [33]byte) *networkMsg {
+	p.Lock()
+	defer p.Unlock()
+
+	nMsg := p.anns[node]
+	delete(p.anns, node)
+
+	return nMsg
+}
+
+// deferNodeAnnValidation defers the validation of the passed remote node
+// announcement if LazyNodeAnnValidation is set, returning true if it did so.
+// Only announcements of nodes within the graph which are newer than the
+// information we already have are deferred, as all others would be rejected
+// by the router anyway. Deferred announcements are neither applied to the
+// graph nor relayed until they've been validated through UseNode.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) deferNodeAnnValidation(nMsg *networkMsg,
+	msg *lnwire.NodeAnnouncement) bool {
+
+	if d.pendingNodeAnns == nil || nMsg.deferredNodeAnn {
+		return false
+	}
+
+	node, err := d.cfg.Router.FetchLightningNode(msg.NodeID)
+	if err != nil {
+		return false
+	}
+	timestamp := time.Unix(int64(msg.Timestamp), 0)
+	if node.HaveNodeAnnouncement && !node.LastUpdate.Before(timestamp) {
+		return false
+	}
+
+	d.pendingNodeAnns.add(nMsg)
+
+	log.Debugf("Deferring validation of node announcement for node=%x "+
+		"until first use", msg.NodeID.SerializeCompressed())
+
+	return true
+}
+
+// UseNode hands the pending node announcement of the passed node over to be
+// validated and applied, if its validation was deferred due to
+// LazyNodeAnnValidation. It's intended to be called once the node is first
+// used for path finding. As path finding shouldn't wait on the gossiper, the
+// announcement is handed over asynchronously, and a failure to validate it is
+// recorded as a rejection, like that of any other announcement.
+func (d *AuthenticatedGossiper) UseNode(node *btcec.PublicKey) {
+	d.useNode(node)
+}
+
+// useNode asynchronously hands the pending node announcement of the passed
+// node over to the networkHandler, returning the channel over which the
+// result of its processing is delivered. If the node has no pending
+// announcement, then nil is returned.
+func (d *AuthenticatedGossiper) useNode(node *btcec.PublicKey) chan error {
+	if d.pendingNodeAnns == nil || atomic.LoadUint32(&d.stopped) == 1 {
+		return nil
+	}
+
+	var nodeKey [33]byte
+	copy(nodeKey[:], node.SerializeCompressed())
+
+	pending := d.pendingNodeAnns.take(nodeKey)
+	if pending == nil {
+		return nil
+	}
+
+	// The announcement is re-processed from scratch, but this time it
+	// won't be deferred once more.
+	nMsg := &networkMsg{
+		msg:             pending.msg,
+		isRemote:        true,
+		peer:            pending.peer,
+		deferredNodeAnn: true,
+		err:             make(chan error, 1),
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		d.enqueueNetworkMsg(nMsg)
+	}()
+
+	return nMsg.err
+}
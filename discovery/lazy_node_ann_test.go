@@ -0,0 +1,98 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/channeldb"
+)
+
+// TestLazyNodeAnnValidation ensures that in lazy validation mode, a remote
+// node announcement is held back without being validated, and is only
+// validated and applied to the graph once its node is first used.
+func TestLazyNodeAnnValidation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.LazyNodeAnnValidation = true
+	})
+	defer cleanup()
+
+	// The node must already be known through one of its channels for its
+	// announcement to be deferred.
+	ctx.router.nodes = append(ctx.router.nodes, &channeldb.LightningNode{
+		PubKey: nodeKeyPub2,
+	})
+
+	// We'll first send an announcement which was modified after being
+	// signed. As its validation is deferred, it should be accepted, but
+	// not yet applied to the graph.
+	invalidAnn, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	invalidAnn.Timestamp++
+
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(invalidAnn, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("expected validation to be deferred, got: %v", err)
+	}
+	if len(ctx.router.nodes) != 1 {
+		t.Fatal("unvalidated node announcement was applied")
+	}
+
+	// useNode hands the pending announcement of the node over to the
+	// gossiper, and waits for its processing to complete.
+	useNode := func() error {
+		errChan := ctx.gossiper.useNode(nodeKeyPub2)
+		if errChan == nil {
+			return nil
+		}
+
+		select {
+		case err := <-errChan:
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("pending node announcement wasn't processed")
+		}
+
+		return nil
+	}
+
+	// Once the node is first used, the announcement should be validated
+	// and rejected.
+	if err := useNode(); err == nil {
+		t.Fatal("invalid node announcement was accepted on first use")
+	}
+	if len(ctx.router.nodes) != 1 {
+		t.Fatal("invalid node announcement was applied")
+	}
+
+	// A valid announcement should also be deferred, and then applied once
+	// the node is first used.
+	validAnn, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(validAnn, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("expected validation to be deferred, got: %v", err)
+	}
+	if len(ctx.router.nodes) != 1 {
+		t.Fatal("unvalidated node announcement was applied")
+	}
+
+	if err := useNode(); err != nil {
+		t.Fatalf("unable to use node: %v", err)
+	}
+	if len(ctx.router.nodes) != 2 ||
+		!ctx.router.nodes[1].HaveNodeAnnouncement {
+
+		t.Fatal("valid node announcement wasn't applied on first use")
+	}
+
+	// Using the node once more is a no-op, as nothing is pending.
+	if ctx.gossiper.useNode(nodeKeyPub2) != nil {
+		t.Fatal("node without pending announcement was used")
+	}
+}
@@ -1,6 +1,11 @@
 package discovery
 
-import "github.com/btcsuite/btclog"
+import (
+	"fmt"
+
+	"github.com/btcsuite/btclog"
+	"github.com/viacoin/lnd/lnwire"
+)
 
 // log is a logger that is initialized with no output filters.  This
 // means the package will not perform any logging by default until the caller
@@ -40,3 +45,42 @@ func (c logClosure) String() string {
 func newLogClosure(c func() string) logClosure {
 	return logClosure(c)
 }
+
+// messageSummary returns a concise, single line description of the passed
+// network message, which is suitable for logging at levels where full dumps
+// of the message would be too noisy.
+func messageSummary(nMsg *networkMsg) string {
+	origin := "local"
+	if nMsg.isRemote {
+		origin = "remote"
+	}
+
+	var details string
+	switch msg := nMsg.msg.(type) {
+	case *lnwire.ChannelAnnouncement:
+		details = fmt.Sprintf("short_chan_id=%v",
+			msg.ShortChannelID.ToUint64())
+
+	case *lnwire.ChannelUpdate:
+		details = fmt.Sprintf("short_chan_id=%v, flags=%v, "+
+			"timestamp=%v", msg.ShortChannelID.ToUint64(),
+			msg.Flags, msg.Timestamp)
+
+	case *lnwire.NodeAnnouncement:
+		details = fmt.Sprintf("node=%x, timestamp=%v",
+			msg.NodeID.SerializeCompressed(), msg.Timestamp)
+
+	case *lnwire.AnnounceSignatures:
+		details = fmt.Sprintf("short_chan_id=%v",
+			msg.ShortChannelID.ToUint64())
+	}
+
+	summary := fmt.Sprintf("%v %v(%v)", origin, nMsg.msg.MsgType(),
+		details)
+	if nMsg.peer != nil {
+		summary += fmt.Sprintf(" from peer=%x",
+			nMsg.peer.SerializeCompressed())
+	}
+
+	return summary
+}
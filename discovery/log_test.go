@@ -0,0 +1,88 @@
+package discovery
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btclog"
+)
+
+// syncBuffer is a bytes.Buffer which is safe for concurrent access, allowing
+// tests to inspect the log output of the gossiper.
+type syncBuffer struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
+
+// TestMessageDumpLogLevel checks that full dumps of the announcements
+// processed by the gossiper are only logged at the trace level.
+//
+// NOTE: This test replaces the package logger, so it MUST NOT be run in
+// parallel.
+func TestMessageDumpLogLevel(t *testing.T) {
+	prevLog := log
+	defer func() {
+		UseLogger(prevLog)
+	}()
+
+	// processWithLevel processes a node announcement with the package
+	// logger at the given level, returning the resulting log output.
+	processWithLevel := func(level btclog.Level) string {
+		var output syncBuffer
+		logger := btclog.NewBackend(&output).Logger("DISC")
+		logger.SetLevel(level)
+		UseLogger(logger)
+
+		ctx, cleanup := newTestCtx(t, 0)
+		defer cleanup()
+
+		na, err := createNodeAnnouncement(nodeKeyPriv2)
+		if err != nil {
+			t.Fatalf("can't create node announcement: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			na, nodeKeyPub2,
+		):
+			if err != nil {
+				t.Fatalf("can't process remote announcement: %v",
+					err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("node announcement wasn't processed")
+		}
+
+		return output.String()
+	}
+
+	const dumpMarker = "(*lnwire.NodeAnnouncement)"
+
+	if output := processWithLevel(btclog.LevelInfo); strings.Contains(
+		output, dumpMarker) {
+
+		t.Fatalf("full message dump logged at info level: %v", output)
+	}
+
+	if output := processWithLevel(btclog.LevelTrace); !strings.Contains(
+		output, dumpMarker) {
+
+		t.Fatalf("full message dump not logged at trace level: %v",
+			output)
+	}
+}
@@ -0,0 +1,239 @@
+package discovery
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// batchSizeBuckets are the upper bounds of the buckets of the histogram of
+// the sizes of the broadcast announcement batches.
+var batchSizeBuckets = []uint64{1, 10, 100, 1000}
+
+// gossipMetrics tracks the counters of the gossiper which are exposed in the
+// Prometheus exposition format through the MetricsHandler.
+type gossipMetrics struct {
+	// processed is the number of processed announcements of each type.
+	processed map[lnwire.MessageType]uint64
+
+	// rejected is the number of rejected announcements of each type.
+	rejected map[lnwire.MessageType]uint64
+
+	// batchSizeCounts is the number of broadcast batches within each
+	// bucket of batchSizeBuckets, with an additional final entry counting
+	// the batches beyond the last bound.
+	batchSizeCounts []uint64
+
+	// numBatches and batchSizeSum are the total number of broadcast
+	// batches and the total number of announcements within them.
+	numBatches   uint64
+	batchSizeSum uint64
+
+	// prematureDepth is the number of announcements currently held back
+	// as premature.
+	prematureDepth int
+
+	sync.Mutex
+}
+
+// newGossipMetrics returns a new gossipMetrics with all counters at zero.
+func newGossipMetrics() *gossipMetrics {
+	return &gossipMetrics{
+		processed:       make(map[lnwire.MessageType]uint64),
+		rejected:        make(map[lnwire.MessageType]uint64),
+		batchSizeCounts: make([]uint64, len(batchSizeBuckets)+1),
+	}
+}
+
+// recordProcessed counts a processed announcement of the passed type.
+func (m *gossipMetrics) recordProcessed(msgType lnwire.MessageType) {
+	m.Lock()
+	m.processed[msgType]++
+	m.Unlock()
+}
+
+// recordRejected counts a rejected announcement of the passed type.
+func (m *gossipMetrics) recordRejected(msgType lnwire.MessageType) {
+	m.Lock()
+	m.rejected[msgType]++
+	m.Unlock()
+}
+
+// recordBatch adds the size of a broadcast batch to the batch size histogram.
+func (m *gossipMetrics) recordBatch(size int) {
+	m.Lock()
+	defer m.Unlock()
+
+	bucket := len(batchSizeBuckets)
+	for i, bound := range batchSizeBuckets {
+		if uint64(size) <= bound {
+			bucket = i
+			break
+		}
+	}
+
+	m.batchSizeCounts[bucket]++
+	m.numBatches++
+	m.batchSizeSum += uint64(size)
+}
+
+// setPrematureDepth sets the number of announcements held back as premature.
+func (m *gossipMetrics) setPrematureDepth(depth int) {
+	m.Lock()
+	m.prematureDepth = depth
+	m.Unlock()
+}
+
+// writeCounters writes a counter per message type of the passed counts to
+// the buffer, ordered by message type so that the output is stable.
+func writeCounters(b *bytes.Buffer, name, help string,
+	counts map[lnwire.MessageType]uint64) {
+
+	fmt.Fprintf(b, "# HELP %v %v\n", name, help)
+	fmt.Fprintf(b, "# TYPE %v counter\n", name)
+
+	msgTypes := make([]lnwire.MessageType, 0, len(counts))
+	for msgType := range counts {
+		msgTypes = append(msgTypes, msgType)
+	}
+	sort.Slice(msgTypes, func(i, j int) bool {
+		return msgTypes[i] < msgTypes[j]
+	})
+
+	for _, msgType := range msgTypes {
+		fmt.Fprintf(b, "%v{type=%q} %v\n", name, msgType,
+			counts[msgType])
+	}
+}
+
+// write writes all metrics, along with the passed number of waiting proofs,
+// to the buffer in the Prometheus text exposition format.
+func (m *gossipMetrics) write(b *bytes.Buffer, numWaitingProofs int) {
+	m.Lock()
+	defer m.Unlock()
+
+	writeCounters(b, "lnd_gossip_messages_processed_total",
+		"Number of processed announcements.", m.processed)
+	writeCounters(b, "lnd_gossip_messages_rejected_total",
+		"Number of rejected announcements.", m.rejected)
+
+	// The histogram buckets are cumulative, so each bucket also counts
+	// the batches of all preceding buckets.
+	b.WriteString("# HELP lnd_gossip_batch_size Number of announcements " +
+		"within each broadcast batch.\n")
+	b.WriteString("# TYPE lnd_gossip_batch_size histogram\n")
+	var cumulative uint64
+	for i, bound := range batchSizeBuckets {
+		cumulative += m.batchSizeCounts[i]
+		fmt.Fprintf(b, "lnd_gossip_batch_size_bucket{le=\"%v\"} %v\n",
+			bound, cumulative)
+	}
+	fmt.Fprintf(b, "lnd_gossip_batch_size_bucket{le=\"+Inf\"} %v\n",
+		m.numBatches)
+	fmt.Fprintf(b, "lnd_gossip_batch_size_sum %v\n", m.batchSizeSum)
+	fmt.Fprintf(b, "lnd_gossip_batch_size_count %v\n", m.numBatches)
+
+	b.WriteString("# HELP lnd_gossip_premature_announcements Number of " +
+		"announcements held back until their block is known.\n")
+	b.WriteString("# TYPE lnd_gossip_premature_announcements gauge\n")
+	fmt.Fprintf(b, "lnd_gossip_premature_announcements %v\n",
+		m.prematureDepth)
+
+	b.WriteString("# HELP lnd_gossip_waiting_proofs Number of channel " +
+		"proofs waiting for the counterparty's half.\n")
+	b.WriteString("# TYPE lnd_gossip_waiting_proofs gauge\n")
+	fmt.Fprintf(b, "lnd_gossip_waiting_proofs %v\n", numWaitingProofs)
+}
+
+// writeLatencyHistograms writes a histogram per message type of the passed
+// latency histograms to the buffer, with all latencies given in seconds.
+func writeLatencyHistograms(b *bytes.Buffer, name, help string,
+	histograms map[lnwire.MessageType]LatencyHistogram) {
+
+	fmt.Fprintf(b, "# HELP %v %v\n", name, help)
+	fmt.Fprintf(b, "# TYPE %v histogram\n", name)
+
+	msgTypes := make([]lnwire.MessageType, 0, len(histograms))
+	for msgType := range histograms {
+		msgTypes = append(msgTypes, msgType)
+	}
+	sort.Slice(msgTypes, func(i, j int) bool {
+		return msgTypes[i] < msgTypes[j]
+	})
+
+	for _, msgType := range msgTypes {
+		h := histograms[msgType]
+
+		var cumulative uint64
+		for i, bound := range h.Buckets {
+			cumulative += h.Counts[i]
+			fmt.Fprintf(b, "%v_bucket{type=%q,le=\"%v\"} %v\n",
+				name, msgType, bound.Seconds(), cumulative)
+		}
+		fmt.Fprintf(b, "%v_bucket{type=%q,le=\"+Inf\"} %v\n", name,
+			msgType, h.NumSamples)
+		fmt.Fprintf(b, "%v_sum{type=%q} %v\n", name, msgType,
+			h.Total.Seconds())
+		fmt.Fprintf(b, "%v_count{type=%q} %v\n", name, msgType,
+			h.NumSamples)
+	}
+}
+
+// writeStats writes the passed stats of the gossiper to the buffer in the
+// Prometheus text exposition format.
+func writeStats(b *bytes.Buffer, stats Stats) {
+	writeLatencyHistograms(b, "lnd_gossip_processing_seconds",
+		"Time taken to validate and apply announcements.",
+		stats.ProcessingLatency)
+	writeLatencyHistograms(b, "lnd_gossip_queue_wait_seconds",
+		"Time announcements waited to be processed.", stats.QueueWait)
+
+	b.WriteString("# HELP lnd_gossip_queued_announcements Number of " +
+		"announcements waiting to be processed.\n")
+	b.WriteString("# TYPE lnd_gossip_queued_announcements gauge\n")
+	fmt.Fprintf(b, "lnd_gossip_queued_announcements %v\n", stats.NumQueued)
+}
+
+// updatePrematureDepth refreshes the premature announcement gauge from the
+// current number of premature announcements.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) updatePrematureDepth() {
+	depth := d.numPremature
+	if d.prematureQueue != nil {
+		depth += d.prematureQueue.Len()
+	}
+	d.metrics.setPrematureDepth(depth)
+}
+
+// MetricsHandler returns an http.Handler which serves the counters of the
+// gossiper in the Prometheus text exposition format. The metrics include the
+// number of processed and rejected announcements by type, the sizes of the
+// broadcast batches, the number of premature announcements and the number of
+// waiting channel proofs, along with the latency histograms and queue depth
+// reported by Stats.
+func (d *AuthenticatedGossiper) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var numWaitingProofs int
+		err := d.waitingProofs.ForAll(func(*channeldb.WaitingProof) error {
+			numWaitingProofs++
+			return nil
+		})
+		if err != nil && err != channeldb.ErrWaitingProofNotFound {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var b bytes.Buffer
+		d.metrics.write(&b, numWaitingProofs)
+		writeStats(&b, d.Stats())
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write(b.Bytes())
+	})
+}
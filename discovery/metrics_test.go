@@ -0,0 +1,176 @@
+package discovery
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestMetricsHandler ensures that the metrics handler exposes the counters of
+// the gossiper in the Prometheus exposition format, and that they reflect the
+// processed announcements.
+func TestMetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	prematureAnn, err := createRemoteChannelAnnouncement(5)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	// The premature announcement is held back without a response, so
+	// we'll only know it has been processed once the announcements
+	// following it have been.
+	ctx.gossiper.ProcessRemoteAnnouncement(prematureAnn, nodeKeyPub2)
+
+	// The first update references a channel which isn't known yet, so it
+	// should be rejected.
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub2):
+		if err == nil {
+			t.Fatal("update for unknown channel was accepted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("update wasn't processed")
+	}
+
+	for _, msg := range []lnwire.Message{ca, ua} {
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			msg, nodeKeyPub2,
+		):
+			if err != nil {
+				t.Fatalf("can't process %T: %v", msg, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%T wasn't processed", msg)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	ctx.gossiper.MetricsHandler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %v", rec.Code)
+	}
+
+	body := rec.Body.String()
+	expectedLines := []string{
+		"# TYPE lnd_gossip_messages_processed_total counter",
+		`lnd_gossip_messages_processed_total{type="ChannelAnnouncement"} 2`,
+		`lnd_gossip_messages_processed_total{type="ChannelUpdate"} 2`,
+		"# TYPE lnd_gossip_messages_rejected_total counter",
+		`lnd_gossip_messages_rejected_total{type="ChannelUpdate"} 1`,
+		"# TYPE lnd_gossip_batch_size histogram",
+		"# TYPE lnd_gossip_premature_announcements gauge",
+		"lnd_gossip_premature_announcements 1",
+		"# TYPE lnd_gossip_waiting_proofs gauge",
+		"lnd_gossip_waiting_proofs 0",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(body, line+"\n") {
+			t.Fatalf("metrics don't contain %q:\n%v", line, body)
+		}
+	}
+}
+
+// TestMetricsHandlerStats ensures that the latency histograms and queue depth
+// reported by Stats are exposed through the MetricsHandler, and that they're
+// updated as announcements are processed.
+func TestMetricsHandlerStats(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	scrape := func() string {
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		rec := httptest.NewRecorder()
+		ctx.gossiper.MetricsHandler().ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("unexpected status code: %v", rec.Code)
+		}
+		return rec.Body.String()
+	}
+
+	// Before any announcement has been processed, only the metric names
+	// should be exposed.
+	body := scrape()
+	expectedLines := []string{
+		"# TYPE lnd_gossip_processing_seconds histogram",
+		"# TYPE lnd_gossip_queue_wait_seconds histogram",
+		"# TYPE lnd_gossip_queued_announcements gauge",
+		"lnd_gossip_queued_announcements 0",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(body, line+"\n") {
+			t.Fatalf("metrics don't contain %q:\n%v", line, body)
+		}
+	}
+	if strings.Contains(body, "lnd_gossip_processing_seconds_count") {
+		t.Fatalf("metrics contain samples before processing:\n%v",
+			body)
+	}
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	for i, msg := range []lnwire.Message{ca, ua} {
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			msg, nodeKeyPub2,
+		):
+			if err != nil {
+				t.Fatalf("can't process %T: %v", msg, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%T wasn't processed", msg)
+		}
+
+		// The counters of the channel announcement should have been
+		// incremented once it's processed, and remain unchanged as the
+		// update is processed.
+		body := scrape()
+		expectedLines := []string{
+			`lnd_gossip_messages_processed_total{type="ChannelAnnouncement"} 1`,
+			`lnd_gossip_processing_seconds_count{type="ChannelAnnouncement"} 1`,
+			`lnd_gossip_processing_seconds_bucket{type="ChannelAnnouncement",le="+Inf"} 1`,
+			`lnd_gossip_queue_wait_seconds_count{type="ChannelAnnouncement"} 1`,
+		}
+		if i == 1 {
+			expectedLines = append(expectedLines,
+				`lnd_gossip_messages_processed_total{type="ChannelUpdate"} 1`,
+				`lnd_gossip_processing_seconds_count{type="ChannelUpdate"} 1`,
+				`lnd_gossip_queue_wait_seconds_count{type="ChannelUpdate"} 1`,
+			)
+		}
+		for _, line := range expectedLines {
+			if !strings.Contains(body, line+"\n") {
+				t.Fatalf("metrics don't contain %q after "+
+					"processing %T:\n%v", line, msg, body)
+			}
+		}
+	}
+}
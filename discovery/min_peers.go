@@ -0,0 +1,26 @@
+package discovery
+
+// enoughPeersForBroadcast returns true if we're connected to at least
+// MinPeersForBroadcast peers, such that our broadcasts propagate widely. Once
+// the threshold has been met, it remains met for the lifetime of the
+// gossiper, so a temporary drop in peers doesn't stall our broadcasts.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) enoughPeersForBroadcast() bool {
+	if d.cfg.MinPeersForBroadcast == 0 || d.minPeersReached {
+		return true
+	}
+
+	numPeers := d.cfg.NumConnectedPeers()
+	if numPeers < d.cfg.MinPeersForBroadcast {
+		log.Tracef("Holding back broadcasts, connected to %v of %v "+
+			"peers", numPeers, d.cfg.MinPeersForBroadcast)
+		return false
+	}
+
+	log.Infof("Connected to %v peers, releasing held back broadcasts",
+		numPeers)
+	d.minPeersReached = true
+
+	return true
+}
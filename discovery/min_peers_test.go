@@ -0,0 +1,62 @@
+package discovery
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestMinPeersForBroadcast ensures that batches of announcements are held back
+// until we're connected to the minimum number of peers, and broadcast once
+// that threshold is met.
+func TestMinPeersForBroadcast(t *testing.T) {
+	t.Parallel()
+
+	var numPeers int32 = 1
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.MinPeersForBroadcast = 2
+		cfg.NumConnectedPeers = func() int {
+			return int(atomic.LoadInt32(&numPeers))
+		}
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("can't process channel announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel announcement wasn't processed")
+	}
+
+	// As we're only connected to a single peer, the announcement should
+	// be held back.
+	select {
+	case <-ctx.broadcastedMessage:
+		t.Fatal("announcement was broadcast below the peer threshold")
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Once a second peer connects, the held back announcement should be
+	// broadcast.
+	atomic.StoreInt32(&numPeers, 2)
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		if _, ok := msg.(*lnwire.ChannelAnnouncement); !ok {
+			t.Fatalf("expected channel announcement to be "+
+				"broadcast, got %T", msg)
+		}
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("announcement wasn't broadcast once the peer " +
+			"threshold was met")
+	}
+}
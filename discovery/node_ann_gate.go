@@ -0,0 +1,69 @@
+package discovery
+
+import (
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// numAnnouncedChannels returns the number of our own channels within the
+// graph which have been announced to the network, meaning that their
+// authentication proof is known.
+func (d *AuthenticatedGossiper) numAnnouncedChannels() (int, error) {
+	var numChans int
+	err := d.cfg.Router.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		if info.AuthProof == nil {
+			return nil
+		}
+
+		if info.NodeKey1.IsEqual(d.selfKey) ||
+			info.NodeKey2.IsEqual(d.selfKey) {
+
+			numChans++
+		}
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return 0, errors.Errorf("unable to count announced channels: "+
+			"%v", err)
+	}
+
+	return numChans, nil
+}
+
+// canAnnounceSelf returns true if we have at least MinChannelsToAnnounce
+// announced channels, such that our own node announcement may be broadcast.
+func (d *AuthenticatedGossiper) canAnnounceSelf() bool {
+	if d.cfg.MinChannelsToAnnounce <= 0 {
+		return true
+	}
+
+	numChans, err := d.numAnnouncedChannels()
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	return numChans >= d.cfg.MinChannelsToAnnounce
+}
+
+// releaseSelfNodeAnn returns our latest withheld node announcement, if any,
+// once we have enough announced channels for it to be broadcast.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) releaseSelfNodeAnn() []lnwire.Message {
+	if d.pendingSelfNodeAnn == nil || !d.canAnnounceSelf() {
+		return nil
+	}
+
+	log.Infof("Reached %v announced channels, broadcasting our node "+
+		"announcement", d.cfg.MinChannelsToAnnounce)
+
+	nodeAnn := d.pendingSelfNodeAnn
+	d.pendingSelfNodeAnn = nil
+
+	return []lnwire.Message{nodeAnn}
+}
@@ -0,0 +1,67 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMinChannelsToAnnounce ensures that our own node announcement is
+// withheld until we have the minimum number of announced channels, and
+// broadcast once the threshold is met.
+func TestMinChannelsToAnnounce(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.MinChannelsToAnnounce = 1
+	})
+	defer cleanup()
+
+	// Without any announced channels, our node announcement should be
+	// accepted, but not broadcast.
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	err = <-ctx.gossiper.ProcessLocalAnnouncement(na, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("can't process local announcement: %v", err)
+	}
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("broadcast %T without announced channels", msg)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// Once one of our channels is announced, the node announcement should
+	// be broadcast along with it.
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	var sawChanAnn, sawNodeAnn bool
+	for i := 0; i < 2; i++ {
+		select {
+		case msg := <-ctx.broadcastedMessage:
+			switch msg {
+			case ca:
+				sawChanAnn = true
+			case na:
+				sawNodeAnn = true
+			}
+		case <-time.After(2 * trickleDelay):
+			t.Fatal("announcements weren't broadcast")
+		}
+	}
+	if !sawChanAnn || !sawNodeAnn {
+		t.Fatalf("expected channel and node announcement, got "+
+			"chan_ann=%v, node_ann=%v", sawChanAnn, sawNodeAnn)
+	}
+}
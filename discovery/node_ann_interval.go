@@ -0,0 +1,56 @@
+package discovery
+
+import "time"
+
+// nodeAnnIntervals enforces a minimum interval between the node
+// announcements accepted for each node, regardless of the peer which sent
+// them. This prevents a node from spamming the network through us by
+// rapidly bumping the timestamp of its announcement.
+//
+// NOTE: This struct isn't safe for concurrent access.
+type nodeAnnIntervals struct {
+	// minInterval is the minimum interval between two accepted node
+	// announcements of the same node.
+	minInterval time.Duration
+
+	// lastAccepted tracks the time at which a node announcement was last
+	// accepted for each node, keyed by its compressed public key.
+	lastAccepted map[[33]byte]time.Time
+
+	// lastPrune is the time at which expired entries were last removed
+	// from lastAccepted.
+	lastPrune time.Time
+}
+
+// newNodeAnnIntervals returns a new nodeAnnIntervals which enforces the
+// passed minimum interval.
+func newNodeAnnIntervals(minInterval time.Duration) *nodeAnnIntervals {
+	return &nodeAnnIntervals{
+		minInterval:  minInterval,
+		lastAccepted: make(map[[33]byte]time.Time),
+	}
+}
+
+// allow returns true if a node announcement of the passed node may be
+// accepted at the passed time.
+func (n *nodeAnnIntervals) allow(node [33]byte, now time.Time) bool {
+	last, ok := n.lastAccepted[node]
+	return !ok || now.Sub(last) >= n.minInterval
+}
+
+// accepted records that a node announcement of the passed node has been
+// accepted at the passed time. Once every interval, the entries of nodes
+// whose interval has passed are removed, which bounds the size of the
+// tracked set.
+func (n *nodeAnnIntervals) accepted(node [33]byte, now time.Time) {
+	if now.Sub(n.lastPrune) >= n.minInterval {
+		for pub, last := range n.lastAccepted {
+			if now.Sub(last) >= n.minInterval {
+				delete(n.lastAccepted, pub)
+			}
+		}
+		n.lastPrune = now
+	}
+
+	n.lastAccepted[node] = now
+}
@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestNodeAnnMinInterval ensures that at most one node announcement of each
+// node is accepted per NodeAnnMinInterval, even if the following ones are
+// newer.
+func TestNodeAnnMinInterval(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	ctx.gossiper.Stop()
+
+	const minInterval = time.Hour
+	cfg := *ctx.gossiper.cfg
+	cfg.Notifier = newMockNotifier()
+	cfg.NodeAnnMinInterval = minInterval
+
+	gossiper, err := New(cfg, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("unable to create gossiper: %v", err)
+	}
+
+	now := time.Now()
+	gossiper.now = func() time.Time {
+		return now
+	}
+
+	if err := gossiper.Start(); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+	defer gossiper.Stop()
+
+	processAnn := func(msg lnwire.Message) error {
+		select {
+		case err := <-gossiper.ProcessRemoteAnnouncement(
+			msg, nodeKeyPub2,
+		):
+			return err
+		case <-time.After(time.Second):
+			t.Fatalf("%T wasn't processed", msg)
+		}
+
+		return nil
+	}
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	if err := processAnn(ca); err != nil {
+		t.Fatalf("can't process channel announcement: %v", err)
+	}
+
+	na1, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	na2, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	na2.Timestamp = na1.Timestamp + 1
+	signer := mockSigner{nodeKeyPriv2}
+	na2.Signature, err = SignAnnouncement(&signer, nodeKeyPub2, na2)
+	if err != nil {
+		t.Fatalf("unable to sign node announcement: %v", err)
+	}
+
+	if err := processAnn(na1); err != nil {
+		t.Fatalf("can't process node announcement: %v", err)
+	}
+
+	// The newer announcement arrives within the interval, so it should
+	// be rejected.
+	err = processAnn(na2)
+	if err == nil || !strings.Contains(err.Error(), "already accepted") {
+		t.Fatalf("expected node announcement within interval to be "+
+			"rejected, got: %v", err)
+	}
+
+	// Once the interval has passed, it should be accepted.
+	now = now.Add(minInterval)
+	if err := processAnn(na2); err != nil {
+		t.Fatalf("can't process node announcement after interval: %v",
+			err)
+	}
+}
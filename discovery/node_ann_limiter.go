@@ -0,0 +1,55 @@
+package discovery
+
+import "time"
+
+// nodeAnnSource identifies the pairing of a peer and a node whose node
+// announcements are rate limited together.
+type nodeAnnSource struct {
+	peer [33]byte
+	node [33]byte
+}
+
+// nodeAnnLimiter limits the number of node announcements processed for each
+// node on behalf of each peer within a fixed window of time. This prevents a
+// peer which floods us with announcements for a node, such as by rapidly
+// changing its alias, from crowding out the processing of channel data.
+//
+// NOTE: This struct isn't safe for concurrent access.
+type nodeAnnLimiter struct {
+	// windowStart is the start of the current window. All counts are
+	// reset once the window has passed.
+	windowStart time.Time
+
+	// counts tracks the number of node announcements processed within the
+	// current window for each source.
+	counts map[nodeAnnSource]int
+}
+
+// newNodeAnnLimiter returns a new, empty nodeAnnLimiter.
+func newNodeAnnLimiter() *nodeAnnLimiter {
+	return &nodeAnnLimiter{
+		counts: make(map[nodeAnnSource]int),
+	}
+}
+
+// allow returns true if another node announcement for the source may be
+// processed at the passed time, given that at most limit announcements are
+// allowed per source within each window of the passed interval. An allowed
+// announcement is counted towards the limit of its source.
+func (l *nodeAnnLimiter) allow(src nodeAnnSource, now time.Time, limit int,
+	interval time.Duration) bool {
+
+	// Once the current window has passed, we'll start a new one with all
+	// counts reset, which also bounds the size of the counts map.
+	if now.Sub(l.windowStart) >= interval {
+		l.windowStart = now
+		l.counts = make(map[nodeAnnSource]int)
+	}
+
+	if l.counts[src] >= limit {
+		return false
+	}
+	l.counts[src]++
+
+	return true
+}
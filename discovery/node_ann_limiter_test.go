@@ -0,0 +1,104 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestNodeAnnRateLimit ensures that node announcements for a single node
+// which are sent by a single peer are throttled once they exceed the
+// configured rate, while channel announcements and updates from the same
+// peer, and node announcements from other peers, are still processed.
+func TestNodeAnnRateLimit(t *testing.T) {
+	t.Parallel()
+
+	const (
+		rateLimit    = 3
+		rateInterval = time.Hour
+	)
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.NodeAnnRateLimit = rateLimit
+		cfg.NodeAnnRateInterval = rateInterval
+	})
+	defer cleanup()
+
+	now := time.Now()
+	ctx.gossiper.now = func() time.Time {
+		return now
+	}
+
+	processAnn := func(msg lnwire.Message, peer *btcec.PublicKey) error {
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(msg, peer):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("announcement wasn't processed")
+		}
+
+		return nil
+	}
+
+	// We'll flood the gossiper with announcements for the same node from
+	// a single peer. Only the first rateLimit of them should be processed.
+	const numAnns = 10
+	for i := 0; i < numAnns; i++ {
+		na, err := createNodeAnnouncement(nodeKeyPriv2)
+		if err != nil {
+			t.Fatalf("can't create node announcement: %v", err)
+		}
+
+		err = processAnn(na, nodeKeyPub2)
+		switch {
+		case i < rateLimit && err != nil:
+			t.Fatalf("node announcement #%v rejected: %v", i, err)
+		case i >= rateLimit && err == nil:
+			t.Fatalf("node announcement #%v wasn't throttled", i)
+		}
+	}
+	if len(ctx.router.nodes) != rateLimit {
+		t.Fatalf("expected %v nodes to be added, got %v", rateLimit,
+			len(ctx.router.nodes))
+	}
+
+	// Channel announcements and updates from the throttled peer should
+	// still flow.
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	if err := processAnn(ca, nodeKeyPub2); err != nil {
+		t.Fatalf("channel announcement rejected: %v", err)
+	}
+
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	if err := processAnn(ua, nodeKeyPub2); err != nil {
+		t.Fatalf("channel update rejected: %v", err)
+	}
+	if len(ctx.router.edges) != 1 {
+		t.Fatalf("channel update wasn't applied")
+	}
+
+	// A different peer relaying an announcement for the same node isn't
+	// subject to the limit of the throttled peer.
+	na, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	if err := processAnn(na, nodeKeyPub1); err != nil {
+		t.Fatalf("node announcement from other peer rejected: %v", err)
+	}
+
+	// Once the interval has passed, the throttled peer should be able to
+	// deliver announcements for the node again.
+	now = now.Add(rateInterval)
+	if err := processAnn(na, nodeKeyPub2); err != nil {
+		t.Fatalf("node announcement rejected after interval: %v", err)
+	}
+}
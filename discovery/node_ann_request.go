@@ -0,0 +1,53 @@
+package discovery
+
+import (
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+)
+
+// requestUnknownNodeAnns requests the node announcement of the target node
+// from the passed peer through the RequestNodeAnn callback, if we haven't
+// received an announcement for the node yet. The node announcement of each
+// node is requested at most once per NodeAnnRequestInterval.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) requestUnknownNodeAnns(peer,
+	node *btcec.PublicKey) {
+
+	if d.cfg.RequestNodeAnn == nil || node.IsEqual(d.selfKey) {
+		return
+	}
+
+	known, err := d.cfg.Router.FetchLightningNode(node)
+	switch {
+	case err == channeldb.ErrGraphNodeNotFound:
+	case err != nil:
+		log.Errorf("Unable to fetch node %x: %v",
+			node.SerializeCompressed(), err)
+		return
+	case known.HaveNodeAnnouncement:
+		return
+	}
+
+	// We'll only request the announcement if we haven't recently done so
+	// already, such that a burst of channel announcements referencing the
+	// node doesn't result in a burst of requests. The peer of the source
+	// is left blank, as the limit applies to the node regardless of the
+	// peer we'd request its announcement from.
+	var src nodeAnnSource
+	copy(src.node[:], node.SerializeCompressed())
+	if !d.nodeAnnRequests.allow(src, d.now(), 1,
+		d.cfg.NodeAnnRequestInterval) {
+
+		return
+	}
+
+	log.Debugf("Requesting node announcement for node=%x from peer=%x",
+		src.node, peer.SerializeCompressed())
+
+	if err := d.cfg.RequestNodeAnn(peer, node); err != nil {
+		log.Errorf("Unable to request node announcement for node=%x "+
+			"from peer=%x: %v", src.node, peer.SerializeCompressed(),
+			err)
+	}
+}
@@ -0,0 +1,116 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestRequestUnknownNodeAnn ensures that a channel announcement referencing a
+// node we haven't received a node announcement for triggers a request for
+// the node announcement to the sender, and that such requests are rate
+// limited.
+func TestRequestUnknownNodeAnn(t *testing.T) {
+	t.Parallel()
+
+	type nodeAnnRequest struct {
+		peer *btcec.PublicKey
+		node *btcec.PublicKey
+	}
+	requests := make(chan nodeAnnRequest, 10)
+
+	const requestInterval = time.Hour
+
+	ctx, cleanup := newTestCtx(t, 2, func(cfg *Config) {
+		cfg.NodeAnnRequestInterval = requestInterval
+		cfg.RequestNodeAnn = func(peer,
+			node *btcec.PublicKey) error {
+
+			requests <- nodeAnnRequest{peer, node}
+			return nil
+		}
+	})
+	defer cleanup()
+
+	now := time.Now()
+	ctx.gossiper.now = func() time.Time {
+		return now
+	}
+
+	processChanAnn := func(height uint32) {
+		ca, err := createRemoteChannelAnnouncement(height)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			ca, nodeKeyPub2,
+		):
+			if err != nil {
+				t.Fatalf("channel announcement rejected: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("channel announcement wasn't processed")
+		}
+	}
+
+	// The channel announcement references our own node along with a node
+	// we don't know of yet, so only the announcement of the unknown node
+	// should be requested from the sender.
+	processChanAnn(0)
+
+	select {
+	case req := <-requests:
+		if !req.peer.IsEqual(nodeKeyPub2) {
+			t.Fatal("node announcement requested from wrong peer")
+		}
+		if !req.node.IsEqual(nodeKeyPub2) {
+			t.Fatal("node announcement requested for wrong node")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't requested")
+	}
+
+	select {
+	case req := <-requests:
+		t.Fatalf("unexpected request for node %x",
+			req.node.SerializeCompressed())
+	default:
+	}
+
+	// Another channel announcement referencing the unknown node shouldn't
+	// trigger another request within the request interval.
+	processChanAnn(1)
+
+	select {
+	case <-requests:
+		t.Fatal("node announcement request wasn't rate limited")
+	default:
+	}
+
+	// Once we've received the announcement of the node, its announcement
+	// shouldn't be requested anymore, even after the interval has passed.
+	na, err := createNodeAnnouncement(nodeKeyPriv2)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("node announcement rejected: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't processed")
+	}
+
+	now = now.Add(requestInterval)
+	processChanAnn(2)
+
+	select {
+	case <-requests:
+		t.Fatal("announcement of known node was requested")
+	default:
+	}
+}
@@ -0,0 +1,170 @@
+package discovery
+
+import (
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+	"github.com/viacoin/lnd/routing"
+)
+
+// nodeChanCounts tracks the number of channels of each node within the
+// graph. Along with the counts, the nodes of each counted channel are
+// tracked, such that the counts can be updated incrementally as channels are
+// added to and pruned from the graph, rather than being reloaded from it.
+type nodeChanCounts struct {
+	// counts is the number of channels of each node, keyed by the node's
+	// compressed public key. It's nil until loaded from the graph.
+	counts map[[33]byte]int
+
+	// chanNodes maps the ID of each counted channel to the compressed
+	// public keys of its nodes.
+	chanNodes map[uint64][2][33]byte
+
+	mu sync.Mutex
+}
+
+// load populates the counts from the channels within the passed graph, if
+// they aren't loaded already.
+func (n *nodeChanCounts) load(graph routing.ChannelGraphSource) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.counts != nil {
+		return nil
+	}
+
+	n.counts = make(map[[33]byte]int)
+	n.chanNodes = make(map[uint64][2][33]byte)
+	err := graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		n.addChannel(info.ChannelID, info.NodeKey1, info.NodeKey2)
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		n.counts = nil
+		n.chanNodes = nil
+		return err
+	}
+
+	return nil
+}
+
+// count returns the number of channels of the passed node.
+func (n *nodeChanCounts) count(node [33]byte) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.counts[node]
+}
+
+// add accounts the passed channel to both of its nodes. If the counts haven't
+// been loaded yet, then they'll include the channel once they are.
+func (n *nodeChanCounts) add(chanID uint64, node1, node2 *btcec.PublicKey) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.counts == nil {
+		return
+	}
+
+	n.addChannel(chanID, node1, node2)
+}
+
+// addChannel accounts the passed channel to both of its nodes, unless it's
+// counted already.
+//
+// NOTE: The mutex MUST be held when calling this method.
+func (n *nodeChanCounts) addChannel(chanID uint64, node1,
+	node2 *btcec.PublicKey) {
+
+	if _, ok := n.chanNodes[chanID]; ok {
+		return
+	}
+
+	var nodes [2][33]byte
+	copy(nodes[0][:], node1.SerializeCompressed())
+	copy(nodes[1][:], node2.SerializeCompressed())
+
+	n.chanNodes[chanID] = nodes
+	n.counts[nodes[0]]++
+	n.counts[nodes[1]]++
+}
+
+// remove stops accounting the passed channels to their nodes, as they've been
+// removed from the graph. Unknown channels are ignored.
+func (n *nodeChanCounts) remove(chanIDs ...uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, chanID := range chanIDs {
+		nodes, ok := n.chanNodes[chanID]
+		if !ok {
+			continue
+		}
+		delete(n.chanNodes, chanID)
+
+		for _, node := range nodes {
+			n.counts[node]--
+			if n.counts[node] == 0 {
+				delete(n.counts, node)
+			}
+		}
+	}
+}
+
+// ChannelsPruned notifies the gossiper of channels which have been pruned
+// from the graph, such as once they're closed, so that they no longer count
+// towards the MaxChannelsPerNode of their nodes.
+func (d *AuthenticatedGossiper) ChannelsPruned(chanIDs ...uint64) {
+	d.nodeChanCounts.remove(chanIDs...)
+}
+
+// checkMaxChannelsPerNode returns an error if either node of the announced
+// channel already has MaxChannelsPerNode channels within the graph. Our own
+// node isn't subject to the limit.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) checkMaxChannelsPerNode(
+	msg *lnwire.ChannelAnnouncement) error {
+
+	if d.cfg.MaxChannelsPerNode == 0 {
+		return nil
+	}
+
+	if err := d.nodeChanCounts.load(d.cfg.Router); err != nil {
+		return err
+	}
+
+	for _, node := range []*btcec.PublicKey{msg.NodeID1, msg.NodeID2} {
+		if node.IsEqual(d.selfKey) {
+			continue
+		}
+
+		var pub [33]byte
+		copy(pub[:], node.SerializeCompressed())
+
+		numChans := d.nodeChanCounts.count(pub)
+		if numChans >= d.cfg.MaxChannelsPerNode {
+			return errors.Errorf("node=%x already has %v channels, "+
+				"which is the maximum", pub, numChans)
+		}
+	}
+
+	return nil
+}
+
+// countNodeChannels accounts the channel of the passed announcement, which
+// has been added to the graph, to both of its nodes.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) countNodeChannels(
+	msg *lnwire.ChannelAnnouncement) {
+
+	d.nodeChanCounts.add(
+		msg.ShortChannelID.ToUint64(), msg.NodeID1, msg.NodeID2,
+	)
+}
@@ -0,0 +1,114 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestMaxChannelsPerNode ensures that once a node has the maximum number of
+// channels within the graph, announcements of further channels of the node
+// are rejected, while channels of other nodes are still accepted.
+func TestMaxChannelsPerNode(t *testing.T) {
+	t.Parallel()
+
+	const maxChannels = 3
+
+	ctx, cleanup := newTestCtx(t, 10, func(cfg *Config) {
+		cfg.MaxChannelsPerNode = maxChannels
+	})
+	defer cleanup()
+
+	abusiveNode, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create node key: %v", err)
+	}
+
+	processChanAnn := func(ca *lnwire.ChannelAnnouncement) error {
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			ca, nodeKeyPub2,
+		):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("channel announcement wasn't processed")
+		}
+
+		return nil
+	}
+
+	// We'll announce channels between the abusive node and a distinct
+	// node each. Only the first maxChannels of them should be accepted.
+	const numChans = maxChannels + 2
+	for i := uint32(0); i < numChans; i++ {
+		peerNode, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to create node key: %v", err)
+		}
+
+		ca, err := createChannelAnnouncementBetween(
+			i, abusiveNode, peerNode,
+		)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		err = processChanAnn(ca)
+		switch {
+		case i < maxChannels && err != nil:
+			t.Fatalf("channel announcement #%v rejected: %v", i,
+				err)
+		case i >= maxChannels && err == nil:
+			t.Fatalf("channel announcement #%v accepted beyond "+
+				"the maximum", i)
+		}
+	}
+	if len(ctx.router.infos) != maxChannels {
+		t.Fatalf("expected %v channels within the graph, got %v",
+			maxChannels, len(ctx.router.infos))
+	}
+
+	// A channel between other nodes should be unaffected.
+	ca, err := createChannelAnnouncementBetween(
+		numChans, nodeKeyPriv1, nodeKeyPriv2,
+	)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	if err := processChanAnn(ca); err != nil {
+		t.Fatalf("channel announcement of other nodes rejected: %v",
+			err)
+	}
+
+	// Once one of the channels of the abusive node is pruned from the
+	// graph, a further channel of the node should be accepted, but only
+	// a single one.
+	prunedChanID := lnwire.ShortChannelID{BlockHeight: 0}
+	ctx.gossiper.ChannelsPruned(prunedChanID.ToUint64())
+
+	for i := uint32(0); i < 2; i++ {
+		peerNode, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to create node key: %v", err)
+		}
+
+		ca, err := createChannelAnnouncementBetween(
+			numChans+1+i, abusiveNode, peerNode,
+		)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		err = processChanAnn(ca)
+		switch {
+		case i == 0 && err != nil:
+			t.Fatalf("channel announcement after prune rejected: "+
+				"%v", err)
+		case i == 1 && err == nil:
+			t.Fatal("channel announcement accepted beyond the " +
+				"maximum after prune")
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+// orphanUpdate is a remote channel update which references a channel that
+// wasn't known to us at the time the update was received.
+type orphanUpdate struct {
+	nMsg   *networkMsg
+	expiry time.Time
+}
+
+// orphanUpdateBuffer is a bounded buffer of orphan channel updates, keyed by
+// the short channel ID of the channel they reference. Each update is retained
+// until either the announcement of its channel arrives, or its TTL expires.
+//
+// NOTE: This struct isn't safe for concurrent access.
+type orphanUpdateBuffer struct {
+	// limit is the maximum number of updates within the buffer.
+	limit int
+
+	// ttl is the duration for which each update is retained.
+	ttl time.Duration
+
+	// numUpdates is the number of updates within the buffer.
+	numUpdates int
+
+	// updates maps a short channel ID to the updates referencing it, in
+	// the order they were received.
+	updates map[uint64][]orphanUpdate
+}
+
+// newOrphanUpdateBuffer returns a new, empty orphanUpdateBuffer.
+func newOrphanUpdateBuffer(limit int,
+	ttl time.Duration) *orphanUpdateBuffer {
+
+	return &orphanUpdateBuffer{
+		limit:   limit,
+		ttl:     ttl,
+		updates: make(map[uint64][]orphanUpdate),
+	}
+}
+
+// add buffers the passed update of the given channel at the passed time. If
+// the buffer is full even after all expired updates have been dropped, then
+// the update isn't buffered, and false is returned. The expired updates are
+// returned, such that the caller can reject them.
+func (b *orphanUpdateBuffer) add(chanID uint64, nMsg *networkMsg,
+	now time.Time) (bool, []*networkMsg) {
+
+	var expired []*networkMsg
+	if b.numUpdates >= b.limit {
+		expired = b.dropExpired(now)
+	}
+	if b.numUpdates >= b.limit {
+		return false, expired
+	}
+
+	b.updates[chanID] = append(b.updates[chanID], orphanUpdate{
+		nMsg:   nMsg,
+		expiry: now.Add(b.ttl),
+	})
+	b.numUpdates++
+
+	return true, expired
+}
+
+// take removes all updates of the given channel from the buffer. The updates
+// which are still live at the passed time are returned first, followed by
+// those which have expired.
+func (b *orphanUpdateBuffer) take(chanID uint64,
+	now time.Time) ([]*networkMsg, []*networkMsg) {
+
+	var live, expired []*networkMsg
+	for _, update := range b.updates[chanID] {
+		if now.Before(update.expiry) {
+			live = append(live, update.nMsg)
+		} else {
+			expired = append(expired, update.nMsg)
+		}
+	}
+
+	b.numUpdates -= len(b.updates[chanID])
+	delete(b.updates, chanID)
+
+	return live, expired
+}
+
+// chanIDs returns the short channel IDs of all channels referenced by the
+// updates within the buffer.
+func (b *orphanUpdateBuffer) chanIDs() []uint64 {
+	chanIDs := make([]uint64, 0, len(b.updates))
+	for chanID := range b.updates {
+		chanIDs = append(chanIDs, chanID)
+	}
+
+	return chanIDs
+}
+
+// dropExpired removes all updates which have expired at the passed time from
+// the buffer, and returns them.
+func (b *orphanUpdateBuffer) dropExpired(now time.Time) []*networkMsg {
+	var expired []*networkMsg
+	for chanID, updates := range b.updates {
+		var live []orphanUpdate
+		for _, update := range updates {
+			if now.Before(update.expiry) {
+				live = append(live, update)
+				continue
+			}
+
+			expired = append(expired, update.nMsg)
+			b.numUpdates--
+		}
+
+		if len(live) == 0 {
+			delete(b.updates, chanID)
+		} else {
+			b.updates[chanID] = live
+		}
+	}
+
+	return expired
+}
+
+// rejectExpiredOrphans rejects the passed orphan updates, whose channel
+// wasn't announced within the OrphanUpdateTTL.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) rejectExpiredOrphans(expired []*networkMsg) {
+	for _, nMsg := range expired {
+		err := errors.Errorf("channel of orphan update wasn't "+
+			"announced within %v", d.cfg.OrphanUpdateTTL)
+		log.Debug(err)
+		d.recordRejection(nMsg, RejectUnknownChannel, err)
+		nMsg.err <- err
+	}
+}
@@ -0,0 +1,80 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+)
+
+// TestOrphanUpdate ensures that a channel update delivered ahead of the
+// announcement of its channel is buffered, and applied once the announcement
+// arrives.
+func TestOrphanUpdate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	// We'll replace the gossiper of the context with one which buffers
+	// orphan updates.
+	ctx.gossiper.Stop()
+
+	cfg := *ctx.gossiper.cfg
+	cfg.OrphanUpdateLimit = 10
+	cfg.OrphanUpdateTTL = time.Minute
+
+	gossiper, err := New(cfg, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("unable to create gossiper: %v", err)
+	}
+	if err := gossiper.Start(); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+	defer gossiper.Stop()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	// As the channel of the update isn't known yet, it should be buffered
+	// rather than rejected.
+	updateErr := gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub2)
+	select {
+	case err := <-updateErr:
+		t.Fatalf("orphan update wasn't buffered: %v", err)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	chanID := ua.ShortChannelID.ToUint64()
+	if len(ctx.router.edges[chanID]) != 0 {
+		t.Fatal("orphan update was applied")
+	}
+
+	// Once the announcement of the channel arrives, the buffered update
+	// should be applied as well.
+	select {
+	case err := <-gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("can't process channel announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel announcement wasn't processed")
+	}
+
+	select {
+	case err := <-updateErr:
+		if err != nil {
+			t.Fatalf("orphan update was rejected: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("orphan update wasn't processed")
+	}
+
+	if len(ctx.router.edges[chanID]) != 1 {
+		t.Fatal("orphan update wasn't applied")
+	}
+}
@@ -0,0 +1,57 @@
+package discovery
+
+import (
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// loadClosedChannels loads the set of our own closed channels through
+// FetchClosedChannels. It's only loaded once as the gossiper starts, after
+// which further closures are added through ChannelClosed.
+func (d *AuthenticatedGossiper) loadClosedChannels() error {
+	chanIDs, err := d.cfg.FetchClosedChannels()
+	if err != nil {
+		return err
+	}
+
+	d.closedChansMtx.Lock()
+	defer d.closedChansMtx.Unlock()
+
+	d.closedChans = make(map[lnwire.ShortChannelID]struct{}, len(chanIDs))
+	for _, chanID := range chanIDs {
+		d.closedChans[chanID] = struct{}{}
+	}
+
+	return nil
+}
+
+// ChannelClosed notifies the gossiper that our own channel with the passed
+// short channel ID has been closed, such that remote announcements of the
+// channel are rejected from now on. It's a no-op unless FetchClosedChannels
+// is set.
+//
+// NOTE: This is safe to call concurrently with the processing of
+// announcements.
+func (d *AuthenticatedGossiper) ChannelClosed(chanID lnwire.ShortChannelID) {
+	d.closedChansMtx.Lock()
+	defer d.closedChansMtx.Unlock()
+
+	if d.closedChans != nil {
+		d.closedChans[chanID] = struct{}{}
+	}
+}
+
+// isOwnClosedChannel returns true if the passed channel announcement is for
+// one of our own channels which we've since closed.
+func (d *AuthenticatedGossiper) isOwnClosedChannel(
+	msg *lnwire.ChannelAnnouncement) bool {
+
+	if !msg.NodeID1.IsEqual(d.selfKey) && !msg.NodeID2.IsEqual(d.selfKey) {
+		return false
+	}
+
+	d.closedChansMtx.Lock()
+	defer d.closedChansMtx.Unlock()
+
+	_, ok := d.closedChans[msg.ShortChannelID]
+	return ok
+}
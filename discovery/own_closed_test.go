@@ -0,0 +1,68 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestOwnClosedChannelAnnouncement ensures that remote announcements of our
+// own channels which we've closed are rejected, while those of our open
+// channels are accepted.
+func TestOwnClosedChannelAnnouncement(t *testing.T) {
+	t.Parallel()
+
+	// The channel at height 0 is one of our closed channels.
+	ctx, cleanup := newTestCtx(t, 2, func(cfg *Config) {
+		cfg.FetchClosedChannels = func() ([]lnwire.ShortChannelID,
+			error) {
+
+			return []lnwire.ShortChannelID{{BlockHeight: 0}}, nil
+		}
+	})
+	defer cleanup()
+
+	processAnn := func(height uint32) error {
+		ca, err := createRemoteChannelAnnouncement(height)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			ca, nodeKeyPub2,
+		):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("channel announcement wasn't processed")
+		}
+
+		return nil
+	}
+
+	if err := processAnn(0); err == nil {
+		t.Fatal("announcement of our closed channel was accepted")
+	}
+	if _, ok := ctx.router.infos[0]; ok {
+		t.Fatal("closed channel was stored")
+	}
+
+	if err := processAnn(1); err != nil {
+		t.Fatalf("announcement of our open channel was rejected: %v",
+			err)
+	}
+
+	// Once we're notified that the channel at height 2 was closed, its
+	// announcement should be rejected as well.
+	closedChanID := lnwire.ShortChannelID{BlockHeight: 2}
+	ctx.gossiper.ChannelClosed(closedChanID)
+
+	if err := processAnn(2); err == nil {
+		t.Fatal("announcement of our newly closed channel was " +
+			"accepted")
+	}
+	if _, ok := ctx.router.infos[closedChanID.ToUint64()]; ok {
+		t.Fatal("newly closed channel was stored")
+	}
+}
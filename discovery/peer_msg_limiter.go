@@ -0,0 +1,49 @@
+package discovery
+
+import (
+	"sync"
+	"time"
+)
+
+// peerMsgLimiter limits the total number of messages processed on behalf of
+// each peer within every second, regardless of their type. This prevents a
+// single peer from monopolizing the gossiper, even if it spreads its messages
+// across many channels and nodes to evade the finer grained limits.
+type peerMsgLimiter struct {
+	// windowStart is the start of the current one second window. All
+	// counts are reset once the window has passed.
+	windowStart time.Time
+
+	// counts tracks the number of messages processed within the current
+	// window for each peer.
+	counts map[[33]byte]int
+
+	sync.Mutex
+}
+
+// newPeerMsgLimiter returns a new, empty peerMsgLimiter.
+func newPeerMsgLimiter() *peerMsgLimiter {
+	return &peerMsgLimiter{
+		counts: make(map[[33]byte]int),
+	}
+}
+
+// allow returns true if another message of the peer may be processed at the
+// passed time, given that at most limit messages are allowed per peer within
+// each second. An allowed message is counted towards the limit of its peer.
+func (l *peerMsgLimiter) allow(peer [33]byte, now time.Time, limit int) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	if now.Sub(l.windowStart) >= time.Second {
+		l.windowStart = now
+		l.counts = make(map[[33]byte]int)
+	}
+
+	if l.counts[peer] >= limit {
+		return false
+	}
+	l.counts[peer]++
+
+	return true
+}
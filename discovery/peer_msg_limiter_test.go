@@ -0,0 +1,98 @@
+package discovery
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestMaxMsgsPerPeerPerSecond ensures that the overall number of messages
+// processed on behalf of a single peer is capped, with the excess reported as
+// a flood, while other peers are unaffected.
+func TestMaxMsgsPerPeerPerSecond(t *testing.T) {
+	t.Parallel()
+
+	const (
+		msgLimit = 3
+		numMsgs  = 5
+	)
+
+	scores := make(chan peerScore, numMsgs+1)
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.MaxMsgsPerPeerPerSecond = msgLimit
+		cfg.ReportPeerScore = func(peer *btcec.PublicKey,
+			delta int, reason string) {
+
+			scores <- peerScore{peer, delta, reason}
+		}
+	})
+	defer cleanup()
+
+	now := time.Now()
+	ctx.gossiper.now = func() time.Time {
+		return now
+	}
+
+	// The updates reference an unknown channel, so any that aren't rate
+	// limited are rejected without being scored.
+	processUpdate := func(peer *btcec.PublicKey) error {
+		ua, err := createUpdateAnnouncement(0)
+		if err != nil {
+			t.Fatalf("can't create update announcement: %v", err)
+		}
+
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ua, peer):
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("update wasn't processed")
+		}
+
+		return nil
+	}
+
+	// Flooding us from a single peer should only have the first messages
+	// within the limit processed.
+	var numLimited int
+	for i := 0; i < numMsgs; i++ {
+		err := processUpdate(nodeKeyPub2)
+		if err != nil && strings.Contains(err.Error(), "more than") {
+			numLimited++
+		}
+	}
+	if numLimited != numMsgs-msgLimit {
+		t.Fatalf("expected %v rate limited messages, got %v",
+			numMsgs-msgLimit, numLimited)
+	}
+
+	for i := 0; i < numLimited; i++ {
+		select {
+		case score := <-scores:
+			if !score.peer.IsEqual(nodeKeyPub2) || score.delta >= 0 {
+				t.Fatalf("unexpected score %v for peer %x",
+					score.delta, score.peer.SerializeCompressed())
+			}
+		default:
+			t.Fatal("flooding peer wasn't scored")
+		}
+	}
+
+	// Another peer should still have its messages processed.
+	otherPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	err = processUpdate(otherPriv.PubKey())
+	if err != nil && strings.Contains(err.Error(), "more than") {
+		t.Fatalf("message of other peer was rate limited: %v", err)
+	}
+
+	// Once the second has passed, the flooding peer may proceed again.
+	now = now.Add(time.Second)
+	err = processUpdate(nodeKeyPub2)
+	if err != nil && strings.Contains(err.Error(), "more than") {
+		t.Fatalf("message was rate limited after a second: %v", err)
+	}
+}
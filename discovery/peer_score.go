@@ -0,0 +1,51 @@
+package discovery
+
+const (
+	// novelAnnScore is the score reported for a peer which delivered a
+	// valid announcement that was new to us.
+	novelAnnScore = 1
+
+	// outdatedAnnScore is the score reported for a peer which delivered an
+	// announcement that's no newer than the one we already know of. As
+	// such announcements may legitimately race with others, they're only
+	// penalized lightly.
+	outdatedAnnScore = -1
+
+	// invalidSigAnnScore is the score reported for a peer which delivered
+	// an announcement carrying an invalid signature. Such announcements
+	// can't be the result of a race, so they're penalized heavily.
+	invalidSigAnnScore = -10
+
+	// floodAnnScore is the score reported for a peer which exceeded the
+	// overall rate at which we process its messages.
+	floodAnnScore = -1
+)
+
+// reportPeerScore reports the passed score for the peer which sent us the
+// passed network announcement to the ReportPeerScore callback, if one is
+// configured. Announcements of our own, or those not sent by a peer, are never
+// scored.
+func (d *AuthenticatedGossiper) reportPeerScore(nMsg *networkMsg, delta int,
+	reason string) {
+
+	if d.cfg.ReportPeerScore == nil || !nMsg.isRemote || nMsg.peer == nil {
+		return
+	}
+
+	d.cfg.ReportPeerScore(nMsg.peer, delta, reason)
+}
+
+// reportRejectionScore reports a negative score for the peer which sent us the
+// passed rejected network announcement, if the reason for the rejection
+// indicates misbehavior of the peer.
+func (d *AuthenticatedGossiper) reportRejectionScore(nMsg *networkMsg,
+	reason RejectionReason) {
+
+	switch reason {
+	case RejectInvalidSig:
+		d.reportPeerScore(nMsg, invalidSigAnnScore, reason.String())
+
+	case RejectOutdated:
+		d.reportPeerScore(nMsg, outdatedAnnScore, reason.String())
+	}
+}
@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// peerScore is a single score reported through the ReportPeerScore callback.
+type peerScore struct {
+	peer   *btcec.PublicKey
+	delta  int
+	reason string
+}
+
+// TestReportPeerScore tests that peers delivering announcements with invalid
+// signatures are scored negatively, while peers delivering valid
+// announcements which are new to us are scored positively.
+func TestReportPeerScore(t *testing.T) {
+	t.Parallel()
+
+	scores := make(chan peerScore, 10)
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.ReportPeerScore = func(peer *btcec.PublicKey,
+			delta int, reason string) {
+
+			scores <- peerScore{peer, delta, reason}
+		}
+	})
+	defer cleanup()
+
+	assertScore := func(positive bool) {
+		select {
+		case score := <-scores:
+			if !score.peer.IsEqual(nodeKeyPub2) {
+				t.Fatalf("wrong peer scored: %x",
+					score.peer.SerializeCompressed())
+			}
+			if positive && score.delta <= 0 {
+				t.Fatalf("expected positive score, got %v (%v)",
+					score.delta, score.reason)
+			}
+			if !positive && score.delta >= 0 {
+				t.Fatalf("expected negative score, got %v (%v)",
+					score.delta, score.reason)
+			}
+
+		default:
+			t.Fatal("peer wasn't scored")
+		}
+	}
+
+	// A node announcement which has been modified after being signed
+	// should result in a negative score for the peer which sent it.
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	na.Timestamp++
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2):
+		if err == nil {
+			t.Fatal("invalid node announcement was accepted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't processed")
+	}
+	assertScore(false)
+
+	// A valid channel announcement that we didn't know of yet should
+	// result in a positive score.
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("can't process channel announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel announcement wasn't processed")
+	}
+	assertScore(true)
+
+	// No other scores should have been reported.
+	select {
+	case score := <-scores:
+		t.Fatalf("unexpected score reported: %v", score.delta)
+	default:
+	}
+}
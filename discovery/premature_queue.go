@@ -0,0 +1,102 @@
+package discovery
+
+import (
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+)
+
+// deferPremature holds back the passed announcement until the chain has
+// reached the passed height. If PrematureQueueSize is set, a remote
+// announcement is persisted within the on-disk queue, which may evict the
+// oldest premature announcements. Otherwise, it's held in memory. Local
+// announcements are always held in memory, as the local sub-systems that sent
+// them wait for a response, which can't be sent once persisted.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) deferPremature(height uint32, nMsg *networkMsg) {
+	if d.prematureQueue == nil || !nMsg.isRemote {
+		d.prematureAnnouncements[height] = append(
+			d.prematureAnnouncements[height], nMsg,
+		)
+		d.numPremature++
+		return
+	}
+
+	ann := &channeldb.PrematureAnnouncement{
+		Height:   height,
+		IsRemote: nMsg.isRemote,
+		Msg:      nMsg.msg,
+	}
+	if nMsg.peer != nil {
+		copy(ann.Peer[:], nMsg.peer.SerializeCompressed())
+	}
+
+	numEvicted, err := d.prematureQueue.Add(ann)
+	if err != nil {
+		log.Errorf("Unable to persist premature %v: %v",
+			messageSummary(nMsg), err)
+		nMsg.err <- err
+		return
+	}
+	if numEvicted != 0 {
+		log.Warnf("Premature announcement queue is full, evicted %v "+
+			"oldest announcements", numEvicted)
+	}
+}
+
+// maturePremature removes and returns all premature announcements which are
+// to be processed once the chain has reached the passed height, in the order
+// in which they were deferred.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) maturePremature(height uint32) []*networkMsg {
+	nMsgs := d.prematureAnnouncements[height]
+	delete(d.prematureAnnouncements, height)
+	d.numPremature -= len(nMsgs)
+
+	if d.prematureQueue == nil {
+		return nMsgs
+	}
+
+	// Announcements that were persisted before a restart may have
+	// matured at a height we've since passed, so we'll replay all
+	// announcements up to the passed height.
+	anns, err := d.prematureQueue.FetchMature(height)
+	if err != nil {
+		log.Errorf("Unable to fetch premature announcements for "+
+			"height %v: %v", height, err)
+		return nMsgs
+	}
+
+	return append(nMsgs, prematureNetworkMsgs(anns)...)
+}
+
+// prematureNetworkMsgs converts the passed premature announcements taken from
+// the on-disk queue back into network messages, such that they can be
+// processed. As the original senders of the announcements are no longer
+// waiting for a response, each message is given a new error channel.
+func prematureNetworkMsgs(
+	anns []*channeldb.PrematureAnnouncement) []*networkMsg {
+
+	var nMsgs []*networkMsg
+	for _, ann := range anns {
+		nMsg := &networkMsg{
+			msg:      ann.Msg,
+			isRemote: ann.IsRemote,
+			err:      make(chan error, 1),
+		}
+		if ann.IsRemote {
+			peer, err := btcec.ParsePubKey(ann.Peer[:], btcec.S256())
+			if err != nil {
+				log.Errorf("Unable to parse peer of premature "+
+					"announcement: %v", err)
+				continue
+			}
+			nMsg.peer = peer
+		}
+
+		nMsgs = append(nMsgs, nMsg)
+	}
+
+	return nMsgs
+}
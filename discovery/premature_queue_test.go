@@ -0,0 +1,239 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestPrematureQueueLocal ensures that local premature announcements are held
+// in memory even if the on-disk queue is configured, such that the local
+// sub-system which sent them receives a response once they're processed.
+func TestPrematureQueueLocal(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.PrematureQueueSize = 1 << 20
+	})
+	defer cleanup()
+
+	const chanHeight = 2
+	ca, err := createRemoteChannelAnnouncement(chanHeight)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	errChan := ctx.gossiper.ProcessLocalAnnouncement(ca, nodeKeyPub1)
+	select {
+	case err := <-errChan:
+		t.Fatalf("premature announcement processed: %v", err)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	if ctx.gossiper.prematureQueue.Len() != 0 {
+		t.Fatal("local premature announcement was persisted")
+	}
+
+	// Once the chain reaches the height of the channel, the local
+	// announcement should be processed and answered.
+	ctx.notifier.notifyBlock(chainhash.Hash{}, chanHeight)
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("unable to process announcement: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("local premature announcement wasn't answered")
+	}
+}
+
+// TestPrematureQueuePersistError ensures that a remote premature announcement
+// which can't be persisted within the on-disk queue receives an error rather
+// than being dropped silently.
+func TestPrematureQueuePersistError(t *testing.T) {
+	t.Parallel()
+
+	// A queue of a single byte can't fit any announcement.
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.PrematureQueueSize = 1
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(2)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		if err == nil {
+			t.Fatal("expected error for unpersisted announcement")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("unpersisted announcement wasn't answered")
+	}
+}
+
+// TestPrematureQueue ensures that premature announcements are persisted
+// within a bounded on-disk queue if configured, where the oldest announcements
+// are evicted once the queue is full, and that the retained announcements are
+// replayed once the chain reaches their height.
+func TestPrematureQueue(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	var anns []*lnwire.ChannelAnnouncement
+	for height := uint32(1); height <= 3; height++ {
+		ca, err := createRemoteChannelAnnouncement(height)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+		anns = append(anns, ca)
+	}
+
+	// We'll size the queue such that it fits exactly two of the
+	// announcements.
+	var b bytes.Buffer
+	queued := &channeldb.PrematureAnnouncement{
+		Height:   1,
+		IsRemote: true,
+		Msg:      anns[0],
+	}
+	if err := queued.Encode(&b); err != nil {
+		t.Fatalf("unable to encode premature announcement: %v", err)
+	}
+
+	ctx.gossiper.Stop()
+
+	notifier := newMockNotifier()
+	cfg := *ctx.gossiper.cfg
+	cfg.Notifier = notifier
+	cfg.PrematureQueueSize = uint64(2 * b.Len())
+
+	gossiper, err := New(cfg, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("unable to create gossiper: %v", err)
+	}
+	if err := gossiper.Start(); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+	defer gossiper.Stop()
+
+	// All of the announcements are premature, so none of them should be
+	// processed right away.
+	for _, ca := range anns {
+		select {
+		case err := <-gossiper.ProcessRemoteAnnouncement(
+			ca, nodeKeyPub2,
+		):
+			t.Fatalf("premature announcement processed: %v", err)
+		case <-time.After(trickleDelay):
+		}
+	}
+
+	if gossiper.prematureQueue.Len() != 2 {
+		t.Fatalf("expected 2 queued announcements, got %v",
+			gossiper.prematureQueue.Len())
+	}
+
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	// Once the chain reaches each of the heights, only the retained
+	// announcement of that height should be replayed, while the oldest
+	// announcement has been evicted.
+	for i, ca := range anns {
+		notifier.notifyBlock(
+			chainhash.Hash{}, ca.ShortChannelID.BlockHeight,
+		)
+
+		// As blocks and announcements are handled by the same
+		// goroutine, the block has been handled once a following
+		// announcement has been.
+		select {
+		case <-gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2):
+		case <-time.After(time.Second):
+			t.Fatal("node announcement wasn't processed")
+		}
+
+		_, ok := ctx.router.infos[ca.ShortChannelID.ToUint64()]
+		switch {
+		case i == 0 && ok:
+			t.Fatal("evicted announcement was replayed")
+		case i != 0 && !ok:
+			t.Fatalf("announcement at height %v wasn't replayed",
+				ca.ShortChannelID.BlockHeight)
+		}
+	}
+
+	if gossiper.prematureQueue.Len() != 0 {
+		t.Fatalf("expected empty queue, got %v announcements",
+			gossiper.prematureQueue.Len())
+	}
+}
+
+// TestResetPrematureQueue ensures that resetting the gossip state of a channel
+// also removes its announcements from the on-disk premature queue, and has
+// them re-processed against the current height.
+func TestResetPrematureQueue(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.PrematureQueueSize = 1 << 20
+	})
+	defer cleanup()
+
+	// The announcement of a channel confirmed at height 5 is premature,
+	// so it's persisted within the queue until that height is reached.
+	const chanHeight = 5
+	ca, err := createRemoteChannelAnnouncement(chanHeight)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		t.Fatalf("premature announcement processed: %v", err)
+	case <-time.After(2 * trickleDelay):
+	}
+
+	// We'll restart at a height beyond that of the channel, though as we
+	// have yet to learn of a new block, the announcement remains queued.
+	ctx.gossiper.Stop()
+	ctx.router.bestHeight = chanHeight + 1
+
+	cfg := *ctx.gossiper.cfg
+	cfg.Notifier = newMockNotifier()
+
+	gossiper, err := New(cfg, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("unable to create gossiper: %v", err)
+	}
+	if err := gossiper.Start(); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+	defer gossiper.Stop()
+
+	// Resetting the gossip state of the channel should take the
+	// announcement out of the queue, and re-process it.
+	err = gossiper.ResetChannelGossipState(ca.ShortChannelID)
+	if err != nil {
+		t.Fatalf("unable to reset channel gossip state: %v", err)
+	}
+
+	if _, ok := ctx.router.infos[ca.ShortChannelID.ToUint64()]; !ok {
+		t.Fatal("channel wasn't added to router")
+	}
+	if gossiper.prematureQueue.Len() != 0 {
+		t.Fatalf("expected empty queue, got %v announcements",
+			gossiper.prematureQueue.Len())
+	}
+}
@@ -0,0 +1,49 @@
+package discovery
+
+import "github.com/viacoin/lnd/lnwire"
+
+// priorityBatch is the batch of announcements which are broadcast once the
+// trickle timer ticks. If prioritizeLocal is set, then announcements stemming
+// from our own messages, such as freshly signed fee updates, are broadcast
+// ahead of those relayed on behalf of remote peers. Otherwise, announcements
+// are broadcast in the order in which they were added.
+type priorityBatch struct {
+	prioritizeLocal bool
+
+	// local holds the announcements stemming from our own messages, if
+	// they're prioritized.
+	local []lnwire.Message
+
+	// remote holds all other announcements.
+	remote []lnwire.Message
+}
+
+// add adds the passed announcements to the batch, which stem from a remote
+// peer if isRemote is set, or from our own messages otherwise.
+func (b *priorityBatch) add(isRemote bool, msgs ...lnwire.Message) {
+	if b.prioritizeLocal && !isRemote {
+		b.local = append(b.local, msgs...)
+		return
+	}
+
+	b.remote = append(b.remote, msgs...)
+}
+
+// messages returns all announcements of the batch in the order in which they
+// should be broadcast.
+func (b *priorityBatch) messages() []lnwire.Message {
+	msgs := make([]lnwire.Message, 0, b.len())
+	msgs = append(msgs, b.local...)
+	return append(msgs, b.remote...)
+}
+
+// len returns the number of announcements within the batch.
+func (b *priorityBatch) len() int {
+	return len(b.local) + len(b.remote)
+}
+
+// reset removes all announcements from the batch.
+func (b *priorityBatch) reset() {
+	b.local = nil
+	b.remote = nil
+}
@@ -0,0 +1,100 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestPrioritizeLocalAnnouncements ensures that if configured, announcements
+// stemming from our own messages are broadcast ahead of remote announcements
+// within the same trickle batch.
+func TestPrioritizeLocalAnnouncements(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	ctx.gossiper.Stop()
+
+	// We'll use a longer trickle delay to ensure all of the announcements
+	// end up within the same batch.
+	batches := make(chan []lnwire.Message, 10)
+	cfg := *ctx.gossiper.cfg
+	cfg.Notifier = newMockNotifier()
+	cfg.TrickleDelay = 5 * trickleDelay
+	cfg.PrioritizeLocalAnnouncements = true
+	cfg.Broadcast = func(_ *btcec.PublicKey, msgs ...lnwire.Message) error {
+		batches <- msgs
+		return nil
+	}
+
+	gossiper, err := New(cfg, nodeKeyPub1)
+	if err != nil {
+		t.Fatalf("unable to create gossiper: %v", err)
+	}
+	if err := gossiper.Start(); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+	defer gossiper.Stop()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	remoteUpdate, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	localUpdate, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	for _, msg := range []lnwire.Message{ca, remoteUpdate} {
+		select {
+		case err := <-gossiper.ProcessRemoteAnnouncement(
+			msg, nodeKeyPub2,
+		):
+			if err != nil {
+				t.Fatalf("can't process %T: %v", msg, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%T wasn't processed", msg)
+		}
+	}
+
+	select {
+	case err := <-gossiper.ProcessLocalAnnouncement(
+		localUpdate, nodeKeyPub1,
+	):
+		if err != nil {
+			t.Fatalf("can't process local update: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("local update wasn't processed")
+	}
+
+	// Although the local update arrived last, it should be broadcast
+	// first.
+	var batch []lnwire.Message
+	select {
+	case batch = <-batches:
+	case <-time.After(time.Second):
+		t.Fatal("batch wasn't broadcast")
+	}
+
+	expectedBatch := []lnwire.Message{localUpdate, ca, remoteUpdate}
+	if len(batch) != len(expectedBatch) {
+		t.Fatalf("expected batch of %v announcements, got %v",
+			len(expectedBatch), len(batch))
+	}
+	for i, msg := range expectedBatch {
+		if batch[i] != msg {
+			t.Fatalf("expected %T at position %v of batch, got %T",
+				msg, i, batch[i])
+		}
+	}
+}
@@ -0,0 +1,466 @@
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// peerSet is a set of the compressed public keys of peers.
+type peerSet map[[33]byte]struct{}
+
+// chanProvenance records the peers from which the announcement of a channel
+// was received.
+type chanProvenance struct {
+	// sources is the set of peers which sent us the announcement.
+	sources peerSet
+
+	// origin is the peer which first delivered the announcement that
+	// added the channel to the graph, and firstSeen the time at which it
+	// did so. The origin is nil if the channel was announced by us, or
+	// was added to the graph before its sources were tracked.
+	origin    *btcec.PublicKey
+	firstSeen time.Time
+
+	// partial is true if the channel was already within the graph when
+	// its sources started to be recorded, such as once its earlier
+	// sources were evicted. As its full set of sources isn't known, the
+	// channel is never considered to be introduced by a single peer.
+	partial bool
+}
+
+// gossipProvenance records the peers from which the channels and nodes within
+// the graph were received, such that the gossip which was introduced by a
+// single peer can be told apart from the gossip corroborated by others. The
+// sources of at most size channels and size nodes are retained. Once full,
+// the sources of the channel or node recorded the longest ago are forgotten,
+// and a channel whose sources were forgotten is no longer considered to be
+// introduced by a single peer once it's recorded again.
+type gossipProvenance struct {
+	// channels maps the short channel ID of each channel to the peers
+	// which sent us its announcement.
+	channels map[uint64]*chanProvenance
+
+	// chanOrder holds the short channel IDs of the channels within
+	// channels, from the oldest to the newest.
+	chanOrder []uint64
+
+	// nodes maps the compressed public key of each node to the peers
+	// which sent us its announcement, or the announcement of one of its
+	// channels.
+	nodes map[[33]byte]peerSet
+
+	// nodeOrder holds the keys of the nodes within nodes, from the oldest
+	// to the newest.
+	nodeOrder [][33]byte
+
+	// size is the maximum number of channels, and of nodes, whose sources
+	// are retained.
+	size int
+
+	mu sync.Mutex
+}
+
+// newGossipProvenance returns a new gossipProvenance without any sources,
+// which retains the sources of at most size channels and size nodes.
+func newGossipProvenance(size int) *gossipProvenance {
+	return &gossipProvenance{
+		channels: make(map[uint64]*chanProvenance),
+		nodes:    make(map[[33]byte]peerSet),
+		size:     size,
+	}
+}
+
+// addChannel records the peer as a source of the channel. The known flag
+// indicates whether the channel was already within the graph, in which case
+// the sources of a channel which isn't yet tracked are only partially known.
+func (p *gossipProvenance) addChannel(chanID uint64, peer [33]byte,
+	known bool) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.channels[chanID] == nil {
+		if len(p.chanOrder) >= p.size {
+			delete(p.channels, p.chanOrder[0])
+			p.chanOrder = p.chanOrder[1:]
+		}
+
+		p.channels[chanID] = &chanProvenance{
+			sources: make(peerSet),
+			partial: known,
+		}
+		p.chanOrder = append(p.chanOrder, chanID)
+	}
+	p.channels[chanID].sources[peer] = struct{}{}
+}
+
+// setOrigin records the peer as the one which first delivered the
+// announcement of the channel at the passed time, unless the channel isn't
+// tracked, or its origin is already known.
+func (p *gossipProvenance) setOrigin(chanID uint64, peer *btcec.PublicKey,
+	firstSeen time.Time) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	chanProv, ok := p.channels[chanID]
+	if !ok || chanProv.origin != nil {
+		return
+	}
+
+	chanProv.origin = peer
+	chanProv.firstSeen = firstSeen
+}
+
+// origin returns the peer which first delivered the announcement of the
+// channel, along with the time at which it did so. False is returned if the
+// origin of the channel isn't known.
+func (p *gossipProvenance) origin(
+	chanID uint64) (*btcec.PublicKey, time.Time, bool) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	chanProv, ok := p.channels[chanID]
+	if !ok || chanProv.origin == nil {
+		return nil, time.Time{}, false
+	}
+
+	return chanProv.origin, chanProv.firstSeen, true
+}
+
+// addNode records the peer as a source of the node.
+func (p *gossipProvenance) addNode(node, peer [33]byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.nodes[node] == nil {
+		if len(p.nodeOrder) >= p.size {
+			delete(p.nodes, p.nodeOrder[0])
+			p.nodeOrder = p.nodeOrder[1:]
+		}
+
+		p.nodes[node] = make(peerSet)
+		p.nodeOrder = append(p.nodeOrder, node)
+	}
+	p.nodes[node][peer] = struct{}{}
+}
+
+// removeChannel forgets all sources of the channel.
+func (p *gossipProvenance) removeChannel(chanID uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.channels[chanID]; !ok {
+		return
+	}
+
+	delete(p.channels, chanID)
+	for i, id := range p.chanOrder {
+		if id == chanID {
+			p.chanOrder = append(
+				p.chanOrder[:i], p.chanOrder[i+1:]...,
+			)
+			break
+		}
+	}
+}
+
+// removeNode forgets all sources of the node.
+func (p *gossipProvenance) removeNode(node [33]byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.nodes[node]; !ok {
+		return
+	}
+
+	delete(p.nodes, node)
+	for i, n := range p.nodeOrder {
+		if n == node {
+			p.nodeOrder = append(
+				p.nodeOrder[:i], p.nodeOrder[i+1:]...,
+			)
+			break
+		}
+	}
+}
+
+// soleSource returns the channels and nodes for which the peer is the only
+// recorded source. Channels whose sources are only partially known are never
+// returned.
+func (p *gossipProvenance) soleSource(peer [33]byte) ([]uint64, [][33]byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	isSole := func(sources peerSet) bool {
+		_, ok := sources[peer]
+		return ok && len(sources) == 1
+	}
+
+	var chanIDs []uint64
+	for chanID, chanProv := range p.channels {
+		if !chanProv.partial && isSole(chanProv.sources) {
+			chanIDs = append(chanIDs, chanID)
+		}
+	}
+
+	var nodes [][33]byte
+	for node, sources := range p.nodes {
+		if isSole(sources) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return chanIDs, nodes
+}
+
+// forgetPeer removes the peer as a source of all channels and nodes.
+func (p *gossipProvenance) forgetPeer(peer [33]byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, chanProv := range p.channels {
+		delete(chanProv.sources, peer)
+	}
+	for _, sources := range p.nodes {
+		delete(sources, peer)
+	}
+}
+
+// provenanceSource returns the source of the passed network announcement as
+// recorded within the gossip provenance. Our own announcements are recorded
+// with ourselves as their source, such that they're never considered to be
+// introduced solely by a peer which echoes them back to us. False is returned
+// if the announcement has no known source.
+func (d *AuthenticatedGossiper) provenanceSource(
+	nMsg *networkMsg) ([33]byte, bool) {
+
+	var source [33]byte
+	switch {
+	case !nMsg.isRemote:
+		copy(source[:], d.selfKey.SerializeCompressed())
+
+	case nMsg.peer != nil:
+		copy(source[:], nMsg.peer.SerializeCompressed())
+
+	default:
+		return source, false
+	}
+
+	return source, true
+}
+
+// recordChanProvenance records the sender of the passed channel announcement
+// as a source of the channel and both of its nodes. If the announcement
+// couldn't be added to the graph, then the sender is only recorded if the
+// channel is already known, as it then corroborates it. Otherwise, a remote
+// sender is also recorded as the origin of the channel.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) recordChanProvenance(nMsg *networkMsg,
+	msg *lnwire.ChannelAnnouncement, addErr error) {
+
+	if d.provenance == nil {
+		return
+	}
+	peer, ok := d.provenanceSource(nMsg)
+	if !ok {
+		return
+	}
+
+	if addErr != nil {
+		_, _, _, err := d.cfg.Router.GetChannelByID(msg.ShortChannelID)
+		if err != nil {
+			return
+		}
+	}
+
+	var node1, node2 [33]byte
+	copy(node1[:], msg.NodeID1.SerializeCompressed())
+	copy(node2[:], msg.NodeID2.SerializeCompressed())
+
+	chanID := msg.ShortChannelID.ToUint64()
+	d.provenance.addChannel(chanID, peer, addErr != nil)
+	d.provenance.addNode(node1, peer)
+	d.provenance.addNode(node2, peer)
+
+	if addErr == nil && nMsg.isRemote {
+		d.provenance.setOrigin(chanID, nMsg.peer, time.Now())
+	}
+}
+
+// AnnouncementProvenance returns the peer which first delivered the
+// announcement of the target channel that we accepted, along with the time at
+// which it did so. If the origin of the announcement isn't known, such as if
+// TrackGossipProvenance isn't set, the channel was announced by us, or its
+// sources have since been forgotten, then false is returned.
+func (d *AuthenticatedGossiper) AnnouncementProvenance(
+	chanID lnwire.ShortChannelID) (*btcec.PublicKey, time.Time, bool) {
+
+	if d.provenance == nil {
+		return nil, time.Time{}, false
+	}
+
+	return d.provenance.origin(chanID.ToUint64())
+}
+
+// recordNodeProvenance records the sender of the passed node announcement as
+// a source of the node. If the announcement couldn't be added to the graph,
+// then the sender is only recorded if the node is already known, as it then
+// corroborates it.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) recordNodeProvenance(nMsg *networkMsg,
+	msg *lnwire.NodeAnnouncement, addErr error) {
+
+	if d.provenance == nil {
+		return
+	}
+	peer, ok := d.provenanceSource(nMsg)
+	if !ok {
+		return
+	}
+
+	if addErr != nil {
+		_, err := d.cfg.Router.FetchLightningNode(msg.NodeID)
+		if err != nil {
+			return
+		}
+	}
+
+	var node [33]byte
+	copy(node[:], msg.NodeID.SerializeCompressed())
+
+	d.provenance.addNode(node, peer)
+}
+
+// purgeRequest is a request to purge the gossip introduced by a single peer.
+type purgeRequest struct {
+	peer *btcec.PublicKey
+
+	errResp chan error
+}
+
+// PurgePeerGossip removes all channels and nodes from the graph that were
+// solely introduced by the target peer, such as once it has been banned for
+// feeding us bad gossip. Channels and nodes which were also announced to us
+// by other peers are preserved, as are nodes which still have channels
+// within the graph, and our own channels and node. The peer is no longer
+// considered a source of any of the preserved gossip.
+//
+// NOTE: The sources of the gossip are only tracked in memory while
+// TrackGossipProvenance is set, so gossip received before the gossiper was
+// started isn't affected.
+func (d *AuthenticatedGossiper) PurgePeerGossip(peer *btcec.PublicKey) error {
+	if d.provenance == nil {
+		return errors.New("gossip provenance isn't tracked")
+	}
+
+	errChan := make(chan error, 1)
+	req := &purgeRequest{
+		peer:    peer,
+		errResp: errChan,
+	}
+
+	select {
+	case d.purgeRequests <- req:
+	case <-d.quit:
+		return ErrShuttingDown
+	}
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-d.quit:
+		return ErrShuttingDown
+	}
+}
+
+// purgePeerGossip removes the channels and nodes for which the target peer is
+// the only recorded source from the graph.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) purgePeerGossip(peer *btcec.PublicKey) error {
+	var peerKey [33]byte
+	copy(peerKey[:], peer.SerializeCompressed())
+
+	chanIDs, nodes := d.provenance.soleSource(peerKey)
+	d.provenance.forgetPeer(peerKey)
+
+	var numChans int
+	for _, chanID := range chanIDs {
+		// Our own channels are never purged, regardless of the peers
+		// which echoed them back to us.
+		shortChanID := lnwire.NewShortChanIDFromInt(chanID)
+		info, _, _, err := d.cfg.Router.GetChannelByID(shortChanID)
+		if err != nil {
+			d.provenance.removeChannel(chanID)
+			continue
+		}
+		if d.isSelfChannel(info) {
+			continue
+		}
+
+		err = d.deleteChannel(shortChanID)
+		if err != nil && err != channeldb.ErrEdgeNotFound {
+			return errors.Errorf("unable to purge "+
+				"short_chan_id=%v: %v", chanID, err)
+		}
+		d.provenance.removeChannel(chanID)
+		numChans++
+	}
+
+	// Nodes that still have channels within the graph must be kept, as
+	// the graph would otherwise be left with dangling channels.
+	connected := make(map[[33]byte]struct{})
+	err := d.cfg.Router.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		var node1, node2 [33]byte
+		copy(node1[:], info.NodeKey1.SerializeCompressed())
+		copy(node2[:], info.NodeKey2.SerializeCompressed())
+		connected[node1] = struct{}{}
+		connected[node2] = struct{}{}
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return err
+	}
+
+	var selfNode [33]byte
+	copy(selfNode[:], d.selfKey.SerializeCompressed())
+
+	var numNodes int
+	for _, node := range nodes {
+		if _, ok := connected[node]; ok || node == selfNode {
+			continue
+		}
+
+		pub, err := btcec.ParsePubKey(node[:], btcec.S256())
+		if err != nil {
+			return err
+		}
+		err = d.cfg.Router.DeleteNode(pub)
+		if err != nil && err != channeldb.ErrGraphNodeNotFound {
+			return errors.Errorf("unable to purge node %x: %v",
+				node[:], err)
+		}
+
+		d.provenance.removeNode(node)
+		if d.graphMemory != nil {
+			d.graphMemory.untrackNode(node)
+		}
+		numNodes++
+	}
+
+	log.Infof("Purged %v channels and %v nodes introduced solely by "+
+		"peer %x", numChans, numNodes, peerKey[:])
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestPurgePeerGossip ensures that purging the gossip of a peer removes the
+// channels and nodes introduced solely by that peer, while preserving those
+// corroborated by other peers, along with our own channels and node.
+func TestPurgePeerGossip(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.TrackGossipProvenance = true
+		cfg.MaxGossipProvenance = 100
+	})
+	defer cleanup()
+
+	var (
+		privs [3]*btcec.PrivateKey
+		err   error
+	)
+	for i := range privs {
+		privs[i], err = btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("can't create private key: %v", err)
+		}
+	}
+	bannedPeer := nodeKeyPub2
+	otherPeer := privs[0].PubKey()
+
+	// The first channel is only announced to us by the banned peer,
+	// while the second one is also announced by another peer. The third
+	// one is our own channel, which the banned peer echoes back to us.
+	soleAnn, err := createChannelAnnouncementBetween(
+		0, nodeKeyPriv2, privs[1],
+	)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	sharedAnn, err := createChannelAnnouncementBetween(
+		1, nodeKeyPriv2, privs[2],
+	)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ownAnn, err := createRemoteChannelAnnouncement(2)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	for _, ann := range []*lnwire.ChannelAnnouncement{
+		soleAnn, sharedAnn, ownAnn,
+	} {
+		err = <-ctx.gossiper.ProcessRemoteAnnouncement(ann, bannedPeer)
+		if err != nil {
+			t.Fatalf("can't process channel announcement: %v", err)
+		}
+	}
+
+	// The other peer's copy of the second announcement is a duplicate,
+	// but it still corroborates the channel.
+	<-ctx.gossiper.ProcessRemoteAnnouncement(sharedAnn, otherPeer)
+
+	// The banned peer also introduces a node without any channels, along
+	// with a node of the corroborated channel, and our own node.
+	loneNodePriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("can't create private key: %v", err)
+	}
+	var nodeAnns []*lnwire.NodeAnnouncement
+	for _, priv := range []*btcec.PrivateKey{
+		loneNodePriv, privs[2], nodeKeyPriv1,
+	} {
+		na, err := createNodeAnnouncement(priv)
+		if err != nil {
+			t.Fatalf("can't create node announcement: %v", err)
+		}
+		err = <-ctx.gossiper.ProcessRemoteAnnouncement(na, bannedPeer)
+		if err != nil {
+			t.Fatalf("can't process node announcement: %v", err)
+		}
+		nodeAnns = append(nodeAnns, na)
+	}
+
+	if err := ctx.gossiper.PurgePeerGossip(bannedPeer); err != nil {
+		t.Fatalf("unable to purge peer gossip: %v", err)
+	}
+
+	if _, ok := ctx.router.infos[soleAnn.ShortChannelID.ToUint64()]; ok {
+		t.Fatal("channel introduced solely by banned peer wasn't purged")
+	}
+	if _, ok := ctx.router.infos[sharedAnn.ShortChannelID.ToUint64()]; !ok {
+		t.Fatal("corroborated channel was purged")
+	}
+	if _, ok := ctx.router.infos[ownAnn.ShortChannelID.ToUint64()]; !ok {
+		t.Fatal("own channel was purged")
+	}
+
+	_, err = ctx.router.FetchLightningNode(nodeAnns[0].NodeID)
+	if err == nil {
+		t.Fatal("node introduced solely by banned peer wasn't purged")
+	}
+
+	// The node of the corroborated channel should be preserved, as the
+	// other peer vouched for it by announcing the channel, as should our
+	// own node.
+	_, err = ctx.router.FetchLightningNode(nodeAnns[1].NodeID)
+	if err != nil {
+		t.Fatalf("node of corroborated channel was purged: %v", err)
+	}
+	_, err = ctx.router.FetchLightningNode(nodeAnns[2].NodeID)
+	if err != nil {
+		t.Fatalf("own node was purged: %v", err)
+	}
+}
+
+// TestGossipProvenanceBound ensures that the gossip provenance retains the
+// sources of at most its size of channels and nodes, forgetting those recorded
+// the longest ago first.
+func TestGossipProvenanceBound(t *testing.T) {
+	t.Parallel()
+
+	p := newGossipProvenance(2)
+
+	var peer [33]byte
+	for chanID := uint64(0); chanID < 3; chanID++ {
+		p.addChannel(chanID, peer, false)
+		p.addNode([33]byte{byte(chanID)}, peer)
+	}
+
+	chanIDs, nodes := p.soleSource(peer)
+	if len(chanIDs) != 2 || len(nodes) != 2 {
+		t.Fatalf("expected 2 channels and 2 nodes, got %v and %v",
+			len(chanIDs), len(nodes))
+	}
+	if _, ok := p.channels[0]; ok {
+		t.Fatal("oldest channel wasn't evicted")
+	}
+	if _, ok := p.nodes[[33]byte{0}]; ok {
+		t.Fatal("oldest node wasn't evicted")
+	}
+
+	// Removing a channel should make room for another one, without
+	// evicting any of the remaining ones.
+	p.removeChannel(1)
+	p.addChannel(3, peer, false)
+	if _, ok := p.channels[2]; !ok {
+		t.Fatal("channel evicted after removal")
+	}
+	if len(p.chanOrder) != 2 {
+		t.Fatalf("expected 2 ordered channels, got %v",
+			len(p.chanOrder))
+	}
+
+	// Once the evicted channel is echoed back to us while still within
+	// the graph, its sources are only partially known, so the echoing
+	// peer must not be considered its sole source.
+	p.addChannel(0, peer, true)
+	chanIDs, _ = p.soleSource(peer)
+	for _, chanID := range chanIDs {
+		if chanID == 0 {
+			t.Fatal("evicted channel considered solely sourced")
+		}
+	}
+}
+
+// TestAnnouncementProvenance ensures that the gossiper records the peer which
+// first delivered an accepted channel announcement, along with the time at
+// which it did so, and that the recorded origins are bounded along with the
+// rest of the gossip provenance.
+func TestAnnouncementProvenance(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.TrackGossipProvenance = true
+		cfg.MaxGossipProvenance = 1
+	})
+	defer cleanup()
+
+	otherPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("can't create private key: %v", err)
+	}
+	otherPeer := otherPriv.PubKey()
+
+	firstAnn, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	secondAnn, err := createRemoteChannelAnnouncement(1)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	before := time.Now()
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(firstAnn, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process channel announcement: %v", err)
+	}
+	after := time.Now()
+
+	assertOrigin := func() {
+		peer, firstSeen, ok := ctx.gossiper.AnnouncementProvenance(
+			firstAnn.ShortChannelID,
+		)
+		if !ok {
+			t.Fatal("expected provenance of the announcement")
+		}
+		if !peer.IsEqual(nodeKeyPub2) {
+			t.Fatalf("expected peer %x, got %x",
+				nodeKeyPub2.SerializeCompressed(),
+				peer.SerializeCompressed())
+		}
+		if firstSeen.Before(before) || firstSeen.After(after) {
+			t.Fatalf("first seen time %v not within [%v, %v]",
+				firstSeen, before, after)
+		}
+	}
+	assertOrigin()
+
+	// Receiving the same announcement from another peer must leave its
+	// origin untouched.
+	<-ctx.gossiper.ProcessRemoteAnnouncement(firstAnn, otherPeer)
+	assertOrigin()
+
+	// As only a single origin is retained, accepting the announcement of
+	// another channel should evict the origin of the first one.
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(secondAnn, otherPeer)
+	if err != nil {
+		t.Fatalf("can't process channel announcement: %v", err)
+	}
+	_, _, ok := ctx.gossiper.AnnouncementProvenance(firstAnn.ShortChannelID)
+	if ok {
+		t.Fatal("expected provenance of the first channel to be evicted")
+	}
+	peer, _, ok := ctx.gossiper.AnnouncementProvenance(
+		secondAnn.ShortChannelID,
+	)
+	if !ok || !peer.IsEqual(otherPeer) {
+		t.Fatal("expected provenance of the second channel")
+	}
+}
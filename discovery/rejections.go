@@ -0,0 +1,198 @@
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+	"github.com/viacoin/lnd/routing"
+)
+
+// RejectionReason describes the reason for which the gossiper rejected, or
+// deferred the processing of, a network announcement.
+type RejectionReason uint8
+
+const (
+	// RejectPremature indicates that the announcement references a block
+	// beyond our current view of the chain tip. Such announcements are
+	// held back until we've caught up to that height.
+	RejectPremature RejectionReason = iota
+
+	// RejectInvalidSig indicates that a signature within the announcement
+	// failed to validate.
+	RejectInvalidSig
+
+	// RejectOutdated indicates that we already have an announcement which
+	// is at least as recent as the rejected one.
+	RejectOutdated
+
+	// RejectWrongChain indicates that the announcement targets a chain
+	// other than the one we operate on.
+	RejectWrongChain
+
+	// RejectRateLimited indicates that the announcement was dropped as
+	// its source exceeded a rate limit.
+	RejectRateLimited
+
+	// RejectUnknownChannel indicates that the announcement references a
+	// channel which isn't known to us.
+	RejectUnknownChannel
+
+	// RejectOther indicates that the announcement was rejected for a
+	// reason not covered by the other rejection reasons. The error within
+	// the RejectionRecord details the exact reason.
+	RejectOther
+
+	// RejectConflict indicates that the announcement conflicts with one
+	// that we already know of, such as a channel announcement carrying a
+	// different proof than the known one.
+	RejectConflict
+
+	// RejectFeatureMismatch indicates that the features advertised within
+	// a node announcement are inconsistent with those of the channels of
+	// the node.
+	RejectFeatureMismatch
+
+	// RejectOversized indicates that the serialized announcement exceeds
+	// the maximum size of the announcements we accept.
+	RejectOversized
+
+	// RejectClosedChannel indicates that the announcement references a
+	// channel whose funding output has been spent on-chain.
+	RejectClosedChannel
+
+	// RejectInvalidPolicy indicates that the channel update advertises a
+	// policy which can't be valid for its channel, such as an HTLC
+	// minimum beyond the capacity of the channel.
+	RejectInvalidPolicy
+)
+
+// String returns a human readable description of the rejection reason.
+func (r RejectionReason) String() string {
+	switch r {
+	case RejectPremature:
+		return "premature"
+	case RejectInvalidSig:
+		return "invalid signature"
+	case RejectOutdated:
+		return "outdated"
+	case RejectWrongChain:
+		return "wrong chain"
+	case RejectRateLimited:
+		return "rate limited"
+	case RejectUnknownChannel:
+		return "unknown channel"
+	case RejectOther:
+		return "other"
+	case RejectConflict:
+		return "conflict"
+	case RejectFeatureMismatch:
+		return "feature mismatch"
+	case RejectOversized:
+		return "oversized"
+	case RejectClosedChannel:
+		return "closed channel"
+	case RejectInvalidPolicy:
+		return "invalid policy"
+	default:
+		return "unknown reason"
+	}
+}
+
+// routerRejectionReason maps an error returned by the router when applying an
+// announcement to the graph to its RejectionReason.
+func routerRejectionReason(err error) RejectionReason {
+	if routing.IsError(err, routing.ErrOutdated) {
+		return RejectOutdated
+	}
+
+	return RejectOther
+}
+
+// RejectionRecord details a single network announcement which was rejected
+// by the gossiper.
+type RejectionRecord struct {
+	// Timestamp is the time at which the announcement was rejected.
+	Timestamp time.Time
+
+	// Peer is the compressed public key of the peer which sent us the
+	// announcement. It's blank for announcements of our own.
+	Peer [33]byte
+
+	// MsgType is the type of the rejected announcement.
+	MsgType lnwire.MessageType
+
+	// ShortChannelID is the short channel ID of the channel that the
+	// announcement references. It's only set for channel related
+	// announcements.
+	ShortChannelID lnwire.ShortChannelID
+
+	// NodeID is the compressed public key of the node that the
+	// announcement references. It's only set for node announcements.
+	NodeID [33]byte
+
+	// Reason is the reason for which the announcement was rejected.
+	Reason RejectionReason
+
+	// Err is the error which caused the announcement to be rejected.
+	Err error
+}
+
+// rejectionLog is a bounded ring buffer of the most recent rejections of the
+// gossiper.
+type rejectionLog struct {
+	// records is the backing slice of the ring buffer, which is never
+	// larger than the size of the log.
+	records []RejectionRecord
+
+	// next is the index within the records at which the next record will
+	// be written once the log is full.
+	next int
+
+	sync.Mutex
+}
+
+// newRejectionLog returns a new rejectionLog which retains at most size
+// records.
+func newRejectionLog(size int) *rejectionLog {
+	return &rejectionLog{
+		records: make([]RejectionRecord, 0, size),
+	}
+}
+
+// add adds a new record to the log, overwriting the oldest record if the log
+// is full.
+func (r *rejectionLog) add(record RejectionRecord) {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.records) < cap(r.records) {
+		r.records = append(r.records, record)
+		return
+	}
+
+	r.records[r.next] = record
+	r.next = (r.next + 1) % len(r.records)
+}
+
+// recent returns up to limit of the most recent records, ordered from newest
+// to oldest. A non-positive limit returns all retained records.
+func (r *rejectionLog) recent(limit int) []RejectionRecord {
+	r.Lock()
+	defer r.Unlock()
+
+	numRecords := len(r.records)
+	if limit <= 0 || limit > numRecords {
+		limit = numRecords
+	}
+
+	// The newest record sits just before the next index to be written,
+	// which wraps around to the end of the records once the log is full.
+	records := make([]RejectionRecord, 0, limit)
+	for i := 0; i < limit; i++ {
+		idx := (r.next - 1 - i + 2*numRecords) % numRecords
+		records = append(records, r.records[idx])
+	}
+
+	return records
+}
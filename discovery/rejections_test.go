@@ -0,0 +1,204 @@
+package discovery
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// auditLogWriter is an io.Writer which sends each line written to it over a
+// channel, allowing tests to safely inspect the announcement audit log.
+type auditLogWriter struct {
+	lines chan string
+}
+
+func (w *auditLogWriter) Write(p []byte) (int, error) {
+	w.lines <- string(p)
+	return len(p), nil
+}
+
+// TestAnnAuditLog ensures that each rejected announcement results in a line
+// being written to the announcement audit log.
+func TestAnnAuditLog(t *testing.T) {
+	t.Parallel()
+
+	auditLog := &auditLogWriter{
+		lines: make(chan string, 10),
+	}
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.AnnAuditLog = auditLog
+	})
+	defer cleanup()
+
+	remoteKey := nodeKeyPub2
+
+	// A node announcement which has been modified after being signed
+	// should be rejected due to its invalid signature.
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	na.Timestamp++
+
+	// A channel announcement which has been modified after being signed
+	// should also be rejected.
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ca.ShortChannelID.TxIndex++
+
+	// Finally, a channel update for a channel we don't know of should be
+	// rejected as we can't verify its signature.
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	invalidAnns := []lnwire.Message{na, ca, ua}
+	for _, ann := range invalidAnns {
+		err := <-ctx.gossiper.ProcessRemoteAnnouncement(ann, remoteKey)
+		if err == nil {
+			t.Fatalf("invalid %T was accepted", ann)
+		}
+
+		select {
+		case line := <-auditLog.lines:
+			msgType := fmt.Sprintf("msg_type=%v", ann.MsgType())
+			if !strings.Contains(line, msgType) {
+				t.Fatalf("audit line %q doesn't contain %v",
+					line, msgType)
+			}
+
+			peer := fmt.Sprintf("peer=%x",
+				remoteKey.SerializeCompressed())
+			if !strings.Contains(line, peer) {
+				t.Fatalf("audit line %q doesn't contain %v",
+					line, peer)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatalf("rejection of %T wasn't audited", ann)
+		}
+	}
+}
+
+// TestRecentRejections ensures that the reasons for which announcements are
+// rejected are retained, bounded by the size of the rejection log.
+func TestRecentRejections(t *testing.T) {
+	t.Parallel()
+
+	// First, we'll send a channel announcement which is premature, as it
+	// references a block beyond our chain tip.
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		// We'll only retain the three most recent rejections.
+		cfg.RejectionLogSize = 3
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(1)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2)
+
+	// Next, we'll send a channel update which targets a foreign chain.
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+	ua.ChainHash[0] ^= 0xff
+	ctx.gossiper.ProcessRemoteAnnouncement(ua, nodeKeyPub2)
+
+	// Finally, we'll send a node announcement which has been modified
+	// after being signed. As messages are processed in order, once we
+	// receive the response for this announcement, the prior ones will
+	// have been processed as well.
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+	na.Timestamp++
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2):
+		if err == nil {
+			t.Fatal("invalid node announcement was accepted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("node announcement wasn't processed")
+	}
+
+	// All three rejections should be retained, with the newest being
+	// first.
+	records := ctx.gossiper.RecentRejections(0)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 rejections, got %v", len(records))
+	}
+
+	if records[0].Reason != RejectInvalidSig {
+		t.Fatalf("expected reason %v, got %v", RejectInvalidSig,
+			records[0].Reason)
+	}
+	var nodeID [33]byte
+	copy(nodeID[:], na.NodeID.SerializeCompressed())
+	if records[0].NodeID != nodeID {
+		t.Fatalf("expected node %x, got %x", nodeID, records[0].NodeID)
+	}
+
+	if records[1].Reason != RejectWrongChain {
+		t.Fatalf("expected reason %v, got %v", RejectWrongChain,
+			records[1].Reason)
+	}
+	if records[1].ShortChannelID != ua.ShortChannelID {
+		t.Fatalf("expected short_chan_id %v, got %v",
+			ua.ShortChannelID, records[1].ShortChannelID)
+	}
+
+	if records[2].Reason != RejectPremature {
+		t.Fatalf("expected reason %v, got %v", RejectPremature,
+			records[2].Reason)
+	}
+	if records[2].ShortChannelID != ca.ShortChannelID {
+		t.Fatalf("expected short_chan_id %v, got %v",
+			ca.ShortChannelID, records[2].ShortChannelID)
+	}
+
+	// Once another message is rejected, the oldest rejection should be
+	// evicted from the log.
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+		lnwire.NewPing(0), nodeKeyPub2):
+
+		if err == nil {
+			t.Fatal("ping message was accepted")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ping message wasn't processed")
+	}
+
+	records = ctx.gossiper.RecentRejections(0)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 rejections, got %v", len(records))
+	}
+	if records[0].Reason != RejectOther {
+		t.Fatalf("expected reason %v, got %v", RejectOther,
+			records[0].Reason)
+	}
+	if records[2].Reason != RejectWrongChain {
+		t.Fatalf("expected reason %v, got %v", RejectWrongChain,
+			records[2].Reason)
+	}
+
+	// The limit should restrict the number of returned rejections.
+	records = ctx.gossiper.RecentRejections(1)
+	if len(records) != 1 || records[0].Reason != RejectOther {
+		t.Fatalf("expected only the newest rejection, got %v",
+			records)
+	}
+}
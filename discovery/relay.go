@@ -0,0 +1,20 @@
+package discovery
+
+import (
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// shouldRelay returns true if the announcements emitted by the passed network
+// message should be added to the broadcast batch. If NoRelayGossip is set,
+// only the announcements stemming from our own messages are relayed.
+func (d *AuthenticatedGossiper) shouldRelay(nMsg *networkMsg) bool {
+	if !d.cfg.NoRelayGossip || !nMsg.isRemote {
+		return true
+	}
+
+	// Proofs are only exchanged between the two parties of a channel, so
+	// the announcements emitted once a remote proof completes the proof
+	// of one of our own channels are our own.
+	_, ok := nMsg.msg.(*lnwire.AnnounceSignatures)
+	return ok
+}
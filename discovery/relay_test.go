@@ -0,0 +1,43 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+)
+
+// TestListenOnlyMode ensures that a gossiper which doesn't relay gossip still
+// applies validated remote announcements to the graph, but doesn't broadcast
+// them.
+func TestListenOnlyMode(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.NoRelayGossip = true
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	select {
+	case err := <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+		if err != nil {
+			t.Fatalf("unable to process channel announcement: %v",
+				err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel announcement wasn't processed")
+	}
+
+	if _, ok := ctx.router.infos[ca.ShortChannelID.ToUint64()]; !ok {
+		t.Fatal("channel wasn't added to the router")
+	}
+
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("remote announcement was relayed: %T", msg)
+	case <-time.After(2 * trickleDelay):
+	}
+}
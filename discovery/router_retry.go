@@ -0,0 +1,63 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/viacoin/lnd/routing"
+)
+
+// retryRouterOp applies the passed operation to the router, retrying it up to
+// RouterRetries times if it fails with an error which may be transient. The
+// delay before each retry starts at RouterRetryBackoff and doubles with each
+// further attempt. Errors signalling that the router deliberately refused
+// the operation, such as for outdated announcements, are returned right away.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) retryRouterOp(op func() error) error {
+	return d.retryOp(
+		"Router operation", d.cfg.RouterRetries,
+		d.cfg.RouterRetryBackoff, op, func(err error) bool {
+			return routing.IsError(err, routing.ErrOutdated,
+				routing.ErrIgnored)
+		},
+	)
+}
+
+// retryStartupOp applies the passed operation required to start the gossiper,
+// retrying it up to StartupRetries times if it fails. The delay before each
+// retry starts at StartupRetryBackoff and doubles with each further attempt.
+func (d *AuthenticatedGossiper) retryStartupOp(desc string,
+	op func() error) error {
+
+	return d.retryOp(
+		desc, d.cfg.StartupRetries, d.cfg.StartupRetryBackoff, op,
+		func(error) bool { return false },
+	)
+}
+
+// retryOp applies the passed operation, retrying it up to the passed number
+// of times with an exponentially growing backoff, unless it fails with an
+// error which isPermanent reports as such. If the gossiper is stopped while
+// waiting to retry, then ErrShuttingDown is returned.
+func (d *AuthenticatedGossiper) retryOp(desc string, retries int,
+	backoff time.Duration, op func() error,
+	isPermanent func(error) bool) error {
+
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= retries || isPermanent(err) {
+			return err
+		}
+
+		log.Warnf("%v failed, retrying in %v (attempt %v of %v): %v",
+			desc, backoff, attempt+1, retries, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-d.quit:
+			return ErrShuttingDown
+		}
+
+		backoff *= 2
+	}
+}
@@ -0,0 +1,164 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestRouterRetryAddNode checks that a node announcement which the router
+// fails to apply is retried, rather than dropped right away.
+func TestRouterRetryAddNode(t *testing.T) {
+	t.Parallel()
+
+	// processNodeAnn has a gossiper which retries the given number of
+	// times process a node announcement, while the first attempt to add
+	// the node fails. The number of nodes added to the router is returned
+	// along with the result of processing the announcement.
+	processNodeAnn := func(retries int) (int, error) {
+		ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+			cfg.RouterRetries = retries
+			cfg.RouterRetryBackoff = time.Millisecond
+		})
+		defer cleanup()
+
+		ctx.router.addNodeFailures = 1
+
+		na, err := createNodeAnnouncement(nodeKeyPriv1)
+		if err != nil {
+			t.Fatalf("can't create node announcement: %v", err)
+		}
+
+		select {
+		case err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+			na, nodeKeyPub2,
+		):
+		case <-time.After(time.Second):
+			t.Fatal("node announcement wasn't processed")
+		}
+
+		if ctx.router.addNodeFailures != 0 {
+			t.Fatalf("failing attempt to add node wasn't made")
+		}
+
+		return len(ctx.router.nodes), err
+	}
+
+	// The first attempt to add the node will fail, while the retry
+	// should succeed.
+	numNodes, err := processNodeAnn(2)
+	if err != nil {
+		t.Fatalf("node announcement wasn't retried: %v", err)
+	}
+	if numNodes != 1 {
+		t.Fatalf("node wasn't added to router")
+	}
+
+	// Without any retries, a failure should result in the announcement
+	// being dropped.
+	numNodes, err = processNodeAnn(0)
+	if err == nil {
+		t.Fatal("node announcement should have been dropped")
+	}
+	if numNodes != 0 {
+		t.Fatalf("dropped node was added to router")
+	}
+}
+
+// TestRouterRetryEdges checks that channel announcements and updates which
+// the router fails to apply are retried, rather than dropped right away.
+func TestRouterRetryEdges(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.RouterRetries = 2
+		cfg.RouterRetryBackoff = time.Millisecond
+	})
+	defer cleanup()
+
+	// The first attempt to add the channel, and to update its policy,
+	// will fail, while the retries should succeed.
+	ctx.router.addEdgeFailures = 1
+	ctx.router.updateEdgeFailures = 1
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	ua, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	for _, msg := range []lnwire.Message{ca, ua} {
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			msg, nodeKeyPub2,
+		):
+			if err != nil {
+				t.Fatalf("%T wasn't retried: %v", msg, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%T wasn't processed", msg)
+		}
+	}
+
+	if ctx.router.addEdgeFailures != 0 {
+		t.Fatal("failing attempt to add edge wasn't made")
+	}
+	if ctx.router.updateEdgeFailures != 0 {
+		t.Fatal("failing attempt to update edge wasn't made")
+	}
+	chanID := ca.ShortChannelID.ToUint64()
+	if _, ok := ctx.router.infos[chanID]; !ok {
+		t.Fatal("edge wasn't added to router")
+	}
+	if len(ctx.router.edges[chanID]) != 1 {
+		t.Fatal("edge update wasn't added to router")
+	}
+}
+
+// TestStartupRetry ensures that the gossiper retries failed registrations for
+// block and stale block notifications when starting, up to the configured
+// number of retries.
+func TestStartupRetry(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	ctx.gossiper.Stop()
+
+	startGossiper := func(retries int) error {
+		notifier := newMockNotifier()
+		notifier.registerFailures = 2
+		notifier.staleRegisterFailures = 2
+
+		cfg := *ctx.gossiper.cfg
+		cfg.Notifier = notifier
+		cfg.StartupRetries = retries
+		cfg.StartupRetryBackoff = time.Millisecond
+
+		gossiper, err := New(cfg, nodeKeyPub1)
+		if err != nil {
+			t.Fatalf("unable to create gossiper: %v", err)
+		}
+		if err := gossiper.Start(); err != nil {
+			return err
+		}
+		gossiper.Stop()
+
+		return nil
+	}
+
+	// With too few retries, the startup should fail.
+	if err := startGossiper(1); err == nil {
+		t.Fatal("gossiper started despite failing notifier")
+	}
+
+	// With enough retries, the gossiper should eventually start.
+	if err := startGossiper(2); err != nil {
+		t.Fatalf("unable to start gossiper: %v", err)
+	}
+}
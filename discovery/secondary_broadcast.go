@@ -0,0 +1,55 @@
+package discovery
+
+import "github.com/viacoin/lnd/lnwire"
+
+// mirrorQueueSize is the maximum number of broadcasts queued to be mirrored
+// over the SecondaryBroadcast transport. Once reached, further broadcasts
+// aren't mirrored until the transport catches up.
+const mirrorQueueSize = 100
+
+// mirrorBroadcast queues the passed broadcast to be mirrored over the
+// SecondaryBroadcast transport by the mirrorHandler, if one is configured.
+// The mirror is best-effort: a degraded secondary transport can neither delay
+// nor fail the broadcast over the primary transport, so if the queue is full,
+// the broadcast isn't mirrored.
+func (d *AuthenticatedGossiper) mirrorBroadcast(msgs ...lnwire.Message) {
+	if d.cfg.SecondaryBroadcast == nil || len(msgs) == 0 {
+		return
+	}
+
+	// The caller may reuse the backing array of the passed slice once we
+	// return, so we'll queue a copy.
+	mirror := make([]lnwire.Message, len(msgs))
+	copy(mirror, msgs)
+
+	select {
+	case d.mirrorQueue <- mirror:
+	case <-d.quit:
+	default:
+		log.Warnf("Secondary transport is falling behind, not "+
+			"mirroring broadcast of %v announcements", len(mirror))
+	}
+}
+
+// mirrorHandler mirrors the broadcasts queued by mirrorBroadcast over the
+// SecondaryBroadcast transport, until the gossiper is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (d *AuthenticatedGossiper) mirrorHandler() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case mirror := <-d.mirrorQueue:
+			err := d.cfg.SecondaryBroadcast(mirror...)
+			if err != nil {
+				log.Warnf("Unable to mirror broadcast of %v "+
+					"announcements over secondary "+
+					"transport: %v", len(mirror), err)
+			}
+
+		case <-d.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package discovery
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestSecondaryBroadcast ensures that each broadcast batch is sent over both
+// the primary and the secondary transport, and that a blocking secondary
+// transport doesn't hold up the primary one.
+func TestSecondaryBroadcast(t *testing.T) {
+	t.Parallel()
+
+	// The first mirrored batch will block the secondary transport until
+	// we release it.
+	release := make(chan struct{})
+	mirrored := make(chan lnwire.Message, 10)
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.SecondaryBroadcast = func(msgs ...lnwire.Message) error {
+			<-release
+			for _, msg := range msgs {
+				mirrored <- msg
+			}
+			return nil
+		}
+	})
+	defer cleanup()
+
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	// The batch should reach the primary transport even though the
+	// secondary one is blocked.
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		if msg != na {
+			t.Fatalf("unexpected broadcast message: %T", msg)
+		}
+	case <-time.After(2 * trickleDelay):
+		t.Fatal("announcement wasn't broadcast over primary transport")
+	}
+
+	// Once released, the batch should be mirrored over the secondary
+	// transport as well.
+	close(release)
+
+	select {
+	case msg := <-mirrored:
+		if msg != na {
+			t.Fatalf("unexpected mirrored message: %T", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("announcement wasn't mirrored over secondary transport")
+	}
+}
+
+// TestSecondaryBroadcastOnce ensures that a batch whose broadcast over the
+// primary transport fails is only mirrored over the secondary transport once
+// it's broadcast successfully, rather than with each attempt.
+func TestSecondaryBroadcastOnce(t *testing.T) {
+	t.Parallel()
+
+	var numAttempts int32
+	broadcasted := make(chan lnwire.Message, 10)
+	mirrored := make(chan lnwire.Message, 10)
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.Broadcast = func(_ *btcec.PublicKey,
+			msgs ...lnwire.Message) error {
+
+			if atomic.AddInt32(&numAttempts, 1) == 1 {
+				return errors.New("unable to broadcast")
+			}
+			for _, msg := range msgs {
+				broadcasted <- msg
+			}
+			return nil
+		}
+		cfg.SecondaryBroadcast = func(msgs ...lnwire.Message) error {
+			for _, msg := range msgs {
+				mirrored <- msg
+			}
+			return nil
+		}
+	})
+	defer cleanup()
+
+	na, err := createNodeAnnouncement(nodeKeyPriv1)
+	if err != nil {
+		t.Fatalf("can't create node announcement: %v", err)
+	}
+
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(na, nodeKeyPub2)
+	if err != nil {
+		t.Fatalf("can't process remote announcement: %v", err)
+	}
+
+	// The first attempt to broadcast the batch fails, so it should only
+	// reach the primary transport with the following attempt.
+	select {
+	case <-broadcasted:
+	case <-time.After(3 * trickleDelay):
+		t.Fatal("announcement wasn't broadcast over primary transport")
+	}
+
+	select {
+	case msg := <-mirrored:
+		if msg != na {
+			t.Fatalf("unexpected mirrored message: %T", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("announcement wasn't mirrored over secondary transport")
+	}
+
+	// The failed attempt shouldn't have resulted in the batch being
+	// mirrored once more.
+	select {
+	case <-mirrored:
+		t.Fatal("announcement was mirrored more than once")
+	case <-time.After(2 * trickleDelay):
+	}
+}
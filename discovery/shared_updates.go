@@ -0,0 +1,60 @@
+package discovery
+
+import (
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// fetchSharedUpdates returns the latest channel updates of our own direction
+// of all channels which we share with the passed peer. As opposed to the full
+// graph dump, this includes the channels which haven't been announced yet.
+func (d *AuthenticatedGossiper) fetchSharedUpdates(
+	peer *btcec.PublicKey) ([]lnwire.Message, error) {
+
+	// We'll only resend the policies of our own direction, as only those
+	// are ours to vouch for, so it suffices to iterate over our own
+	// channels rather than the entire graph.
+	var updates []lnwire.Message
+	err := d.cfg.Router.ForAllOutgoingChannels(func(
+		chanInfo *channeldb.ChannelEdgeInfo,
+		policy *channeldb.ChannelEdgePolicy) error {
+
+		if !chanInfo.NodeKey1.IsEqual(peer) &&
+			!chanInfo.NodeKey2.IsEqual(peer) {
+
+			return nil
+		}
+		if policy == nil {
+			return nil
+		}
+
+		update := ChanUpdateFromPolicy(chanInfo.ChainHash, policy)
+		updates = append(updates, update)
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// resendSharedUpdates sends the latest channel updates of all channels which
+// we share with the passed peer directly to that peer, such that a
+// reconnecting peer learns of any policy changes made while it was offline.
+func (d *AuthenticatedGossiper) resendSharedUpdates(peer *btcec.PublicKey) error {
+	updates, err := d.fetchSharedUpdates(peer)
+	if err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	log.Debugf("Resending %v shared channel updates to %x", len(updates),
+		peer.SerializeCompressed())
+
+	return d.sendToPeer(peer, updates...)
+}
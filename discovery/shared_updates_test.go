@@ -0,0 +1,110 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestResendSharedUpdates ensures that once a peer connects, the latest
+// updates of our own direction of only those channels which we share with the
+// peer are resent to it.
+func TestResendSharedUpdates(t *testing.T) {
+	t.Parallel()
+
+	sent := make(chan lnwire.Message, 10)
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.ResendSharedUpdates = true
+		cfg.SendToPeer = func(target *btcec.PublicKey,
+			msgs ...lnwire.Message) error {
+
+			if !target.IsEqual(nodeKeyPub2) {
+				t.Errorf("messages sent to unexpected peer %x",
+					target.SerializeCompressed())
+			}
+			for _, msg := range msgs {
+				sent <- msg
+			}
+			return nil
+		}
+	})
+	defer cleanup()
+
+	otherPriv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("can't create private key: %v", err)
+	}
+
+	// We'll add a channel shared with the peer, along with both of its
+	// policies, and a channel with another node. Neither of them has been
+	// announced yet. The peer is the first node of the shared channel, so
+	// our own policy is the second one.
+	sharedChanID := uint64(1)
+	ctx.router.infos[sharedChanID] = &channeldb.ChannelEdgeInfo{
+		ChannelID: sharedChanID,
+		NodeKey1:  nodeKeyPub2,
+		NodeKey2:  nodeKeyPub1,
+	}
+	ctx.router.edges[sharedChanID] = []*channeldb.ChannelEdgePolicy{
+		{
+			ChannelID:  sharedChanID,
+			LastUpdate: time.Unix(123456, 0),
+			Flags:      0,
+		},
+		{
+			ChannelID:     sharedChanID,
+			LastUpdate:    time.Unix(123456, 0),
+			Flags:         lnwire.ChanUpdateDirection,
+			TimeLockDelta: 144,
+		},
+	}
+
+	otherChanID := uint64(2)
+	ctx.router.infos[otherChanID] = &channeldb.ChannelEdgeInfo{
+		ChannelID: otherChanID,
+		NodeKey1:  nodeKeyPub1,
+		NodeKey2:  otherPriv.PubKey(),
+	}
+	ctx.router.edges[otherChanID] = []*channeldb.ChannelEdgePolicy{
+		{
+			ChannelID:  otherChanID,
+			LastUpdate: time.Unix(123456, 0),
+		},
+	}
+
+	ctx.gossiper.SynchronizeNode(nodeKeyPub2)
+
+	// Only our own update of the shared channel should be sent to the
+	// peer.
+	var updates []*lnwire.ChannelUpdate
+out:
+	for {
+		select {
+		case msg := <-sent:
+			if update, ok := msg.(*lnwire.ChannelUpdate); ok {
+				updates = append(updates, update)
+			}
+		case <-time.After(2 * trickleDelay):
+			break out
+		}
+	}
+
+	if len(updates) != 1 {
+		t.Fatalf("expected 1 channel update, got %v", len(updates))
+	}
+	update := updates[0]
+	if update.ShortChannelID.ToUint64() != sharedChanID {
+		t.Fatalf("expected update of channel %v, got %v",
+			sharedChanID, update.ShortChannelID.ToUint64())
+	}
+	if update.Flags&lnwire.ChanUpdateDirection == 0 {
+		t.Fatal("expected update of our own direction")
+	}
+	if update.TimeLockDelta != 144 {
+		t.Fatalf("expected time lock delta of 144, got %v",
+			update.TimeLockDelta)
+	}
+}
@@ -0,0 +1,107 @@
+package discovery
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// updateTimestampOffset is the offset of the timestamp of a ChannelUpdate
+// within its serialized data to be signed, following the chain hash and the
+// short channel ID.
+const updateTimestampOffset = 32 + 8
+
+// updateSignKey is the set of material fields of a ChannelUpdate which are
+// covered by its signature, except for its timestamp. As any change to these
+// fields results in a distinct key, a change to any field implicitly
+// invalidates the cached signing data of the prior update. The timestamp is
+// excluded, as it's bumped each time an update is re-signed, and is instead
+// written into the cached data upon each lookup.
+type updateSignKey struct {
+	chainHash       chainhash.Hash
+	shortChannelID  lnwire.ShortChannelID
+	flags           uint16
+	timeLockDelta   uint16
+	htlcMinimumMsat lnwire.MilliSatoshi
+	baseFee         uint32
+	feeRate         uint32
+	htlcMaximumMsat lnwire.MilliSatoshi
+	extraOpaqueData string
+}
+
+// newUpdateSignKey returns the updateSignKey of the passed ChannelUpdate.
+func newUpdateSignKey(update *lnwire.ChannelUpdate) updateSignKey {
+	return updateSignKey{
+		chainHash:       update.ChainHash,
+		shortChannelID:  update.ShortChannelID,
+		flags:           update.Flags,
+		timeLockDelta:   update.TimeLockDelta,
+		htlcMinimumMsat: update.HtlcMinimumMsat,
+		baseFee:         update.BaseFee,
+		feeRate:         update.FeeRate,
+		htlcMaximumMsat: update.HtlcMaximumMsat,
+		extraOpaqueData: string(update.ExtraOpaqueData),
+	}
+}
+
+// signDataCache is a bounded cache which maps the material fields of a
+// ChannelUpdate to the serialized data which is to be signed. This allows
+// bulk re-signing of updates whose only change is their timestamp to skip
+// re-serialization.
+type signDataCache struct {
+	// maxSize is the maximum number of entries within the cache. Once
+	// reached, the cache is flushed before adding a new entry.
+	maxSize int
+
+	// hits is the number of lookups that were served from the cache.
+	hits uint64
+
+	entries map[updateSignKey][]byte
+	sync.Mutex
+}
+
+// newSignDataCache returns a new signDataCache which holds at most maxSize
+// entries.
+func newSignDataCache(maxSize int) *signDataCache {
+	return &signDataCache{
+		maxSize: maxSize,
+		entries: make(map[updateSignKey][]byte),
+	}
+}
+
+// dataToSign returns the serialized data of the passed ChannelUpdate which is
+// to be signed, serializing the update only if it isn't already cached.
+func (c *signDataCache) dataToSign(update *lnwire.ChannelUpdate) ([]byte, error) {
+	key := newUpdateSignKey(update)
+
+	c.Lock()
+	defer c.Unlock()
+
+	if cached, ok := c.entries[key]; ok {
+		c.hits++
+
+		// The cached data may carry the timestamp of a prior update,
+		// so we'll return a copy carrying the current one.
+		data := make([]byte, len(cached))
+		copy(data, cached)
+		binary.BigEndian.PutUint32(
+			data[updateTimestampOffset:], update.Timestamp,
+		)
+
+		return data, nil
+	}
+
+	data, err := update.DataToSign()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.entries) >= c.maxSize {
+		c.entries = make(map[updateSignKey][]byte)
+	}
+	c.entries[key] = data
+
+	return data, nil
+}
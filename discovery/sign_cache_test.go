@@ -0,0 +1,139 @@
+package discovery
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestSignDataCache ensures that the serialized data of an unchanged channel
+// update, or one whose only change is its timestamp, is served from the cache,
+// while any other change to the update results in it being serialized anew.
+func TestSignDataCache(t *testing.T) {
+	t.Parallel()
+
+	update, err := createUpdateAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create update announcement: %v", err)
+	}
+
+	cache := newSignDataCache(10)
+
+	data, err := cache.dataToSign(update)
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if cache.hits != 0 {
+		t.Fatalf("expected no cache hits, got %v", cache.hits)
+	}
+
+	// Signing the same update again should be served from the cache.
+	cachedData, err := cache.dataToSign(update)
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if cache.hits != 1 {
+		t.Fatalf("expected a single cache hit, got %v", cache.hits)
+	}
+	if !bytes.Equal(data, cachedData) {
+		t.Fatal("cached data doesn't match serialized data")
+	}
+
+	// Re-signing the update with a new timestamp should be served from
+	// the cache as well, with the data reflecting the new timestamp.
+	update.Timestamp++
+	cachedData, err = cache.dataToSign(update)
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if cache.hits != 2 {
+		t.Fatalf("expected two cache hits, got %v", cache.hits)
+	}
+
+	expectedData, err := update.DataToSign()
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if !bytes.Equal(cachedData, expectedData) {
+		t.Fatal("cached data doesn't reflect the new timestamp")
+	}
+
+	// Once a field of the update changes, the update should be
+	// serialized once more, reflecting the new field.
+	update.FeeRate++
+	newData, err := cache.dataToSign(update)
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if cache.hits != 2 {
+		t.Fatalf("expected two cache hits, got %v", cache.hits)
+	}
+
+	expectedData, err = update.DataToSign()
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if !bytes.Equal(newData, expectedData) {
+		t.Fatal("data doesn't reflect the changed update")
+	}
+
+	// The same should hold if only the extra data of the update changes,
+	// as it's covered by the signature as well.
+	err = update.SetInboundFee(lnwire.InboundFee{BaseFee: -1})
+	if err != nil {
+		t.Fatalf("unable to set inbound fee: %v", err)
+	}
+	newData, err = cache.dataToSign(update)
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if cache.hits != 2 {
+		t.Fatalf("expected two cache hits, got %v", cache.hits)
+	}
+
+	expectedData, err = update.DataToSign()
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if !bytes.Equal(newData, expectedData) {
+		t.Fatal("data doesn't reflect the changed extra data")
+	}
+}
+
+// benchmarkDataToSign benchmarks retrieving the data to sign of a channel
+// update being re-signed with a new timestamp, using the cache if one is
+// passed.
+func benchmarkDataToSign(b *testing.B, cache *signDataCache) {
+	update, err := createUpdateAnnouncement(0)
+	if err != nil {
+		b.Fatalf("can't create update announcement: %v", err)
+	}
+
+	dataToSign := func(update *lnwire.ChannelUpdate) ([]byte, error) {
+		if cache == nil {
+			return update.DataToSign()
+		}
+		return cache.dataToSign(update)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		update.Timestamp++
+		if _, err := dataToSign(update); err != nil {
+			b.Fatalf("unable to get data to sign: %v", err)
+		}
+	}
+}
+
+// BenchmarkDataToSignNoCache benchmarks serializing a channel update for
+// signing without a cache.
+func BenchmarkDataToSignNoCache(b *testing.B) {
+	benchmarkDataToSign(b, nil)
+}
+
+// BenchmarkDataToSignCache benchmarks serializing a channel update for
+// signing with a cache.
+func BenchmarkDataToSignCache(b *testing.B) {
+	benchmarkDataToSign(b, newSignDataCache(10))
+}
@@ -0,0 +1,111 @@
+package discovery
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/viacoin/lnd/channeldb"
+)
+
+// GossiperSnapshot is a point-in-time view of the internal state of the
+// gossiper. All fields are captured at once, so the snapshot is internally
+// consistent. Snapshots are purely observational, and can be compared to
+// learn how the state of the gossiper evolves.
+type GossiperSnapshot struct {
+	// BestHeight is the height of the chain tip as known to the gossiper.
+	BestHeight uint32
+
+	// PrematureAnns is the number of premature announcements held back
+	// for each block height at which they're to be processed.
+	PrematureAnns map[uint32]int
+
+	// WaitingProofs holds the keys of all halves of channel proofs that
+	// are waiting for their opposite half, in ascending order.
+	WaitingProofs []channeldb.WaitingProofKey
+
+	// BatchDepth is the number of announcements within the current batch
+	// awaiting broadcast.
+	BatchDepth int
+}
+
+// snapshotRequest is a request for a snapshot of the internal state of the
+// gossiper.
+type snapshotRequest struct {
+	resp chan GossiperSnapshot
+
+	errResp chan error
+}
+
+// Snapshot returns a consistent point-in-time view of the internal state of
+// the gossiper, consisting of the premature announcements by height, the
+// waiting channel proofs, the best known height, and the depth of the current
+// announcement batch.
+func (d *AuthenticatedGossiper) Snapshot() (GossiperSnapshot, error) {
+	req := &snapshotRequest{
+		resp:    make(chan GossiperSnapshot, 1),
+		errResp: make(chan error, 1),
+	}
+
+	select {
+	case d.snapshotRequests <- req:
+	case <-d.quit:
+		return GossiperSnapshot{}, ErrShuttingDown
+	}
+
+	select {
+	case snapshot := <-req.resp:
+		return snapshot, nil
+	case err := <-req.errResp:
+		return GossiperSnapshot{}, err
+	case <-d.quit:
+		return GossiperSnapshot{}, ErrShuttingDown
+	}
+}
+
+// snapshot captures the internal state of the gossiper, along with the passed
+// depth of the current announcement batch.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) snapshot(batchDepth int) (GossiperSnapshot,
+	error) {
+
+	snapshot := GossiperSnapshot{
+		BestHeight:    d.bestHeight,
+		PrematureAnns: make(map[uint32]int),
+		BatchDepth:    batchDepth,
+	}
+
+	for height, nMsgs := range d.prematureAnnouncements {
+		if len(nMsgs) != 0 {
+			snapshot.PrematureAnns[height] += len(nMsgs)
+		}
+	}
+	if d.prematureQueue != nil {
+		counts, err := d.prematureQueue.HeightCounts()
+		if err != nil {
+			return GossiperSnapshot{}, err
+		}
+		for height, count := range counts {
+			snapshot.PrematureAnns[height] += count
+		}
+	}
+
+	addProof := func(proof *channeldb.WaitingProof) error {
+		snapshot.WaitingProofs = append(
+			snapshot.WaitingProofs, proof.Key(),
+		)
+		return nil
+	}
+	err := d.waitingProofs.ForAll(addProof)
+	if err != nil && err != channeldb.ErrWaitingProofNotFound {
+		return GossiperSnapshot{}, err
+	}
+	sort.Slice(snapshot.WaitingProofs, func(i, j int) bool {
+		return bytes.Compare(
+			snapshot.WaitingProofs[i][:],
+			snapshot.WaitingProofs[j][:],
+		) < 0
+	})
+
+	return snapshot, nil
+}
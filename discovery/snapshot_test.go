@@ -0,0 +1,93 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/channeldb"
+)
+
+// TestGossiperSnapshot ensures that the differences between two snapshots of
+// the gossiper's state reflect the announcements processed in between them.
+func TestGossiperSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, proofMatureDelta)
+	defer cleanup()
+
+	before, err := ctx.gossiper.Snapshot()
+	if err != nil {
+		t.Fatalf("unable to take snapshot: %v", err)
+	}
+	if before.BestHeight != proofMatureDelta {
+		t.Fatalf("expected best height %v, got %v", proofMatureDelta,
+			before.BestHeight)
+	}
+	if len(before.PrematureAnns) != 0 || len(before.WaitingProofs) != 0 {
+		t.Fatalf("expected empty snapshot, got %v", before)
+	}
+
+	// We'll send two channel announcements which are premature at
+	// distinct heights. These are held back, so we won't receive a
+	// response to them.
+	prematureHeights := []uint32{proofMatureDelta + 1, proofMatureDelta + 2}
+	for _, height := range prematureHeights {
+		ca, err := createRemoteChannelAnnouncement(height)
+		if err != nil {
+			t.Fatalf("can't create channel announcement: %v", err)
+		}
+
+		select {
+		case <-ctx.gossiper.ProcessRemoteAnnouncement(ca, nodeKeyPub2):
+			t.Fatal("premature announcement was processed")
+		case <-time.After(trickleDelay):
+		}
+	}
+
+	// We'll also send half of the proof of a channel we don't know of
+	// yet, which should be stored as a waiting proof.
+	batch, err := createAnnouncements(0)
+	if err != nil {
+		t.Fatalf("can't generate announcements: %v", err)
+	}
+	err = <-ctx.gossiper.ProcessRemoteAnnouncement(
+		batch.remoteProofAnn, nodeKeyPub2,
+	)
+	if err != nil {
+		t.Fatalf("unable to process proof: %v", err)
+	}
+
+	after, err := ctx.gossiper.Snapshot()
+	if err != nil {
+		t.Fatalf("unable to take snapshot: %v", err)
+	}
+
+	if after.BestHeight != before.BestHeight {
+		t.Fatalf("expected best height %v, got %v", before.BestHeight,
+			after.BestHeight)
+	}
+	if after.BatchDepth != before.BatchDepth {
+		t.Fatalf("expected batch depth %v, got %v", before.BatchDepth,
+			after.BatchDepth)
+	}
+
+	if len(after.PrematureAnns) != len(prematureHeights) {
+		t.Fatalf("expected premature announcements at %v heights, "+
+			"got %v", len(prematureHeights), after)
+	}
+	for _, height := range prematureHeights {
+		if after.PrematureAnns[height] != 1 {
+			t.Fatalf("expected 1 premature announcement at "+
+				"height %v, got %v", height,
+				after.PrematureAnns[height])
+		}
+	}
+
+	proof := channeldb.NewWaitingProof(true, batch.remoteProofAnn)
+	if len(after.WaitingProofs) != 1 ||
+		after.WaitingProofs[0] != proof.Key() {
+
+		t.Fatalf("expected waiting proof %x, got %v", proof.Key(),
+			after.WaitingProofs)
+	}
+}
@@ -0,0 +1,140 @@
+package discovery
+
+import (
+	"net"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+const (
+	// msgTypeSize is the number of bytes used to encode the type of a
+	// message on the wire.
+	msgTypeSize = 2
+
+	// chanAnnWireSize is the number of bytes used to encode a channel
+	// announcement on the wire: four signatures, an empty feature vector,
+	// the chain hash, the short channel ID and four public keys.
+	chanAnnWireSize = msgTypeSize + 4*64 + 2 + 32 + 8 + 4*33
+
+	// chanUpdateWireSize is the number of bytes used to encode a channel
+	// update on the wire: the signature, chain hash, short channel ID,
+	// timestamp, flags, time lock delta, minimum HTLC, base fee and fee
+	// rate.
+	chanUpdateWireSize = msgTypeSize + 64 + 32 + 8 + 4 + 2 + 2 + 8 + 4 + 4
+
+	// maxHTLCWireSize is the number of bytes used to encode the maximum
+	// HTLC of a channel update on the wire, which is only present if the
+	// ChanUpdateOptionMaxHtlc flag is set.
+	maxHTLCWireSize = 8
+
+	// nodeAnnBaseWireSize is the number of bytes used to encode a node
+	// announcement on the wire, excluding its feature vector and
+	// addresses: the signature, timestamp, public key, color, alias, and
+	// the length of the addresses.
+	nodeAnnBaseWireSize = msgTypeSize + 64 + 4 + 33 + 3 + 32 + 2
+)
+
+// EstimateSyncSize estimates the size of a full sync of our graph to a peer,
+// as performed by SynchronizeNode, returning the number of messages the sync
+// would consist of along with their approximate size on the wire in bytes.
+// The estimate is derived from the graph directly, without re-creating the
+// announcements themselves.
+func (d *AuthenticatedGossiper) EstimateSyncSize() (int, int, error) {
+	var numMessages, numBytes int
+
+	// Just as with a sync, each channel for which we have a proof results
+	// in its announcement, along with an update for each of its known
+	// directed edges.
+	err := d.cfg.Router.ForEachChannel(func(
+		chanInfo *channeldb.ChannelEdgeInfo,
+		e1, e2 *channeldb.ChannelEdgePolicy) error {
+
+		if chanInfo.AuthProof == nil {
+			return nil
+		}
+
+		numMessages++
+		numBytes += chanAnnWireSize
+
+		for _, edge := range []*channeldb.ChannelEdgePolicy{e1, e2} {
+			if edge == nil {
+				continue
+			}
+
+			numMessages++
+			numBytes += chanUpdateWireSize +
+				len(edge.ExtraOpaqueData)
+
+			if edge.Flags&lnwire.ChanUpdateOptionMaxHtlc != 0 {
+				numBytes += maxHTLCWireSize
+			}
+		}
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return 0, 0, err
+	}
+
+	// Each node that we've received an announcement for results in its
+	// announcement as well.
+	err = d.cfg.Router.ForEachNode(func(node *channeldb.LightningNode) error {
+		if !node.HaveNodeAnnouncement {
+			return nil
+		}
+
+		numMessages++
+		numBytes += nodeAnnBaseWireSize
+
+		if node.Features != nil {
+			var features byteCounter
+			if err := node.Features.Encode(&features); err != nil {
+				return err
+			}
+			numBytes += int(features)
+		}
+
+		for _, addr := range node.Addresses {
+			numBytes += addrWireSize(addr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return numMessages, numBytes, nil
+}
+
+// addrWireSize returns the number of bytes used to encode the passed address
+// within a node announcement.
+func addrWireSize(addr net.Addr) int {
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		if addr.IP.To4() != nil {
+			return 1 + 4 + 2
+		}
+		return 1 + 16 + 2
+
+	case *lnwire.OnionAddr:
+		if addr.IsV3() {
+			return 1 + 35 + 2
+		}
+		return 1 + 10 + 2
+
+	// We'll assume the size of an IPv6 address for any other address.
+	default:
+		return 1 + 16 + 2
+	}
+}
+
+// byteCounter is an io.Writer which merely counts the number of bytes
+// written to it.
+type byteCounter int
+
+func (b *byteCounter) Write(p []byte) (int, error) {
+	*b += byteCounter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,196 @@
+package discovery
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestEstimateSyncSize checks that the estimated size of a full graph sync is
+// within a small tolerance of the size of the actual serialized sync.
+func TestEstimateSyncSize(t *testing.T) {
+	t.Parallel()
+
+	// We'll populate a synthetic graph directly, as the estimate doesn't
+	// depend on the validity of the announcements within it.
+	router := newMockRouter(0)
+	gossiper := &AuthenticatedGossiper{
+		cfg: &Config{
+			Router: router,
+		},
+	}
+
+	const numChans = 20
+	for i := 0; i < numChans; i++ {
+		var keys [4]*btcec.PublicKey
+		for j := range keys {
+			priv, err := btcec.NewPrivateKey(btcec.S256())
+			if err != nil {
+				t.Fatalf("unable to generate key: %v", err)
+			}
+			keys[j] = priv.PubKey()
+		}
+
+		chanID := uint64(i)
+		router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+			ChannelID:   chanID,
+			NodeKey1:    keys[0],
+			NodeKey2:    keys[1],
+			BitcoinKey1: keys[2],
+			BitcoinKey2: keys[3],
+			AuthProof: &channeldb.ChannelAuthProof{
+				NodeSig1:    testSig,
+				NodeSig2:    testSig,
+				BitcoinSig1: testSig,
+				BitcoinSig2: testSig,
+			},
+		}
+
+		// Only every other channel has an update in both directions.
+		numEdges := 1 + i%2
+		for j := 0; j < numEdges; j++ {
+			router.edges[chanID] = append(router.edges[chanID],
+				&channeldb.ChannelEdgePolicy{
+					Signature:  testSig,
+					ChannelID:  chanID,
+					LastUpdate: time.Unix(int64(i), 0),
+					Flags:      uint16(j),
+				},
+			)
+		}
+
+		// We'll use a mix of IPv4 and IPv6 addresses for the nodes.
+		addrs := testAddrs
+		if i%3 == 0 {
+			addrs = []net.Addr{
+				testAddr,
+				&net.TCPAddr{IP: net.IPv6loopback, Port: 9735},
+			}
+		}
+		router.nodes = append(router.nodes, &channeldb.LightningNode{
+			HaveNodeAnnouncement: true,
+			AuthSig:              testSig,
+			LastUpdate:           time.Unix(int64(i), 0),
+			Addresses:            addrs,
+			PubKey:               keys[0],
+			Alias:                fmt.Sprintf("node%v", i),
+			Features:             testFeatures,
+		})
+	}
+
+	numMessages, numBytes, err := gossiper.EstimateSyncSize()
+	if err != nil {
+		t.Fatalf("unable to estimate sync size: %v", err)
+	}
+
+	announcements, _, _, err := gossiper.fetchGraphAnnouncements()
+	if err != nil {
+		t.Fatalf("unable to fetch graph announcements: %v", err)
+	}
+	if numMessages != len(announcements) {
+		t.Fatalf("expected %v messages, estimated %v",
+			len(announcements), numMessages)
+	}
+
+	var sync bytes.Buffer
+	for _, msg := range announcements {
+		if _, err := lnwire.WriteMessage(&sync, msg, 0); err != nil {
+			t.Fatalf("unable to serialize announcement: %v", err)
+		}
+	}
+
+	// The estimate should be within 5% of the actual size.
+	const tolerance = 0.05
+	actual := float64(sync.Len())
+	if diff := float64(numBytes) - actual; diff > actual*tolerance ||
+		-diff > actual*tolerance {
+
+		t.Fatalf("estimated sync size of %v bytes isn't within %v%% "+
+			"of the actual size of %v bytes", numBytes,
+			tolerance*100, sync.Len())
+	}
+}
+
+// TestEstimateSyncSizeMaxHTLC checks that the maximum HTLC of channel updates
+// which carry one is accounted for within the estimated size of a sync.
+func TestEstimateSyncSizeMaxHTLC(t *testing.T) {
+	t.Parallel()
+
+	router := newMockRouter(0)
+	gossiper := &AuthenticatedGossiper{
+		cfg: &Config{
+			Router: router,
+		},
+	}
+
+	var keys [4]*btcec.PublicKey
+	for i := range keys {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		keys[i] = priv.PubKey()
+	}
+
+	// We'll add a single channel, with only one of its updates carrying
+	// a maximum HTLC.
+	const chanID = 1
+	router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+		ChannelID:   chanID,
+		NodeKey1:    keys[0],
+		NodeKey2:    keys[1],
+		BitcoinKey1: keys[2],
+		BitcoinKey2: keys[3],
+		AuthProof: &channeldb.ChannelAuthProof{
+			NodeSig1:    testSig,
+			NodeSig2:    testSig,
+			BitcoinSig1: testSig,
+			BitcoinSig2: testSig,
+		},
+	}
+	router.edges[chanID] = []*channeldb.ChannelEdgePolicy{
+		{
+			Signature:  testSig,
+			ChannelID:  chanID,
+			LastUpdate: time.Unix(1, 0),
+		},
+		{
+			Signature:  testSig,
+			ChannelID:  chanID,
+			LastUpdate: time.Unix(1, 0),
+			Flags: lnwire.ChanUpdateDirection |
+				lnwire.ChanUpdateOptionMaxHtlc,
+			MaxHTLC: 100000,
+		},
+	}
+
+	_, numBytes, err := gossiper.EstimateSyncSize()
+	if err != nil {
+		t.Fatalf("unable to estimate sync size: %v", err)
+	}
+
+	announcements, _, _, err := gossiper.fetchGraphAnnouncements()
+	if err != nil {
+		t.Fatalf("unable to fetch graph announcements: %v", err)
+	}
+
+	var sync bytes.Buffer
+	for _, msg := range announcements {
+		if _, err := lnwire.WriteMessage(&sync, msg, 0); err != nil {
+			t.Fatalf("unable to serialize announcement: %v", err)
+		}
+	}
+
+	// As the sync lacks node announcements, the estimate should be
+	// exact.
+	if numBytes != sync.Len() {
+		t.Fatalf("estimated sync size of %v bytes, actual size is %v "+
+			"bytes", numBytes, sync.Len())
+	}
+}
@@ -0,0 +1,64 @@
+package discovery
+
+import (
+	"io/ioutil"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// dropOversizedMessages returns the passed messages of a sync with the target
+// peer, without those which can't be sent to it as a single message. These
+// are the messages which fail to serialize, or whose serialized form exceeds
+// either the maximum size allowed on the wire, or the MaxSyncMessageSize. As
+// announcements are signed, they can't be split, so each such message is
+// skipped with a warning instead, as otherwise the peer would disconnect
+// mid-sync. If a channel announcement is skipped, then so are the updates of
+// the channel that follow it, as the peer wouldn't be able to validate them.
+func (d *AuthenticatedGossiper) dropOversizedMessages(
+	targetNode *btcec.PublicKey, msgs []lnwire.Message) []lnwire.Message {
+
+	var (
+		filtered     []lnwire.Message
+		skippedChans = make(map[lnwire.ShortChannelID]struct{})
+	)
+	for _, msg := range msgs {
+		if update, ok := msg.(*lnwire.ChannelUpdate); ok {
+			_, skipped := skippedChans[update.ShortChannelID]
+			if skipped {
+				log.Warnf("Skipping %v for short_chan_id=%v "+
+					"within sync with %x, as its channel "+
+					"announcement was skipped",
+					msg.MsgType(), update.ShortChannelID,
+					targetNode.SerializeCompressed())
+				continue
+			}
+		}
+
+		size, err := lnwire.WriteMessage(ioutil.Discard, msg, 0)
+		switch {
+		case err != nil:
+			log.Warnf("Skipping %v within sync with %x: %v",
+				msg.MsgType(), targetNode.SerializeCompressed(),
+				err)
+
+		case d.cfg.MaxSyncMessageSize != 0 &&
+			size > d.cfg.MaxSyncMessageSize:
+
+			log.Warnf("Skipping %v within sync with %x: size "+
+				"of %v bytes exceeds maximum of %v bytes",
+				msg.MsgType(), targetNode.SerializeCompressed(),
+				size, d.cfg.MaxSyncMessageSize)
+
+		default:
+			filtered = append(filtered, msg)
+			continue
+		}
+
+		if chanAnn, ok := msg.(*lnwire.ChannelAnnouncement); ok {
+			skippedChans[chanAnn.ShortChannelID] = struct{}{}
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,147 @@
+package discovery
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestSyncSkipsOversizedMessages checks that messages which are too large to
+// be sent to a peer are skipped during a sync, rather than failing it.
+func TestSyncSkipsOversizedMessages(t *testing.T) {
+	t.Parallel()
+
+	var keys [4]*btcec.PublicKey
+	for i := range keys {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		keys[i] = priv.PubKey()
+	}
+
+	// We'll populate the graph with a single channel, a node with a
+	// single address and a node with so many addresses that its
+	// announcement exceeds the maximum message size.
+	router := newMockRouter(0)
+	router.infos[0] = &channeldb.ChannelEdgeInfo{
+		NodeKey1:    keys[0],
+		NodeKey2:    keys[1],
+		BitcoinKey1: keys[2],
+		BitcoinKey2: keys[3],
+		AuthProof: &channeldb.ChannelAuthProof{
+			NodeSig1:    testSig,
+			NodeSig2:    testSig,
+			BitcoinSig1: testSig,
+			BitcoinSig2: testSig,
+		},
+	}
+	router.edges[0] = []*channeldb.ChannelEdgePolicy{{
+		Signature:  testSig,
+		LastUpdate: time.Unix(1, 0),
+	}}
+
+	var addrs []net.Addr
+	for i := 0; i < 10000; i++ {
+		addrs = append(addrs, &net.TCPAddr{
+			IP:   net.IPv4(10, 0, byte(i>>8), byte(i)),
+			Port: 9735,
+		})
+	}
+	smallNode := &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		LastUpdate:           time.Unix(1, 0),
+		Addresses:            []net.Addr{testAddr},
+		PubKey:               keys[0],
+		AuthSig:              testSig,
+		Features:             testFeatures,
+	}
+	router.nodes = append(router.nodes, smallNode, &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		LastUpdate:           time.Unix(1, 0),
+		Addresses:            addrs,
+		PubKey:               keys[1],
+		AuthSig:              testSig,
+		Features:             testFeatures,
+	})
+
+	tests := []struct {
+		name string
+
+		maxSize int
+
+		// expected holds the types of the messages that should be
+		// sent, in order.
+		expected []lnwire.MessageType
+	}{
+		{
+			// Without a configured maximum, only the oversized
+			// node announcement should be skipped.
+			name: "wire limit",
+			expected: []lnwire.MessageType{
+				lnwire.MsgChannelAnnouncement,
+				lnwire.MsgChannelUpdate,
+				lnwire.MsgNodeAnnouncement,
+			},
+		},
+		{
+			// With a maximum below the size of the channel
+			// announcement, it should be skipped along with its
+			// update.
+			name:    "configured limit",
+			maxSize: 300,
+			expected: []lnwire.MessageType{
+				lnwire.MsgNodeAnnouncement,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var sent []lnwire.Message
+		gossiper := &AuthenticatedGossiper{
+			cfg: &Config{
+				Router: router,
+				SendToPeer: func(_ *btcec.PublicKey,
+					msgs ...lnwire.Message) error {
+
+					sent = append(sent, msgs...)
+					return nil
+				},
+				MaxSyncMessageSize: test.maxSize,
+			},
+			now: time.Now,
+		}
+
+		err := gossiper.synchronizeWithNode(&syncRequest{
+			node: nodeKeyPub2,
+		})
+		if err != nil {
+			t.Fatalf("%v: unable to sync with node: %v", test.name,
+				err)
+		}
+
+		if len(sent) != len(test.expected) {
+			t.Fatalf("%v: expected %v messages, got %v", test.name,
+				len(test.expected), len(sent))
+		}
+		for i, msg := range sent {
+			if msg.MsgType() != test.expected[i] {
+				t.Fatalf("%v: expected message %v to be %v, "+
+					"got %v", test.name, i,
+					test.expected[i], msg.MsgType())
+			}
+		}
+
+		// The node announcement that was sent should be the one of
+		// the node with a single address.
+		nodeAnn := sent[len(sent)-1].(*lnwire.NodeAnnouncement)
+		if !nodeAnn.NodeID.IsEqual(smallNode.PubKey) {
+			t.Fatalf("%v: oversized node announcement was sent",
+				test.name)
+		}
+	}
+}
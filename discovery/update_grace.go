@@ -0,0 +1,33 @@
+package discovery
+
+import (
+	"time"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// outdatedUpdateAge returns how much older the passed channel update is than
+// the policy we hold for the opposite direction of its channel, if we hold no
+// policy for its own direction yet. Such an update is most likely a valid one
+// we lost, resent by a peer during a resync. If we do hold a policy for its
+// direction, or the update isn't older than the opposite policy, then false
+// is returned, as the update is handled as usual.
+func outdatedUpdateAge(msg *lnwire.ChannelUpdate,
+	e1, e2 *channeldb.ChannelEdgePolicy) (time.Duration, bool) {
+
+	policy, opposite := e1, e2
+	if msg.Flags&lnwire.ChanUpdateDirection != 0 {
+		policy, opposite = e2, e1
+	}
+	if policy != nil || opposite == nil {
+		return 0, false
+	}
+
+	timestamp := time.Unix(int64(msg.Timestamp), 0)
+	if !timestamp.Before(opposite.LastUpdate) {
+		return 0, false
+	}
+
+	return opposite.LastUpdate.Sub(timestamp), true
+}
@@ -0,0 +1,119 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestOutdatedUpdateGrace ensures that a remote channel update for a direction
+// we hold no policy for, which is slightly older than the policy of the
+// opposite direction, is applied without being broadcast, while one beyond
+// the grace is rejected.
+func TestOutdatedUpdateGrace(t *testing.T) {
+	t.Parallel()
+
+	const grace = time.Minute
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.OutdatedUpdateGrace = grace
+	})
+	defer cleanup()
+
+	ca, err := createRemoteChannelAnnouncement(0)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+
+	// newUpdate creates an update for the passed direction of the channel
+	// with the passed timestamp.
+	const timestamp = 1500000000
+	newUpdate := func(flags uint16, ts uint32) *lnwire.ChannelUpdate {
+		update, err := createUpdateAnnouncement(0)
+		if err != nil {
+			t.Fatalf("can't create update announcement: %v", err)
+		}
+		update.Flags = flags
+		update.Timestamp = ts
+
+		priv := nodeKeyPriv1
+		if flags&lnwire.ChanUpdateDirection != 0 {
+			priv = nodeKeyPriv2
+		}
+		signer := mockSigner{priv}
+		update.Signature, err = SignAnnouncement(
+			&signer, priv.PubKey(), update,
+		)
+		if err != nil {
+			t.Fatalf("can't sign update announcement: %v", err)
+		}
+
+		return update
+	}
+
+	processErr := func(msg lnwire.Message) error {
+		select {
+		case err := <-ctx.gossiper.ProcessRemoteAnnouncement(
+			msg, nodeKeyPub2,
+		):
+			return err
+		case <-time.After(time.Second):
+			t.Fatalf("%T wasn't processed", msg)
+		}
+
+		return nil
+	}
+	process := func(msg lnwire.Message) {
+		if err := processErr(msg); err != nil {
+			t.Fatalf("can't process %T: %v", msg, err)
+		}
+	}
+
+	// We'll start out holding a policy for the first direction of the
+	// channel only.
+	process(ca)
+	process(newUpdate(0, timestamp))
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ctx.broadcastedMessage:
+		case <-time.After(2 * trickleDelay):
+			t.Fatal("announcement wasn't broadcast")
+		}
+	}
+
+	// As we hold no policy for the second direction, an update for it
+	// which is older than the policy of the first direction beyond the
+	// grace should be rejected.
+	graceSecs := uint32(grace / time.Second)
+	err = processErr(newUpdate(
+		lnwire.ChanUpdateDirection, timestamp-2*graceSecs,
+	))
+	if err == nil {
+		t.Fatal("update beyond the grace was accepted")
+	}
+
+	chanID := ca.ShortChannelID.ToUint64()
+	if len(ctx.router.edges[chanID]) != 1 {
+		t.Fatalf("expected 1 applied update, got %v",
+			len(ctx.router.edges[chanID]))
+	}
+
+	// A slightly older update for the second direction should be
+	// applied, but not broadcast.
+	process(newUpdate(lnwire.ChanUpdateDirection, timestamp-graceSecs/2))
+
+	edges := ctx.router.edges[chanID]
+	if len(edges) != 2 {
+		t.Fatalf("expected 2 applied updates, got %v", len(edges))
+	}
+	if edges[1].Flags&lnwire.ChanUpdateDirection == 0 {
+		t.Fatal("expected update of the second direction to be " +
+			"applied")
+	}
+	select {
+	case msg := <-ctx.broadcastedMessage:
+		t.Fatalf("outdated update was broadcast: %v", msg)
+	case <-time.After(2 * trickleDelay):
+	}
+}
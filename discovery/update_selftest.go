@@ -0,0 +1,65 @@
+package discovery
+
+import (
+	"math/rand"
+
+	"github.com/go-errors/errors"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// selfTestUpdates re-validates the signatures of a random sample of the
+// channel updates that we've signed for our own channels, returning the short
+// channel IDs of all updates within the sample whose signature no longer
+// verifies against our identity key. Such a mismatch can only be the result of
+// a botched key rotation or data corruption, as we verify each update once
+// signed.
+func (d *AuthenticatedGossiper) selfTestUpdates() ([]lnwire.ShortChannelID,
+	error) {
+
+	type updateTuple struct {
+		info *channeldb.ChannelEdgeInfo
+		edge *channeldb.ChannelEdgePolicy
+	}
+	var selfUpdates []updateTuple
+	err := d.cfg.Router.ForAllOutgoingChannels(func(
+		info *channeldb.ChannelEdgeInfo,
+		edge *channeldb.ChannelEdgePolicy) error {
+
+		selfUpdates = append(selfUpdates, updateTuple{
+			info: info,
+			edge: edge,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Errorf("unable to retrieve outgoing "+
+			"channels: %v", err)
+	}
+
+	// We'll only verify a random sample of our updates, so the cost of
+	// each self-test remains bounded for nodes with many channels.
+	sampleSize := d.cfg.SelfTestSampleSize
+	if sampleSize > len(selfUpdates) {
+		sampleSize = len(selfUpdates)
+	}
+
+	var invalid []lnwire.ShortChannelID
+	for _, i := range rand.Perm(len(selfUpdates))[:sampleSize] {
+		info, edge := selfUpdates[i].info, selfUpdates[i].edge
+
+		chanUpdate := ChanUpdateFromPolicy(info.ChainHash, edge)
+
+		if edge.Signature == nil {
+			invalid = append(invalid, chanUpdate.ShortChannelID)
+			continue
+		}
+
+		err := d.validateChannelUpdateAnn(d.selfKey, chanUpdate)
+		if err != nil {
+			invalid = append(invalid, chanUpdate.ShortChannelID)
+		}
+	}
+
+	return invalid, nil
+}
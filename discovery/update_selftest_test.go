@@ -0,0 +1,81 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestSelfTestUpdates tests that the self-test of the gossiper detects a
+// channel update of our own whose signature doesn't verify against our
+// identity key.
+func TestSelfTestUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.SelfTestSampleSize = 10
+	})
+	defer cleanup()
+
+	// We'll populate the graph with two of our own channels, each with a
+	// properly signed channel update.
+	const numChans = 2
+	for i := uint64(1); i <= numChans; i++ {
+		ctx.router.infos[i] = &channeldb.ChannelEdgeInfo{
+			ChannelID: i,
+			NodeKey1:  nodeKeyPub1,
+			NodeKey2:  nodeKeyPub2,
+		}
+
+		edge := &channeldb.ChannelEdgePolicy{
+			ChannelID:                 i,
+			LastUpdate:                time.Unix(int64(i), 0),
+			TimeLockDelta:             uint16(i),
+			MinHTLC:                   lnwire.MilliSatoshi(i),
+			FeeBaseMSat:               lnwire.MilliSatoshi(i),
+			FeeProportionalMillionths: lnwire.MilliSatoshi(i),
+		}
+		update := &lnwire.ChannelUpdate{
+			ShortChannelID:  lnwire.NewShortChanIDFromInt(i),
+			Timestamp:       uint32(edge.LastUpdate.Unix()),
+			TimeLockDelta:   edge.TimeLockDelta,
+			HtlcMinimumMsat: edge.MinHTLC,
+			BaseFee:         uint32(edge.FeeBaseMSat),
+			FeeRate:         uint32(edge.FeeProportionalMillionths),
+		}
+		sig, err := SignAnnouncement(
+			&mockSigner{nodeKeyPriv1}, nodeKeyPub1, update,
+		)
+		if err != nil {
+			t.Fatalf("unable to sign update: %v", err)
+		}
+		edge.Signature = sig
+
+		ctx.router.edges[i] = []*channeldb.ChannelEdgePolicy{edge}
+	}
+
+	// With all signatures intact, the self-test shouldn't detect any
+	// mismatches.
+	invalid, err := ctx.gossiper.selfTestUpdates()
+	if err != nil {
+		t.Fatalf("unable to self-test updates: %v", err)
+	}
+	if len(invalid) != 0 {
+		t.Fatalf("expected no invalid updates, got %v", invalid)
+	}
+
+	// We'll now corrupt the stored signature of the second channel by
+	// replacing it with the signature of the first one.
+	ctx.router.edges[2][0].Signature = ctx.router.edges[1][0].Signature
+
+	invalid, err = ctx.gossiper.selfTestUpdates()
+	if err != nil {
+		t.Fatalf("unable to self-test updates: %v", err)
+	}
+	if len(invalid) != 1 || invalid[0].ToUint64() != 2 {
+		t.Fatalf("expected update of channel 2 to be invalid, "+
+			"got %v", invalid)
+	}
+}
@@ -3,6 +3,7 @@ package discovery
 import (
 	"github.com/go-errors/errors"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/viacoin/lnd/channeldb"
 	"github.com/viacoin/lnd/lnwallet"
 	"github.com/viacoin/lnd/lnwire"
@@ -45,35 +46,43 @@ func createChanAnnouncement(chanProof *channeldb.ChannelAuthProof,
 	// is nil.
 	var edge1Ann, edge2Ann *lnwire.ChannelUpdate
 	if e1 != nil {
-		edge1Ann = &lnwire.ChannelUpdate{
-			Signature:       e1.Signature,
-			ChainHash:       chanInfo.ChainHash,
-			ShortChannelID:  chanID,
-			Timestamp:       uint32(e1.LastUpdate.Unix()),
-			Flags:           0,
-			TimeLockDelta:   e1.TimeLockDelta,
-			HtlcMinimumMsat: e1.MinHTLC,
-			BaseFee:         uint32(e1.FeeBaseMSat),
-			FeeRate:         uint32(e1.FeeProportionalMillionths),
-		}
+		edge1Ann = ChanUpdateFromPolicy(chanInfo.ChainHash, e1)
 	}
 	if e2 != nil {
-		edge2Ann = &lnwire.ChannelUpdate{
-			Signature:       e2.Signature,
-			ChainHash:       chanInfo.ChainHash,
-			ShortChannelID:  chanID,
-			Timestamp:       uint32(e2.LastUpdate.Unix()),
-			Flags:           1,
-			TimeLockDelta:   e2.TimeLockDelta,
-			HtlcMinimumMsat: e2.MinHTLC,
-			BaseFee:         uint32(e2.FeeBaseMSat),
-			FeeRate:         uint32(e2.FeeProportionalMillionths),
-		}
+		edge2Ann = ChanUpdateFromPolicy(chanInfo.ChainHash, e2)
 	}
 
 	return chanAnn, edge1Ann, edge2Ann
 }
 
+// ChanUpdateFromPolicy creates the channel update which announces the passed
+// policy of a channel within the target chain.
+func ChanUpdateFromPolicy(chainHash chainhash.Hash,
+	policy *channeldb.ChannelEdgePolicy) *lnwire.ChannelUpdate {
+
+	return &lnwire.ChannelUpdate{
+		Signature:       policy.Signature,
+		ChainHash:       chainHash,
+		ShortChannelID:  lnwire.NewShortChanIDFromInt(policy.ChannelID),
+		Timestamp:       uint32(policy.LastUpdate.Unix()),
+		Flags:           policy.Flags,
+		TimeLockDelta:   policy.TimeLockDelta,
+		HtlcMinimumMsat: policy.MinHTLC,
+		BaseFee:         uint32(policy.FeeBaseMSat),
+		FeeRate:         uint32(policy.FeeProportionalMillionths),
+		HtlcMaximumMsat: policy.MaxHTLC,
+		ExtraOpaqueData: policy.ExtraOpaqueData,
+	}
+}
+
+// isSelfChannel returns true if we're either node of the passed channel.
+func (d *AuthenticatedGossiper) isSelfChannel(
+	info *channeldb.ChannelEdgeInfo) bool {
+
+	return info.NodeKey1.IsEqual(d.selfKey) ||
+		info.NodeKey2.IsEqual(d.selfKey)
+}
+
 // copyPubKey performs a copy of the target public key, setting a fresh curve
 // parameter during the process.
 func copyPubKey(pub *btcec.PublicKey) *btcec.PublicKey {
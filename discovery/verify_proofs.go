@@ -0,0 +1,61 @@
+package discovery
+
+import (
+	"fmt"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// ProofError is returned by VerifyAllProofs for each stored channel proof
+// which fails to validate.
+type ProofError struct {
+	// ShortChannelID is the short channel ID of the channel whose proof
+	// failed to validate.
+	ShortChannelID lnwire.ShortChannelID
+
+	// Err is the reason for which the proof failed to validate.
+	Err error
+}
+
+// Error returns a human readable description of the proof failure.
+//
+// NOTE: Part of the error interface.
+func (e *ProofError) Error() string {
+	return fmt.Sprintf("invalid proof for short_chan_id=%v: %v",
+		e.ShortChannelID, e.Err)
+}
+
+// VerifyAllProofs re-creates the channel announcement of each channel within
+// the graph from its stored authentication proof, and validates the
+// signatures of the announcement. A ProofError is returned for each channel
+// whose proof fails to validate, which can only be the result of data
+// corruption, or a botched migration. Channels without a proof are skipped.
+func (d *AuthenticatedGossiper) VerifyAllProofs() []error {
+	var errs []error
+	err := d.cfg.Router.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		if info.AuthProof == nil {
+			return nil
+		}
+
+		chanAnn, _, _ := createChanAnnouncement(
+			info.AuthProof, info, nil, nil,
+		)
+		if err := d.validateChannelAnn(chanAnn); err != nil {
+			errs = append(errs, &ProofError{
+				ShortChannelID: chanAnn.ShortChannelID,
+				Err:            err,
+			})
+		}
+
+		return nil
+	})
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		errs = append(errs, fmt.Errorf("unable to iterate over "+
+			"channels: %v", err))
+	}
+
+	return errs
+}
@@ -0,0 +1,64 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+// TestVerifyAllProofs ensures that only the stored channel proofs which fail
+// to validate are reported.
+func TestVerifyAllProofs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cleanup := newTestCtx(t, 0)
+	defer cleanup()
+
+	// We'll store the proofs of two channels, the second of which has
+	// been tampered with by swapping its first node signature for one of
+	// another channel.
+	validAnn, err := createRemoteChannelAnnouncement(1)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	tamperedAnn, err := createRemoteChannelAnnouncement(2)
+	if err != nil {
+		t.Fatalf("can't create channel announcement: %v", err)
+	}
+	tamperedAnn.NodeSig1 = validAnn.NodeSig1
+
+	anns := []*lnwire.ChannelAnnouncement{validAnn, tamperedAnn}
+	for _, ann := range anns {
+		chanID := ann.ShortChannelID.ToUint64()
+		ctx.router.infos[chanID] = &channeldb.ChannelEdgeInfo{
+			ChannelID:   chanID,
+			ChainHash:   ann.ChainHash,
+			NodeKey1:    ann.NodeID1,
+			NodeKey2:    ann.NodeID2,
+			BitcoinKey1: ann.BitcoinKey1,
+			BitcoinKey2: ann.BitcoinKey2,
+			AuthProof: &channeldb.ChannelAuthProof{
+				NodeSig1:    ann.NodeSig1,
+				NodeSig2:    ann.NodeSig2,
+				BitcoinSig1: ann.BitcoinSig1,
+				BitcoinSig2: ann.BitcoinSig2,
+			},
+		}
+	}
+
+	errs := ctx.gossiper.VerifyAllProofs()
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 invalid proof, got %v: %v", len(errs),
+			errs)
+	}
+
+	proofErr, ok := errs[0].(*ProofError)
+	if !ok {
+		t.Fatalf("expected ProofError, got %T", errs[0])
+	}
+	if proofErr.ShortChannelID != tamperedAnn.ShortChannelID {
+		t.Fatalf("expected invalid proof of %v, got %v",
+			tamperedAnn.ShortChannelID, proofErr.ShortChannelID)
+	}
+}
@@ -0,0 +1,29 @@
+package discovery
+
+// noteWrongChainAnn counts the passed announcement, which targets a chain
+// other than ours, towards the MaxWrongChainAnns of the peer that sent it.
+// Once the peer exceeds the maximum, it's handed to the WrongChainPeer
+// callback, and its count is reset.
+//
+// NOTE: This MUST only be called from within the networkHandler.
+func (d *AuthenticatedGossiper) noteWrongChainAnn(nMsg *networkMsg) {
+	if d.cfg.MaxWrongChainAnns == 0 || !nMsg.isRemote || nMsg.peer == nil {
+		return
+	}
+
+	var peer [33]byte
+	copy(peer[:], nMsg.peer.SerializeCompressed())
+
+	d.wrongChainAnns[peer]++
+	numAnns := d.wrongChainAnns[peer]
+	if numAnns <= d.cfg.MaxWrongChainAnns {
+		return
+	}
+
+	log.Warnf("Peer %x sent %v announcements for a chain other than "+
+		"ours, exceeding maximum of %v", peer[:], numAnns,
+		d.cfg.MaxWrongChainAnns)
+
+	delete(d.wrongChainAnns, peer)
+	d.cfg.WrongChainPeer(nMsg.peer, numAnns)
+}
@@ -0,0 +1,88 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestWrongChainPeer checks that a peer which persistently sends us
+// announcements for a chain other than ours is handed to the WrongChainPeer
+// callback once it exceeds the MaxWrongChainAnns.
+func TestWrongChainPeer(t *testing.T) {
+	t.Parallel()
+
+	type wrongChainPeer struct {
+		peer    *btcec.PublicKey
+		numAnns int
+	}
+	wrongChainPeers := make(chan wrongChainPeer, 10)
+
+	const maxWrongChainAnns = 3
+	ctx, cleanup := newTestCtx(t, 0, func(cfg *Config) {
+		cfg.MaxWrongChainAnns = maxWrongChainAnns
+		cfg.WrongChainPeer = func(peer *btcec.PublicKey,
+			numAnns int) {
+
+			wrongChainPeers <- wrongChainPeer{peer, numAnns}
+		}
+	})
+	defer cleanup()
+
+	sendWrongChainUpdate := func(peer *btcec.PublicKey) {
+		ua, err := createUpdateAnnouncement(0)
+		if err != nil {
+			t.Fatalf("can't create update announcement: %v", err)
+		}
+		ua.ChainHash[0] ^= 0xff
+
+		// Announcements for another chain are dropped without a
+		// response, so we won't wait for one.
+		ctx.gossiper.ProcessRemoteAnnouncement(ua, peer)
+	}
+
+	// We'll have one peer send as many wrong-chain announcements as
+	// permitted, interleaved with those of another peer, whose
+	// announcements shouldn't count towards the former's.
+	for i := 0; i < maxWrongChainAnns; i++ {
+		sendWrongChainUpdate(nodeKeyPub2)
+		sendWrongChainUpdate(nodeKeyPub1)
+	}
+
+	select {
+	case p := <-wrongChainPeers:
+		t.Fatalf("peer %x reported before exceeding the maximum",
+			p.peer.SerializeCompressed())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once the peer sends another one, it should be reported.
+	sendWrongChainUpdate(nodeKeyPub2)
+
+	select {
+	case p := <-wrongChainPeers:
+		if !p.peer.IsEqual(nodeKeyPub2) {
+			t.Fatalf("expected peer %x to be reported, got %x",
+				nodeKeyPub2.SerializeCompressed(),
+				p.peer.SerializeCompressed())
+		}
+		if p.numAnns != maxWrongChainAnns+1 {
+			t.Fatalf("expected %v announcements to be reported, "+
+				"got %v", maxWrongChainAnns+1, p.numAnns)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("peer wasn't reported")
+	}
+
+	// The count of the peer should've been reset, so a single further
+	// announcement shouldn't have it reported again.
+	sendWrongChainUpdate(nodeKeyPub2)
+
+	select {
+	case p := <-wrongChainPeers:
+		t.Fatalf("peer %x reported again after reset",
+			p.peer.SerializeCompressed())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
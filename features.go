@@ -19,4 +19,12 @@ var localFeatures = lnwire.NewFeatureVector([]lnwire.Feature{
 		Name: "announce-graph",
 		Flag: lnwire.OptionalFlag,
 	},
+	{
+		Name: compressedGossipFeature,
+		Flag: lnwire.OptionalFlag,
+	},
 })
+
+// compressedGossipFeature is the local feature which signals that we're able
+// to receive announcements within compressed batches.
+const compressedGossipFeature = "compressed-gossip"
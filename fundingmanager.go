@@ -23,6 +23,7 @@ import (
 	"github.com/viacoin/lnd/lnrpc"
 	"github.com/viacoin/lnd/lnwallet"
 	"github.com/viacoin/lnd/lnwire"
+	"github.com/viacoin/lnd/routing"
 	"google.golang.org/grpc"
 )
 
@@ -213,6 +214,14 @@ type fundingConfig struct {
 	// initially announcing channels.
 	DefaultRoutingPolicy htlcswitch.ForwardingPolicy
 
+	// DefaultPolicyForChannel is an optional hook which returns the fee
+	// schedule to initially announce for a newly opened channel of the
+	// passed capacity with the passed peer, overriding the fees of the
+	// DefaultRoutingPolicy. If nil, the DefaultRoutingPolicy is used for
+	// all channels.
+	DefaultPolicyForChannel func(chanAmt btcutil.Amount,
+		peer *btcec.PublicKey) routing.FeeSchema
+
 	// NumRequiredConfs is a function closure that helps the funding
 	// manager decide how many confirmations it should require for a
 	// channel extended to it. The function is able to take into account
@@ -226,6 +235,77 @@ type fundingConfig struct {
 	// in order to give us more time to claim funds in the case of a
 	// contract breach.
 	RequiredRemoteDelay func(btcutil.Amount) uint16
+
+	// MinChanSize is the smallest inbound channel we'll accept.
+	MinChanSize btcutil.Amount
+
+	// MaxChanSize is the largest channel we'll accept, or open ourselves.
+	// It must not exceed maxFundingAmount. If zero, then maxFundingAmount
+	// is used.
+	MaxChanSize btcutil.Amount
+
+	// MaxAcceptedPushAmt is the largest push amount we'll accept within
+	// an inbound channel. If zero, then no limit is enforced.
+	MaxAcceptedPushAmt lnwire.MilliSatoshi
+
+	// ChannelAcceptor is an optional hook which is consulted before we
+	// accept an inbound channel. It returns whether the channel should be
+	// accepted, and if not, the reason for the rejection which is sent to
+	// the remote peer. If nil, all inbound channels that pass our other
+	// checks are accepted.
+	ChannelAcceptor func(req *ChannelAcceptRequest) (bool, string)
+}
+
+// ChannelAcceptRequest describes an inbound channel proposed by a remote
+// peer, which is handed to the ChannelAcceptor in order to decide whether the
+// channel should be accepted.
+type ChannelAcceptRequest struct {
+	// Node is the identity public key of the peer proposing the channel.
+	Node *btcec.PublicKey
+
+	// ChainHash is the genesis hash of the chain the channel is to be
+	// opened within.
+	ChainHash chainhash.Hash
+
+	// PendingChanID is the temporary ID of the channel, which is used to
+	// identify it until the funding transaction has been broadcast.
+	PendingChanID [32]byte
+
+	// FundingAmt is the total capacity of the channel.
+	FundingAmt btcutil.Amount
+
+	// PushAmt is the amount the remote peer pushes to us upon opening
+	// the channel.
+	PushAmt lnwire.MilliSatoshi
+
+	// DustLimit is the dust limit requested by the remote peer.
+	DustLimit btcutil.Amount
+
+	// MaxValueInFlight is the maximum amount of outstanding HTLCs the
+	// remote peer permits us to have.
+	MaxValueInFlight lnwire.MilliSatoshi
+
+	// ChannelReserve is the reserve the remote peer requires us to keep.
+	ChannelReserve btcutil.Amount
+
+	// MinHtlc is the smallest HTLC the remote peer will accept.
+	MinHtlc lnwire.MilliSatoshi
+
+	// FeePerKiloWeight is the initial fee rate of the commitment
+	// transaction.
+	FeePerKiloWeight uint64
+
+	// CsvDelay is the delay the remote peer requires us to wait before we
+	// can sweep our funds in the case of a unilateral close.
+	CsvDelay uint16
+
+	// MaxAcceptedHTLCs is the maximum number of HTLCs the remote peer
+	// will accept.
+	MaxAcceptedHTLCs uint16
+
+	// ChannelFlags are the flags of the channel, such as whether it's to
+	// be announced to the network.
+	ChannelFlags byte
 }
 
 // fundingManager acts as an orchestrator/bridge between the wallet's
@@ -669,6 +749,17 @@ func (f *fundingManager) processFundingOpen(msg *lnwire.OpenChannel,
 	}
 }
 
+// maxChanSize returns the largest channel we'll accept, or open ourselves,
+// falling back to the current soft-limit for channel size if no maximum
+// channel size has been configured.
+func (f *fundingManager) maxChanSize() btcutil.Amount {
+	if f.cfg.MaxChanSize == 0 {
+		return maxFundingAmount
+	}
+
+	return f.cfg.MaxChanSize
+}
+
 // handleFundingOpen creates an initial 'ChannelReservation' within the wallet,
 // then responds to the source peer with an accept channel message progressing
 // the funding workflow.
@@ -710,9 +801,9 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		return
 	}
 
-	// We'll reject any request to create a channel that's above the
-	// current soft-limit for channel size.
-	if msg.FundingAmount > maxFundingAmount {
+	// We'll reject any request to create a channel that's above our
+	// configured maximum channel size.
+	if msg.FundingAmount > f.maxChanSize() {
 		f.failFundingFlow(
 			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
 			lnwire.ErrorData{byte(lnwire.ErrChanTooLarge)},
@@ -720,6 +811,67 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		return
 	}
 
+	// Similarly, we'll reject any request to create a channel that's below
+	// our configured minimum channel size.
+	if msg.FundingAmount < f.cfg.MinChanSize {
+		f.failFundingFlow(
+			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
+			lnwire.ErrorData{byte(lnwire.ErrChanTooSmall)},
+		)
+		return
+	}
+
+	// We'll also reject any request to create a channel which pushes
+	// more funds to us than we're willing to accept.
+	maxPushAmt := f.cfg.MaxAcceptedPushAmt
+	if maxPushAmt != 0 && msg.PushAmount > maxPushAmt {
+		err := fmt.Errorf("push amount of %v exceeds the maximum "+
+			"accepted push amount of %v", msg.PushAmount, maxPushAmt)
+		f.failFundingFlow(
+			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
+			[]byte(err.Error()),
+		)
+		return
+	}
+
+	// Finally, we'll give the channel acceptor, if any, the chance to
+	// reject the channel, in which case the reason for the rejection is
+	// sent to the remote peer.
+	if f.cfg.ChannelAcceptor != nil {
+		accept, reason := f.cfg.ChannelAcceptor(&ChannelAcceptRequest{
+			Node:             fmsg.peerAddress.IdentityKey,
+			ChainHash:        msg.ChainHash,
+			PendingChanID:    msg.PendingChannelID,
+			FundingAmt:       msg.FundingAmount,
+			PushAmt:          msg.PushAmount,
+			DustLimit:        msg.DustLimit,
+			MaxValueInFlight: msg.MaxValueInFlight,
+			ChannelReserve:   msg.ChannelReserve,
+			MinHtlc:          msg.HtlcMinimum,
+			FeePerKiloWeight: uint64(msg.FeePerKiloWeight),
+			CsvDelay:         msg.CsvDelay,
+			MaxAcceptedHTLCs: msg.MaxAcceptedHTLCs,
+			ChannelFlags:     msg.ChannelFlags,
+		})
+		if !accept {
+			if reason == "" {
+				reason = "channel rejected"
+			}
+
+			fndgLog.Infof("Rejecting fundingRequest(amt=%v, "+
+				"pendingId=%x) from peer(%x): %v", amt,
+				msg.PendingChannelID,
+				fmsg.peerAddress.IdentityKey.SerializeCompressed(),
+				reason)
+
+			f.failFundingFlow(
+				fmsg.peerAddress.IdentityKey,
+				msg.PendingChannelID, []byte(reason),
+			)
+			return
+		}
+	}
+
 	// TODO(roasbeef): error if funding flow already ongoing
 	fndgLog.Infof("Recv'd fundingRequest(amt=%v, push=%v, delay=%v, "+
 		"pendingId=%x) from peer(%x)", amt, msg.PushAmount,
@@ -1527,7 +1679,7 @@ func (f *fundingManager) sendChannelAnnouncement(completeChan *channeldb.OpenCha
 	// channel can be utilized during path finding.
 	err := f.announceChannel(f.cfg.IDKey, completeChan.IdentityPub,
 		channel.LocalFundingKey, channel.RemoteFundingKey,
-		*shortChanID, chanID)
+		*shortChanID, chanID, completeChan.Capacity)
 	if err != nil {
 		fndgLog.Errorf("channel announcement failed: %v", err)
 		return
@@ -1719,6 +1871,23 @@ type chanAnnouncement struct {
 	chanProof     *lnwire.AnnounceSignatures
 }
 
+// initialRoutingPolicy returns the routing policy which is initially used for
+// a newly opened channel of the passed capacity with the passed peer. This is
+// the DefaultRoutingPolicy, with its fees overridden by the
+// DefaultPolicyForChannel hook if set.
+func (f *fundingManager) initialRoutingPolicy(chanAmt btcutil.Amount,
+	peer *btcec.PublicKey) htlcswitch.ForwardingPolicy {
+
+	policy := f.cfg.DefaultRoutingPolicy
+	if f.cfg.DefaultPolicyForChannel != nil {
+		feeSchema := f.cfg.DefaultPolicyForChannel(chanAmt, peer)
+		policy.BaseFee = feeSchema.BaseFee
+		policy.FeeRate = feeSchema.FeeRate
+	}
+
+	return policy
+}
+
 // newChanAnnouncement creates the authenticated channel announcement messages
 // required to broadcast a newly created channel to the network. The
 // announcement is two part: the first part authenticates the existence of the
@@ -1728,8 +1897,8 @@ type chanAnnouncement struct {
 // channel.
 func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey *btcec.PublicKey,
 	localFundingKey, remoteFundingKey *btcec.PublicKey,
-	shortChanID lnwire.ShortChannelID,
-	chanID lnwire.ChannelID) (*chanAnnouncement, error) {
+	shortChanID lnwire.ShortChannelID, chanID lnwire.ChannelID,
+	chanAmt btcutil.Amount) (*chanAnnouncement, error) {
 
 	chainHash := *f.cfg.Wallet.Cfg.NetParams.GenesisHash
 
@@ -1774,15 +1943,16 @@ func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey *btcec.Pu
 		chanFlags = 1
 	}
 
+	policy := f.initialRoutingPolicy(chanAmt, remotePubKey)
 	chanUpdateAnn := &lnwire.ChannelUpdate{
 		ShortChannelID:  shortChanID,
 		ChainHash:       chainHash,
 		Timestamp:       uint32(time.Now().Unix()),
 		Flags:           chanFlags,
-		TimeLockDelta:   uint16(f.cfg.DefaultRoutingPolicy.TimeLockDelta),
-		HtlcMinimumMsat: f.cfg.DefaultRoutingPolicy.MinHTLC,
-		BaseFee:         uint32(f.cfg.DefaultRoutingPolicy.BaseFee),
-		FeeRate:         uint32(f.cfg.DefaultRoutingPolicy.FeeRate),
+		TimeLockDelta:   uint16(policy.TimeLockDelta),
+		HtlcMinimumMsat: policy.MinHTLC,
+		BaseFee:         uint32(policy.BaseFee),
+		FeeRate:         uint32(policy.FeeRate),
 	}
 
 	// With the channel update announcement constructed, we'll generate a
@@ -1847,14 +2017,14 @@ func (f *fundingManager) newChanAnnouncement(localPubKey, remotePubKey *btcec.Pu
 // finish, either successfully or with an error.
 func (f *fundingManager) announceChannel(localIDKey, remoteIDKey, localFundingKey,
 	remoteFundingKey *btcec.PublicKey, shortChanID lnwire.ShortChannelID,
-	chanID lnwire.ChannelID) error {
+	chanID lnwire.ChannelID, chanAmt btcutil.Amount) error {
 
 	// First, we'll create the batch of announcements to be sent upon
 	// initial channel creation. This includes the channel announcement
 	// itself, the channel update announcement, and our half of the channel
 	// proof needed to fully authenticate the channel.
 	ann, err := f.newChanAnnouncement(localIDKey, remoteIDKey,
-		localFundingKey, remoteFundingKey, shortChanID, chanID)
+		localFundingKey, remoteFundingKey, shortChanID, chanID, chanAmt)
 	if err != nil {
 		fndgLog.Errorf("can't generate channel announcement: %v", err)
 		return err
@@ -1927,6 +2097,14 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		msg.pushAmt, capacity, msg.chainHash, msg.peerAddress.Address,
 		ourDustLimit)
 
+	// We'll refuse to open a channel that's above our configured maximum
+	// channel size, as we'd reject such a channel if it were opened to us.
+	if maxChanSize := f.maxChanSize(); capacity > maxChanSize {
+		msg.err <- fmt.Errorf("channel size of %v exceeds the maximum "+
+			"channel size of %v", capacity, maxChanSize)
+		return
+	}
+
 	// First, we'll query the fee estimator for a fee that should get the
 	// commitment transaction into the next block (conf target of 1). We
 	// target the next block here to ensure that we'll be able to execute a
@@ -20,6 +20,7 @@ import (
 	"github.com/viacoin/lnd/lnrpc"
 	"github.com/viacoin/lnd/lnwallet"
 	"github.com/viacoin/lnd/lnwire"
+	"github.com/viacoin/lnd/routing"
 
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/wire"
@@ -321,6 +322,8 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 		FindPeer:       oldCfg.FindPeer,
 		TempChanIDSeed: oldCfg.TempChanIDSeed,
 		FindChannel:    oldCfg.FindChannel,
+
+		MaxAcceptedPushAmt: oldCfg.MaxAcceptedPushAmt,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -1380,3 +1383,233 @@ func TestFundingManagerRestartAfterReceivingFundingLocked(t *testing.T) {
 	// channel.
 	assertHandleFundingLocked(t, alice, bob)
 }
+
+// proposeChannel has Alice propose a channel with the passed funding and push
+// amounts to Bob, returning the OpenChannel message sent by Alice along with
+// Bob's response to it.
+func proposeChannel(t *testing.T, alice, bob *testNode, localFundingAmt,
+	pushAmt btcutil.Amount) (*lnwire.OpenChannel, lnwire.Message) {
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	errChan := make(chan error, 1)
+	initReq := &openChanReq{
+		targetPeerID:    int32(1),
+		targetPubkey:    bob.privKey.PubKey(),
+		chainHash:       *activeNetParams.GenesisHash,
+		localFundingAmt: localFundingAmt,
+		pushAmt:         lnwire.NewMSatFromSatoshis(pushAmt),
+		updates:         updateChan,
+		err:             errChan,
+	}
+
+	alice.fundingMgr.initFundingWorkflow(bobAddr, initReq)
+
+	var aliceMsg lnwire.Message
+	select {
+	case aliceMsg = <-alice.msgChan:
+	case err := <-initReq.err:
+		t.Fatalf("error init funding workflow: %v", err)
+	case <-time.After(time.Second * 5):
+		t.Fatalf("alice did not send OpenChannel message")
+	}
+
+	openChannelReq, ok := aliceMsg.(*lnwire.OpenChannel)
+	if !ok {
+		t.Fatalf("expected OpenChannel to be sent from alice, "+
+			"instead got %T", aliceMsg)
+	}
+
+	bob.fundingMgr.processFundingOpen(openChannelReq, aliceAddr)
+
+	var bobMsg lnwire.Message
+	select {
+	case bobMsg = <-bob.msgChan:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("bob did not respond to OpenChannel message")
+	}
+
+	return openChannelReq, bobMsg
+}
+
+// TestFundingManagerChannelAcceptor checks that an inbound channel rejected
+// by the channel acceptor is declined, with the reason of the rejection sent
+// to the remote peer.
+func TestFundingManagerChannelAcceptor(t *testing.T) {
+	disableFndgLogger(t)
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Bob will only accept channels of at least minChanSize.
+	const (
+		minChanSize  = btcutil.Amount(1000000)
+		rejectReason = "channel below minimum size"
+	)
+	bob.fundingMgr.cfg.ChannelAcceptor = func(
+		req *ChannelAcceptRequest) (bool, string) {
+
+		if req.FundingAmt < minChanSize {
+			return false, rejectReason
+		}
+		return true, ""
+	}
+
+	// Alice attempts to open a channel below Bob's minimum size. Rather
+	// than accepting the channel, Bob should decline it with the reason
+	// given by the acceptor.
+	openChannelReq, bobMsg := proposeChannel(
+		t, alice, bob, minChanSize/2, 0,
+	)
+
+	errorMsg, ok := bobMsg.(*lnwire.Error)
+	if !ok {
+		t.Fatalf("expected Error to be sent from bob, instead got %T",
+			bobMsg)
+	}
+	if string(errorMsg.Data) != rejectReason {
+		t.Fatalf("expected rejection reason %q, got %q", rejectReason,
+			errorMsg.Data)
+	}
+	if errorMsg.ChanID != openChannelReq.PendingChannelID {
+		t.Fatalf("expected error for pending channel %x, got %x",
+			openChannelReq.PendingChannelID, errorMsg.ChanID)
+	}
+}
+
+// TestFundingManagerMaxChanSize checks that inbound channels above the
+// configured maximum channel size are rejected, while channels of exactly the
+// maximum size are accepted.
+func TestFundingManagerMaxChanSize(t *testing.T) {
+	disableFndgLogger(t)
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	const maxChanSize = btcutil.Amount(1000000)
+	bob.fundingMgr.cfg.MaxChanSize = maxChanSize
+
+	// A channel above Bob's maximum channel size should be rejected.
+	_, bobMsg := proposeChannel(t, alice, bob, maxChanSize+1, 0)
+	errorMsg, ok := bobMsg.(*lnwire.Error)
+	if !ok {
+		t.Fatalf("expected Error to be sent from bob, instead got %T",
+			bobMsg)
+	}
+	if lnwire.ErrorCode(errorMsg.Data[0]) != lnwire.ErrChanTooLarge {
+		t.Fatalf("expected ErrChanTooLarge, got %v",
+			lnwire.ErrorCode(errorMsg.Data[0]))
+	}
+
+	// A channel of exactly Bob's maximum channel size should be accepted.
+	_, bobMsg = proposeChannel(t, alice, bob, maxChanSize, 0)
+	if _, ok := bobMsg.(*lnwire.AcceptChannel); !ok {
+		t.Fatalf("expected AcceptChannel to be sent from bob, "+
+			"instead got %T", bobMsg)
+	}
+}
+
+// TestFundingManagerMaxAcceptedPushAmt checks that inbound channels pushing
+// more than the configured maximum accepted push amount are rejected.
+func TestFundingManagerMaxAcceptedPushAmt(t *testing.T) {
+	disableFndgLogger(t)
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	const maxPushAmt = btcutil.Amount(100000)
+	bob.fundingMgr.cfg.MaxAcceptedPushAmt = lnwire.NewMSatFromSatoshis(
+		maxPushAmt,
+	)
+
+	// Alice attempts to open a channel pushing more than Bob is willing
+	// to accept, which Bob should reject.
+	_, bobMsg := proposeChannel(t, alice, bob, 500000, maxPushAmt+1)
+	if _, ok := bobMsg.(*lnwire.Error); !ok {
+		t.Fatalf("expected Error to be sent from bob, instead got %T",
+			bobMsg)
+	}
+}
+
+// TestFundingManagerDefaultPolicyForChannel checks that the initial channel
+// update of a newly opened channel carries the fee schedule returned by the
+// DefaultPolicyForChannel hook, and that the default routing policy is used
+// if no hook is set.
+func TestFundingManagerDefaultPolicyForChannel(t *testing.T) {
+	disableFndgLogger(t)
+
+	alice, bob := setupFundingManagers(t)
+	defer tearDownFundingManagers(t, alice, bob)
+
+	// Alice charges a larger fee for channels of at least 100000 satoshis.
+	const largeChanSize = btcutil.Amount(100000)
+	overrideFees := routing.FeeSchema{
+		BaseFee: 5000,
+		FeeRate: 100,
+	}
+	defaultPolicy := alice.fundingMgr.cfg.DefaultRoutingPolicy
+	alice.fundingMgr.cfg.DefaultPolicyForChannel = func(
+		chanAmt btcutil.Amount, peer *btcec.PublicKey) routing.FeeSchema {
+
+		if !peer.IsEqual(bobPubKey) {
+			t.Errorf("expected policy for channel with bob, got %x",
+				peer.SerializeCompressed())
+		}
+
+		if chanAmt < largeChanSize {
+			return routing.FeeSchema{
+				BaseFee: defaultPolicy.BaseFee,
+				FeeRate: defaultPolicy.FeeRate,
+			}
+		}
+		return overrideFees
+	}
+
+	updateChan := make(chan *lnrpc.OpenStatusUpdate)
+	openChannel(t, alice, bob, 500000, 0, 1, updateChan)
+
+	alice.mockNotifier.confChannel <- &chainntnfs.TxConfirmation{}
+	bob.mockNotifier.confChannel <- &chainntnfs.TxConfirmation{}
+
+	checkNodeSendingFundingLocked(t, alice)
+	checkNodeSendingFundingLocked(t, bob)
+
+	// Both of them should announce their initial channel update, with
+	// Alice using her override fees, and Bob the default policy.
+	fetchUpdate := func(node *testNode) *lnwire.ChannelUpdate {
+		var update *lnwire.ChannelUpdate
+		for i := 0; i < 4; i++ {
+			select {
+			case msg := <-node.announceChan:
+				if u, ok := msg.(*lnwire.ChannelUpdate); ok {
+					update = u
+				}
+			case <-time.After(time.Second * 5):
+				t.Fatalf("announcement %v wasn't sent", i)
+			}
+		}
+		if update == nil {
+			t.Fatal("no ChannelUpdate was sent")
+		}
+		return update
+	}
+
+	aliceUpdate := fetchUpdate(alice)
+	if lnwire.MilliSatoshi(aliceUpdate.BaseFee) != overrideFees.BaseFee ||
+		aliceUpdate.FeeRate != overrideFees.FeeRate {
+
+		t.Fatalf("expected alice to announce base fee %v and fee "+
+			"rate %v, got %v and %v", overrideFees.BaseFee,
+			overrideFees.FeeRate, aliceUpdate.BaseFee,
+			aliceUpdate.FeeRate)
+	}
+
+	bobPolicy := bob.fundingMgr.cfg.DefaultRoutingPolicy
+	bobUpdate := fetchUpdate(bob)
+	if lnwire.MilliSatoshi(bobUpdate.BaseFee) != bobPolicy.BaseFee ||
+		bobUpdate.FeeRate != bobPolicy.FeeRate {
+
+		t.Fatalf("expected bob to announce the default fees, got "+
+			"base fee %v and fee rate %v", bobUpdate.BaseFee,
+			bobUpdate.FeeRate)
+	}
+}
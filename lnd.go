@@ -59,8 +59,12 @@ var (
 
 // lndMain is the true entry point for lnd. This function is required since
 // defers created in the top-level scope of a main method aren't executed if
-// os.Exit() is called.
-func lndMain() error {
+// os.Exit() is called. The channelAcceptor is an optional hook which decides
+// whether inbound channels are accepted, allowing builds which embed lnd to
+// supply their own policies. It's handed to the funding manager.
+func lndMain(
+	channelAcceptor func(req *ChannelAcceptRequest) (bool, string)) error {
+
 	// Load the configuration, and parse any command line options. This
 	// function will also set up logging properly.
 	loadedConfig, err := loadConfig()
@@ -72,6 +76,9 @@ func lndMain() error {
 		if logRotator != nil {
 			logRotator.Close()
 		}
+		if annAuditRotator != nil {
+			annAuditRotator.Close()
+		}
 	}()
 
 	// Show version at startup.
@@ -155,6 +162,19 @@ func lndMain() error {
 		return err
 	}
 
+	// Expose the metrics of the gossiper, along with those of the
+	// process, if requested.
+	if cfg.MetricsPort != "" {
+		go func() {
+			listenAddr := net.JoinHostPort("", cfg.MetricsPort)
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", newMetricsHandler(
+				server.authGossiper.MetricsHandler(),
+			))
+			fmt.Println(http.ListenAndServe(listenAddr, mux))
+		}()
+	}
+
 	// Next, we'll initialize the funding manager itself so it can answer
 	// queries while the wallet+chain are still syncing.
 	nodeSigner := newNodeSigner(idPrivKey)
@@ -221,6 +241,12 @@ func lndMain() error {
 			// configuration
 			return 4
 		},
+		MinChanSize: btcutil.Amount(cfg.MinChanSize),
+		MaxChanSize: btcutil.Amount(cfg.MaxChanSize),
+		MaxAcceptedPushAmt: lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(cfg.MaxAcceptedPushAmt),
+		),
+		ChannelAcceptor: channelAcceptor,
 	})
 	if err != nil {
 		return err
@@ -408,7 +434,7 @@ func main() {
 
 	// Call the "real" main in a nested manner so the defers will properly
 	// be executed in the case of a graceful shutdown.
-	if err := lndMain(); err != nil {
+	if err := lndMain(nil); err != nil {
 		if e, ok := err.(*flags.Error); ok && e.Type == flags.ErrHelp {
 		} else {
 			fmt.Fprintln(os.Stderr, err)
@@ -98,6 +98,8 @@ It has these top-level messages:
 	FeeReportResponse
 	FeeUpdateRequest
 	FeeUpdateResponse
+	EstimateFundingFeeRequest
+	EstimateFundingFeeResponse
 */
 package lnrpc
 
@@ -2214,6 +2216,7 @@ type RoutingPolicy struct {
 	MinHtlc          int64  `protobuf:"varint,2,opt,name=min_htlc" json:"min_htlc,omitempty"`
 	FeeBaseMsat      int64  `protobuf:"varint,3,opt,name=fee_base_msat" json:"fee_base_msat,omitempty"`
 	FeeRateMilliMsat int64  `protobuf:"varint,4,opt,name=fee_rate_milli_msat" json:"fee_rate_milli_msat,omitempty"`
+	Disabled         bool   `protobuf:"varint,5,opt,name=disabled" json:"disabled,omitempty"`
 }
 
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
@@ -2249,6 +2252,13 @@ func (m *RoutingPolicy) GetFeeRateMilliMsat() int64 {
 	return 0
 }
 
+func (m *RoutingPolicy) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
 // *
 // A fully authenticated channel along with all its unique attributes.
 // Once an authenticated channel announcement has been processed on the network,
@@ -3361,6 +3371,67 @@ func (m *FeeUpdateResponse) String() string            { return proto.CompactTex
 func (*FeeUpdateResponse) ProtoMessage()               {}
 func (*FeeUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
 
+type EstimateFundingFeeRequest struct {
+	// / The size of the channel to be funded, denominated in satoshis.
+	LocalFundingAmount int64 `protobuf:"varint,1,opt,name=local_funding_amount" json:"local_funding_amount,omitempty"`
+	// / The number of blocks within which the funding transaction should confirm.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf" json:"target_conf,omitempty"`
+}
+
+func (m *EstimateFundingFeeRequest) Reset()                    { *m = EstimateFundingFeeRequest{} }
+func (m *EstimateFundingFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFundingFeeRequest) ProtoMessage()               {}
+func (*EstimateFundingFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+
+func (m *EstimateFundingFeeRequest) GetLocalFundingAmount() int64 {
+	if m != nil {
+		return m.LocalFundingAmount
+	}
+	return 0
+}
+
+func (m *EstimateFundingFeeRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+type EstimateFundingFeeResponse struct {
+	// / The estimated fee of the funding transaction, denominated in satoshis.
+	FeeSat int64 `protobuf:"varint,1,opt,name=fee_sat" json:"fee_sat,omitempty"`
+	// / The estimated fee rate, denominated in satoshis per weight unit.
+	SatPerWeight int64 `protobuf:"varint,2,opt,name=sat_per_weight" json:"sat_per_weight,omitempty"`
+	// / The weight of the funding transaction template the fee is estimated for.
+	TxWeight int64 `protobuf:"varint,3,opt,name=tx_weight" json:"tx_weight,omitempty"`
+}
+
+func (m *EstimateFundingFeeResponse) Reset()                    { *m = EstimateFundingFeeResponse{} }
+func (m *EstimateFundingFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFundingFeeResponse) ProtoMessage()               {}
+func (*EstimateFundingFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+
+func (m *EstimateFundingFeeResponse) GetFeeSat() int64 {
+	if m != nil {
+		return m.FeeSat
+	}
+	return 0
+}
+
+func (m *EstimateFundingFeeResponse) GetSatPerWeight() int64 {
+	if m != nil {
+		return m.SatPerWeight
+	}
+	return 0
+}
+
+func (m *EstimateFundingFeeResponse) GetTxWeight() int64 {
+	if m != nil {
+		return m.TxWeight
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
 	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
@@ -3455,6 +3526,8 @@ func init() {
 	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
 	proto.RegisterType((*FeeUpdateRequest)(nil), "lnrpc.FeeUpdateRequest")
 	proto.RegisterType((*FeeUpdateResponse)(nil), "lnrpc.FeeUpdateResponse")
+	proto.RegisterType((*EstimateFundingFeeRequest)(nil), "lnrpc.EstimateFundingFeeRequest")
+	proto.RegisterType((*EstimateFundingFeeResponse)(nil), "lnrpc.EstimateFundingFeeResponse")
 	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
 }
 
@@ -3655,6 +3728,11 @@ type LightningClient interface {
 	// UpdateFees allows the caller to update the fee schedule for all channels
 	// globally, or a particular channel.
 	UpdateFees(ctx context.Context, in *FeeUpdateRequest, opts ...grpc.CallOption) (*FeeUpdateResponse, error)
+	// * lncli: `estimatefundingfee`
+	// EstimateFundingFee returns the estimated on-chain fee of the funding
+	// transaction for a channel of the given size, targeting confirmation within
+	// the given number of blocks.
+	EstimateFundingFee(ctx context.Context, in *EstimateFundingFeeRequest, opts ...grpc.CallOption) (*EstimateFundingFeeResponse, error)
 }
 
 type lightningClient struct {
@@ -4153,6 +4231,15 @@ func (c *lightningClient) UpdateFees(ctx context.Context, in *FeeUpdateRequest,
 	return out, nil
 }
 
+func (c *lightningClient) EstimateFundingFee(ctx context.Context, in *EstimateFundingFeeRequest, opts ...grpc.CallOption) (*EstimateFundingFeeResponse, error) {
+	out := new(EstimateFundingFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/EstimateFundingFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Lightning service
 
 type LightningServer interface {
@@ -4342,6 +4429,11 @@ type LightningServer interface {
 	// UpdateFees allows the caller to update the fee schedule for all channels
 	// globally, or a particular channel.
 	UpdateFees(context.Context, *FeeUpdateRequest) (*FeeUpdateResponse, error)
+	// * lncli: `estimatefundingfee`
+	// EstimateFundingFee returns the estimated on-chain fee of the funding
+	// transaction for a channel of the given size, targeting confirmation within
+	// the given number of blocks.
+	EstimateFundingFee(context.Context, *EstimateFundingFeeRequest) (*EstimateFundingFeeResponse, error)
 }
 
 func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
@@ -5073,6 +5165,24 @@ func _Lightning_UpdateFees_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Lightning_EstimateFundingFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFundingFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).EstimateFundingFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/EstimateFundingFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).EstimateFundingFee(ctx, req.(*EstimateFundingFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Lightning_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "lnrpc.Lightning",
 	HandlerType: (*LightningServer)(nil),
@@ -5209,6 +5319,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateFees",
 			Handler:    _Lightning_UpdateFees_Handler,
 		},
+		{
+			MethodName: "EstimateFundingFee",
+			Handler:    _Lightning_EstimateFundingFee_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -5249,292 +5363,297 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 4580 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5b, 0xdd, 0x6f, 0x1c, 0x4b,
-	0x56, 0x4f, 0x8f, 0x67, 0xec, 0x99, 0x33, 0x33, 0xfe, 0x28, 0x7f, 0x4d, 0x26, 0xd9, 0x6c, 0x52,
-	0x1b, 0xdd, 0x18, 0xef, 0xca, 0x4e, 0xbc, 0xec, 0x25, 0x9b, 0x00, 0x57, 0xce, 0xa7, 0x2f, 0xeb,
-	0x9b, 0xeb, 0x6d, 0xe7, 0xde, 0xc0, 0xae, 0x50, 0xd3, 0x9e, 0x2e, 0x8f, 0x7b, 0xd3, 0xd3, 0xdd,
-	0xb7, 0xbb, 0x26, 0xce, 0x6c, 0x14, 0x09, 0x5d, 0x10, 0xbc, 0x80, 0xf6, 0x61, 0x11, 0x08, 0x09,
-	0xa1, 0x95, 0x10, 0x8f, 0xf0, 0x0f, 0xf0, 0x1f, 0x20, 0x90, 0x90, 0xf6, 0x89, 0x17, 0x9e, 0xf8,
-	0x07, 0x78, 0xe0, 0x89, 0x17, 0x54, 0x9f, 0x5d, 0xd5, 0xdd, 0x4e, 0x82, 0x40, 0x3c, 0x79, 0xea,
-	0x57, 0xd5, 0xa7, 0xaa, 0x4e, 0x9d, 0x3a, 0x5f, 0x75, 0x0c, 0x9d, 0x2c, 0x1d, 0xed, 0xa4, 0x59,
-	0x42, 0x13, 0xd4, 0x8a, 0xe2, 0x2c, 0x1d, 0x0d, 0xaf, 0x8e, 0x93, 0x64, 0x1c, 0x91, 0x5d, 0x3f,
-	0x0d, 0x77, 0xfd, 0x38, 0x4e, 0xa8, 0x4f, 0xc3, 0x24, 0xce, 0xc5, 0x20, 0xfc, 0x1f, 0x0e, 0x74,
-	0x9f, 0x67, 0x7e, 0x9c, 0xfb, 0x23, 0x06, 0xa3, 0x01, 0x2c, 0xd0, 0xd7, 0xde, 0x99, 0x9f, 0x9f,
-	0x0d, 0x9c, 0xeb, 0xce, 0x56, 0xc7, 0x55, 0x4d, 0xb4, 0x01, 0xf3, 0xfe, 0x24, 0x99, 0xc6, 0x74,
-	0xd0, 0xb8, 0xee, 0x6c, 0xcd, 0xb9, 0xb2, 0x85, 0xbe, 0x03, 0x2b, 0xf1, 0x74, 0xe2, 0x8d, 0x92,
-	0xf8, 0x34, 0xcc, 0x26, 0x82, 0xf8, 0x60, 0xee, 0xba, 0xb3, 0xd5, 0x72, 0xab, 0x1d, 0xe8, 0x1a,
-	0xc0, 0x49, 0x94, 0x8c, 0x5e, 0x8a, 0x29, 0x9a, 0x7c, 0x0a, 0x03, 0x41, 0x18, 0x7a, 0xb2, 0x45,
-	0xc2, 0xf1, 0x19, 0x1d, 0xb4, 0x38, 0x21, 0x0b, 0x63, 0x34, 0x68, 0x38, 0x21, 0x5e, 0x4e, 0xfd,
-	0x49, 0x3a, 0x98, 0xe7, 0xab, 0x31, 0x10, 0xde, 0x9f, 0x50, 0x3f, 0xf2, 0x4e, 0x09, 0xc9, 0x07,
-	0x0b, 0xb2, 0x5f, 0x23, 0x78, 0x00, 0x1b, 0x4f, 0x09, 0x35, 0x76, 0x9d, 0xbb, 0xe4, 0xab, 0x29,
-	0xc9, 0x29, 0x3e, 0x04, 0x64, 0xc0, 0x8f, 0x08, 0xf5, 0xc3, 0x28, 0x47, 0x1f, 0x43, 0x8f, 0x1a,
-	0x83, 0x07, 0xce, 0xf5, 0xb9, 0xad, 0xee, 0x1e, 0xda, 0xe1, 0xfc, 0xdd, 0x31, 0x3e, 0x70, 0xad,
-	0x71, 0xf8, 0x5f, 0x1c, 0xe8, 0x1e, 0x93, 0x38, 0x90, 0xd4, 0x11, 0x82, 0x66, 0x40, 0x72, 0xca,
-	0x19, 0xdb, 0x73, 0xf9, 0x6f, 0xf4, 0x4d, 0xe8, 0xb2, 0xbf, 0x5e, 0x4e, 0xb3, 0x30, 0x1e, 0x73,
-	0xd6, 0x76, 0x5c, 0x60, 0xd0, 0x31, 0x47, 0xd0, 0x32, 0xcc, 0xf9, 0x13, 0xca, 0x19, 0x3a, 0xe7,
-	0xb2, 0x9f, 0xe8, 0x06, 0xf4, 0x52, 0x7f, 0x36, 0x21, 0x31, 0x2d, 0x98, 0xd8, 0x73, 0xbb, 0x12,
-	0x3b, 0x60, 0x5c, 0xdc, 0x81, 0x55, 0x73, 0x88, 0xa2, 0xde, 0xe2, 0xd4, 0x57, 0x8c, 0x91, 0x72,
-	0x92, 0x5b, 0xb0, 0xa4, 0xc6, 0x67, 0x62, 0xb1, 0x9c, 0xad, 0x1d, 0x77, 0x51, 0xc2, 0x8a, 0x41,
-	0x7f, 0xe6, 0x40, 0x4f, 0x6c, 0x29, 0x4f, 0x93, 0x38, 0x27, 0xe8, 0x26, 0xf4, 0xd5, 0x97, 0x24,
-	0xcb, 0x92, 0x4c, 0x4a, 0x8d, 0x0d, 0xa2, 0x6d, 0x58, 0x56, 0x40, 0x9a, 0x91, 0x70, 0xe2, 0x8f,
-	0x09, 0xdf, 0x6a, 0xcf, 0xad, 0xe0, 0x68, 0xaf, 0xa0, 0x98, 0x25, 0x53, 0x4a, 0xf8, 0xd6, 0xbb,
-	0x7b, 0x3d, 0xc9, 0x6e, 0x97, 0x61, 0xae, 0x3d, 0x04, 0x7f, 0xed, 0x40, 0xef, 0xe1, 0x99, 0x1f,
-	0xc7, 0x24, 0x3a, 0x4a, 0xc2, 0x98, 0x32, 0x31, 0x3a, 0x9d, 0xc6, 0x41, 0x18, 0x8f, 0x3d, 0xfa,
-	0x3a, 0x0c, 0x24, 0xcb, 0x2d, 0x8c, 0x2d, 0xca, 0x6c, 0x33, 0x26, 0x49, 0xfe, 0x57, 0x70, 0x46,
-	0x2f, 0x99, 0xd2, 0x74, 0x4a, 0xbd, 0x30, 0x0e, 0xc8, 0x6b, 0xbe, 0xa6, 0xbe, 0x6b, 0x61, 0xf8,
-	0x37, 0x61, 0xf9, 0x90, 0xc9, 0x67, 0x1c, 0xc6, 0xe3, 0xfd, 0x20, 0xc8, 0x48, 0x9e, 0xb3, 0x4b,
-	0x93, 0x4e, 0x4f, 0x5e, 0x92, 0x99, 0xe4, 0x8b, 0x6c, 0x31, 0x51, 0x38, 0x4b, 0x72, 0x2a, 0xe7,
-	0xe3, 0xbf, 0xf1, 0x2f, 0x1c, 0x58, 0x62, 0xbc, 0xfd, 0xcc, 0x8f, 0x67, 0x4a, 0x64, 0x0e, 0xa1,
-	0xc7, 0x48, 0x3d, 0x4f, 0xf6, 0xc5, 0xd5, 0x13, 0xa2, 0xb7, 0x25, 0x79, 0x51, 0x1a, 0xbd, 0x63,
-	0x0e, 0x7d, 0x1c, 0xd3, 0x6c, 0xe6, 0x5a, 0x5f, 0x0f, 0x3f, 0x81, 0x95, 0xca, 0x10, 0x26, 0x60,
-	0xc5, 0xfa, 0xd8, 0x4f, 0xb4, 0x06, 0xad, 0x57, 0x7e, 0x34, 0x25, 0xf2, 0xa2, 0x8b, 0xc6, 0xbd,
-	0xc6, 0x5d, 0x07, 0x7f, 0x04, 0xcb, 0xc5, 0x9c, 0x52, 0x02, 0x10, 0x34, 0x35, 0x8b, 0x3b, 0x2e,
-	0xff, 0xcd, 0x58, 0xc1, 0xc6, 0x3d, 0x4c, 0x42, 0x7d, 0xb7, 0xd8, 0x38, 0x3f, 0x08, 0x94, 0x80,
-	0xf0, 0xdf, 0x17, 0xe9, 0x14, 0x7c, 0x0b, 0x56, 0x8c, 0xef, 0xdf, 0x31, 0xd1, 0x5f, 0x3b, 0xb0,
-	0xf2, 0x8c, 0x9c, 0x4b, 0x76, 0xab, 0xa9, 0xee, 0x42, 0x93, 0xce, 0x52, 0xc2, 0x47, 0x2e, 0xee,
-	0xdd, 0x94, 0xdc, 0xaa, 0x8c, 0xdb, 0x91, 0xcd, 0xe7, 0xb3, 0x94, 0xb8, 0xfc, 0x0b, 0xfc, 0x39,
-	0x74, 0x0d, 0x10, 0x6d, 0xc2, 0xea, 0x8b, 0x4f, 0x9f, 0x3f, 0x7b, 0x7c, 0x7c, 0xec, 0x1d, 0x7d,
-	0xf1, 0xe0, 0x07, 0x8f, 0x7f, 0xc7, 0x3b, 0xd8, 0x3f, 0x3e, 0x58, 0xbe, 0x84, 0x36, 0x00, 0x3d,
-	0x7b, 0x7c, 0xfc, 0xfc, 0xf1, 0x23, 0x0b, 0x77, 0xd0, 0x12, 0x74, 0x4d, 0xa0, 0x81, 0x87, 0x30,
-	0x78, 0x46, 0xce, 0x5f, 0x84, 0x34, 0x26, 0x79, 0x6e, 0x4f, 0x8f, 0x77, 0x00, 0x99, 0x6b, 0x92,
-	0xdb, 0x1c, 0xc0, 0x82, 0x2f, 0x20, 0xa5, 0x81, 0x65, 0x13, 0x7f, 0x04, 0xe8, 0x38, 0x1c, 0xc7,
-	0x9f, 0x91, 0x3c, 0xf7, 0xc7, 0x44, 0x6d, 0x76, 0x19, 0xe6, 0x26, 0xf9, 0x58, 0x4a, 0x38, 0xfb,
-	0x89, 0xbf, 0x0b, 0xab, 0xd6, 0x38, 0x49, 0xf8, 0x2a, 0x74, 0xf2, 0x70, 0x1c, 0xfb, 0x74, 0x9a,
-	0x11, 0x49, 0xba, 0x00, 0xf0, 0x13, 0x58, 0xfb, 0x92, 0x64, 0xe1, 0xe9, 0xec, 0x7d, 0xe4, 0x6d,
-	0x3a, 0x8d, 0x32, 0x9d, 0xc7, 0xb0, 0x5e, 0xa2, 0x23, 0xa7, 0x17, 0x52, 0x25, 0xcf, 0xaf, 0xed,
-	0x8a, 0x86, 0x71, 0x41, 0x1a, 0xe6, 0x05, 0xc1, 0x5f, 0x00, 0x7a, 0x98, 0xc4, 0x31, 0x19, 0xd1,
-	0x23, 0x42, 0x32, 0xb5, 0x98, 0x6f, 0x1b, 0x32, 0xd4, 0xdd, 0xdb, 0x94, 0x07, 0x5b, 0xbe, 0x75,
-	0x52, 0xb8, 0x10, 0x34, 0x53, 0x92, 0x4d, 0x38, 0xe1, 0xb6, 0xcb, 0x7f, 0xe3, 0x5d, 0x58, 0xb5,
-	0xc8, 0x16, 0x3c, 0x4f, 0x09, 0xc9, 0x3c, 0xb9, 0xba, 0x96, 0xab, 0x9a, 0xf8, 0x0e, 0xac, 0x3f,
-	0x0a, 0xf3, 0x51, 0x75, 0x29, 0xec, 0x93, 0xe9, 0x89, 0x57, 0x5c, 0x1d, 0xd5, 0x64, 0xe6, 0xa5,
-	0xfc, 0x89, 0x98, 0x06, 0xff, 0x91, 0x03, 0xcd, 0x83, 0xe7, 0x87, 0x0f, 0xd1, 0x10, 0xda, 0x61,
-	0x3c, 0x4a, 0x26, 0x4c, 0x29, 0x0b, 0x76, 0xe8, 0xf6, 0x85, 0x76, 0xf6, 0x2a, 0x74, 0xb8, 0x2e,
-	0x67, 0x96, 0x90, 0xeb, 0x9f, 0x9e, 0x5b, 0x00, 0xcc, 0x0a, 0x93, 0xd7, 0x69, 0x98, 0x71, 0x33,
-	0xab, 0x8c, 0x67, 0x93, 0x6b, 0xa9, 0x6a, 0x07, 0xfe, 0xa7, 0x26, 0xf4, 0xf7, 0x47, 0x34, 0x7c,
-	0x45, 0xa4, 0xd6, 0xe4, 0xb3, 0x72, 0x40, 0xae, 0x47, 0xb6, 0x98, 0x7e, 0xcf, 0xc8, 0x24, 0xa1,
-	0xc4, 0xb3, 0x8e, 0xc9, 0x06, 0xd9, 0xa8, 0x91, 0x20, 0xe4, 0xa5, 0x4c, 0xff, 0xf2, 0xf5, 0x75,
-	0x5c, 0x1b, 0x64, 0x2c, 0x63, 0x00, 0xe3, 0x32, 0x5b, 0x59, 0xd3, 0x55, 0x4d, 0xc6, 0x8f, 0x91,
-	0x9f, 0xfa, 0xa3, 0x90, 0xce, 0xb8, 0x91, 0x9a, 0x73, 0x75, 0x9b, 0xd1, 0x8e, 0x92, 0x91, 0x1f,
-	0x79, 0x27, 0x7e, 0xe4, 0xc7, 0x23, 0x22, 0x0d, 0xbe, 0x0d, 0xa2, 0x8f, 0x60, 0x51, 0x2e, 0x49,
-	0x0d, 0x13, 0x76, 0xbf, 0x84, 0x32, 0xdf, 0x60, 0x94, 0x4c, 0x26, 0x21, 0x65, 0xae, 0xc0, 0xa0,
-	0x2d, 0x7c, 0x83, 0x02, 0xe1, 0x3b, 0x11, 0xad, 0x73, 0xc1, 0xc3, 0x8e, 0x98, 0xcd, 0x02, 0x19,
-	0x95, 0x53, 0x42, 0xbc, 0x94, 0x64, 0xde, 0xcb, 0xf3, 0x01, 0x08, 0x2a, 0x05, 0xc2, 0x4e, 0x63,
-	0x1a, 0xe7, 0x84, 0xd2, 0x88, 0x04, 0x7a, 0x41, 0x5d, 0x3e, 0xac, 0xda, 0x81, 0x6e, 0xc3, 0xaa,
-	0xf0, 0x4e, 0x72, 0x9f, 0x26, 0xf9, 0x59, 0x98, 0x7b, 0x39, 0x89, 0xe9, 0xa0, 0xc7, 0xc7, 0xd7,
-	0x75, 0xa1, 0xbb, 0xb0, 0x59, 0x82, 0x33, 0x32, 0x22, 0xe1, 0x2b, 0x12, 0x0c, 0xfa, 0xfc, 0xab,
-	0x8b, 0xba, 0xd1, 0x75, 0xe8, 0x32, 0xa7, 0x6c, 0x9a, 0x06, 0x3e, 0x25, 0xf9, 0x60, 0x91, 0x9f,
-	0x83, 0x09, 0xa1, 0x3b, 0xd0, 0x4f, 0x89, 0x30, 0x7f, 0x67, 0x34, 0x1a, 0xe5, 0x83, 0x25, 0x6e,
-	0x73, 0xba, 0xf2, 0xb2, 0x31, 0xf9, 0x75, 0xed, 0x11, 0x78, 0x1d, 0x56, 0x0f, 0xc3, 0x9c, 0x4a,
-	0x59, 0xd2, 0xfa, 0xed, 0x00, 0xd6, 0x6c, 0x58, 0xde, 0xb6, 0xdb, 0xd0, 0x96, 0x82, 0x91, 0x0f,
-	0xba, 0x9c, 0xf8, 0x9a, 0x24, 0x6e, 0xc9, 0xa4, 0xab, 0x47, 0xe1, 0x3f, 0x6c, 0x40, 0x93, 0xdd,
-	0xa4, 0x8b, 0x6f, 0x9d, 0x79, 0x85, 0x1b, 0xd6, 0x15, 0x36, 0x15, 0xea, 0x9c, 0xa5, 0x50, 0xb9,
-	0x33, 0x3a, 0xa3, 0x44, 0xf2, 0x5b, 0xc8, 0xa4, 0x81, 0x14, 0xfd, 0x19, 0x19, 0xbd, 0xe2, 0x82,
-	0xa9, 0xfb, 0x19, 0xc2, 0xc4, 0x36, 0xf7, 0xa9, 0xf8, 0x5a, 0x48, 0xa5, 0x6e, 0xab, 0x3e, 0xfe,
-	0xe5, 0x42, 0xd1, 0xc7, 0xbf, 0x1b, 0xc0, 0x42, 0x18, 0x9f, 0x24, 0xd3, 0x38, 0xe0, 0x12, 0xd8,
-	0x76, 0x55, 0x93, 0x5d, 0xf2, 0x94, 0x3b, 0x1e, 0xe1, 0x84, 0x48, 0xd1, 0x2b, 0x00, 0x8c, 0x98,
-	0x87, 0x91, 0x73, 0x9d, 0xa2, 0x99, 0xfc, 0x31, 0xac, 0x18, 0x98, 0xe4, 0xf0, 0x0d, 0x68, 0xb1,
-	0xdd, 0x2b, 0x57, 0x55, 0x9d, 0x1d, 0x57, 0x46, 0xa2, 0x07, 0x2f, 0xc3, 0xe2, 0x53, 0x42, 0x3f,
-	0x8d, 0x4f, 0x13, 0x45, 0xe9, 0x3f, 0x1b, 0xb0, 0xa4, 0x21, 0x49, 0x68, 0x0b, 0x96, 0xc2, 0x80,
-	0xc4, 0x34, 0xa4, 0x33, 0xcf, 0x72, 0x64, 0xca, 0x30, 0x53, 0xef, 0x7e, 0x14, 0xfa, 0xb9, 0x54,
-	0x10, 0xa2, 0x81, 0xf6, 0x60, 0x8d, 0xc9, 0x96, 0x12, 0x17, 0x7d, 0xec, 0xc2, 0x7f, 0xaa, 0xed,
-	0x63, 0xd7, 0x81, 0xe1, 0x42, 0x01, 0x15, 0x9f, 0x08, 0x65, 0x56, 0xd7, 0xc5, 0xb8, 0x26, 0x28,
-	0xb1, 0x2d, 0xb7, 0xf8, 0xb8, 0x02, 0xa8, 0x84, 0x14, 0xf3, 0xc2, 0x77, 0x2b, 0x87, 0x14, 0x46,
-	0x58, 0xd2, 0xae, 0x84, 0x25, 0x5b, 0xb0, 0x94, 0xcf, 0xe2, 0x11, 0x09, 0x3c, 0x9a, 0xb0, 0x79,
-	0xc3, 0x98, 0x9f, 0x4e, 0xdb, 0x2d, 0xc3, 0x3c, 0x80, 0x22, 0x39, 0x8d, 0x09, 0xe5, 0x7a, 0xa1,
-	0xed, 0xaa, 0x26, 0x53, 0xb1, 0x7c, 0x88, 0x10, 0xfa, 0x8e, 0x2b, 0x5b, 0xf8, 0xa7, 0xdc, 0xd4,
-	0xe9, 0x18, 0xe9, 0x0b, 0x7e, 0x0f, 0xd1, 0x15, 0xe8, 0x88, 0xf9, 0xf3, 0x33, 0x5f, 0x5a, 0xdf,
-	0x36, 0x07, 0x8e, 0xcf, 0x7c, 0x16, 0x02, 0x58, 0x5b, 0x12, 0x12, 0xdf, 0xe5, 0xd8, 0x81, 0xd8,
-	0xd1, 0x4d, 0x58, 0x54, 0xd1, 0x57, 0xee, 0x45, 0xe4, 0x94, 0x2a, 0x9f, 0x35, 0x9e, 0x4e, 0xd8,
-	0x74, 0xf9, 0x21, 0x39, 0xa5, 0xf8, 0x19, 0xac, 0xc8, 0xdb, 0xf6, 0x79, 0x4a, 0xd4, 0xd4, 0xdf,
-	0x2f, 0x6b, 0x73, 0x61, 0x6e, 0x57, 0xa5, 0x14, 0x99, 0x8e, 0x76, 0x49, 0xc5, 0x63, 0x17, 0x90,
-	0xec, 0x7e, 0x18, 0x25, 0x39, 0x91, 0x04, 0x31, 0xf4, 0x46, 0x51, 0x92, 0x97, 0xbd, 0x71, 0x13,
-	0x63, 0x7c, 0xcb, 0xa7, 0xa3, 0x11, 0xbb, 0xa5, 0xc2, 0x60, 0xab, 0x26, 0x26, 0xb0, 0xca, 0x89,
-	0x29, 0xb5, 0xa0, 0x9d, 0xbc, 0x0f, 0x5f, 0x65, 0x6f, 0x64, 0x06, 0x07, 0x6b, 0xd0, 0x3a, 0x4d,
-	0xb2, 0x11, 0x91, 0x13, 0x89, 0x06, 0xfe, 0x57, 0x07, 0x56, 0xf8, 0x3c, 0xc7, 0xd4, 0xa7, 0xd3,
-	0x5c, 0x2e, 0xfd, 0xd7, 0xa1, 0xcf, 0x96, 0x49, 0x94, 0x98, 0xca, 0x59, 0xd6, 0xf4, 0x8d, 0xe2,
-	0xa8, 0x18, 0x7c, 0x70, 0xc9, 0xb5, 0x07, 0xa3, 0x4f, 0xa0, 0x67, 0x86, 0xbf, 0x7c, 0xc2, 0xee,
-	0xde, 0x65, 0xb5, 0xc4, 0xca, 0xa9, 0x1f, 0x5c, 0x72, 0xad, 0x0f, 0xd0, 0x7d, 0x00, 0x6e, 0x23,
-	0x39, 0x59, 0x19, 0x09, 0x5d, 0xb6, 0x77, 0x68, 0x30, 0xfa, 0xe0, 0x92, 0x6b, 0x0c, 0x7f, 0xd0,
-	0x86, 0x79, 0xa1, 0xd4, 0xf1, 0x53, 0xe8, 0x5b, 0x2b, 0xb5, 0x7c, 0xe9, 0x9e, 0xf0, 0xa5, 0x2b,
-	0x31, 0x4e, 0xa3, 0x26, 0xc6, 0xf9, 0x37, 0x07, 0x10, 0x93, 0x94, 0xd2, 0x59, 0x7c, 0x04, 0x8b,
-	0xd4, 0xcf, 0xc6, 0x84, 0x7a, 0xb6, 0x1b, 0x55, 0x42, 0xb9, 0xf5, 0x49, 0x02, 0xcb, 0x97, 0xe8,
-	0xb9, 0x26, 0x84, 0x76, 0x00, 0x19, 0x4d, 0x15, 0xb8, 0x0a, 0xbd, 0x5d, 0xd3, 0xc3, 0x14, 0x8c,
-	0x70, 0x04, 0x54, 0xc8, 0x26, 0x7d, 0xa7, 0x26, 0xd7, 0x9d, 0xb5, 0x7d, 0x4c, 0x35, 0xa7, 0x53,
-	0x16, 0x15, 0xfb, 0x54, 0x79, 0x1b, 0xaa, 0x8d, 0x7f, 0xe9, 0xc0, 0x32, 0xdb, 0xa0, 0x25, 0x04,
-	0xf7, 0x80, 0x0b, 0xd0, 0x07, 0xca, 0x80, 0x35, 0xf6, 0x7f, 0x2f, 0x02, 0x77, 0xa1, 0xc3, 0x09,
-	0x26, 0x29, 0x89, 0xa5, 0x04, 0x0c, 0x6c, 0x09, 0x28, 0xae, 0xee, 0xc1, 0x25, 0xb7, 0x18, 0x6c,
-	0x9c, 0xff, 0x26, 0xac, 0xcb, 0x55, 0xda, 0x07, 0x87, 0xff, 0x18, 0x60, 0xa3, 0xdc, 0xa3, 0xad,
-	0xb4, 0x74, 0x3d, 0xa2, 0x70, 0x72, 0x92, 0x68, 0x2f, 0xc6, 0x31, 0xbd, 0x12, 0xab, 0x0b, 0x9d,
-	0xc2, 0xba, 0x52, 0xe6, 0x6c, 0xfe, 0x42, 0x75, 0x37, 0xb8, 0x15, 0xba, 0x6d, 0xf3, 0xab, 0x34,
-	0x9f, 0x82, 0x4d, 0xe9, 0xaa, 0x27, 0x87, 0xc6, 0x30, 0xd0, 0x46, 0x43, 0xaa, 0x10, 0xc3, 0xb0,
-	0xb0, 0xa9, 0xbe, 0xfd, 0xee, 0xa9, 0xf8, 0x95, 0x09, 0x14, 0x7a, 0x21, 0x31, 0xf4, 0x1a, 0xae,
-	0xa9, 0x3e, 0xae, 0x23, 0xaa, 0xd3, 0x35, 0x3f, 0x64, 0x67, 0x4f, 0xd8, 0xb7, 0xf6, 0x9c, 0xef,
-	0xa1, 0x3b, 0xfc, 0x47, 0x07, 0x16, 0x6d, 0x6a, 0xcc, 0x04, 0x49, 0x5f, 0x56, 0x5d, 0x03, 0x65,
-	0x8a, 0x4b, 0x70, 0xd5, 0x1b, 0x6f, 0xd4, 0x79, 0xe3, 0xa6, 0xcf, 0x3d, 0xf7, 0x3e, 0x9f, 0xbb,
-	0xf9, 0x61, 0x3e, 0x77, 0xab, 0xce, 0xe7, 0x1e, 0xfe, 0xa2, 0x01, 0xa8, 0x7a, 0xba, 0xe8, 0x89,
-	0x08, 0x07, 0x62, 0x12, 0xc9, 0x0b, 0xf5, 0x9d, 0x0f, 0x12, 0x10, 0x05, 0xab, 0x8f, 0x99, 0xa0,
-	0x9a, 0x17, 0xc6, 0xb4, 0x89, 0x7d, 0xb7, 0xae, 0x0b, 0x6d, 0xc3, 0x32, 0x37, 0x95, 0xb9, 0x47,
-	0xc3, 0x28, 0x2a, 0x6e, 0x56, 0xdf, 0xad, 0xe0, 0xa5, 0x80, 0xa1, 0xf9, 0xfe, 0x80, 0xa1, 0xf5,
-	0xfe, 0x80, 0x61, 0xbe, 0x1c, 0x30, 0x0c, 0xdf, 0x40, 0xdf, 0x12, 0x90, 0xff, 0x33, 0xe6, 0x94,
-	0x4d, 0xaf, 0x10, 0x05, 0x0b, 0x1b, 0x7e, 0xdd, 0x00, 0x54, 0x95, 0xd1, 0xff, 0xcf, 0x25, 0x70,
-	0x81, 0xb3, 0xd4, 0xcc, 0x9c, 0x14, 0x38, 0x4b, 0xc1, 0x6c, 0xc1, 0xd2, 0xc4, 0xa7, 0xd3, 0x8c,
-	0xb9, 0x9d, 0x56, 0x88, 0x5b, 0x86, 0x99, 0x4c, 0x14, 0x27, 0xe9, 0xa9, 0x5e, 0xe9, 0x1b, 0xd6,
-	0x75, 0xe1, 0xef, 0xc3, 0xda, 0x0b, 0x3f, 0x8a, 0x08, 0x7d, 0x20, 0x26, 0x53, 0xa6, 0xed, 0x06,
-	0xf4, 0xce, 0x45, 0xf6, 0xc6, 0x4b, 0xe2, 0x68, 0x26, 0xc3, 0xe3, 0xae, 0xc4, 0x3e, 0x8f, 0xa3,
-	0x19, 0xbe, 0x03, 0xeb, 0xa5, 0x4f, 0x8b, 0xb4, 0x82, 0xad, 0x36, 0x55, 0x93, 0x29, 0x64, 0xc9,
-	0x27, 0x7b, 0x3a, 0xbc, 0x07, 0x1b, 0xe5, 0x8e, 0xf7, 0x12, 0xfb, 0x04, 0xd0, 0x0f, 0xa7, 0x24,
-	0x9b, 0xf1, 0xd4, 0xa8, 0x4e, 0x82, 0x6d, 0x96, 0x43, 0xa5, 0xf9, 0x74, 0x7a, 0xf2, 0x03, 0x32,
-	0x53, 0x19, 0xe5, 0x86, 0xce, 0x28, 0xe3, 0xfb, 0xb0, 0x6a, 0x11, 0xd0, 0xb9, 0xdd, 0x79, 0x9e,
-	0x5e, 0x55, 0x61, 0x84, 0x9d, 0x82, 0x95, 0x7d, 0xf8, 0x2f, 0x1c, 0x98, 0x3b, 0x48, 0x52, 0x33,
-	0xba, 0x77, 0xec, 0xe8, 0x5e, 0xea, 0x23, 0x4f, 0xab, 0x9b, 0x86, 0xbc, 0x22, 0x26, 0xc8, 0xb4,
-	0x89, 0x3f, 0xa1, 0xcc, 0x91, 0x3e, 0x4d, 0xb2, 0x73, 0x3f, 0x0b, 0xa4, 0x0c, 0x94, 0x50, 0xb6,
-	0xfc, 0xe2, 0x26, 0xb2, 0x9f, 0xcc, 0xb1, 0xe6, 0x29, 0x0e, 0x75, 0xbe, 0xb2, 0x85, 0x7f, 0xe6,
-	0x40, 0x8b, 0xaf, 0x95, 0x09, 0x8e, 0x30, 0x58, 0xfc, 0x95, 0x80, 0x67, 0x50, 0x1c, 0x21, 0x38,
-	0x25, 0xb8, 0xf4, 0x76, 0xd0, 0x28, 0xbf, 0x1d, 0xb0, 0x50, 0x43, 0xb4, 0x8a, 0xa4, 0x7c, 0x01,
-	0xa0, 0x6b, 0xd0, 0x3c, 0x4b, 0x52, 0x65, 0x16, 0x40, 0x85, 0xcc, 0x49, 0xea, 0x72, 0x1c, 0x6f,
-	0xc3, 0xd2, 0xb3, 0x24, 0x20, 0x46, 0xd4, 0x75, 0xe1, 0x31, 0xe1, 0xdf, 0x77, 0xa0, 0xad, 0x06,
-	0xa3, 0x2d, 0x68, 0x32, 0xf5, 0x5e, 0xf2, 0x3c, 0x74, 0xe2, 0x8b, 0x8d, 0x73, 0xf9, 0x08, 0x76,
-	0xdb, 0xb8, 0xdf, 0x5f, 0xd8, 0x5e, 0xe5, 0xf5, 0x17, 0x76, 0x8d, 0xb9, 0x6b, 0x7c, 0xcd, 0x25,
-	0x03, 0x50, 0x42, 0xf1, 0xcf, 0x1d, 0xe8, 0x5b, 0x73, 0x30, 0x07, 0x2e, 0xf2, 0x73, 0x2a, 0x93,
-	0x05, 0x92, 0x89, 0x26, 0x64, 0x46, 0xe8, 0x0d, 0x3b, 0x42, 0xd7, 0x11, 0xe2, 0x9c, 0x19, 0x21,
-	0xde, 0x86, 0x8e, 0x0c, 0xc7, 0x89, 0xe2, 0x9b, 0x7a, 0x59, 0x61, 0x33, 0xaa, 0x94, 0x5e, 0x31,
-	0x08, 0xdf, 0x87, 0xae, 0xd1, 0xc3, 0x26, 0x8c, 0x09, 0x3d, 0x4f, 0xb2, 0x97, 0x2a, 0x25, 0x20,
-	0x9b, 0x3a, 0xe3, 0xdc, 0x28, 0x32, 0xce, 0xf8, 0xef, 0x1c, 0xe8, 0x33, 0x99, 0x08, 0xe3, 0xf1,
-	0x51, 0x12, 0x85, 0xa3, 0x19, 0x97, 0x0d, 0x75, 0xfc, 0x5e, 0x40, 0x22, 0xea, 0x6b, 0xd9, 0xb0,
-	0x61, 0x66, 0x31, 0x27, 0x61, 0xcc, 0x73, 0x1e, 0x52, 0x32, 0x74, 0x9b, 0xc9, 0x38, 0x53, 0xe7,
-	0x27, 0x7e, 0x4e, 0xbc, 0x09, 0x73, 0x2c, 0xa5, 0x02, 0xb3, 0x40, 0xa6, 0x96, 0x18, 0x90, 0xf9,
-	0x94, 0x78, 0x93, 0x30, 0x8a, 0x42, 0x31, 0x56, 0xc8, 0x72, 0x5d, 0x17, 0xfe, 0x87, 0x06, 0x74,
-	0xa5, 0x42, 0x78, 0x1c, 0x8c, 0x45, 0xfe, 0x4a, 0x9a, 0x71, 0x7d, 0xd1, 0x0c, 0x44, 0xf5, 0x5b,
-	0x86, 0xdf, 0x40, 0xca, 0x07, 0x38, 0x57, 0x3d, 0x40, 0x16, 0x4c, 0x27, 0x01, 0xb9, 0xc3, 0x3d,
-	0x0c, 0xf1, 0x40, 0x57, 0x00, 0xaa, 0x77, 0x8f, 0xf7, 0xb6, 0x8a, 0x5e, 0x0e, 0x58, 0x3e, 0xc5,
-	0x7c, 0xc9, 0xa7, 0xb8, 0x0b, 0x3d, 0x49, 0x86, 0xf3, 0x9d, 0x27, 0x45, 0x0a, 0x51, 0xb6, 0xce,
-	0xc4, 0xb5, 0x46, 0xaa, 0x2f, 0xf7, 0xd4, 0x97, 0xed, 0xf7, 0x7d, 0xa9, 0x46, 0xe2, 0x75, 0x58,
-	0x95, 0xcc, 0x7b, 0x9a, 0xf9, 0xe9, 0x99, 0x52, 0xb2, 0x81, 0x7e, 0x2d, 0xe2, 0x30, 0xda, 0x86,
-	0x16, 0xfb, 0x4c, 0xe9, 0xb9, 0xfa, 0xeb, 0x25, 0x86, 0xa0, 0x2d, 0x68, 0x91, 0x60, 0x4c, 0x94,
-	0x53, 0x8b, 0x6c, 0x57, 0x9c, 0x9d, 0x91, 0x2b, 0x06, 0xb0, 0xcb, 0xce, 0xd0, 0xd2, 0x65, 0xb7,
-	0x75, 0xe4, 0x3c, 0x6b, 0x7e, 0x1a, 0xe0, 0x35, 0x40, 0xcf, 0x84, 0xd4, 0x9a, 0x19, 0x99, 0x3f,
-	0x98, 0x83, 0xae, 0x01, 0xb3, 0x7b, 0x3b, 0x66, 0x0b, 0xf6, 0x82, 0xd0, 0x9f, 0x10, 0x4a, 0x32,
-	0x29, 0xa9, 0x25, 0x94, 0xab, 0xd2, 0x57, 0x63, 0x2f, 0x99, 0x52, 0x2f, 0x20, 0xe3, 0x8c, 0x88,
-	0x48, 0xd7, 0x71, 0x4b, 0x28, 0x1b, 0x37, 0xf1, 0x5f, 0x9b, 0xe3, 0x84, 0x3c, 0x94, 0x50, 0x95,
-	0x5f, 0x11, 0x3c, 0x6a, 0x16, 0xf9, 0x15, 0xc1, 0x91, 0xb2, 0xc6, 0x69, 0xd5, 0x68, 0x9c, 0x8f,
-	0x61, 0x43, 0xe8, 0x16, 0x79, 0x37, 0xbd, 0x92, 0x98, 0x5c, 0xd0, 0xcb, 0x3c, 0x35, 0xb6, 0x66,
-	0x25, 0xe0, 0x79, 0xf8, 0x53, 0x91, 0xd8, 0x75, 0xdc, 0x0a, 0xce, 0xc6, 0xb2, 0xeb, 0x68, 0x8d,
-	0x15, 0x09, 0xde, 0x0a, 0xce, 0xc7, 0xfa, 0xaf, 0xed, 0xb1, 0x1d, 0x39, 0xb6, 0x84, 0xe3, 0x3e,
-	0x74, 0x8f, 0x69, 0x92, 0xaa, 0x43, 0x59, 0x84, 0x9e, 0x68, 0xca, 0xa4, 0xfe, 0x15, 0xb8, 0xcc,
-	0xa5, 0xe8, 0x79, 0x92, 0x26, 0x51, 0x32, 0x9e, 0x1d, 0x4f, 0x4f, 0xf2, 0x51, 0x16, 0xa6, 0xcc,
-	0xe1, 0xc4, 0xff, 0xec, 0xc0, 0xaa, 0xd5, 0x2b, 0x23, 0xca, 0x5f, 0x15, 0x22, 0xad, 0xf3, 0xb0,
-	0x42, 0xf0, 0x56, 0x0c, 0xc5, 0x27, 0x06, 0x8a, 0xe0, 0xf8, 0x0b, 0x99, 0x9a, 0xdd, 0x87, 0x25,
-	0xb5, 0x32, 0xf5, 0xa1, 0x90, 0xc2, 0x41, 0x55, 0x0a, 0xe5, 0xf7, 0x8b, 0xf2, 0x03, 0x45, 0xe2,
-	0x37, 0x84, 0x33, 0x46, 0x02, 0xbe, 0x47, 0x15, 0x2f, 0x0d, 0xd5, 0xf7, 0xa6, 0x03, 0xa8, 0x56,
-	0x30, 0xd2, 0x60, 0x8e, 0xff, 0xc4, 0x01, 0x28, 0x56, 0xc7, 0x04, 0xa3, 0x50, 0xde, 0x0e, 0xcf,
-	0x6a, 0x15, 0x00, 0x73, 0x9d, 0x74, 0x96, 0xb0, 0xb0, 0x07, 0x5d, 0x85, 0x31, 0x5f, 0xe4, 0x16,
-	0x2c, 0x8d, 0xa3, 0xe4, 0x84, 0x5b, 0x57, 0xfe, 0x7e, 0x94, 0xcb, 0xa7, 0x8d, 0x45, 0x01, 0x3f,
-	0x91, 0x68, 0x61, 0x3c, 0x9a, 0x86, 0xf1, 0xc0, 0x7f, 0xda, 0xd0, 0xf9, 0xab, 0x62, 0xcf, 0x17,
-	0xde, 0x32, 0xb4, 0x57, 0x51, 0x8e, 0x17, 0xe4, 0x8b, 0x78, 0x10, 0x7d, 0xf4, 0xde, 0x30, 0xe9,
-	0x3e, 0x2c, 0x66, 0x42, 0xfb, 0x28, 0xd5, 0xd4, 0x7c, 0x87, 0x6a, 0xea, 0x67, 0x96, 0xdd, 0xf9,
-	0x15, 0x58, 0xf6, 0x83, 0x57, 0x24, 0xa3, 0x21, 0x77, 0x83, 0xb9, 0x79, 0x17, 0x0a, 0x75, 0xc9,
-	0xc0, 0xb9, 0xd5, 0xbd, 0x05, 0x4b, 0xf2, 0x39, 0x49, 0x8f, 0x94, 0xcf, 0xf3, 0x05, 0xcc, 0x06,
-	0xe2, 0xbf, 0x71, 0x64, 0xae, 0xcc, 0x3e, 0xc3, 0x8b, 0x39, 0x62, 0xee, 0xae, 0x51, 0xda, 0xdd,
-	0xb7, 0x64, 0xea, 0x2b, 0x50, 0xbe, 0xb6, 0x4c, 0x20, 0x0a, 0x50, 0xa6, 0x19, 0x6d, 0x96, 0x36,
-	0x3f, 0x84, 0xa5, 0x78, 0x07, 0x96, 0x8e, 0x09, 0xdd, 0x67, 0x27, 0xa8, 0x14, 0xe3, 0x15, 0xe8,
-	0xc4, 0xe4, 0xdc, 0x13, 0x47, 0x2c, 0xcc, 0x78, 0x3b, 0x26, 0xe7, 0x7c, 0x0c, 0x46, 0xb0, 0x5c,
-	0x8c, 0x97, 0xb7, 0xee, 0xbf, 0x1a, 0xb0, 0xf0, 0x69, 0xfc, 0x2a, 0x09, 0x47, 0x3c, 0x99, 0x35,
-	0x21, 0x93, 0x44, 0x3d, 0x0c, 0xb3, 0xdf, 0xcc, 0x2b, 0xe0, 0x6f, 0x1e, 0x29, 0x95, 0x59, 0x26,
-	0xd5, 0x64, 0x16, 0x32, 0x2b, 0xaa, 0x10, 0x84, 0xb4, 0x19, 0x08, 0xf3, 0x26, 0x33, 0xb3, 0xb0,
-	0x42, 0xb6, 0x8a, 0x57, 0xf1, 0x96, 0xf1, 0x2a, 0xce, 0xd3, 0x96, 0xe2, 0x39, 0x87, 0x1f, 0x49,
-	0xdb, 0x55, 0x4d, 0xee, 0xf5, 0x66, 0x44, 0xc4, 0x9d, 0xdc, 0xd6, 0x2e, 0x48, 0xaf, 0xd7, 0x04,
-	0x99, 0x3d, 0x16, 0x1f, 0x88, 0x31, 0x42, 0x5f, 0x99, 0x10, 0xf3, 0x4f, 0xca, 0xb5, 0x19, 0x1d,
-	0x21, 0x26, 0x25, 0x98, 0x29, 0xb5, 0x80, 0x68, 0xdd, 0x23, 0xf6, 0x00, 0xa2, 0xca, 0xa2, 0x8c,
-	0x1b, 0x3e, 0xb3, 0x78, 0x96, 0x92, 0x2d, 0xee, 0xc7, 0xf8, 0x51, 0x74, 0xe2, 0x8f, 0x5e, 0x7a,
-	0xdc, 0x79, 0xea, 0x89, 0xdc, 0x81, 0x05, 0xe2, 0x2f, 0x01, 0xed, 0x07, 0x81, 0xe4, 0xbf, 0x8e,
-	0x17, 0x0a, 0xce, 0x39, 0x16, 0xe7, 0x6a, 0x76, 0xd0, 0xa8, 0xdd, 0x01, 0x7e, 0x0c, 0xdd, 0x23,
-	0xa3, 0x8c, 0x85, 0x1f, 0x95, 0x2a, 0x60, 0x91, 0xc7, 0x6b, 0x20, 0xc6, 0x84, 0x0d, 0x73, 0x42,
-	0xfc, 0x6b, 0x80, 0x0e, 0xc3, 0x9c, 0xea, 0xf5, 0xe9, 0x48, 0x4e, 0xe7, 0x93, 0x8c, 0x48, 0x4e,
-	0x62, 0x3c, 0x92, 0xdb, 0x17, 0x0f, 0x59, 0xe5, 0x8d, 0x6d, 0x43, 0x3b, 0x14, 0x90, 0xd2, 0xd4,
-	0x8b, 0x52, 0xc4, 0xd5, 0x48, 0xdd, 0xcf, 0x5c, 0x0e, 0x09, 0x5a, 0x86, 0xe0, 0x67, 0x0e, 0x2c,
-	0xc8, 0xad, 0x31, 0x83, 0x69, 0x15, 0xf0, 0x88, 0x8d, 0x59, 0x58, 0x7d, 0x0d, 0x46, 0x55, 0xa6,
-	0xe6, 0xea, 0x64, 0x0a, 0x41, 0x33, 0xf5, 0xe9, 0x19, 0xf7, 0xa6, 0x3b, 0x2e, 0xff, 0xad, 0xa2,
-	0xa6, 0x96, 0x8e, 0x9a, 0xd4, 0xa3, 0x9d, 0x5c, 0x94, 0x7e, 0x4f, 0x7a, 0x20, 0x1e, 0xed, 0x0a,
-	0xb8, 0xe0, 0x81, 0x5c, 0x60, 0x99, 0x07, 0x72, 0xa8, 0xab, 0xfb, 0xf1, 0x10, 0x06, 0x8f, 0x48,
-	0x44, 0x28, 0xd9, 0x8f, 0xa2, 0x32, 0xfd, 0x2b, 0x70, 0xb9, 0xa6, 0x4f, 0xde, 0xea, 0x27, 0xb0,
-	0xf2, 0x88, 0x9c, 0x4c, 0xc7, 0x87, 0xe4, 0x55, 0x91, 0x5c, 0x46, 0xd0, 0xcc, 0xcf, 0x92, 0x73,
-	0x79, 0x5e, 0xfc, 0x37, 0xfa, 0x06, 0x40, 0xc4, 0xc6, 0x78, 0x79, 0x4a, 0x46, 0xaa, 0x08, 0x81,
-	0x23, 0xc7, 0x29, 0x19, 0xe1, 0x8f, 0x01, 0x99, 0x74, 0xe4, 0x16, 0xd8, 0x5d, 0x9b, 0x9e, 0x78,
-	0xf9, 0x2c, 0xa7, 0x64, 0xa2, 0xd4, 0x8c, 0x09, 0xe1, 0x5b, 0xd0, 0x3b, 0xf2, 0x67, 0x2e, 0xf9,
-	0x4a, 0xd6, 0x45, 0xb1, 0xe0, 0xcc, 0x9f, 0x31, 0xf1, 0xd4, 0xc1, 0x19, 0xef, 0xc6, 0x7f, 0xd5,
-	0x80, 0x79, 0x31, 0x92, 0x51, 0x0d, 0x48, 0x4e, 0xc3, 0x58, 0xe4, 0x77, 0x25, 0x55, 0x03, 0xaa,
-	0x9c, 0x77, 0xa3, 0xe6, 0xbc, 0xa5, 0x13, 0xa5, 0x1e, 0x6c, 0xe5, 0xc1, 0x5a, 0x18, 0x8f, 0x3d,
-	0xc3, 0x09, 0x11, 0x65, 0x6f, 0x4d, 0x19, 0x7b, 0x2a, 0xa0, 0x14, 0x05, 0x17, 0x37, 0x5a, 0xac,
-	0x4f, 0x09, 0xa2, 0x34, 0x1c, 0x26, 0x54, 0xab, 0x37, 0x16, 0x44, 0x21, 0x54, 0x45, 0x6f, 0x54,
-	0xf4, 0x43, 0xbb, 0x4e, 0x3f, 0x20, 0x58, 0x7e, 0x42, 0x88, 0x4b, 0xd2, 0x24, 0xd3, 0xa5, 0x63,
-	0x7f, 0xe9, 0xc0, 0xb2, 0xb4, 0x08, 0xba, 0x0f, 0xdd, 0xb0, 0xcc, 0x87, 0x53, 0x97, 0xa7, 0xbc,
-	0x09, 0x7d, 0x1e, 0x40, 0xb1, 0xe8, 0x88, 0x47, 0x4b, 0x32, 0x7b, 0x60, 0x81, 0x6c, 0x97, 0x2a,
-	0x9d, 0x36, 0x09, 0x23, 0xc9, 0x3e, 0x13, 0x62, 0xa6, 0x4e, 0x05, 0x58, 0x9c, 0x79, 0x8e, 0xab,
-	0xdb, 0xf8, 0x08, 0x56, 0x8c, 0xf5, 0x4a, 0x71, 0xb9, 0x0f, 0xea, 0xd9, 0x48, 0x24, 0x03, 0x84,
-	0xd4, 0x6f, 0xda, 0xc6, 0xad, 0xf8, 0xcc, 0x1a, 0x8c, 0xff, 0xde, 0xe1, 0x2c, 0x90, 0x3e, 0x94,
-	0xae, 0x19, 0x99, 0x17, 0x6e, 0x8d, 0x90, 0xe5, 0x83, 0x4b, 0xae, 0x6c, 0xa3, 0xef, 0x7d, 0xa0,
-	0x67, 0xa2, 0x5f, 0x78, 0x2e, 0xe0, 0xcd, 0x5c, 0x1d, 0x6f, 0xde, 0xb1, 0xf3, 0x07, 0x0b, 0xd0,
-	0xca, 0x47, 0x49, 0x4a, 0xf0, 0x2a, 0x67, 0x81, 0x5a, 0xaf, 0x60, 0xc1, 0xde, 0xdf, 0x5e, 0x81,
-	0x8e, 0x8e, 0x82, 0xd0, 0x4f, 0xa0, 0x6f, 0xe5, 0xb9, 0xd0, 0x15, 0xb9, 0xc2, 0xba, 0xc4, 0xd9,
-	0xf0, 0x6a, 0x7d, 0xa7, 0xbc, 0xe9, 0xd7, 0xbe, 0xfe, 0xe5, 0xbf, 0xff, 0xbc, 0x31, 0x40, 0x1b,
-	0xbb, 0xaf, 0xee, 0xec, 0xca, 0x44, 0xd6, 0x2e, 0xcf, 0xcb, 0x89, 0x67, 0xd4, 0x97, 0xb0, 0x68,
-	0xe7, 0xc1, 0xd0, 0x55, 0x9b, 0x1d, 0xa5, 0xd9, 0xbe, 0x71, 0x41, 0xaf, 0x9c, 0xee, 0x2a, 0x9f,
-	0x6e, 0x03, 0xad, 0x99, 0xd3, 0xe9, 0xe8, 0x84, 0xf0, 0x87, 0x6f, 0xb3, 0x20, 0x14, 0x29, 0x7a,
-	0xf5, 0x85, 0xa2, 0xc3, 0xcb, 0xd5, 0xe2, 0x4f, 0x59, 0x2d, 0x8a, 0x07, 0x7c, 0x2a, 0x84, 0x96,
-	0xd9, 0x54, 0x66, 0x3d, 0x28, 0xfa, 0x31, 0x74, 0x74, 0x55, 0x1b, 0xda, 0x34, 0x6a, 0xf8, 0xcc,
-	0x3a, 0xb9, 0xe1, 0xa0, 0xda, 0xa1, 0x22, 0x0d, 0x4e, 0x79, 0x1d, 0x57, 0x28, 0xdf, 0x73, 0xb6,
-	0xd1, 0x21, 0xac, 0x4b, 0x83, 0x73, 0x42, 0xfe, 0x27, 0x3b, 0xa9, 0x29, 0x63, 0xbd, 0xed, 0xa0,
-	0xfb, 0xd0, 0x56, 0x85, 0x7e, 0x68, 0xa3, 0xbe, 0xda, 0x70, 0xb8, 0x59, 0xc1, 0xe5, 0xc5, 0xd9,
-	0x07, 0x28, 0xea, 0xda, 0xd0, 0xe0, 0xa2, 0xf2, 0x3b, 0xcd, 0xc4, 0x9a, 0x22, 0xb8, 0x31, 0x2f,
-	0xeb, 0xb3, 0xcb, 0xe6, 0xd0, 0x37, 0x8b, 0xf1, 0xb5, 0x05, 0x75, 0xef, 0x20, 0x88, 0x37, 0x38,
-	0xef, 0x96, 0xd1, 0x22, 0xe3, 0x5d, 0x4c, 0xce, 0x55, 0x09, 0xc8, 0x23, 0xe8, 0x1a, 0xb5, 0x72,
-	0x48, 0x51, 0xa8, 0xd6, 0xd9, 0x0d, 0x87, 0x75, 0x5d, 0x72, 0xb9, 0xbf, 0x05, 0x7d, 0xab, 0xe8,
-	0x4d, 0xdf, 0x8c, 0xba, 0x92, 0x3a, 0x7d, 0x33, 0xea, 0xeb, 0xe4, 0x7e, 0x04, 0x5d, 0xa3, 0x44,
-	0x0d, 0x19, 0x2f, 0x85, 0xa5, 0x12, 0x34, 0xbd, 0xa2, 0x9a, 0x8a, 0x36, 0xbc, 0xc6, 0xf7, 0xbb,
-	0x88, 0x3b, 0x6c, 0xbf, 0xbc, 0x0e, 0x82, 0x09, 0xc9, 0x4f, 0x60, 0xd1, 0x2e, 0x4d, 0xd3, 0xb7,
-	0xaa, 0xb6, 0xc8, 0x4d, 0xdf, 0xaa, 0x0b, 0xea, 0xd9, 0xa4, 0x40, 0x6e, 0xaf, 0xea, 0x49, 0x76,
-	0xdf, 0xc8, 0x6c, 0xdf, 0x5b, 0xf4, 0x43, 0xa6, 0x3a, 0x64, 0x61, 0x0a, 0x2a, 0x4a, 0xf5, 0xec,
-	0xf2, 0x15, 0x2d, 0xed, 0x95, 0x1a, 0x16, 0xbc, 0xc2, 0x89, 0x77, 0x51, 0xb1, 0x03, 0xf4, 0x19,
-	0x2c, 0xc8, 0x02, 0x15, 0xb4, 0x5e, 0x48, 0xb5, 0x91, 0x31, 0x19, 0x6e, 0x94, 0x61, 0x49, 0x6c,
-	0x95, 0x13, 0xeb, 0xa3, 0x2e, 0x23, 0x36, 0x26, 0x34, 0x64, 0x34, 0x22, 0x58, 0xb2, 0xdf, 0x2c,
-	0x72, 0xcd, 0x8e, 0xda, 0xd7, 0x52, 0xcd, 0x8e, 0xfa, 0x07, 0x10, 0x5b, 0xc9, 0x28, 0xe5, 0xb2,
-	0xab, 0x1e, 0x82, 0x7f, 0x17, 0x7a, 0x66, 0x35, 0x14, 0x1a, 0x1a, 0x3b, 0x2f, 0x55, 0x4e, 0x0d,
-	0xaf, 0xd4, 0xf6, 0xd9, 0x47, 0x8b, 0x7a, 0xe6, 0x34, 0xe8, 0x47, 0xb0, 0x64, 0x3c, 0xae, 0x1d,
-	0xcf, 0xe2, 0x91, 0x16, 0x9d, 0xea, 0x83, 0xfd, 0xb0, 0xce, 0xb6, 0xe0, 0x4d, 0x4e, 0x78, 0x05,
-	0x5b, 0x84, 0x99, 0xd8, 0x3c, 0x84, 0xae, 0xf9, 0x70, 0xf7, 0x0e, 0xba, 0x9b, 0x46, 0x97, 0xf9,
-	0x84, 0x7e, 0xdb, 0x41, 0x7f, 0xee, 0x40, 0xcf, 0xac, 0xe3, 0x40, 0x56, 0xd2, 0xa1, 0x44, 0x67,
-	0x60, 0xf6, 0x99, 0x84, 0xf0, 0x33, 0xbe, 0xc8, 0x83, 0xed, 0x27, 0x16, 0x93, 0xdf, 0x58, 0x3e,
-	0xc3, 0x8e, 0x59, 0xbf, 0xfd, 0xb6, 0xdc, 0x69, 0x16, 0x34, 0xbc, 0xbd, 0xed, 0xa0, 0x7b, 0xa2,
-	0x4a, 0x5f, 0x79, 0xe7, 0xc8, 0x50, 0x6b, 0x65, 0x76, 0x99, 0xa5, 0xef, 0x5b, 0xce, 0x6d, 0x07,
-	0xfd, 0x9e, 0x28, 0xd9, 0x96, 0xdf, 0x72, 0xae, 0x7f, 0xe8, 0xf7, 0xf8, 0x26, 0xdf, 0xc9, 0x35,
-	0x7c, 0xd9, 0xda, 0x49, 0x59, 0xaf, 0x1f, 0x01, 0x14, 0xa1, 0x16, 0x2a, 0xc5, 0x1d, 0x5a, 0xe3,
-	0x55, 0xa3, 0x31, 0xfb, 0x34, 0x55, 0x78, 0x22, 0x94, 0x40, 0xcf, 0x08, 0x72, 0x72, 0x7d, 0x9c,
-	0xd5, 0x90, 0x69, 0x38, 0xac, 0xeb, 0x92, 0xf4, 0xbf, 0xc5, 0xe9, 0x7f, 0x03, 0x5d, 0x31, 0xe9,
-	0xef, 0xbe, 0x31, 0x43, 0xac, 0xb7, 0xe8, 0x4b, 0xe8, 0x1f, 0x26, 0xc9, 0xcb, 0x69, 0xaa, 0x63,
-	0x75, 0x3b, 0x68, 0x60, 0x61, 0xde, 0xb0, 0xb4, 0x29, 0x7c, 0x83, 0x53, 0xbe, 0x82, 0x2e, 0xdb,
-	0x94, 0x8b, 0xc0, 0xef, 0x2d, 0xf2, 0x61, 0x45, 0x5b, 0x3b, 0xbd, 0x91, 0xa1, 0x4d, 0xc7, 0x8c,
-	0xbf, 0x2a, 0x73, 0x58, 0xfe, 0x87, 0x9e, 0x23, 0x57, 0x34, 0x6f, 0x3b, 0xe8, 0x08, 0x7a, 0x8f,
-	0xc8, 0x28, 0x09, 0x88, 0xf4, 0xf3, 0x57, 0x8b, 0x95, 0xeb, 0x00, 0x61, 0xd8, 0xb7, 0x40, 0x5b,
-	0x03, 0xa4, 0xfe, 0x2c, 0x23, 0x5f, 0xed, 0xbe, 0x91, 0x11, 0xc4, 0x5b, 0xa5, 0x01, 0x54, 0xd4,
-	0x63, 0x69, 0x80, 0x52, 0x98, 0x64, 0x69, 0x80, 0x4a, 0x98, 0x64, 0x69, 0x00, 0x15, 0x75, 0xa1,
-	0x88, 0x05, 0x4f, 0xa5, 0xc8, 0x4a, 0xdb, 0xcc, 0x8b, 0xe2, 0xb1, 0xe1, 0xf5, 0x8b, 0x07, 0xd8,
-	0xb3, 0x6d, 0xdb, 0xb3, 0x1d, 0x43, 0xff, 0x11, 0x11, 0xcc, 0x12, 0x49, 0xf4, 0xa1, 0xad, 0x52,
-	0xcc, 0x84, 0x7b, 0x59, 0xdd, 0xf0, 0x3e, 0x5b, 0xc1, 0xf3, 0x0c, 0x36, 0xfa, 0x31, 0x74, 0x9f,
-	0x12, 0xaa, 0xb2, 0xe6, 0xda, 0xf3, 0x28, 0xa5, 0xd1, 0x87, 0x35, 0x49, 0x77, 0x7c, 0x9d, 0x53,
-	0x1b, 0xa2, 0x81, 0xa6, 0xb6, 0x4b, 0x82, 0x31, 0x11, 0x97, 0xdf, 0x0b, 0x83, 0xb7, 0xe8, 0xb7,
-	0x39, 0x71, 0xfd, 0xa4, 0xb6, 0x61, 0x24, 0x5b, 0x4d, 0xe2, 0x4b, 0x25, 0xbc, 0x8e, 0x72, 0x9c,
-	0x04, 0xc4, 0x30, 0x75, 0x31, 0x74, 0x8d, 0xf7, 0x53, 0x7d, 0xa1, 0xaa, 0x8f, 0xb2, 0xfa, 0x42,
-	0xd5, 0x3c, 0xb7, 0xe2, 0x2d, 0x3e, 0x0f, 0x46, 0xd7, 0x8b, 0x79, 0xc4, 0x13, 0x6b, 0x31, 0xd3,
-	0xee, 0x1b, 0x7f, 0x42, 0xdf, 0xa2, 0x17, 0xbc, 0x76, 0xd3, 0x7c, 0x19, 0x28, 0x3c, 0x9f, 0xf2,
-	0x23, 0x82, 0x66, 0x96, 0xd1, 0x65, 0x7b, 0x43, 0x62, 0x2a, 0x6e, 0x11, 0xbf, 0x07, 0x70, 0x4c,
-	0x93, 0xf4, 0x91, 0x4f, 0x26, 0x49, 0x5c, 0x68, 0xb2, 0x22, 0xfb, 0x5d, 0x68, 0x32, 0x23, 0x05,
-	0x8e, 0x5e, 0x18, 0xbe, 0xa7, 0xf5, 0xb0, 0xa2, 0x84, 0xeb, 0xc2, 0x04, 0xb9, 0x66, 0x48, 0x4d,
-	0x92, 0x5c, 0xb9, 0xa1, 0x22, 0xf3, 0x67, 0xb8, 0xa1, 0x56, 0xea, 0xd0, 0x70, 0x43, 0xed, 0x14,
-	0x21, 0x73, 0x43, 0x8b, 0x24, 0x80, 0x76, 0x43, 0x2b, 0xf9, 0x05, 0xad, 0x43, 0x6b, 0x32, 0x06,
-	0x47, 0xd0, 0x29, 0x62, 0x55, 0x35, 0x51, 0x39, 0xb2, 0xd5, 0xc6, 0xaa, 0x12, 0x42, 0xe2, 0x65,
-	0xce, 0x67, 0x40, 0x6d, 0xc6, 0x67, 0xfe, 0x7e, 0xfc, 0x1c, 0x40, 0xec, 0xee, 0x09, 0x6b, 0x19,
-	0x24, 0xad, 0x48, 0xd1, 0x24, 0x69, 0x87, 0x64, 0xca, 0x93, 0xc1, 0x9a, 0xe4, 0x3d, 0x67, 0xfb,
-	0x64, 0x9e, 0xff, 0x33, 0xdf, 0x77, 0xff, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x45, 0xd4, 0x32, 0xab,
-	0xfe, 0x37, 0x00, 0x00,
+	// 4662 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbd, 0x5b, 0xcd, 0x6f, 0x1c, 0x47,
+	0x76, 0xf7, 0x0c, 0x67, 0xf8, 0x51, 0x33, 0xfc, 0x2a, 0x52, 0xe4, 0x68, 0x24, 0x7b, 0xe5, 0x5a,
+	0x63, 0xad, 0x68, 0x17, 0xa4, 0xcc, 0x4d, 0x1c, 0xaf, 0x9d, 0xc4, 0xa0, 0x44, 0xd1, 0xf4, 0x2e,
+	0x2d, 0x73, 0x9b, 0xb2, 0x9d, 0x4d, 0x10, 0x4c, 0x9a, 0x33, 0x2d, 0x72, 0x56, 0x33, 0xd3, 0xb3,
+	0xd3, 0x3d, 0xa2, 0xb8, 0x8e, 0x80, 0x85, 0x13, 0x24, 0x97, 0x04, 0x39, 0x6c, 0x90, 0x20, 0x40,
+	0x10, 0x2c, 0x90, 0x7b, 0xfe, 0x81, 0xfc, 0x05, 0x09, 0x12, 0x60, 0x81, 0x3d, 0xe5, 0x92, 0xd3,
+	0xfe, 0x03, 0x7b, 0xc8, 0x29, 0x97, 0xbc, 0xf7, 0xea, 0x55, 0x75, 0x55, 0x77, 0x8f, 0xa4, 0x20,
+	0x41, 0x4e, 0x9c, 0xfa, 0xd5, 0xeb, 0x57, 0x55, 0xaf, 0x5e, 0xbd, 0xaf, 0x2a, 0x8a, 0xa5, 0xc9,
+	0xb8, 0xbb, 0x33, 0x9e, 0xc4, 0x69, 0x2c, 0xeb, 0x83, 0x11, 0x34, 0xda, 0x37, 0xcf, 0xe3, 0xf8,
+	0x7c, 0x10, 0xed, 0x86, 0xe3, 0xfe, 0x6e, 0x38, 0x1a, 0xc5, 0x69, 0x98, 0xf6, 0xe3, 0x51, 0xa2,
+	0x89, 0xd4, 0xaf, 0x2a, 0xa2, 0xf1, 0x68, 0x12, 0x8e, 0x92, 0xb0, 0x8b, 0xb0, 0x6c, 0x89, 0x85,
+	0xf4, 0x59, 0xe7, 0x22, 0x4c, 0x2e, 0x5a, 0x95, 0x5b, 0x95, 0xdb, 0x4b, 0x81, 0x69, 0xca, 0x2d,
+	0x31, 0x1f, 0x0e, 0xe3, 0xe9, 0x28, 0x6d, 0x55, 0xa1, 0x63, 0x2e, 0xe0, 0x96, 0xfc, 0x96, 0x58,
+	0x1f, 0x4d, 0x87, 0x9d, 0x6e, 0x3c, 0x7a, 0xdc, 0x9f, 0x0c, 0x35, 0xf3, 0xd6, 0x1c, 0x90, 0xd4,
+	0x83, 0x62, 0x87, 0x7c, 0x43, 0x88, 0xb3, 0x41, 0xdc, 0x7d, 0xa2, 0x87, 0xa8, 0xd1, 0x10, 0x0e,
+	0x22, 0x95, 0x68, 0x72, 0x2b, 0xea, 0x9f, 0x5f, 0xa4, 0xad, 0x3a, 0x31, 0xf2, 0x30, 0xe4, 0x91,
+	0xf6, 0x87, 0x51, 0x27, 0x49, 0xc3, 0xe1, 0xb8, 0x35, 0x4f, 0xb3, 0x71, 0x10, 0xea, 0x87, 0x65,
+	0x0e, 0x3a, 0x8f, 0xa3, 0x28, 0x69, 0x2d, 0x70, 0xbf, 0x45, 0x54, 0x4b, 0x6c, 0x7d, 0x14, 0xa5,
+	0xce, 0xaa, 0x93, 0x20, 0xfa, 0xd1, 0x34, 0x4a, 0x52, 0x75, 0x2c, 0xa4, 0x03, 0x1f, 0x44, 0x69,
+	0xd8, 0x1f, 0x24, 0xf2, 0x5d, 0xd1, 0x4c, 0x1d, 0x62, 0x10, 0xcc, 0xdc, 0xed, 0xc6, 0x9e, 0xdc,
+	0x21, 0xf9, 0xee, 0x38, 0x1f, 0x04, 0x1e, 0x9d, 0xfa, 0x39, 0xc8, 0xf6, 0x34, 0x1a, 0xf5, 0x98,
+	0xbb, 0x94, 0xa2, 0xd6, 0x83, 0xbf, 0x24, 0xd8, 0x66, 0x40, 0xbf, 0xe5, 0xd7, 0x44, 0x03, 0xff,
+	0xc2, 0xcc, 0x27, 0xfd, 0xd1, 0x39, 0x89, 0x16, 0x04, 0x82, 0xd0, 0x29, 0x21, 0x72, 0x4d, 0xcc,
+	0x85, 0xc3, 0x94, 0x04, 0x3a, 0x17, 0xe0, 0x4f, 0xf9, 0xa6, 0x68, 0x8e, 0xc3, 0xab, 0x61, 0x34,
+	0x4a, 0x33, 0x21, 0x36, 0x83, 0x06, 0x63, 0x47, 0x28, 0xc5, 0x1d, 0xb1, 0xe1, 0x92, 0x18, 0xee,
+	0x75, 0xe2, 0xbe, 0xee, 0x50, 0xf2, 0x20, 0x6f, 0x8b, 0x55, 0x43, 0x3f, 0xd1, 0x93, 0x25, 0xb1,
+	0x2e, 0x05, 0x2b, 0x0c, 0x1b, 0x01, 0xfd, 0x55, 0x45, 0x34, 0xf5, 0x92, 0x92, 0x31, 0x2c, 0x31,
+	0x92, 0x6f, 0x89, 0x65, 0xf3, 0x65, 0x34, 0x99, 0xc4, 0x13, 0xd6, 0x1a, 0x1f, 0x94, 0x77, 0xc4,
+	0x9a, 0x01, 0xc6, 0x93, 0xa8, 0x3f, 0x0c, 0xcf, 0x23, 0x5a, 0x6a, 0x33, 0x28, 0xe0, 0x72, 0x2f,
+	0xe3, 0x38, 0x89, 0xa7, 0x69, 0x44, 0x4b, 0x6f, 0xec, 0x35, 0x59, 0xdc, 0x01, 0x62, 0x81, 0x4f,
+	0xa2, 0xbe, 0x82, 0x69, 0xdd, 0xbf, 0x00, 0xed, 0x8e, 0x06, 0x27, 0x71, 0x1f, 0x94, 0x12, 0xd4,
+	0xe8, 0xf1, 0x74, 0xd4, 0x83, 0xb5, 0x75, 0xd2, 0x67, 0xfd, 0x1e, 0x8b, 0xdc, 0xc3, 0x70, 0x52,
+	0x6e, 0x1b, 0x85, 0xc4, 0xf2, 0x2f, 0xe0, 0xc8, 0x0f, 0x06, 0x1a, 0x4f, 0xd3, 0x4e, 0x7f, 0xd4,
+	0x8b, 0x9e, 0xd1, 0x9c, 0x96, 0x03, 0x0f, 0x53, 0xbf, 0x23, 0xd6, 0x8e, 0x51, 0x3f, 0x47, 0xf0,
+	0xe5, 0x7e, 0xaf, 0x37, 0x89, 0x92, 0x04, 0x0f, 0xcd, 0x78, 0x7a, 0xf6, 0x24, 0xba, 0x62, 0xb9,
+	0x70, 0x0b, 0x55, 0xe1, 0x22, 0x4e, 0x52, 0x1e, 0x8f, 0x7e, 0xab, 0x9f, 0x55, 0xc4, 0x2a, 0xca,
+	0xf6, 0x93, 0x70, 0x74, 0x65, 0x54, 0xe6, 0x58, 0x34, 0x91, 0xd5, 0xa3, 0x78, 0x5f, 0x1f, 0x3d,
+	0xad, 0x7a, 0xb7, 0x59, 0x16, 0x39, 0xea, 0x1d, 0x97, 0xf4, 0xc1, 0x28, 0x9d, 0x5c, 0x05, 0xde,
+	0xd7, 0xed, 0x0f, 0xc5, 0x7a, 0x81, 0x04, 0x15, 0x2c, 0x9b, 0x1f, 0xfe, 0x94, 0x9b, 0xa2, 0xfe,
+	0x34, 0x1c, 0x4c, 0x23, 0x3e, 0xe8, 0xba, 0xf1, 0x7e, 0xf5, 0xbd, 0x8a, 0xfa, 0x86, 0x58, 0xcb,
+	0xc6, 0x64, 0x0d, 0x80, 0xa5, 0x58, 0x11, 0xc3, 0x52, 0xf0, 0x37, 0x8a, 0x02, 0xe9, 0xee, 0xc3,
+	0x5e, 0x24, 0x8e, 0xf6, 0x87, 0x30, 0xb8, 0xa1, 0xc3, 0xdf, 0xb3, 0x6c, 0x8a, 0x7a, 0x5b, 0xac,
+	0x3b, 0xdf, 0xbf, 0x60, 0xa0, 0xbf, 0xaf, 0x88, 0xf5, 0x87, 0xd1, 0x25, 0x8b, 0xdb, 0x0c, 0xf5,
+	0x1e, 0x50, 0x5e, 0x8d, 0x23, 0xa2, 0x5c, 0xd9, 0x7b, 0x8b, 0xa5, 0x55, 0xa0, 0xdb, 0xe1, 0xe6,
+	0x23, 0xa0, 0x0d, 0xe8, 0x0b, 0xf5, 0xa9, 0x68, 0x38, 0xa0, 0xdc, 0x16, 0x1b, 0x5f, 0x7c, 0xfc,
+	0xe8, 0xe1, 0x83, 0xd3, 0xd3, 0xce, 0xc9, 0x67, 0xf7, 0xbe, 0xf7, 0xe0, 0x07, 0x9d, 0xa3, 0xfd,
+	0xd3, 0xa3, 0xb5, 0xd7, 0x60, 0xe2, 0x12, 0xd0, 0x47, 0x0f, 0x0e, 0x3c, 0xbc, 0x22, 0x57, 0x45,
+	0xc3, 0x05, 0xaa, 0xaa, 0x2d, 0x5a, 0x30, 0xee, 0x17, 0xfd, 0x74, 0x04, 0x3c, 0xfd, 0xe1, 0xd5,
+	0x0e, 0x30, 0x71, 0xe6, 0xc4, 0xcb, 0x04, 0x0b, 0x1c, 0x6a, 0xc8, 0x58, 0x60, 0x6e, 0x82, 0xf4,
+	0xe5, 0x69, 0xff, 0x7c, 0xf4, 0x09, 0xfc, 0x86, 0x83, 0x62, 0x16, 0x0b, 0xfb, 0x37, 0x4c, 0xce,
+	0x59, 0xc3, 0xf1, 0xa7, 0xfa, 0xb6, 0xd8, 0xf0, 0xe8, 0x98, 0xf1, 0x4d, 0xb1, 0x94, 0x00, 0x1c,
+	0xa6, 0xd3, 0x49, 0xc4, 0xac, 0x33, 0x40, 0x1d, 0x8a, 0xcd, 0xcf, 0xa3, 0x49, 0xff, 0xf1, 0xd5,
+	0xcb, 0xd8, 0xfb, 0x7c, 0xaa, 0x79, 0x3e, 0x0f, 0xc4, 0xb5, 0x1c, 0x1f, 0x1e, 0x5e, 0x6b, 0x15,
+	0xef, 0xdf, 0x62, 0xa0, 0x1b, 0xce, 0x01, 0xa9, 0xba, 0x07, 0x44, 0x7d, 0x26, 0xe4, 0xfd, 0x18,
+	0xce, 0x73, 0x37, 0x3d, 0x89, 0xa2, 0x89, 0x99, 0xcc, 0x37, 0x1d, 0x1d, 0x6a, 0xec, 0x6d, 0xf3,
+	0xc6, 0xe6, 0x4f, 0x1d, 0x2b, 0x17, 0xe8, 0xcb, 0x38, 0x9a, 0x0c, 0x89, 0xf1, 0x62, 0x40, 0xbf,
+	0xd5, 0xae, 0xd8, 0xf0, 0xd8, 0x66, 0x32, 0x1f, 0x43, 0xbb, 0xc3, 0xb3, 0xab, 0x07, 0xa6, 0xa9,
+	0xde, 0x11, 0xd7, 0x0e, 0xfa, 0x49, 0xb7, 0x38, 0x15, 0xfc, 0x64, 0x7a, 0xd6, 0xc9, 0x8e, 0x8e,
+	0x69, 0xa2, 0x7b, 0xc9, 0x7f, 0xa2, 0x87, 0x51, 0x7f, 0x5a, 0x11, 0xb5, 0xa3, 0x47, 0xc7, 0xf7,
+	0x65, 0x5b, 0x2c, 0xf6, 0x47, 0xdd, 0x78, 0x88, 0x46, 0x59, 0x8b, 0xc3, 0xb6, 0x67, 0xfa, 0x59,
+	0x10, 0x3b, 0xd9, 0x72, 0xf4, 0x84, 0x64, 0x7f, 0x9a, 0x41, 0x06, 0xa0, 0x17, 0x8e, 0x9e, 0x8d,
+	0xfb, 0x13, 0x72, 0xb3, 0xc6, 0x79, 0xd6, 0xc8, 0x4a, 0x15, 0x3b, 0xd4, 0xbf, 0xd6, 0xc4, 0xf2,
+	0x3e, 0x78, 0xa9, 0xa7, 0x11, 0x5b, 0x4d, 0x1a, 0x95, 0x00, 0x9e, 0x0f, 0xb7, 0xd0, 0xbe, 0x4f,
+	0xa2, 0x61, 0x9c, 0x46, 0x1d, 0x6f, 0x9b, 0x7c, 0x10, 0xa9, 0xba, 0x9a, 0x51, 0x67, 0x8c, 0xf6,
+	0x97, 0xe6, 0x07, 0x54, 0x1e, 0x88, 0x22, 0x43, 0x00, 0xa5, 0x8c, 0x33, 0xab, 0x05, 0xa6, 0x89,
+	0xf2, 0xe8, 0x86, 0xe3, 0xb0, 0xdb, 0x4f, 0xaf, 0xc8, 0x49, 0xcd, 0x05, 0xb6, 0x8d, 0xbc, 0x61,
+	0x85, 0xe0, 0xbb, 0xcf, 0xc2, 0x41, 0x38, 0xea, 0x46, 0xec, 0xf0, 0x7d, 0x50, 0x7e, 0x43, 0xac,
+	0xf0, 0x94, 0x0c, 0x99, 0xf6, 0xfb, 0x39, 0x14, 0x63, 0x03, 0x90, 0xf3, 0xb0, 0x9f, 0x62, 0x28,
+	0xd0, 0x5a, 0xd4, 0xb1, 0x41, 0x86, 0xd0, 0x4a, 0x74, 0xeb, 0x52, 0xcb, 0x70, 0x49, 0x8f, 0xe6,
+	0x81, 0xc8, 0x05, 0x88, 0x3b, 0xa0, 0x52, 0x9d, 0x27, 0x97, 0x2d, 0xa1, 0xb9, 0x64, 0x08, 0xee,
+	0xc6, 0x14, 0x36, 0x3c, 0x4d, 0x07, 0x51, 0xcf, 0x4e, 0xa8, 0x41, 0x64, 0xc5, 0x0e, 0x79, 0x57,
+	0x6c, 0xe8, 0xe8, 0x24, 0x09, 0xd3, 0x38, 0xb9, 0xe8, 0x27, 0x9d, 0x04, 0x5c, 0x5b, 0xab, 0x49,
+	0xf4, 0x65, 0x5d, 0x60, 0xe0, 0xb6, 0x73, 0xf0, 0x24, 0xea, 0x46, 0xb0, 0x5f, 0xbd, 0xd6, 0x32,
+	0x7d, 0x35, 0xab, 0x5b, 0xde, 0x12, 0x0d, 0x0c, 0xca, 0xa6, 0xe3, 0x5e, 0x98, 0x42, 0x70, 0xb4,
+	0x42, 0xfb, 0xe0, 0x42, 0xf2, 0x1d, 0xf0, 0xbf, 0x91, 0x76, 0x7f, 0x17, 0xe9, 0xa0, 0x9b, 0xb4,
+	0x56, 0xc9, 0xe7, 0x34, 0xf8, 0xb0, 0xa1, 0xfe, 0x06, 0x3e, 0x85, 0xba, 0x26, 0x36, 0x8e, 0xfb,
+	0x49, 0xca, 0xba, 0x64, 0xed, 0xdb, 0x91, 0xd8, 0xf4, 0x61, 0x3e, 0x6d, 0x77, 0x61, 0xb7, 0x19,
+	0x03, 0xa1, 0x20, 0xf3, 0x4d, 0x66, 0xee, 0xe9, 0x64, 0x60, 0xa9, 0xd4, 0x9f, 0x54, 0x45, 0x0d,
+	0x4f, 0xd2, 0xec, 0x53, 0xe7, 0x1e, 0xe1, 0xaa, 0x77, 0x84, 0x5d, 0x83, 0x3a, 0xe7, 0x19, 0x54,
+	0x0a, 0x46, 0xaf, 0x60, 0xcd, 0x5a, 0xde, 0x5a, 0x27, 0x1d, 0x24, 0xeb, 0x07, 0xf1, 0x3d, 0x25,
+	0xc5, 0xb4, 0xfd, 0x88, 0xa0, 0xda, 0x82, 0x84, 0xf5, 0xd7, 0x5a, 0x2b, 0x6d, 0xdb, 0xf4, 0xd1,
+	0x97, 0x0b, 0x59, 0x1f, 0x7d, 0x07, 0x33, 0xea, 0x8f, 0xce, 0xe0, 0x54, 0xf7, 0x48, 0x03, 0x17,
+	0x03, 0xd3, 0xc4, 0x43, 0x3e, 0xa6, 0xc0, 0x03, 0xa2, 0x59, 0x56, 0xbd, 0x0c, 0x50, 0x12, 0x23,
+	0x8c, 0x84, 0x6c, 0x8a, 0x15, 0xf2, 0xbb, 0x62, 0xdd, 0xc1, 0x58, 0xc2, 0x6f, 0x8a, 0x3a, 0xae,
+	0xde, 0x84, 0xaa, 0x66, 0xef, 0xc8, 0x18, 0xe9, 0x1e, 0xb5, 0x26, 0x56, 0x20, 0x08, 0xfe, 0x78,
+	0xf4, 0x38, 0x36, 0x9c, 0xfe, 0xb3, 0x2a, 0x56, 0x2d, 0xc4, 0x8c, 0x6e, 0x8b, 0xd5, 0x7e, 0x0f,
+	0x96, 0x03, 0x07, 0xb1, 0xe3, 0x05, 0x32, 0x79, 0x18, 0xcd, 0x3b, 0x18, 0xf4, 0x30, 0x61, 0x03,
+	0xa1, 0x1b, 0x10, 0xcc, 0x6d, 0xa2, 0x6e, 0x19, 0x75, 0xb1, 0xdb, 0xae, 0xe3, 0xa7, 0xd2, 0x3e,
+	0x3c, 0x0e, 0x88, 0x6b, 0x03, 0x94, 0x7d, 0xa2, 0x8d, 0x59, 0x59, 0x17, 0x4a, 0x4d, 0x73, 0xc2,
+	0x25, 0xd7, 0x89, 0x2e, 0x03, 0x0a, 0x29, 0xc5, 0xbc, 0x8e, 0xdd, 0xf2, 0x29, 0x85, 0x93, 0x96,
+	0x2c, 0x16, 0xd2, 0x12, 0x90, 0x43, 0x72, 0x05, 0x67, 0xb5, 0xd7, 0x49, 0x63, 0x1c, 0xb7, 0x3f,
+	0xa2, 0xdd, 0x59, 0x0c, 0xf2, 0x30, 0x25, 0x50, 0x20, 0xcd, 0x51, 0x94, 0x92, 0x5d, 0x80, 0xbd,
+	0xe5, 0x26, 0x9a, 0x58, 0x22, 0xd1, 0x4a, 0x0f, 0xae, 0x4e, 0xb7, 0xd4, 0x8f, 0xc9, 0xd5, 0xd9,
+	0x1c, 0xe9, 0x33, 0x3a, 0x87, 0xf2, 0x86, 0x58, 0xd2, 0xe3, 0x27, 0x17, 0x21, 0x7b, 0xdf, 0x45,
+	0x02, 0x4e, 0x2f, 0x42, 0x4c, 0x01, 0xbc, 0x25, 0x69, 0x8d, 0x6f, 0x10, 0x76, 0xa4, 0x57, 0xf4,
+	0x96, 0x58, 0x31, 0xd9, 0x57, 0xd2, 0x19, 0x44, 0x8f, 0x53, 0x13, 0xb3, 0x02, 0x8a, 0xc3, 0x25,
+	0xc7, 0x80, 0xa9, 0x87, 0x62, 0x9d, 0x4f, 0xdb, 0xa7, 0xb0, 0x0f, 0x3c, 0xf4, 0x77, 0xf2, 0xd6,
+	0x5c, 0xbb, 0xdb, 0x0d, 0xd6, 0x22, 0x37, 0xd0, 0xce, 0x99, 0x78, 0x15, 0xc0, 0x5a, 0x34, 0x70,
+	0x7f, 0x10, 0x27, 0x11, 0x33, 0x84, 0x1d, 0xe8, 0x42, 0x33, 0x1f, 0x8d, 0xbb, 0x18, 0xca, 0x2d,
+	0x99, 0x76, 0xbb, 0x78, 0x4a, 0xb5, 0xc3, 0x36, 0x4d, 0x15, 0x81, 0xcf, 0x46, 0x66, 0xc6, 0x2c,
+	0xd8, 0x20, 0xef, 0xd5, 0x67, 0xd9, 0xec, 0xba, 0xc9, 0x01, 0xa8, 0xea, 0xe3, 0x78, 0xd2, 0x8d,
+	0x78, 0x20, 0xdd, 0x50, 0xff, 0x0e, 0xa1, 0x24, 0x8d, 0x73, 0x0a, 0x19, 0xf2, 0x34, 0xe1, 0xa9,
+	0xff, 0x16, 0x8c, 0x82, 0xa0, 0x51, 0x53, 0x1e, 0x65, 0xd3, 0x9e, 0x28, 0x42, 0x35, 0xf1, 0xd1,
+	0x6b, 0x81, 0x4f, 0x2c, 0x3f, 0x84, 0x85, 0x3b, 0x5b, 0x4b, 0x03, 0x36, 0xf6, 0xae, 0x9b, 0x29,
+	0x16, 0x76, 0x1d, 0x38, 0x78, 0x1f, 0xc8, 0x0f, 0xc0, 0x5d, 0xa1, 0x8f, 0x24, 0xb6, 0x9c, 0x09,
+	0x5d, 0xf7, 0x57, 0xe8, 0x08, 0x1a, 0x3e, 0x77, 0xc8, 0xef, 0x2d, 0x8a, 0x79, 0x6d, 0xd4, 0xd5,
+	0x47, 0x62, 0xd9, 0x9b, 0xa9, 0x17, 0x4b, 0x37, 0x75, 0x2c, 0x5d, 0xc8, 0x71, 0xaa, 0x25, 0x39,
+	0xce, 0x7f, 0x54, 0x84, 0x44, 0x4d, 0xc9, 0xed, 0x05, 0x78, 0xdf, 0x34, 0x9c, 0x9c, 0x47, 0x69,
+	0xc7, 0x0f, 0xa3, 0x72, 0x28, 0x79, 0x9f, 0xb8, 0xe7, 0xc5, 0x12, 0x90, 0xb9, 0x3a, 0x10, 0x64,
+	0xae, 0xd2, 0x69, 0x9a, 0xc4, 0x55, 0xdb, 0xed, 0x92, 0x1e, 0x34, 0x30, 0x3a, 0x10, 0x30, 0x29,
+	0x1b, 0xc7, 0x4e, 0x35, 0xb2, 0x9d, 0xa5, 0x7d, 0x68, 0x9a, 0xc7, 0x53, 0xcc, 0x8a, 0xc3, 0xd4,
+	0x44, 0x1b, 0xa6, 0xad, 0x7e, 0x51, 0x11, 0x6b, 0xb8, 0x40, 0x4f, 0x09, 0xde, 0x17, 0xa4, 0x40,
+	0xaf, 0xa8, 0x03, 0x1e, 0xed, 0xff, 0x5e, 0x05, 0xde, 0x13, 0x4b, 0xc4, 0x30, 0x06, 0x8e, 0xac,
+	0x01, 0x2d, 0x5f, 0x03, 0xb2, 0xa3, 0x0b, 0x1f, 0x67, 0xc4, 0xce, 0xfe, 0x6f, 0x8b, 0x6b, 0x3c,
+	0x4b, 0x7f, 0xe3, 0xd4, 0x9f, 0x09, 0xb1, 0x95, 0xef, 0xb1, 0x5e, 0x9a, 0x43, 0x8f, 0x41, 0x7f,
+	0x78, 0x16, 0xdb, 0x28, 0xa6, 0xe2, 0x46, 0x25, 0x5e, 0x97, 0x7c, 0x2c, 0xae, 0x19, 0x63, 0x8e,
+	0xe3, 0x67, 0xa6, 0xbb, 0x4a, 0x5e, 0xe8, 0xae, 0x2f, 0xaf, 0xdc, 0x78, 0x06, 0x76, 0xb5, 0xab,
+	0x9c, 0x9d, 0x3c, 0x17, 0x2d, 0xeb, 0x34, 0xd8, 0x84, 0x38, 0x8e, 0x05, 0x87, 0xfa, 0xe6, 0x8b,
+	0x87, 0xa2, 0x23, 0xd3, 0x33, 0xe8, 0x4c, 0x66, 0xf2, 0x99, 0x78, 0xc3, 0xf4, 0x91, 0x8d, 0x28,
+	0x0e, 0x57, 0x7b, 0x95, 0x95, 0x1d, 0xe2, 0xb7, 0xfe, 0x98, 0x2f, 0xe1, 0xdb, 0xfe, 0x97, 0x8a,
+	0x58, 0xf1, 0xb9, 0xa1, 0x0b, 0xe2, 0x58, 0xd6, 0x1c, 0x03, 0xe3, 0x8a, 0x73, 0x70, 0x31, 0x1a,
+	0xaf, 0x96, 0x45, 0xe3, 0x6e, 0xcc, 0x3d, 0xf7, 0xb2, 0x98, 0xbb, 0xf6, 0x6a, 0x31, 0x77, 0xbd,
+	0x2c, 0xe6, 0x6e, 0xff, 0xac, 0x2a, 0x64, 0x71, 0x77, 0xe5, 0xa1, 0x4e, 0x07, 0xe0, 0x27, 0x1f,
+	0xa8, 0x6f, 0xbd, 0x92, 0x82, 0x18, 0xd8, 0x7c, 0x8c, 0x8a, 0xea, 0x1e, 0x18, 0xd7, 0x27, 0x42,
+	0xbc, 0x50, 0xd2, 0x85, 0x95, 0x1f, 0x72, 0x95, 0x09, 0x84, 0x55, 0x83, 0x41, 0x76, 0xb2, 0x96,
+	0x83, 0x02, 0x9e, 0x4b, 0x18, 0x6a, 0x2f, 0x4f, 0x18, 0xea, 0x2f, 0x4f, 0x18, 0xe6, 0xf3, 0x09,
+	0x43, 0xfb, 0x4b, 0xb1, 0xec, 0x29, 0xc8, 0xff, 0x99, 0x70, 0xf2, 0xae, 0x57, 0xab, 0x82, 0x87,
+	0xb5, 0xbf, 0x82, 0xfd, 0x29, 0xea, 0xe8, 0xff, 0xe7, 0x14, 0x48, 0xe1, 0x3c, 0x33, 0x33, 0xc7,
+	0x0a, 0xe7, 0x19, 0x18, 0x38, 0x02, 0x43, 0xac, 0x32, 0x60, 0xd8, 0xe9, 0xa5, 0xb8, 0x79, 0x18,
+	0x75, 0x22, 0xdb, 0xc9, 0x8e, 0xe9, 0xe5, 0xd8, 0xb0, 0xac, 0x4b, 0x7d, 0x47, 0x6c, 0x7e, 0x11,
+	0x0e, 0x06, 0x51, 0x7a, 0x4f, 0x0f, 0x66, 0x5c, 0x1b, 0x84, 0x5a, 0x97, 0xba, 0x7a, 0xd3, 0x89,
+	0x47, 0x83, 0x2b, 0x4e, 0x8f, 0x1b, 0x8c, 0x7d, 0x0a, 0x10, 0xd6, 0x08, 0x72, 0x9f, 0x66, 0x65,
+	0x05, 0xdf, 0x6c, 0x9a, 0x26, 0x1a, 0x64, 0x96, 0x93, 0x3f, 0x9c, 0xda, 0x13, 0x5b, 0xf9, 0x8e,
+	0x97, 0x32, 0xfb, 0x50, 0xc8, 0xef, 0x4f, 0xa3, 0xc9, 0x15, 0x95, 0x46, 0x6d, 0x11, 0x6c, 0x3b,
+	0x9f, 0x2a, 0x61, 0x69, 0xe5, 0x7b, 0xd1, 0x95, 0xa9, 0x28, 0x57, 0x6d, 0x45, 0x59, 0x7d, 0x20,
+	0x36, 0x3c, 0x06, 0xb6, 0xb6, 0x3b, 0x4f, 0xe5, 0x55, 0x93, 0x46, 0xf8, 0x25, 0x58, 0xee, 0x53,
+	0x7f, 0x53, 0x11, 0x73, 0x47, 0xf1, 0xd8, 0xcd, 0xee, 0x2b, 0x7e, 0x76, 0xcf, 0xf6, 0xa8, 0x63,
+	0xcd, 0x4d, 0x95, 0x8f, 0x88, 0x0b, 0xa2, 0x35, 0x81, 0xb9, 0x60, 0x20, 0x0d, 0x36, 0xf1, 0x32,
+	0x9c, 0xf4, 0x58, 0x07, 0x72, 0x28, 0x4e, 0x3f, 0x3b, 0x89, 0xf8, 0x13, 0x03, 0x6b, 0x2a, 0x71,
+	0x98, 0xfd, 0xe5, 0x96, 0xfa, 0xcb, 0x8a, 0xa8, 0xd3, 0x5c, 0x51, 0x71, 0xb4, 0xc3, 0xa2, 0x5b,
+	0x02, 0xaa, 0xa0, 0x54, 0xb4, 0xe2, 0xe4, 0xe0, 0xdc, 0xdd, 0x41, 0x35, 0x7f, 0x77, 0x80, 0xa9,
+	0x86, 0x6e, 0x65, 0x45, 0xf9, 0x0c, 0x80, 0xaf, 0x6b, 0x17, 0xf1, 0xd8, 0xb8, 0x05, 0x61, 0x52,
+	0xe6, 0x78, 0x1c, 0x10, 0xae, 0xee, 0x88, 0xd5, 0x87, 0x60, 0xa5, 0x9d, 0xac, 0x6b, 0xe6, 0x36,
+	0xa9, 0x9f, 0x54, 0xc4, 0xa2, 0x21, 0x86, 0x05, 0xd4, 0xd0, 0xbc, 0xe7, 0x22, 0x0f, 0x5b, 0xf8,
+	0x42, 0xba, 0x80, 0x28, 0xf0, 0xb4, 0x51, 0xdc, 0x9f, 0xf9, 0x5e, 0x13, 0xf5, 0x67, 0x7e, 0x0d,
+	0xc3, 0x35, 0x9a, 0x73, 0xce, 0x01, 0xe4, 0x50, 0xf5, 0xd3, 0x8a, 0x58, 0xf6, 0xc6, 0xc0, 0x00,
+	0x6e, 0x10, 0x26, 0x29, 0x17, 0x0b, 0x58, 0x88, 0x2e, 0xe4, 0x66, 0xe8, 0x55, 0x3f, 0x43, 0xb7,
+	0x19, 0xe2, 0x9c, 0x9b, 0x21, 0xde, 0x15, 0x4b, 0x9c, 0x8e, 0x47, 0x46, 0x6e, 0xe6, 0x66, 0x05,
+	0x47, 0x34, 0x25, 0xbd, 0x8c, 0x08, 0xb4, 0xb5, 0xe1, 0xf4, 0xe0, 0x80, 0x90, 0x5d, 0x5d, 0xc6,
+	0x93, 0x27, 0xa6, 0x24, 0xc0, 0x4d, 0x5b, 0x71, 0xae, 0x66, 0x15, 0x67, 0xf5, 0xcf, 0xb0, 0x24,
+	0xd4, 0x09, 0x58, 0xd0, 0x49, 0x3c, 0xe8, 0x77, 0xaf, 0x48, 0x37, 0xcc, 0xf6, 0x77, 0x7a, 0xd1,
+	0x20, 0x0d, 0xad, 0x6e, 0xf8, 0x30, 0x7a, 0xcc, 0x61, 0x7f, 0x44, 0x35, 0x0f, 0xd6, 0x0c, 0xdb,
+	0x46, 0x1d, 0x47, 0x73, 0x7e, 0x16, 0x42, 0xf4, 0x3f, 0xc4, 0xc0, 0x92, 0x0d, 0x98, 0x07, 0xa2,
+	0x59, 0x42, 0x60, 0x02, 0x82, 0xea, 0x0c, 0xc1, 0xc5, 0xf4, 0x35, 0xad, 0xd6, 0xe5, 0xb2, 0x2e,
+	0x1c, 0xb3, 0xd7, 0x4f, 0xc2, 0xb3, 0x41, 0xd4, 0x23, 0xed, 0x5e, 0x0c, 0x6c, 0x5b, 0xfd, 0x53,
+	0x55, 0x34, 0xd8, 0x58, 0x3c, 0xe8, 0x9d, 0xeb, 0xda, 0x16, 0xbb, 0x78, 0x7b, 0x08, 0x1d, 0xc4,
+	0xf4, 0x7b, 0x41, 0x81, 0x83, 0xe4, 0x37, 0x77, 0xae, 0xb8, 0xb9, 0x98, 0x68, 0x83, 0xe8, 0xdf,
+	0xa1, 0xe8, 0x43, 0x5f, 0xde, 0x65, 0x80, 0xe9, 0xdd, 0xa3, 0xde, 0x7a, 0xd6, 0x4b, 0x80, 0x17,
+	0x6f, 0xcc, 0xe7, 0xe2, 0x8d, 0xf7, 0x40, 0x69, 0x35, 0x1b, 0xda, 0x13, 0x2a, 0x98, 0x64, 0x6a,
+	0xee, 0xed, 0x57, 0xe0, 0x51, 0x9a, 0x2f, 0xf7, 0xcc, 0x97, 0x8b, 0x2f, 0xfb, 0xd2, 0x50, 0x62,
+	0xd1, 0x8a, 0x85, 0xf7, 0xd1, 0x24, 0x1c, 0x5f, 0x18, 0x03, 0xdc, 0xb3, 0x37, 0x49, 0x04, 0x43,
+	0xac, 0x50, 0xc7, 0xcf, 0x8c, 0x0d, 0x2c, 0x3f, 0x7a, 0x9a, 0x04, 0x54, 0xa9, 0x1e, 0xc1, 0x46,
+	0x98, 0x80, 0x57, 0xfa, 0x61, 0x3a, 0xee, 0x51, 0xa0, 0x09, 0xd0, 0x10, 0x20, 0x9a, 0x33, 0x04,
+	0xbe, 0xfd, 0xc4, 0xfa, 0xc0, 0xe8, 0xe3, 0x9e, 0xda, 0xc4, 0x6b, 0x02, 0xd2, 0x68, 0xb7, 0x5a,
+	0xf3, 0xc7, 0x73, 0x70, 0x0c, 0x32, 0x18, 0xcf, 0xf4, 0x39, 0x4e, 0xb8, 0xd3, 0xeb, 0x87, 0xc3,
+	0x28, 0x8d, 0x26, 0xac, 0xc5, 0x39, 0x94, 0xcc, 0xec, 0x53, 0x88, 0xa8, 0x21, 0xa5, 0xeb, 0x45,
+	0xe7, 0x93, 0x48, 0x67, 0xc1, 0x95, 0x20, 0x87, 0x22, 0xdd, 0x30, 0x7c, 0xe6, 0xd2, 0x69, 0x7d,
+	0xc8, 0xa1, 0xa6, 0xf6, 0xa2, 0x65, 0x54, 0xcb, 0x6a, 0x2f, 0x5a, 0x22, 0x79, 0x6b, 0x54, 0x2f,
+	0xb1, 0x46, 0xef, 0x8a, 0x2d, 0x6d, 0x77, 0xf8, 0xdc, 0x76, 0x72, 0x6a, 0x32, 0xa3, 0x17, 0xa3,
+	0x38, 0x9c, 0xb3, 0x51, 0xf0, 0xa4, 0xff, 0x63, 0x5d, 0xf4, 0xad, 0x04, 0x05, 0x1c, 0x69, 0xf1,
+	0xa8, 0x7a, 0xb4, 0xba, 0xf8, 0x5b, 0xc0, 0x89, 0x16, 0xd6, 0xe8, 0xd1, 0x2e, 0x31, 0x6d, 0x0e,
+	0x57, 0xcb, 0xa2, 0x71, 0x9a, 0x82, 0x79, 0xe7, 0x4d, 0x59, 0x11, 0x4d, 0xdd, 0xe4, 0x82, 0xff,
+	0x0d, 0x71, 0x9d, 0xb4, 0xe8, 0x51, 0x0c, 0x4a, 0x17, 0x9f, 0x5f, 0x9d, 0x4e, 0xcf, 0x92, 0xee,
+	0xa4, 0x3f, 0xc6, 0x60, 0x54, 0xfd, 0x5b, 0x45, 0x6c, 0x78, 0xbd, 0x9c, 0x6d, 0xfe, 0xba, 0x56,
+	0x69, 0x5b, 0xa3, 0xd5, 0x8a, 0xb7, 0xee, 0x18, 0x45, 0x4d, 0xa8, 0x13, 0xe7, 0xcf, 0xb8, 0x6c,
+	0xbb, 0x2f, 0x56, 0xcd, 0xcc, 0xcc, 0x87, 0x5a, 0x0b, 0x5b, 0x45, 0x2d, 0xe4, 0xef, 0x57, 0xf8,
+	0x03, 0xc3, 0xe2, 0xb7, 0x75, 0xa0, 0x16, 0xf5, 0x68, 0x8d, 0x26, 0x97, 0x6a, 0x9b, 0xef, 0xdd,
+	0xe0, 0xd0, 0xcc, 0xa0, 0x6b, 0xc1, 0x44, 0xfd, 0x79, 0x45, 0x88, 0x6c, 0x76, 0xa8, 0x18, 0x99,
+	0x61, 0xaf, 0x50, 0xc5, 0x2b, 0x03, 0x30, 0xac, 0xb2, 0x15, 0xc4, 0xcc, 0x57, 0x34, 0x0c, 0x86,
+	0x71, 0xca, 0xdb, 0x62, 0xf5, 0x7c, 0x10, 0x9f, 0x91, 0xe7, 0xa5, 0xbb, 0xa5, 0x84, 0xaf, 0x3d,
+	0x56, 0x34, 0x7c, 0xc8, 0x68, 0xe6, 0x58, 0x6a, 0x8e, 0x63, 0x51, 0x7f, 0x51, 0xb5, 0xb5, 0xad,
+	0x6c, 0xcd, 0x33, 0x4f, 0x99, 0xdc, 0x2b, 0x18, 0xc7, 0x19, 0xb5, 0x24, 0x4a, 0xb0, 0x4f, 0x5e,
+	0x9a, 0x42, 0x7d, 0x00, 0xc9, 0x91, 0xb6, 0x3e, 0xc6, 0x34, 0xd5, 0x5e, 0x60, 0x9a, 0x96, 0x27,
+	0x9e, 0x4f, 0xfa, 0x35, 0x50, 0xed, 0xde, 0xd3, 0x68, 0x92, 0xf6, 0x29, 0x44, 0x26, 0xd7, 0xaf,
+	0x0d, 0xea, 0xaa, 0x83, 0x93, 0x47, 0x06, 0x29, 0xf1, 0x55, 0x93, 0xa5, 0xe4, 0xab, 0xfb, 0x0c,
+	0x46, 0x42, 0xf5, 0x0f, 0x15, 0xae, 0xa3, 0xf9, 0x7b, 0x38, 0x5b, 0x22, 0xee, 0xea, 0xaa, 0xb9,
+	0xd5, 0x7d, 0x9d, 0xcb, 0x62, 0x3d, 0x13, 0x87, 0x73, 0x71, 0x51, 0x83, 0x5c, 0x82, 0xf4, 0x45,
+	0x5a, 0x7b, 0x15, 0x91, 0xaa, 0x1d, 0xbc, 0x03, 0x4f, 0xf7, 0x71, 0x07, 0x8d, 0x61, 0xbc, 0x01,
+	0x16, 0x26, 0xba, 0xec, 0xe8, 0x2d, 0xd6, 0x2e, 0x7e, 0x11, 0x00, 0xa2, 0xc1, 0x92, 0x78, 0x46,
+	0xcf, 0xa7, 0xee, 0xbf, 0xaa, 0x62, 0xe1, 0xe3, 0xd1, 0xd3, 0xb8, 0xdf, 0xa5, 0x42, 0xd7, 0x10,
+	0xb2, 0x51, 0x73, 0x69, 0x8c, 0xbf, 0x31, 0x62, 0xa0, 0xfb, 0x90, 0x71, 0xca, 0x15, 0x28, 0xd3,
+	0x44, 0x0f, 0x39, 0xc9, 0x5e, 0x28, 0x68, 0x6d, 0x73, 0x10, 0x8c, 0x34, 0x27, 0xee, 0xa3, 0x0b,
+	0x6e, 0x65, 0x37, 0xe6, 0x75, 0xe7, 0xc6, 0x9c, 0x4a, 0x9a, 0xfa, 0xaa, 0x87, 0xb6, 0x04, 0x4b,
+	0x9a, 0xba, 0x49, 0x11, 0xf1, 0x24, 0xd2, 0x39, 0x29, 0xf9, 0xda, 0x05, 0x8e, 0x88, 0x5d, 0x10,
+	0xfd, 0xb1, 0xfe, 0x40, 0xd3, 0x68, 0x7b, 0xe5, 0x42, 0x18, 0xbb, 0xe4, 0xdf, 0x6d, 0x2c, 0x69,
+	0x35, 0xc9, 0xc1, 0x68, 0xd4, 0xc0, 0x1e, 0x1b, 0xdb, 0xa3, 0xd7, 0x20, 0xf4, 0x0b, 0x8c, 0x3c,
+	0xee, 0xc4, 0xd3, 0xfa, 0xca, 0x8a, 0x5b, 0x14, 0xe3, 0x40, 0x9e, 0x73, 0x16, 0x42, 0x44, 0x44,
+	0x81, 0x55, 0x53, 0xd7, 0x15, 0x3c, 0x50, 0x7d, 0x2e, 0x24, 0x84, 0x66, 0x2c, 0x7f, 0x9b, 0x4b,
+	0x64, 0x92, 0xab, 0x78, 0x92, 0x2b, 0x59, 0x41, 0xb5, 0x74, 0x05, 0xea, 0x81, 0x68, 0x9c, 0x38,
+	0x4f, 0x5c, 0x68, 0xab, 0xcc, 0xe3, 0x16, 0xde, 0x5e, 0x07, 0x71, 0x06, 0xac, 0xba, 0x03, 0xaa,
+	0xdf, 0x14, 0x12, 0xef, 0x4b, 0xec, 0xfc, 0x6c, 0x96, 0x67, 0x6b, 0x4d, 0x4e, 0x96, 0xc7, 0x18,
+	0x65, 0x79, 0xfb, 0xfa, 0x92, 0x2b, 0xbf, 0xb0, 0x3b, 0x78, 0x95, 0x4b, 0x90, 0xb1, 0xd4, 0x2b,
+	0xac, 0xe2, 0x86, 0xd2, 0xf6, 0x63, 0xc8, 0xc1, 0xa0, 0xe7, 0x08, 0x20, 0x4f, 0x59, 0xe0, 0xa5,
+	0xa1, 0xc3, 0xf4, 0x1e, 0xf7, 0xe8, 0x85, 0x79, 0x58, 0xf9, 0xfb, 0x8c, 0xa2, 0x4e, 0xcd, 0x95,
+	0xe9, 0x14, 0x5e, 0x8a, 0x87, 0xe9, 0x05, 0x45, 0xda, 0x70, 0x1e, 0xf0, 0xb7, 0xc9, 0xa8, 0xea,
+	0x36, 0xa3, 0x32, 0x17, 0x7a, 0x3c, 0x29, 0x7b, 0xd7, 0x74, 0x4f, 0x5f, 0xe8, 0x65, 0x70, 0x26,
+	0x03, 0x9e, 0x60, 0x5e, 0x06, 0x4c, 0x1a, 0xd8, 0x7e, 0x7c, 0x10, 0x71, 0x10, 0x41, 0xae, 0x1c,
+	0xed, 0x0f, 0x06, 0x79, 0xfe, 0xe0, 0x2e, 0x4b, 0xfa, 0xf8, 0x54, 0x1f, 0x8a, 0xf5, 0x83, 0xe8,
+	0x6c, 0x7a, 0x7e, 0x1c, 0x3d, 0xcd, 0x0a, 0xcf, 0xb0, 0x9c, 0xe4, 0x22, 0xbe, 0xe4, 0xfd, 0xa2,
+	0xdf, 0xf2, 0x75, 0x21, 0x06, 0x48, 0xd3, 0x49, 0xc6, 0x51, 0xd7, 0x3c, 0x50, 0x20, 0xe4, 0x14,
+	0x00, 0xf5, 0xae, 0x90, 0x2e, 0x1f, 0x5e, 0x02, 0x9e, 0x35, 0xc8, 0x53, 0x92, 0xab, 0x24, 0x8d,
+	0x86, 0xc6, 0xcc, 0xb8, 0x90, 0x7a, 0x5b, 0x34, 0x61, 0x4e, 0x30, 0x30, 0xbf, 0x99, 0xc2, 0xc4,
+	0x2d, 0xbc, 0x42, 0xf5, 0xb4, 0x89, 0x1b, 0x75, 0xab, 0xbf, 0xab, 0x8a, 0x79, 0x4d, 0x89, 0x5c,
+	0xf1, 0x29, 0x57, 0x7f, 0xa4, 0x6b, 0xbf, 0xcc, 0xd5, 0x81, 0x0a, 0xfb, 0x5d, 0x2d, 0xd9, 0x6f,
+	0x0e, 0xa2, 0xcc, 0x65, 0x2e, 0x6f, 0xac, 0x87, 0x51, 0x5e, 0x0a, 0xe9, 0x8a, 0x7e, 0x12, 0x57,
+	0xe3, 0xbc, 0xd4, 0x00, 0xb9, 0x0c, 0x39, 0x3b, 0xd1, 0x7a, 0x7e, 0x46, 0x11, 0xd9, 0x71, 0xb8,
+	0x50, 0xa9, 0xdd, 0x58, 0xd0, 0x8f, 0xa4, 0x0a, 0x76, 0xa3, 0x60, 0x1f, 0x16, 0xcb, 0xec, 0x03,
+	0x58, 0xec, 0xc3, 0x08, 0xce, 0xcf, 0x38, 0x9e, 0xd8, 0x67, 0x65, 0x7f, 0x5b, 0x11, 0x6b, 0xec,
+	0x11, 0x6c, 0x1f, 0x9c, 0x49, 0xd7, 0x7d, 0x54, 0xca, 0x6a, 0x98, 0x30, 0x22, 0x25, 0x57, 0x98,
+	0x39, 0x51, 0x26, 0xc5, 0x95, 0x05, 0x0f, 0xc4, 0x55, 0x9a, 0x52, 0x1b, 0x64, 0x56, 0x2c, 0x3e,
+	0x17, 0x42, 0x57, 0x67, 0x92, 0x2f, 0x12, 0x5e, 0x25, 0xb0, 0x6d, 0x75, 0x22, 0xd6, 0x9d, 0xf9,
+	0xb2, 0xba, 0x7c, 0x20, 0xcc, 0x95, 0x92, 0x2e, 0x14, 0x68, 0xad, 0xdf, 0xf6, 0x9d, 0x5b, 0xf6,
+	0x99, 0x47, 0xac, 0xfe, 0xb1, 0x42, 0x22, 0xe0, 0x18, 0xca, 0xbe, 0x27, 0x99, 0xd7, 0x61, 0x8d,
+	0xd6, 0xe5, 0xa3, 0xd7, 0x02, 0x6e, 0xcb, 0xdf, 0x78, 0xc5, 0xc8, 0xc4, 0xde, 0xfe, 0xcc, 0x90,
+	0xcd, 0x5c, 0x99, 0x6c, 0x5e, 0xb0, 0xf2, 0x7b, 0x0b, 0xa2, 0x9e, 0x74, 0xe3, 0x71, 0xa4, 0x36,
+	0x48, 0x04, 0x66, 0xbe, 0x7c, 0x1e, 0x7f, 0x24, 0xae, 0x3f, 0x00, 0x3d, 0x1e, 0x02, 0x76, 0xa8,
+	0xef, 0x57, 0x68, 0xbd, 0x7a, 0x35, 0xb3, 0xae, 0x65, 0x2a, 0x2f, 0xb8, 0x96, 0x81, 0x6d, 0xe2,
+	0xeb, 0x22, 0xac, 0xd9, 0x9a, 0x3b, 0x4d, 0x07, 0x52, 0x7f, 0x24, 0xda, 0x65, 0x43, 0x66, 0x05,
+	0x32, 0x9c, 0x3a, 0x2e, 0x95, 0x0b, 0x64, 0xdc, 0xc4, 0x5c, 0x06, 0xef, 0xde, 0x71, 0xb7, 0x2f,
+	0xb3, 0xe2, 0xf0, 0x5c, 0x90, 0x43, 0xe9, 0x10, 0x3d, 0x33, 0x24, 0xa6, 0xb8, 0x63, 0x80, 0xbd,
+	0x9f, 0x40, 0xb7, 0x4d, 0xfb, 0xe4, 0x0f, 0xc5, 0xb2, 0x57, 0xf4, 0x93, 0x37, 0x78, 0x4b, 0xca,
+	0xaa, 0x88, 0xed, 0x9b, 0xe5, 0x9d, 0x2c, 0xca, 0x37, 0xbe, 0xfa, 0xc5, 0x2f, 0x7f, 0x5a, 0x6d,
+	0xc9, 0xad, 0xdd, 0xa7, 0xef, 0xec, 0x72, 0x55, 0x6f, 0x97, 0x8a, 0x94, 0xfa, 0x4e, 0xf9, 0x89,
+	0x58, 0xf1, 0x8b, 0x82, 0xf2, 0xa6, 0xbf, 0xff, 0xb9, 0xd1, 0x5e, 0x9f, 0xd1, 0xcb, 0xc3, 0xdd,
+	0xa4, 0xe1, 0xb6, 0xe4, 0xa6, 0x3b, 0x9c, 0x4d, 0xc7, 0x22, 0x7a, 0x05, 0xe0, 0xbe, 0x8e, 0x95,
+	0x86, 0x5f, 0xf9, 0xab, 0xd9, 0xf6, 0xf5, 0xe2, 0x4b, 0x58, 0x7e, 0x3a, 0xab, 0x5a, 0x34, 0x94,
+	0x94, 0x6b, 0x38, 0x94, 0xfb, 0x38, 0x56, 0xfe, 0xbe, 0x58, 0xb2, 0x4f, 0xfc, 0xe4, 0xb6, 0xf3,
+	0xa0, 0xd1, 0x7d, 0x34, 0xd8, 0x6e, 0x15, 0x3b, 0x4c, 0x6a, 0x45, 0x9c, 0xaf, 0xa9, 0x02, 0xe7,
+	0xf7, 0x2b, 0x77, 0xe4, 0xb1, 0xb8, 0xc6, 0x1e, 0xf6, 0x2c, 0xfa, 0x9f, 0xac, 0xa4, 0xe4, 0x4d,
+	0xef, 0xdd, 0x0a, 0x1c, 0xf6, 0x45, 0xf3, 0xea, 0x51, 0x6e, 0x95, 0x3f, 0xbd, 0x6c, 0x6f, 0x17,
+	0x70, 0xd6, 0xca, 0x7d, 0x48, 0x8a, 0xec, 0x23, 0x3f, 0xd9, 0x9a, 0xf5, 0x16, 0xd1, 0x0a, 0xb1,
+	0xe4, 0x45, 0xe0, 0x39, 0xbd, 0x71, 0xf4, 0xdf, 0x10, 0xca, 0xaf, 0x65, 0xf4, 0xa5, 0xaf, 0x0b,
+	0x5f, 0xc0, 0x50, 0x6d, 0x91, 0xec, 0xd6, 0xe4, 0x0a, 0xca, 0x0e, 0x42, 0x69, 0xf3, 0x1e, 0xe6,
+	0x00, 0xb2, 0xd9, 0xec, 0xe1, 0xa0, 0x34, 0x1c, 0x8a, 0x8f, 0x0e, 0xdb, 0xed, 0xb2, 0x2e, 0x9e,
+	0xee, 0x77, 0xc5, 0xb2, 0xf7, 0x02, 0xd0, 0x9e, 0x8c, 0xb2, 0xf7, 0x85, 0xf6, 0x64, 0x94, 0x3f,
+	0x1a, 0xfc, 0x3d, 0xd1, 0x70, 0xde, 0xeb, 0x49, 0xe7, 0xda, 0x34, 0xf7, 0x1e, 0xcf, 0xce, 0xa8,
+	0xe4, 0x79, 0x9f, 0xda, 0xa4, 0xf5, 0xae, 0xa8, 0x25, 0x5c, 0x2f, 0x3d, 0x0a, 0x41, 0x25, 0xf9,
+	0xa1, 0x58, 0xf1, 0xdf, 0xe9, 0xd9, 0x53, 0x55, 0xfa, 0xe2, 0xcf, 0x9e, 0xaa, 0x19, 0x8f, 0xfb,
+	0x58, 0x21, 0xef, 0x6c, 0xd8, 0x41, 0x76, 0xbf, 0xe4, 0xd2, 0xe7, 0x73, 0xf9, 0x7d, 0x34, 0x1d,
+	0xfc, 0x4a, 0x47, 0x66, 0xef, 0x16, 0xfd, 0xb7, 0x3c, 0x56, 0xdb, 0x0b, 0x0f, 0x7a, 0xd4, 0x3a,
+	0x31, 0x6f, 0xc8, 0x6c, 0x05, 0xf2, 0x13, 0xb1, 0xc0, 0xaf, 0x75, 0xe4, 0xb5, 0x4c, 0xab, 0x9d,
+	0x12, 0x51, 0x7b, 0x2b, 0x0f, 0x33, 0xb3, 0x0d, 0x62, 0xb6, 0x2c, 0x1b, 0xc8, 0x0c, 0x8c, 0x6b,
+	0x1f, 0x79, 0x0c, 0xc4, 0xaa, 0x7f, 0x81, 0x93, 0x58, 0x71, 0x94, 0x5e, 0x1d, 0x5b, 0x71, 0x94,
+	0xdf, 0x06, 0xf9, 0x46, 0xc6, 0x18, 0x97, 0x5d, 0x73, 0x2b, 0xfe, 0x07, 0xa2, 0xe9, 0x3e, 0x0d,
+	0x93, 0x6d, 0x67, 0xe5, 0xb9, 0x67, 0x64, 0xed, 0x1b, 0xa5, 0x7d, 0xfe, 0xd6, 0xca, 0xa6, 0x3b,
+	0x0c, 0xa8, 0xcd, 0xaa, 0x73, 0xd3, 0x78, 0x7a, 0x35, 0xea, 0x5a, 0xd5, 0x29, 0xbe, 0x5e, 0x68,
+	0x97, 0x39, 0x53, 0xb5, 0x4d, 0x8c, 0xd7, 0x95, 0xc7, 0x18, 0xd5, 0xe6, 0xbe, 0x68, 0xb8, 0xb7,
+	0x98, 0x2f, 0xe0, 0xbb, 0xed, 0x74, 0xb9, 0xef, 0x09, 0xc0, 0xa4, 0xfc, 0x35, 0x3e, 0x58, 0x77,
+	0x1e, 0xb5, 0x48, 0xaf, 0xca, 0x92, 0xe3, 0xd3, 0x72, 0xfb, 0x5c, 0x46, 0xea, 0x21, 0x4d, 0xf2,
+	0xe8, 0xce, 0xa1, 0x27, 0xe4, 0x2f, 0xbd, 0x20, 0x69, 0xc7, 0x7d, 0xcc, 0xfe, 0x3c, 0xdf, 0xe9,
+	0xbe, 0xee, 0x78, 0x0e, 0x13, 0x7b, 0x5f, 0xff, 0xcb, 0x82, 0x49, 0x47, 0xa4, 0x63, 0xd6, 0xf2,
+	0xe2, 0x72, 0xff, 0x0f, 0xe0, 0x76, 0x05, 0xbe, 0xfd, 0x43, 0xfd, 0x7e, 0x9d, 0xbf, 0x25, 0xa9,
+	0xbf, 0xea, 0xf7, 0xea, 0x2d, 0x5a, 0xc9, 0x1b, 0xea, 0xba, 0xb7, 0x92, 0xbc, 0x5d, 0x3f, 0x11,
+	0x22, 0xcb, 0x2d, 0x65, 0x2e, 0xd1, 0xb2, 0x16, 0xaf, 0x98, 0x7e, 0xfa, 0xbb, 0x69, 0xf2, 0x31,
+	0x6d, 0x04, 0x9a, 0x4e, 0x56, 0x97, 0xd8, 0xed, 0x2c, 0xe6, 0x88, 0xed, 0x76, 0x59, 0x17, 0xf3,
+	0xff, 0x3a, 0xf1, 0x7f, 0x5d, 0xde, 0x70, 0xf9, 0xc3, 0xf9, 0x77, 0x72, 0xca, 0xe7, 0xf2, 0x73,
+	0xb1, 0x7c, 0x1c, 0xc7, 0x4f, 0xa6, 0x63, 0x5b, 0x9c, 0xf0, 0xb3, 0x24, 0xcc, 0x6b, 0xdb, 0xb9,
+	0x45, 0xa9, 0x37, 0x89, 0xf3, 0x0d, 0x79, 0xdd, 0xe7, 0x9c, 0x65, 0xba, 0xcf, 0x65, 0x28, 0xd6,
+	0xad, 0xb7, 0xb3, 0x0b, 0x69, 0xfb, 0x7c, 0xdc, 0x84, 0xb3, 0x30, 0x86, 0x17, 0x7f, 0xd8, 0x31,
+	0x12, 0xc3, 0x13, 0xb6, 0xf6, 0x44, 0x34, 0x0f, 0xa2, 0x6e, 0xdc, 0x8b, 0x38, 0xb1, 0xd9, 0xc8,
+	0x66, 0x6e, 0x33, 0xa2, 0xf6, 0xb2, 0x07, 0xfa, 0x16, 0x00, 0x12, 0x1a, 0xc8, 0x94, 0x40, 0x22,
+	0x3a, 0x65, 0x7a, 0x6e, 0x2c, 0x80, 0x49, 0xf3, 0x3c, 0x0b, 0x90, 0xcb, 0x0b, 0x3d, 0x0b, 0x50,
+	0xc8, 0x0b, 0x3d, 0x0b, 0x60, 0xd2, 0x4c, 0x30, 0x67, 0xeb, 0x85, 0x54, 0xd2, 0xfa, 0xcc, 0x59,
+	0x09, 0x68, 0xfb, 0xd6, 0x6c, 0x02, 0x7f, 0xb4, 0x3b, 0xfe, 0x68, 0xa7, 0x62, 0xf9, 0x20, 0xd2,
+	0xc2, 0xd2, 0xb7, 0x06, 0x6d, 0xdf, 0xa4, 0xb8, 0x37, 0x0c, 0x79, 0x73, 0x43, 0x7d, 0xbe, 0x81,
+	0xa7, 0x92, 0x3d, 0x44, 0x48, 0x0d, 0xb0, 0xdc, 0xe6, 0x9a, 0xc0, 0x46, 0x1e, 0xb9, 0x7b, 0x83,
+	0x76, 0xc9, 0x2d, 0x83, 0xba, 0x45, 0xdc, 0xda, 0xb2, 0x65, 0xb9, 0xed, 0xe2, 0xbd, 0x83, 0x3e,
+	0xfc, 0x1d, 0x30, 0x03, 0xf2, 0x77, 0x89, 0xb9, 0xbd, 0x5f, 0xdc, 0x72, 0xaa, 0xcb, 0x2e, 0xf3,
+	0xd5, 0x1c, 0x5e, 0xc6, 0x19, 0x6b, 0x8e, 0x8e, 0xab, 0x1b, 0x89, 0x86, 0x73, 0x99, 0x6c, 0x0f,
+	0x54, 0xf1, 0x86, 0xda, 0x1e, 0xa8, 0x92, 0xbb, 0x67, 0x75, 0x9b, 0xc6, 0x51, 0xf2, 0x56, 0x36,
+	0x8e, 0xbe, 0x6f, 0xce, 0x46, 0xda, 0xfd, 0x32, 0x1c, 0xa6, 0xcf, 0xe5, 0x17, 0xf4, 0x90, 0xd5,
+	0xbd, 0x0a, 0xc9, 0x22, 0x9f, 0xfc, 0xad, 0x89, 0x15, 0x96, 0xd3, 0xe5, 0x47, 0x43, 0x7a, 0x28,
+	0xf2, 0x88, 0x90, 0x77, 0x61, 0x31, 0xff, 0x20, 0x8c, 0x86, 0x90, 0x08, 0x5b, 0x4b, 0x96, 0x95,
+	0xfb, 0x33, 0x4b, 0xe6, 0xd4, 0xfc, 0x61, 0x3e, 0x59, 0xec, 0xe9, 0xdd, 0x24, 0x19, 0xe5, 0x9a,
+	0x79, 0x23, 0x60, 0x05, 0x52, 0x72, 0x2b, 0x60, 0xc2, 0x50, 0x5d, 0xea, 0x74, 0xc2, 0x50, 0xaf,
+	0x56, 0xea, 0x84, 0xa1, 0x7e, 0x4d, 0x14, 0xc3, 0xd0, 0xac, 0xea, 0x61, 0xc3, 0xd0, 0x42, 0x41,
+	0xc5, 0xda, 0xd0, 0x92, 0x12, 0xc9, 0x89, 0x58, 0xca, 0x92, 0x73, 0x33, 0x50, 0x3e, 0x95, 0xb7,
+	0xce, 0xaa, 0x90, 0x33, 0xab, 0x35, 0x92, 0xb3, 0x90, 0x8b, 0x28, 0x67, 0xba, 0x4c, 0x7f, 0x24,
+	0x84, 0x5e, 0xdd, 0x21, 0xb6, 0x1c, 0x96, 0x5e, 0x6a, 0xec, 0xb2, 0xcc, 0xe5, 0xa0, 0x1c, 0xc9,
+	0x28, 0xcb, 0x12, 0x4d, 0xfa, 0x0f, 0x84, 0x2c, 0x66, 0x89, 0x56, 0xfa, 0x33, 0x73, 0xd6, 0xf6,
+	0x9b, 0x2f, 0xa0, 0xd0, 0xe3, 0x9d, 0xcd, 0xd3, 0x3f, 0x4d, 0x7e, 0xfb, 0xbf, 0x01, 0x2b, 0xbc,
+	0x04, 0x17, 0x66, 0x39, 0x00, 0x00,
 }
@@ -1384,6 +1384,7 @@ func (lc *LightningChannel) closeObserver(channelCloseNtfn *chainntnfs.SpendEven
 			SettledBalance: lc.channelState.LocalBalance.ToSatoshis(),
 			CloseType:      channeldb.ForceClose,
 			IsPending:      true,
+			ShortChanID:    lc.channelState.ShortChanID,
 		}
 		if err := lc.DeleteState(&closeSummary); err != nil {
 			walletLog.Errorf("unable to delete channel state: %v",
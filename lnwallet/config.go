@@ -47,4 +47,8 @@ type Config struct {
 	// NetParams is the set of parameters that tells the wallet which chain
 	// it will be operating on.
 	NetParams chaincfg.Params
+
+	// ChangeAddressType is the type of address used for the change output
+	// of any funding transaction we craft. It defaults to a p2wkh address.
+	ChangeAddressType AddressType
 }
@@ -14,6 +14,11 @@ var (
 	// ErrTweakOverdose signals a SignDescriptor is invalid because both of its
 	// SingleTweak and DoubleTweak are non-nil.
 	ErrTweakOverdose = errors.New("sign descriptor should only have one tweak")
+
+	// ErrInvalidTweakLength signals a SignDescriptor is invalid because its
+	// SingleTweak is neither blank nor a 32-byte scalar.
+	ErrInvalidTweakLength = errors.New("sign descriptor single tweak " +
+		"must be 32 bytes")
 )
 
 // SignDescriptor houses the necessary information required to successfully sign
@@ -139,10 +144,16 @@ func ReadSignDescriptor(r io.Reader, sd *SignDescriptor) error {
 	// special meaning and a zero-length slice for a SingleTweak is invalid,
 	// we can use the zero-length slice as the flag for a nil-valued
 	// SingleTweak.
-	if len(singleTweak) == 0 {
+	switch len(singleTweak) {
+	case 0:
 		sd.SingleTweak = nil
-	} else {
+	case 32:
 		sd.SingleTweak = singleTweak
+
+	// Any other length can only be the result of corruption, and would
+	// lead to an invalid key being derived for signing.
+	default:
+		return ErrInvalidTweakLength
 	}
 
 	doubleTweakBytes, err := wire.ReadVarBytes(r, 0, 32, "doubleTweak")
@@ -120,3 +120,38 @@ func TestSignDescriptorSerialization(t *testing.T) {
 		}
 	}
 }
+
+// TestSignDescriptorInvalidTweakLength ensures that a serialized sign
+// descriptor with a single tweak that isn't 32 bytes fails to deserialize.
+func TestSignDescriptorInvalidTweakLength(t *testing.T) {
+	priv, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	sd := &SignDescriptor{
+		PubKey:      priv.PubKey(),
+		SingleTweak: bytes.Repeat([]byte{0x02}, 31),
+		WitnessScript: []byte{
+			0x00, 0x14, 0xee, 0x91, 0x41, 0x7e, 0x85, 0x6c, 0xde,
+			0x10, 0xa2, 0x91, 0x1e, 0xdc, 0xbd, 0xbd, 0x69, 0xe2,
+			0xef, 0xb5, 0x71, 0x48,
+		},
+		Output: &wire.TxOut{
+			Value:    5000000000,
+			PkScript: []byte{0x00, 0x14},
+		},
+		HashType: txscript.SigHashAll,
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSignDescriptor(&buf, sd); err != nil {
+		t.Fatalf("unable to serialize sign descriptor: %v", err)
+	}
+
+	desSd := &SignDescriptor{}
+	err = ReadSignDescriptor(&buf, desSd)
+	if err != ErrInvalidTweakLength {
+		t.Fatalf("expected %v, got %v", ErrInvalidTweakLength, err)
+	}
+}
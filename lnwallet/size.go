@@ -139,6 +139,29 @@ const (
 	// WitnessCommitmentTxWeight 224 weight
 	WitnessCommitmentTxWeight = WitnessHeaderSize + WitnessSize
 
+	// BaseFundingTxSize 125 bytes
+	//	- Version: 4 bytes
+	//	- WitnessHeader <---- part of the witness data
+	//	- CountTxIn: 1 byte
+	//	- TxIn: 41 bytes
+	//		P2WKHInput
+	//	- CountTxOut: 1 byte
+	//	- TxOut: 74 bytes
+	//		FundingOutput: 43 bytes
+	//		ChangeOutput: 31 bytes
+	//	- LockTime: 4 bytes
+	BaseFundingTxSize = 4 + 1 + InputSize + 1 + P2WSHOutputSize +
+		P2WKHOutputSize + 4
+
+	// FundingTxWeight 610 weight
+	//
+	// This is the weight of a representative funding transaction, which
+	// spends a single P2WKH input and pays to the funding output along
+	// with a change output. It's used to estimate the fee of a funding
+	// transaction before its inputs are selected.
+	FundingTxWeight = blockchain.WitnessScaleFactor*BaseFundingTxSize +
+		WitnessHeaderSize + P2WKHWitnessSize
+
 	// HTLCWeight 172 weight
 	HTLCWeight = blockchain.WitnessScaleFactor * HTLCSize
 
@@ -1312,7 +1312,7 @@ func (l *LightningWallet) selectCoinsAndChange(feeRate uint64, amt btcutil.Amoun
 	// Record any change output(s) generated as a result of the coin
 	// selection.
 	if changeAmt != 0 {
-		changeAddr, err := l.NewAddress(WitnessPubKey, true)
+		changeAddr, err := l.NewAddress(l.Cfg.ChangeAddressType, true)
 		if err != nil {
 			return err
 		}
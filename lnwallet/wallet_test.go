@@ -0,0 +1,80 @@
+package lnwallet
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// mockChangeWallet is a WalletController which records the type of each
+// address requested from it. Only the methods required for coin selection are
+// implemented.
+type mockChangeWallet struct {
+	WalletController
+
+	addrTypes []AddressType
+}
+
+func (m *mockChangeWallet) ListUnspentWitness(confirms int32) ([]*Utxo, error) {
+	return []*Utxo{
+		{
+			Value: btcutil.SatoshiPerBitcoin,
+		},
+	}, nil
+}
+
+func (m *mockChangeWallet) LockOutpoint(o wire.OutPoint) {}
+
+func (m *mockChangeWallet) NewAddress(addrType AddressType,
+	change bool) (btcutil.Address, error) {
+
+	m.addrTypes = append(m.addrTypes, addrType)
+
+	return btcutil.NewAddressWitnessPubKeyHash(
+		make([]byte, 20), &chaincfg.TestNet3Params,
+	)
+}
+
+// TestChangeAddressType checks that the change output of a funding
+// transaction is paid to an address of the configured change address type.
+func TestChangeAddressType(t *testing.T) {
+	t.Parallel()
+
+	for _, addrType := range []AddressType{
+		WitnessPubKey, NestedWitnessPubKey,
+	} {
+		wc := &mockChangeWallet{}
+		wallet := &LightningWallet{
+			Cfg: Config{
+				ChangeAddressType: addrType,
+			},
+			WalletController: wc,
+			lockedOutPoints:  make(map[wire.OutPoint]struct{}),
+		}
+
+		// Funding half of our only coin should result in a change
+		// output.
+		contribution := &ChannelContribution{}
+		err := wallet.selectCoinsAndChange(
+			1, btcutil.SatoshiPerBitcoin/2, contribution,
+		)
+		if err != nil {
+			t.Fatalf("unable to select coins: %v", err)
+		}
+		if len(contribution.ChangeOutputs) != 1 {
+			t.Fatalf("expected a single change output, got %v",
+				len(contribution.ChangeOutputs))
+		}
+
+		if len(wc.addrTypes) != 1 {
+			t.Fatalf("expected a single address to be requested, "+
+				"got %v", len(wc.addrTypes))
+		}
+		if wc.addrTypes[0] != addrType {
+			t.Fatalf("expected change address of type %v, got %v",
+				addrType, wc.addrTypes[0])
+		}
+	}
+}
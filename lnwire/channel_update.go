@@ -3,11 +3,30 @@ package lnwire
 import (
 	"bytes"
 	"io"
+	"io/ioutil"
 
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 )
 
+const (
+	// ChanUpdateDirection is the bit within the flags of a ChannelUpdate
+	// which indicates the direction of the update. If unset, then the
+	// update originates from the first node of the channel, otherwise
+	// from the second node.
+	ChanUpdateDirection uint16 = 1 << 0
+
+	// ChanUpdateDisabled is the bit within the flags of a ChannelUpdate
+	// which indicates that the channel is disabled in the direction of
+	// the update.
+	ChanUpdateDisabled uint16 = 1 << 1
+
+	// ChanUpdateOptionMaxHtlc is the bit within the flags of a
+	// ChannelUpdate which indicates that the update carries the
+	// HtlcMaximumMsat field.
+	ChanUpdateOptionMaxHtlc uint16 = 1 << 8
+)
+
 // ChannelUpdate message is used after channel has been initially announced.
 // Each side independently announces its fees and minimum expiry for HTLCs and
 // other parameters. Also this message is used to redeclare initially setted
@@ -54,6 +73,18 @@ type ChannelUpdate struct {
 	// FeeRate is the fee rate that will be charged per millionth of a
 	// satoshi.
 	FeeRate uint32
+
+	// HtlcMaximumMsat is the maximum HTLC value which will be accepted.
+	// It's only present on the wire if the ChanUpdateOptionMaxHtlc bit
+	// of the flags is set.
+	HtlcMaximumMsat MilliSatoshi
+
+	// ExtraOpaqueData is the set of data that was appended to this
+	// message, beyond the fields known to us. It's expected to be a TLV
+	// stream, such as one carrying the InboundFee of the channel. It's
+	// covered by the signature, so it must be retained as is in order to
+	// relay the update.
+	ExtraOpaqueData []byte
 }
 
 // A compile time check to ensure ChannelUpdate implements the lnwire.Message
@@ -65,7 +96,7 @@ var _ Message = (*ChannelUpdate)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		&a.Signature,
 		a.ChainHash[:],
 		&a.ShortChannelID,
@@ -76,6 +107,28 @@ func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
 		&a.BaseFee,
 		&a.FeeRate,
 	)
+	if err != nil {
+		return err
+	}
+
+	// The maximum HTLC is only present if signalled within the flags.
+	if a.Flags&ChanUpdateOptionMaxHtlc != 0 {
+		if err := readElement(r, &a.HtlcMaximumMsat); err != nil {
+			return err
+		}
+	}
+
+	// Any remaining data is extra data we don't know of, which we'll
+	// retain so the update can be relayed as is.
+	a.ExtraOpaqueData, err = ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(a.ExtraOpaqueData) == 0 {
+		a.ExtraOpaqueData = nil
+	}
+
+	return nil
 }
 
 // Encode serializes the target ChannelUpdate into the passed io.Writer
@@ -83,7 +136,7 @@ func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (a *ChannelUpdate) Encode(w io.Writer, pver uint32) error {
-	return writeElements(w,
+	err := writeElements(w,
 		a.Signature,
 		a.ChainHash[:],
 		a.ShortChannelID,
@@ -94,6 +147,18 @@ func (a *ChannelUpdate) Encode(w io.Writer, pver uint32) error {
 		a.BaseFee,
 		a.FeeRate,
 	)
+	if err != nil {
+		return err
+	}
+
+	if a.Flags&ChanUpdateOptionMaxHtlc != 0 {
+		if err := writeElement(w, a.HtlcMaximumMsat); err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write(a.ExtraOpaqueData)
+	return err
 }
 
 // MsgType returns the integer uniquely identifying this message type on the
@@ -108,37 +173,11 @@ func (a *ChannelUpdate) MsgType() MessageType {
 // observing the specified protocol version.
 //
 // This is part of the lnwire.Message interface.
+//
+// NOTE: As the update may carry extra data beyond the fields known to us, its
+// length is only bounded by the maximum message payload.
 func (a *ChannelUpdate) MaxPayloadLength(pver uint32) uint32 {
-	var length uint32
-
-	// Signature - 64 bytes
-	length += 64
-
-	// ChainHash - 64 bytes
-	length += 32
-
-	// ShortChannelID - 8 bytes
-	length += 8
-
-	// Timestamp - 4 bytes
-	length += 4
-
-	// Flags - 2 bytes
-	length += 2
-
-	// Expiry - 2 bytes
-	length += 2
-
-	// HtlcMinimumMstat - 8 bytes
-	length += 8
-
-	// FeeBaseMstat - 4 bytes
-	length += 4
-
-	// FeeProportionalMillionths - 4 bytes
-	length += 4
-
-	return length
+	return MaxMessagePayload
 }
 
 // DataToSign is used to retrieve part of the announcement message which should
@@ -161,5 +200,18 @@ func (a *ChannelUpdate) DataToSign() ([]byte, error) {
 		return nil, err
 	}
 
+	// The maximum HTLC is covered by the signature whenever it's present.
+	if a.Flags&ChanUpdateOptionMaxHtlc != 0 {
+		err := writeElement(&w, a.HtlcMaximumMsat)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// As is any extra data appended to the update.
+	if _, err := w.Write(a.ExtraOpaqueData); err != nil {
+		return nil, err
+	}
+
 	return w.Bytes(), nil
 }
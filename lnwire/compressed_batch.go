@@ -0,0 +1,175 @@
+package lnwire
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// MaxCompressedBatchSize is the maximum number of bytes the messages within a
+// CompressedBatch may occupy once decompressed. It leaves enough room for the
+// overhead of compressing incompressible data, such that the compressed form
+// of a full batch always fits within a single message.
+const MaxCompressedBatchSize = 60000
+
+// CompressedBatch is a message which carries a batch of other messages in
+// compressed form. It's only sent to peers which have signalled support for
+// it within their local features. Each message within the batch is prefixed
+// by its 2-byte length, and the whole batch is compressed using zlib.
+type CompressedBatch struct {
+	// Data is the compressed serialization of the messages within the
+	// batch.
+	Data []byte
+}
+
+// A compile time check to ensure CompressedBatch implements the
+// lnwire.Message interface.
+var _ Message = (*CompressedBatch)(nil)
+
+// NewCompressedBatch compresses the passed messages into a single
+// CompressedBatch. An error is returned if the serialized messages exceed
+// MaxCompressedBatchSize.
+func NewCompressedBatch(msgs ...Message) (*CompressedBatch, error) {
+	var raw bytes.Buffer
+	for _, msg := range msgs {
+		if err := writeBatchedMessage(&raw, msg); err != nil {
+			return nil, err
+		}
+	}
+	if raw.Len() > MaxCompressedBatchSize {
+		return nil, fmt.Errorf("batch of %d bytes exceeds maximum "+
+			"batch size of %d bytes", raw.Len(),
+			MaxCompressedBatchSize)
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(raw.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &CompressedBatch{
+		Data: compressed.Bytes(),
+	}, nil
+}
+
+// BatchedMessageSize returns the number of bytes the passed message occupies
+// within the decompressed data of a CompressedBatch.
+func BatchedMessageSize(msg Message) (int, error) {
+	var b bytes.Buffer
+	if err := writeBatchedMessage(&b, msg); err != nil {
+		return 0, err
+	}
+
+	return b.Len(), nil
+}
+
+// writeBatchedMessage writes the passed message to w, prefixed by its length.
+func writeBatchedMessage(w io.Writer, msg Message) error {
+	var b bytes.Buffer
+	if _, err := WriteMessage(&b, msg, 0); err != nil {
+		return err
+	}
+
+	if err := writeElement(w, uint16(b.Len())); err != nil {
+		return err
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Messages decompresses and returns the messages within the batch. Nested
+// batches aren't allowed, and the decompressed data may not exceed
+// MaxCompressedBatchSize.
+func (c *CompressedBatch) Messages() ([]Message, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(c.Data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	// We'll read a single byte beyond the maximum size, such that an
+	// oversized batch can be detected without decompressing all of it.
+	raw, err := ioutil.ReadAll(
+		io.LimitReader(zr, MaxCompressedBatchSize+1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > MaxCompressedBatchSize {
+		return nil, fmt.Errorf("decompressed batch exceeds maximum "+
+			"batch size of %d bytes", MaxCompressedBatchSize)
+	}
+
+	var msgs []Message
+	r := bytes.NewReader(raw)
+	for r.Len() > 0 {
+		var l [2]byte
+		if _, err := io.ReadFull(r, l[:]); err != nil {
+			return nil, err
+		}
+		msgBytes := make([]byte, binary.BigEndian.Uint16(l[:]))
+		if _, err := io.ReadFull(r, msgBytes); err != nil {
+			return nil, err
+		}
+
+		msg, err := ReadMessage(bytes.NewReader(msgBytes), 0)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := msg.(*CompressedBatch); ok {
+			return nil, fmt.Errorf("nested compressed batch")
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// Decode deserializes a serialized CompressedBatch stored in the passed
+// io.Reader observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *CompressedBatch) Decode(r io.Reader, pver uint32) error {
+	var l [2]byte
+	if _, err := io.ReadFull(r, l[:]); err != nil {
+		return err
+	}
+
+	c.Data = make([]byte, binary.BigEndian.Uint16(l[:]))
+	return readElement(r, c.Data)
+}
+
+// Encode serializes the target CompressedBatch into the passed io.Writer
+// observing the protocol version specified.
+//
+// This is part of the lnwire.Message interface.
+func (c *CompressedBatch) Encode(w io.Writer, pver uint32) error {
+	return writeElements(w,
+		uint16(len(c.Data)),
+		c.Data,
+	)
+}
+
+// MsgType returns the integer uniquely identifying this message type on the
+// wire.
+//
+// This is part of the lnwire.Message interface.
+func (c *CompressedBatch) MsgType() MessageType {
+	return MsgCompressedBatch
+}
+
+// MaxPayloadLength returns the maximum allowed payload size for a
+// CompressedBatch complete message observing the specified protocol version.
+//
+// This is part of the lnwire.Message interface.
+func (c *CompressedBatch) MaxPayloadLength(uint32) uint32 {
+	return MaxMessagePayload
+}
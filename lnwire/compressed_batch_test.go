@@ -0,0 +1,80 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// TestCompressedBatchRoundTrip ensures that the messages within a
+// CompressedBatch survive a round trip over the wire.
+func TestCompressedBatchRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var msgs []Message
+	for i := 0; i < 50; i++ {
+		msgs = append(msgs, &ChannelUpdate{
+			Signature:       testSig,
+			ShortChannelID:  NewShortChanIDFromInt(uint64(i)),
+			Timestamp:       uint32(i),
+			TimeLockDelta:   144,
+			HtlcMinimumMsat: 1000,
+			BaseFee:         1000,
+			FeeRate:         1,
+		})
+	}
+
+	batch, err := NewCompressedBatch(msgs...)
+	if err != nil {
+		t.Fatalf("unable to create batch: %v", err)
+	}
+
+	var uncompressedSize int
+	for _, msg := range msgs {
+		size, err := BatchedMessageSize(msg)
+		if err != nil {
+			t.Fatalf("unable to size message: %v", err)
+		}
+		uncompressedSize += size
+	}
+	if len(batch.Data) >= uncompressedSize {
+		t.Fatalf("expected batch to be compressed below %v bytes, "+
+			"got %v bytes", uncompressedSize, len(batch.Data))
+	}
+
+	var b bytes.Buffer
+	if _, err := WriteMessage(&b, batch, 0); err != nil {
+		t.Fatalf("unable to encode batch: %v", err)
+	}
+	msg, err := ReadMessage(&b, 0)
+	if err != nil {
+		t.Fatalf("unable to decode batch: %v", err)
+	}
+
+	decoded, err := msg.(*CompressedBatch).Messages()
+	if err != nil {
+		t.Fatalf("unable to decompress batch: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, msgs) {
+		t.Fatalf("decompressed messages don't match original")
+	}
+}
+
+// TestCompressedBatchNested ensures that a batch nested within another batch
+// is refused.
+func TestCompressedBatchNested(t *testing.T) {
+	t.Parallel()
+
+	inner, err := NewCompressedBatch(NewPing(10))
+	if err != nil {
+		t.Fatalf("unable to create batch: %v", err)
+	}
+	outer, err := NewCompressedBatch(inner)
+	if err != nil {
+		t.Fatalf("unable to create batch: %v", err)
+	}
+
+	if _, err := outer.Messages(); err == nil {
+		t.Fatal("expected nested batch to be refused")
+	}
+}
@@ -32,6 +32,11 @@ const (
 	// FundingOpen request for a channel that is above their current
 	// soft-limit.
 	ErrChanTooLarge ErrorCode = 3
+
+	// ErrChanTooSmall is returned by a remote peer that receives a
+	// FundingOpen request for a channel that is below their configured
+	// minimum channel size.
+	ErrChanTooSmall ErrorCode = 4
 )
 
 // String returns a human readable version of the target ErrorCode.
@@ -43,6 +48,8 @@ func (e ErrorCode) String() string {
 		return "Synchronizing blockchain"
 	case ErrChanTooLarge:
 		return "channel too large"
+	case ErrChanTooSmall:
+		return "channel too small"
 	default:
 		return "unknown error"
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"io"
 	"math"
+	"sort"
 
 	"github.com/go-errors/errors"
 )
@@ -261,6 +262,21 @@ func (f *FeatureVector) Compare(f2 *FeatureVector) (*SharedFeatures, error) {
 	return shared, nil
 }
 
+// Missing returns the indexes of the features which are set within the
+// feature vector, but not within the passed feature vector, in ascending
+// order.
+func (f *FeatureVector) Missing(f2 *FeatureVector) []int {
+	var missing []int
+	for index := range f.flags {
+		if _, exist := f2.flags[index]; !exist {
+			missing = append(missing, index)
+		}
+	}
+	sort.Ints(missing)
+
+	return missing
+}
+
 // Copy generate new distinct instance of the feature vector.
 func (f *FeatureVector) Copy() *FeatureVector {
 	features := make([]Feature, len(f.featuresMap))
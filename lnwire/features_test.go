@@ -133,6 +133,37 @@ func TestDecodeEncodeFeaturesVector(t *testing.T) {
 	}
 }
 
+// TestMissingFeatures checks that the features set within one feature vector,
+// but not within another, are reported as missing.
+func TestMissingFeatures(t *testing.T) {
+	t.Parallel()
+
+	const (
+		first  = "first"
+		second = "second"
+		third  = "third"
+	)
+
+	f := NewFeatureVector([]Feature{
+		{first, OptionalFlag},
+		{second, RequiredFlag},
+		{third, OptionalFlag},
+	})
+	f2 := NewFeatureVector([]Feature{
+		{first, RequiredFlag},
+	})
+
+	missing := f.Missing(f2)
+	if !reflect.DeepEqual(missing, []int{1, 2}) {
+		t.Fatalf("expected features [1 2] to be missing, got %v",
+			missing)
+	}
+
+	if missing := f2.Missing(f); len(missing) != 0 {
+		t.Fatalf("expected no missing features, got %v", missing)
+	}
+}
+
 func TestFeatureFlagString(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,106 @@
+package lnwire
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// InboundFeeRecordType is the type of the TLV record within the extra data of
+// a ChannelUpdate which carries the inbound fee of the channel.
+const InboundFeeRecordType uint64 = 55555
+
+// inboundFeeLength is the length of the value of the inbound fee record.
+const inboundFeeLength = 8
+
+// InboundFee is the fee a node charges for HTLCs which arrive over a channel,
+// as opposed to the regular fee which is charged for HTLCs that leave over
+// it. Unlike regular fees, inbound fees may be negative, granting a discount.
+//
+// NOTE: Inbound fees aren't standardized yet, so they're only carried along
+// with channel updates, and not taken into account for path finding.
+type InboundFee struct {
+	// BaseFee is the base fee in milli-satoshis charged for each incoming
+	// HTLC.
+	BaseFee int32
+
+	// FeeRate is the fee rate charged for each incoming HTLC, in
+	// millionths of the amount of the HTLC.
+	FeeRate int32
+}
+
+// ParseInboundFee returns the inbound fee carried within the passed extra
+// data of a ChannelUpdate, or nil if it doesn't carry one. An error is
+// returned if the extra data isn't a valid TLV stream, or if the inbound fee
+// record is malformed.
+func ParseInboundFee(extraData []byte) (*InboundFee, error) {
+	records, err := parseTLVStream(extraData)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if record.Type != InboundFeeRecordType {
+			continue
+		}
+
+		if len(record.Value) != inboundFeeLength {
+			return nil, fmt.Errorf("inbound fee record has length "+
+				"%d, expected %d", len(record.Value),
+				inboundFeeLength)
+		}
+
+		baseFee := binary.BigEndian.Uint32(record.Value[:4])
+		feeRate := binary.BigEndian.Uint32(record.Value[4:])
+
+		return &InboundFee{
+			BaseFee: int32(baseFee),
+			FeeRate: int32(feeRate),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// InboundFee returns the inbound fee carried within the extra data of the
+// channel update, or nil if it doesn't carry one.
+func (a *ChannelUpdate) InboundFee() (*InboundFee, error) {
+	return ParseInboundFee(a.ExtraOpaqueData)
+}
+
+// SetInboundFee sets the inbound fee carried within the extra data of the
+// channel update, replacing any prior inbound fee, while preserving all other
+// records. As the extra data is covered by the signature of the update, the
+// update must be signed afterwards.
+func (a *ChannelUpdate) SetInboundFee(fee InboundFee) error {
+	records, err := parseTLVStream(a.ExtraOpaqueData)
+	if err != nil {
+		return err
+	}
+
+	value := make([]byte, inboundFeeLength)
+	binary.BigEndian.PutUint32(value[:4], uint32(fee.BaseFee))
+	binary.BigEndian.PutUint32(value[4:], uint32(fee.FeeRate))
+
+	feeRecord := tlvRecord{
+		Type:  InboundFeeRecordType,
+		Value: value,
+	}
+
+	replaced := false
+	for i, record := range records {
+		if record.Type == InboundFeeRecordType {
+			records[i] = feeRecord
+			replaced = true
+		}
+	}
+	if !replaced {
+		records = append(records, feeRecord)
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].Type < records[j].Type
+		})
+	}
+
+	a.ExtraOpaqueData, err = encodeTLVStream(records)
+	return err
+}
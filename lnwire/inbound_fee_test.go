@@ -0,0 +1,200 @@
+package lnwire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// TestInboundFee tests that the inbound fee of a channel update is carried
+// within its extra data, preserving any other records, and that it survives
+// an encoding round trip.
+func TestInboundFee(t *testing.T) {
+	t.Parallel()
+
+	// We'll start with an update carrying two unknown records, one on
+	// either side of the inbound fee record.
+	update := &ChannelUpdate{
+		Signature:      testSig,
+		ShortChannelID: NewShortChanIDFromInt(1),
+		Timestamp:      1,
+		ExtraOpaqueData: []byte{
+			0x01, 0x02, 0xaa, 0xbb,
+			0xfe, 0x00, 0x01, 0x00, 0x00, 0x00,
+		},
+	}
+
+	fee, err := update.InboundFee()
+	if err != nil {
+		t.Fatalf("unable to parse inbound fee: %v", err)
+	}
+	if fee != nil {
+		t.Fatalf("expected no inbound fee, got %v", fee)
+	}
+
+	expectedFee := InboundFee{BaseFee: -1000, FeeRate: 250}
+	if err := update.SetInboundFee(expectedFee); err != nil {
+		t.Fatalf("unable to set inbound fee: %v", err)
+	}
+
+	// The inbound fee record should've been inserted between the two
+	// unknown records.
+	expectedData := []byte{
+		0x01, 0x02, 0xaa, 0xbb,
+		0xfd, 0xd9, 0x03, 0x08,
+		0xff, 0xff, 0xfc, 0x18, 0x00, 0x00, 0x00, 0xfa,
+		0xfe, 0x00, 0x01, 0x00, 0x00, 0x00,
+	}
+	if !bytes.Equal(update.ExtraOpaqueData, expectedData) {
+		t.Fatalf("expected extra data %x, got %x", expectedData,
+			update.ExtraOpaqueData)
+	}
+
+	// The inbound fee and the extra data should survive an encoding round
+	// trip, and be covered by the signed data.
+	var b bytes.Buffer
+	if err := update.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode update: %v", err)
+	}
+	update2 := &ChannelUpdate{}
+	if err := update2.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode update: %v", err)
+	}
+	if !reflect.DeepEqual(update, update2) {
+		t.Fatalf("updates don't match: %v vs %v", update, update2)
+	}
+
+	fee, err = update2.InboundFee()
+	if err != nil {
+		t.Fatalf("unable to parse inbound fee: %v", err)
+	}
+	if fee == nil || *fee != expectedFee {
+		t.Fatalf("expected inbound fee %v, got %v", expectedFee, fee)
+	}
+
+	data, err := update2.DataToSign()
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	if !bytes.HasSuffix(data, expectedData) {
+		t.Fatalf("extra data isn't covered by the signed data")
+	}
+
+	// Replacing the inbound fee shouldn't affect the other records.
+	expectedFee = InboundFee{BaseFee: 1, FeeRate: -1}
+	if err := update2.SetInboundFee(expectedFee); err != nil {
+		t.Fatalf("unable to set inbound fee: %v", err)
+	}
+	records, err := parseTLVStream(update2.ExtraOpaqueData)
+	if err != nil {
+		t.Fatalf("unable to parse extra data: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	fee, err = update2.InboundFee()
+	if err != nil {
+		t.Fatalf("unable to parse inbound fee: %v", err)
+	}
+	if fee == nil || *fee != expectedFee {
+		t.Fatalf("expected inbound fee %v, got %v", expectedFee, fee)
+	}
+}
+
+// TestInboundFeeMalformed tests that malformed extra data is rejected when
+// parsing the inbound fee.
+func TestInboundFeeMalformed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "non minimal type",
+			data: []byte{0xfd, 0x00, 0x01, 0x00},
+		},
+		{
+			name: "unordered records",
+			data: []byte{0x02, 0x00, 0x01, 0x00},
+		},
+		{
+			name: "length exceeds stream",
+			data: []byte{0x01, 0x05, 0x00},
+		},
+		{
+			name: "truncated type",
+			data: []byte{0xfd, 0xd9},
+		},
+		{
+			name: "wrong inbound fee length",
+			data: []byte{0xfd, 0xd9, 0x03, 0x04, 0, 0, 0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseInboundFee(test.data); err == nil {
+			t.Fatalf("%s: expected error", test.name)
+		}
+	}
+}
+
+// TestChannelUpdateNoExtraData tests that a channel update which carries no
+// data beyond its known fields, as sent by nodes unaware of inbound fees, is
+// decoded without extra data or an inbound fee, and re-encoded unchanged.
+func TestChannelUpdateNoExtraData(t *testing.T) {
+	t.Parallel()
+
+	for _, flags := range []uint16{0, ChanUpdateOptionMaxHtlc} {
+		update := &ChannelUpdate{
+			Signature:       testSig,
+			ShortChannelID:  NewShortChanIDFromInt(1),
+			Timestamp:       1,
+			Flags:           flags,
+			TimeLockDelta:   144,
+			HtlcMinimumMsat: 1000,
+			BaseFee:         1,
+			FeeRate:         1,
+		}
+		if flags&ChanUpdateOptionMaxHtlc != 0 {
+			update.HtlcMaximumMsat = 100000
+		}
+
+		var b bytes.Buffer
+		if err := update.Encode(&b, 0); err != nil {
+			t.Fatalf("unable to encode update: %v", err)
+		}
+		encoded := b.Bytes()
+
+		update2 := &ChannelUpdate{}
+		err := update2.Decode(bytes.NewReader(encoded), 0)
+		if err != nil {
+			t.Fatalf("unable to decode update: %v", err)
+		}
+		if update2.ExtraOpaqueData != nil {
+			t.Fatalf("expected no extra data, got %x",
+				update2.ExtraOpaqueData)
+		}
+		if !reflect.DeepEqual(update, update2) {
+			t.Fatalf("updates don't match: %v vs %v", update,
+				update2)
+		}
+
+		fee, err := update2.InboundFee()
+		if err != nil {
+			t.Fatalf("unable to parse inbound fee: %v", err)
+		}
+		if fee != nil {
+			t.Fatalf("expected no inbound fee, got %v", fee)
+		}
+
+		var b2 bytes.Buffer
+		if err := update2.Encode(&b2, 0); err != nil {
+			t.Fatalf("unable to encode update: %v", err)
+		}
+		if !bytes.Equal(b2.Bytes(), encoded) {
+			t.Fatalf("re-encoded update %x doesn't match %x",
+				b2.Bytes(), encoded)
+		}
+	}
+}
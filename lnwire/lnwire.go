@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"strings"
 
 	"net"
 
@@ -318,6 +319,35 @@ func writeElement(w io.Writer, element interface{}) error {
 			return err
 		}
 
+	case *OnionAddr:
+		if e == nil {
+			return fmt.Errorf("cannot write nil OnionAddr")
+		}
+
+		service, err := e.decodeService()
+		if err != nil {
+			return err
+		}
+
+		var descriptor [1]byte
+		if e.IsV3() {
+			descriptor[0] = uint8(v3OnionAddr)
+		} else {
+			descriptor[0] = uint8(v2OnionAddr)
+		}
+		if _, err := w.Write(descriptor[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(service); err != nil {
+			return err
+		}
+
+		var port [2]byte
+		binary.BigEndian.PutUint16(port[:], uint16(e.Port))
+		if _, err := w.Write(port[:]); err != nil {
+			return err
+		}
+
 	case []net.Addr:
 		// First, we'll encode all the addresses into an intermediate
 		// buffer. We need to do this in order to compute the total
@@ -648,11 +678,25 @@ func readElement(r io.Reader, element interface{}) error {
 
 				addrBytesRead += aType.AddrLen()
 
-			case v2OnionAddr:
-				addrBytesRead += aType.AddrLen()
-				continue
+			case v2OnionAddr, v3OnionAddr:
+				service := make([]byte, aType.AddrLen()-2)
+				if _, err = io.ReadFull(addrBuf, service); err != nil {
+					return err
+				}
+
+				var port [2]byte
+				if _, err = io.ReadFull(addrBuf, port[:]); err != nil {
+					return err
+				}
+
+				host := strings.ToLower(
+					onionEncoding.EncodeToString(service),
+				)
+				addresses = append(addresses, &OnionAddr{
+					OnionService: host + OnionSuffix,
+					Port:         int(binary.BigEndian.Uint16(port[:])),
+				})
 
-			case v3OnionAddr:
 				addrBytesRead += aType.AddrLen()
 				continue
 
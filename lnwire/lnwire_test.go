@@ -429,6 +429,20 @@ func TestLightningWireProtocol(t *testing.T) {
 				BaseFee:         uint32(r.Int31()),
 				FeeRate:         uint32(r.Int31()),
 			}
+			if req.Flags&ChanUpdateOptionMaxHtlc != 0 {
+				req.HtlcMaximumMsat = MilliSatoshi(r.Int63())
+			}
+			if r.Intn(2) == 0 {
+				err := req.SetInboundFee(InboundFee{
+					BaseFee: r.Int31() - r.Int31(),
+					FeeRate: r.Int31() - r.Int31(),
+				})
+				if err != nil {
+					t.Fatalf("unable to set inbound "+
+						"fee: %v", err)
+					return
+				}
+			}
 			if _, err := r.Read(req.ChainHash[:]); err != nil {
 				t.Fatalf("unable to generate chain hash: %v", err)
 				return
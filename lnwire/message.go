@@ -45,6 +45,7 @@ const (
 	MsgNodeAnnouncement                    = 257
 	MsgChannelUpdate                       = 258
 	MsgAnnounceSignatures                  = 259
+	MsgCompressedBatch                     = 32769
 )
 
 // String return the string representation of message type.
@@ -94,6 +95,8 @@ func (t MessageType) String() string {
 		return "Pong"
 	case MsgUpdateFee:
 		return "UpdateFee"
+	case MsgCompressedBatch:
+		return "CompressedBatch"
 	default:
 		return "<unknown>"
 	}
@@ -183,6 +186,8 @@ func makeEmptyMessage(msgType MessageType) (Message, error) {
 		msg = &AnnounceSignatures{}
 	case MsgPong:
 		msg = &Pong{}
+	case MsgCompressedBatch:
+		msg = &CompressedBatch{}
 	default:
 		return nil, fmt.Errorf("unknown message type [%d]", msgType)
 	}
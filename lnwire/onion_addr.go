@@ -0,0 +1,99 @@
+package lnwire
+
+import (
+	"encoding/base32"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// OnionSuffix is the suffix of the host of all onion services.
+	OnionSuffix = ".onion"
+
+	// V2OnionServiceLen is the length of the base32 encoded host of a
+	// version 2 onion service, excluding the suffix.
+	V2OnionServiceLen = 16
+
+	// V3OnionServiceLen is the length of the base32 encoded host of a
+	// version 3 onion service, excluding the suffix.
+	V3OnionServiceLen = 56
+)
+
+// onionEncoding is the base32 encoding used for the hosts of onion services.
+// The hosts are conventionally lower case, so they're converted to upper
+// case before decoding.
+var onionEncoding = base32.StdEncoding
+
+// OnionAddr represents the address of a Tor onion service.
+type OnionAddr struct {
+	// OnionService is the host of the onion service, including the
+	// ".onion" suffix.
+	OnionService string
+
+	// Port is the port that the onion service is reachable at.
+	Port int
+}
+
+// A compile time assertion to ensure that OnionAddr meets the net.Addr
+// interface.
+var _ net.Addr = (*OnionAddr)(nil)
+
+// NewOnionAddr creates a new OnionAddr from the passed host and port,
+// ensuring that the host is a well formed version 2 or 3 onion service.
+func NewOnionAddr(host string, port int) (*OnionAddr, error) {
+	addr := &OnionAddr{
+		OnionService: strings.ToLower(host),
+		Port:         port,
+	}
+
+	if _, err := addr.decodeService(); err != nil {
+		return nil, err
+	}
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid onion service port: %v", port)
+	}
+
+	return addr, nil
+}
+
+// IsV3 returns true if the target address is a version 3 onion service.
+func (o *OnionAddr) IsV3() bool {
+	service := strings.TrimSuffix(o.OnionService, OnionSuffix)
+	return len(service) == V3OnionServiceLen
+}
+
+// decodeService returns the raw bytes encoded within the host of the onion
+// service.
+func (o *OnionAddr) decodeService() ([]byte, error) {
+	if !strings.HasSuffix(o.OnionService, OnionSuffix) {
+		return nil, fmt.Errorf("onion service %v doesn't have the %v "+
+			"suffix", o.OnionService, OnionSuffix)
+	}
+
+	service := strings.TrimSuffix(o.OnionService, OnionSuffix)
+	switch len(service) {
+	case V2OnionServiceLen, V3OnionServiceLen:
+	default:
+		return nil, fmt.Errorf("invalid onion service length: %v",
+			len(service))
+	}
+
+	return onionEncoding.DecodeString(strings.ToUpper(service))
+}
+
+// String returns a human readable string describing the target OnionAddr in
+// the form host.onion:port.
+//
+// This part of the net.Addr interface.
+func (o *OnionAddr) String() string {
+	return net.JoinHostPort(o.OnionService, strconv.Itoa(o.Port))
+}
+
+// Network returns the name of the network this address is binded to.
+//
+// This part of the net.Addr interface.
+func (o *OnionAddr) Network() string {
+	return "tor"
+}
@@ -524,7 +524,9 @@ func (f *FailTemporaryChannelFailure) Decode(r io.Reader, pver uint32) error {
 
 	if length != 0 {
 		f.Update = &ChannelUpdate{}
-		return f.Update.Decode(r, pver)
+		return f.Update.Decode(
+			io.LimitReader(r, int64(length)), pver,
+		)
 	}
 	return nil
 }
@@ -597,13 +599,8 @@ func (f *FailAmountBelowMinimum) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
-	var length uint16
-	if err := readElement(r, &length); err != nil {
-		return err
-	}
-
 	f.Update = ChannelUpdate{}
-	return f.Update.Decode(r, pver)
+	return readChannelUpdate(r, &f.Update, pver)
 }
 
 // Encode writes the failure in bytes stream.
@@ -614,12 +611,7 @@ func (f *FailAmountBelowMinimum) Encode(w io.Writer, pver uint32) error {
 		return err
 	}
 
-	err := writeElement(w, uint16(f.Update.MaxPayloadLength(pver)))
-	if err != nil {
-		return err
-	}
-
-	return f.Update.Encode(w, pver)
+	return writeChannelUpdate(w, &f.Update, pver)
 }
 
 // FailFeeInsufficient is returned if the HTLC does not pay sufficient fee, we
@@ -668,13 +660,8 @@ func (f *FailFeeInsufficient) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
-	var length uint16
-	if err := readElement(r, &length); err != nil {
-		return err
-	}
-
 	f.Update = ChannelUpdate{}
-	return f.Update.Decode(r, pver)
+	return readChannelUpdate(r, &f.Update, pver)
 }
 
 // Encode writes the failure in bytes stream.
@@ -685,12 +672,7 @@ func (f *FailFeeInsufficient) Encode(w io.Writer, pver uint32) error {
 		return err
 	}
 
-	err := writeElement(w, uint16(f.Update.MaxPayloadLength(pver)))
-	if err != nil {
-		return err
-	}
-
-	return f.Update.Encode(w, pver)
+	return writeChannelUpdate(w, &f.Update, pver)
 }
 
 // FailIncorrectCltvExpiry is returned if outgoing cltv value does not match
@@ -739,13 +721,8 @@ func (f *FailIncorrectCltvExpiry) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
-	var length uint16
-	if err := readElement(r, &length); err != nil {
-		return err
-	}
-
 	f.Update = ChannelUpdate{}
-	return f.Update.Decode(r, pver)
+	return readChannelUpdate(r, &f.Update, pver)
 }
 
 // Encode writes the failure in bytes stream.
@@ -756,12 +733,7 @@ func (f *FailIncorrectCltvExpiry) Encode(w io.Writer, pver uint32) error {
 		return err
 	}
 
-	err := writeElement(w, uint16(f.Update.MaxPayloadLength(pver)))
-	if err != nil {
-		return err
-	}
-
-	return f.Update.Encode(w, pver)
+	return writeChannelUpdate(w, &f.Update, pver)
 }
 
 // FailExpiryTooSoon is returned if the ctlv-expiry is too near, we tell them
@@ -799,25 +771,15 @@ func (f *FailExpiryTooSoon) Error() string {
 //
 // NOTE: Part of the Serializable interface.
 func (f *FailExpiryTooSoon) Decode(r io.Reader, pver uint32) error {
-	var length uint16
-	if err := readElement(r, &length); err != nil {
-		return err
-	}
-
 	f.Update = ChannelUpdate{}
-	return f.Update.Decode(r, pver)
+	return readChannelUpdate(r, &f.Update, pver)
 }
 
 // Encode writes the failure in bytes stream.
 //
 // NOTE: Part of the Serializable interface.
 func (f *FailExpiryTooSoon) Encode(w io.Writer, pver uint32) error {
-	err := writeElement(w, uint16(f.Update.MaxPayloadLength(pver)))
-	if err != nil {
-		return err
-	}
-
-	return f.Update.Encode(w, pver)
+	return writeChannelUpdate(w, &f.Update, pver)
 }
 
 // FailChannelDisabled is returned if the channel is disabled, we tell them the
@@ -866,13 +828,8 @@ func (f *FailChannelDisabled) Decode(r io.Reader, pver uint32) error {
 		return err
 	}
 
-	var length uint16
-	if err := readElement(r, &length); err != nil {
-		return err
-	}
-
 	f.Update = ChannelUpdate{}
-	return f.Update.Decode(r, pver)
+	return readChannelUpdate(r, &f.Update, pver)
 }
 
 // Encode writes the failure in bytes stream.
@@ -883,12 +840,7 @@ func (f *FailChannelDisabled) Encode(w io.Writer, pver uint32) error {
 		return err
 	}
 
-	err := writeElement(w, uint16(f.Update.MaxPayloadLength(pver)))
-	if err != nil {
-		return err
-	}
-
-	return f.Update.Encode(w, pver)
+	return writeChannelUpdate(w, &f.Update, pver)
 }
 
 // FailFinalIncorrectCltvExpiry is returned if the outgoing_cltv_value does not
@@ -1147,3 +1099,31 @@ func makeEmptyOnionError(code FailCode) (FailureMessage, error) {
 		return nil, errors.Errorf("unknown error code: %v", code)
 	}
 }
+
+// writeChannelUpdate writes the passed channel update to w, prefixed by its
+// length, as it's embedded within failure messages.
+func writeChannelUpdate(w io.Writer, update *ChannelUpdate, pver uint32) error {
+	var b bytes.Buffer
+	if err := update.Encode(&b, pver); err != nil {
+		return err
+	}
+
+	if err := writeElement(w, uint16(b.Len())); err != nil {
+		return err
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// readChannelUpdate reads a length prefixed channel update, as embedded
+// within failure messages, from r. As the update may carry extra data, its
+// decoding is bounded by the length prefix.
+func readChannelUpdate(r io.Reader, update *ChannelUpdate, pver uint32) error {
+	var length uint16
+	if err := readElement(r, &length); err != nil {
+		return err
+	}
+
+	return update.Decode(io.LimitReader(r, int64(length)), pver)
+}
@@ -16,6 +16,12 @@ var (
 		ShortChannelID: NewShortChanIDFromInt(1),
 		Timestamp:      1,
 		Flags:          1,
+
+		// The update carries an inbound fee record, ensuring that
+		// the failures frame it along with the update.
+		ExtraOpaqueData: []byte{
+			0xfd, 0xd9, 0x03, 0x08, 0, 0, 0, 1, 0, 0, 0, 2,
+		},
 	}
 )
 
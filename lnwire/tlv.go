@@ -0,0 +1,140 @@
+package lnwire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// tlvRecord is a single type-length-value record of a TLV stream, as carried
+// within the extra data of some messages.
+type tlvRecord struct {
+	// Type is the type of the record.
+	Type uint64
+
+	// Value is the raw value of the record.
+	Value []byte
+}
+
+// writeBigSize writes the passed integer to w using the minimal BigSize
+// encoding, in which integers below 0xfd occupy a single byte, and larger
+// integers are prefixed by a byte denoting their width.
+func writeBigSize(w io.Writer, n uint64) error {
+	var b []byte
+	switch {
+	case n < 0xfd:
+		b = []byte{byte(n)}
+	case n <= 0xffff:
+		b = make([]byte, 3)
+		b[0] = 0xfd
+		binary.BigEndian.PutUint16(b[1:], uint16(n))
+	case n <= 0xffffffff:
+		b = make([]byte, 5)
+		b[0] = 0xfe
+		binary.BigEndian.PutUint32(b[1:], uint32(n))
+	default:
+		b = make([]byte, 9)
+		b[0] = 0xff
+		binary.BigEndian.PutUint64(b[1:], n)
+	}
+
+	_, err := w.Write(b)
+	return err
+}
+
+// readBigSize reads a BigSize encoded integer from r, rejecting integers which
+// aren't minimally encoded.
+func readBigSize(r io.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:1]); err != nil {
+		return 0, err
+	}
+
+	var n, min uint64
+	switch b[0] {
+	case 0xfd:
+		if _, err := io.ReadFull(r, b[:2]); err != nil {
+			return 0, err
+		}
+		n, min = uint64(binary.BigEndian.Uint16(b[:2])), 0xfd
+	case 0xfe:
+		if _, err := io.ReadFull(r, b[:4]); err != nil {
+			return 0, err
+		}
+		n, min = uint64(binary.BigEndian.Uint32(b[:4])), 0x10000
+	case 0xff:
+		if _, err := io.ReadFull(r, b[:8]); err != nil {
+			return 0, err
+		}
+		n, min = binary.BigEndian.Uint64(b[:8]), 0x100000000
+	default:
+		return uint64(b[0]), nil
+	}
+
+	if n < min {
+		return 0, fmt.Errorf("BigSize %d isn't minimally encoded", n)
+	}
+
+	return n, nil
+}
+
+// parseTLVStream parses the passed raw TLV stream into its records. The types
+// of the records must be strictly increasing.
+func parseTLVStream(data []byte) ([]tlvRecord, error) {
+	var records []tlvRecord
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		recordType, err := readBigSize(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 &&
+			recordType <= records[len(records)-1].Type {
+
+			return nil, fmt.Errorf("TLV record type %d out of "+
+				"order", recordType)
+		}
+
+		length, err := readBigSize(r)
+		if err != nil {
+			return nil, err
+		}
+		if length > uint64(r.Len()) {
+			return nil, fmt.Errorf("TLV record type %d of length "+
+				"%d exceeds stream", recordType, length)
+		}
+
+		value := make([]byte, length)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+
+		records = append(records, tlvRecord{
+			Type:  recordType,
+			Value: value,
+		})
+	}
+
+	return records, nil
+}
+
+// encodeTLVStream encodes the passed records, which must be sorted by their
+// type, into a raw TLV stream.
+func encodeTLVStream(records []tlvRecord) ([]byte, error) {
+	var b bytes.Buffer
+	for _, record := range records {
+		if err := writeBigSize(&b, record.Type); err != nil {
+			return nil, err
+		}
+		err := writeBigSize(&b, uint64(len(record.Value)))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := b.Write(record.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Bytes(), nil
+}
@@ -54,6 +54,11 @@ var (
 	// It is written to by the Write method of the logWriter type.
 	logRotatorPipe *io.PipeWriter
 
+	// annAuditRotator is the log rotator backing the announcement audit
+	// log. It's only initialized if an audit log has been configured, and
+	// should be closed on application shutdown.
+	annAuditRotator *rotator.Rotator
+
 	ltndLog = backendLog.Logger("LTND")
 	lnwlLog = backendLog.Logger("LNWL")
 	peerLog = backendLog.Logger("PEER")
@@ -128,6 +133,28 @@ func initLogRotator(logFile string) {
 	logRotatorPipe = pw
 }
 
+// initAnnAuditRotator initializes a dedicated log rotator which writes the
+// announcement audit log to auditFile, creating roll files in the same
+// directory. The returned writer is the write-end pipe of the rotator.
+func initAnnAuditRotator(auditFile string) (io.Writer, error) {
+	auditDir, _ := filepath.Split(auditFile)
+	if err := os.MkdirAll(auditDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log "+
+			"directory: %v", err)
+	}
+	r, err := rotator.New(auditFile, 10*1024, false, 3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit log "+
+			"rotator: %v", err)
+	}
+
+	pr, pw := io.Pipe()
+	go r.Run(pr)
+
+	annAuditRotator = r
+	return pw, nil
+}
+
 // setLogLevel sets the logging level for provided subsystem.  Invalid
 // subsystems are ignored.  Uninitialized subsystems are dynamically created as
 // needed.
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// startTime is the time at which the process was started.
+var startTime = time.Now()
+
+// writeProcessMetrics writes basic metrics of the running process to the
+// passed writer in the Prometheus text exposition format.
+func writeProcessMetrics(w io.Writer) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	fmt.Fprint(w, "# HELP go_goroutines Number of goroutines that "+
+		"currently exist.\n")
+	fmt.Fprint(w, "# TYPE go_goroutines gauge\n")
+	fmt.Fprintf(w, "go_goroutines %v\n", runtime.NumGoroutine())
+
+	fmt.Fprint(w, "# HELP go_memstats_alloc_bytes Number of bytes "+
+		"allocated and still in use.\n")
+	fmt.Fprint(w, "# TYPE go_memstats_alloc_bytes gauge\n")
+	fmt.Fprintf(w, "go_memstats_alloc_bytes %v\n", memStats.Alloc)
+
+	fmt.Fprint(w, "# HELP go_memstats_sys_bytes Number of bytes "+
+		"obtained from the system.\n")
+	fmt.Fprint(w, "# TYPE go_memstats_sys_bytes gauge\n")
+	fmt.Fprintf(w, "go_memstats_sys_bytes %v\n", memStats.Sys)
+
+	fmt.Fprint(w, "# HELP go_gc_cycles_total Number of completed GC "+
+		"cycles.\n")
+	fmt.Fprint(w, "# TYPE go_gc_cycles_total counter\n")
+	fmt.Fprintf(w, "go_gc_cycles_total %v\n", memStats.NumGC)
+
+	fmt.Fprint(w, "# HELP process_start_time_seconds Start time of the "+
+		"process since the unix epoch in seconds.\n")
+	fmt.Fprint(w, "# TYPE process_start_time_seconds gauge\n")
+	fmt.Fprintf(w, "process_start_time_seconds %v\n", startTime.Unix())
+}
+
+// newMetricsHandler returns an http.Handler which serves the metrics of the
+// passed handler, followed by the basic metrics of the running process.
+func newMetricsHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r)
+		writeProcessMetrics(w)
+	})
+}
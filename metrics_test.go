@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestMetricsHandler ensures that the metrics handler serves the metrics of
+// the wrapped handler, followed by the metrics of the process.
+func TestMetricsHandler(t *testing.T) {
+	t.Parallel()
+
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "lnd_gossip_queued_announcements 0\n")
+	})
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	newMetricsHandler(inner).ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "lnd_gossip_queued_announcements 0\n") {
+		t.Fatalf("metrics of the wrapped handler are missing:\n%v", body)
+	}
+
+	expectedMetrics := []string{
+		"go_goroutines",
+		"go_memstats_alloc_bytes",
+		"go_memstats_sys_bytes",
+		"go_gc_cycles_total",
+		"process_start_time_seconds",
+	}
+	for _, name := range expectedMetrics {
+		if !strings.Contains(body, "\n"+name+" ") {
+			t.Fatalf("metrics don't contain %v:\n%v", name, body)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/boltdb/bolt"
+)
+
+// nurseryExportVersion is the version of the serialization format of nursery
+// exports. It's written as the first byte of each export, and must be bumped
+// whenever the format changes.
+const nurseryExportVersion uint8 = 0
+
+// writeNurseryExport serializes the passed kid and baby outputs into a single
+// versioned stream. The stream consists of the version byte, followed by the
+// number of kid outputs and the kid outputs themselves, and finally the number
+// of baby outputs and the baby outputs themselves.
+func writeNurseryExport(w io.Writer, kids []kidOutput,
+	babies []babyOutput) error {
+
+	if _, err := w.Write([]byte{nurseryExportVersion}); err != nil {
+		return err
+	}
+
+	var scratch [4]byte
+	byteOrder.PutUint32(scratch[:], uint32(len(kids)))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	for i := range kids {
+		if err := kids[i].Encode(w); err != nil {
+			return err
+		}
+	}
+
+	byteOrder.PutUint32(scratch[:], uint32(len(babies)))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+	for i := range babies {
+		if err := babies[i].Encode(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readNurseryExport deserializes the kid and baby outputs from a stream
+// written by writeNurseryExport.
+func readNurseryExport(r io.Reader) ([]kidOutput, []babyOutput, error) {
+	var version [1]byte
+	if _, err := io.ReadFull(r, version[:]); err != nil {
+		return nil, nil, err
+	}
+	if version[0] != nurseryExportVersion {
+		return nil, nil, fmt.Errorf("unknown nursery export version: "+
+			"%v", version[0])
+	}
+
+	var scratch [4]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, nil, err
+	}
+	kids := make([]kidOutput, byteOrder.Uint32(scratch[:]))
+	for i := range kids {
+		if err := kids[i].Decode(r); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, nil, err
+	}
+	babies := make([]babyOutput, byteOrder.Uint32(scratch[:]))
+	for i := range babies {
+		if err := babies[i].Decode(r); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return kids, babies, nil
+}
+
+// ExportState writes all outputs currently incubated by the nursery to the
+// passed writer, such that they can be imported into the nursery of another
+// node through ImportState. This allows in-flight outputs to be migrated along
+// with a node.
+func (u *utxoNursery) ExportState(w io.Writer) error {
+	var kids []kidOutput
+	err := u.db.View(func(tx *bolt.Tx) error {
+		// First, we'll gather all outputs which are still waiting for
+		// the initial confirmation of their commitment transaction.
+		if psclBucket := tx.Bucket(preschoolBucket); psclBucket != nil {
+			err := psclBucket.ForEach(func(_, kidBytes []byte) error {
+				var kid kidOutput
+				err := kid.Decode(bytes.NewReader(kidBytes))
+				if err != nil {
+					return err
+				}
+
+				kids = append(kids, kid)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Next, we'll gather all confirmed outputs which are waiting
+		// to reach maturity, skipping the last graduated height which
+		// shares their bucket.
+		kgtnBucket := tx.Bucket(kindergartenBucket)
+		if kgtnBucket == nil {
+			return nil
+		}
+		return kgtnBucket.ForEach(func(k, v []byte) error {
+			if bytes.Equal(k, lastGraduatedHeightKey) {
+				return nil
+			}
+
+			kgtnOutputs, err := deserializeKidList(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+			for _, kid := range kgtnOutputs {
+				kids = append(kids, *kid)
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// TODO(roasbeef): include baby outputs once they're persisted by the
+	// nursery.
+	return writeNurseryExport(w, kids, nil)
+}
+
+// ImportState imports all outputs within the passed export, as written by
+// ExportState, into the nursery. Outputs that haven't been confirmed yet are
+// placed in preschool, while all others are placed in kindergarten.
+//
+// NOTE: This MUST be called before the nursery is started, such that imported
+// preschool outputs are registered for confirmation.
+func (u *utxoNursery) ImportState(r io.Reader) error {
+	kids, babies, err := readNurseryExport(r)
+	if err != nil {
+		return fmt.Errorf("unable to read nursery export: %v", err)
+	}
+
+	// The nursery doesn't persist baby outputs yet, so we're unable to
+	// continue their incubation.
+	if len(babies) != 0 {
+		return fmt.Errorf("unable to import %v baby outputs",
+			len(babies))
+	}
+
+	for i := range kids {
+		kid := &kids[i]
+		if kid.ConfHeight() == 0 {
+			if err := kid.enterPreschool(u.db); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := u.db.Update(func(tx *bolt.Tx) error {
+			return kid.enterKindergarten(tx)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	utxnLog.Infof("Imported %v outputs into the nursery", len(kids))
+
+	return nil
+}
+
+// ExportStateFile writes all outputs currently incubated by the nursery to the
+// file at the passed path, as done by ExportState. Any existing file at the
+// path is replaced.
+func (u *utxoNursery) ExportStateFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if err := u.ExportState(w); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	utxnLog.Infof("Exported nursery state to %v", path)
+
+	return f.Sync()
+}
+
+// ImportStateFile imports all outputs within the nursery export at the passed
+// path into the nursery, as done by ImportState.
+//
+// NOTE: This MUST be called before the nursery is started.
+func (u *utxoNursery) ImportStateFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return u.ImportState(bufio.NewReader(f))
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/roasbeef/btcd/wire"
 	"github.com/viacoin/lnd/chainntnfs"
 	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/discovery"
 	"github.com/viacoin/lnd/htlcswitch"
 	"github.com/viacoin/lnd/lnrpc"
 	"github.com/viacoin/lnd/lnwallet"
@@ -213,7 +214,7 @@ func (p *peer) Start() error {
 	go func() {
 		defer p.wg.Done()
 
-		msg, err := p.readNextMessage()
+		msg, _, err := p.readNextMessage()
 		if err != nil {
 			readErr <- err
 			msgChan <- nil
@@ -428,11 +429,12 @@ func (p *peer) String() string {
 }
 
 // readNextMessage reads, and returns the next message on the wire along with
-// any additional raw payload.
-func (p *peer) readNextMessage() (lnwire.Message, error) {
+// the size of its raw payload.
+func (p *peer) readNextMessage() (lnwire.Message, int, error) {
 	noiseConn, ok := p.conn.(*brontide.Conn)
 	if !ok {
-		return nil, fmt.Errorf("brontide.Conn required to read messages")
+		return nil, 0, fmt.Errorf("brontide.Conn required to read " +
+			"messages")
 	}
 
 	// First we'll read the next _full_ message. We do this rather than
@@ -442,7 +444,7 @@ func (p *peer) readNextMessage() (lnwire.Message, error) {
 	rawMsg, err := noiseConn.ReadNextMessage()
 	atomic.AddUint64(&p.bytesReceived, uint64(len(rawMsg)))
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// Next, create a new io.Reader implementation from the raw message,
@@ -450,13 +452,13 @@ func (p *peer) readNextMessage() (lnwire.Message, error) {
 	msgReader := bytes.NewReader(rawMsg)
 	nextMsg, err := lnwire.ReadMessage(msgReader, 0)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// TODO(roasbeef): add message summaries
 	p.logWireMessage(nextMsg, true)
 
-	return nextMsg, nil
+	return nextMsg, len(rawMsg), nil
 }
 
 // chanMsgStream implements a goroutine-safe, in-order stream of messages to be
@@ -600,7 +602,7 @@ func (p *peer) readHandler() {
 	chanMsgStreams := make(map[lnwire.ChannelID]*chanMsgStream)
 out:
 	for atomic.LoadInt32(&p.disconnect) == 0 {
-		nextMsg, err := p.readNextMessage()
+		nextMsg, msgSize, err := p.readNextMessage()
 		if err != nil {
 			peerLog.Infof("unable to read message from %v: %v",
 				p, err)
@@ -692,8 +694,13 @@ out:
 			*lnwire.NodeAnnouncement,
 			*lnwire.AnnounceSignatures:
 
-			p.server.authGossiper.ProcessRemoteAnnouncement(msg,
-				p.addr.IdentityKey)
+			p.server.authGossiper.ProcessSizedRemoteAnnouncement(
+				msg, msgSize, p.addr.IdentityKey,
+			)
+
+		case *lnwire.CompressedBatch:
+			p.processCompressedBatch(msg)
+
 		default:
 			peerLog.Errorf("unknown message received from peer "+
 				"%v", p)
@@ -732,6 +739,33 @@ out:
 	peerLog.Tracef("readHandler for peer %v done", p)
 }
 
+// processCompressedBatch decompresses the passed batch, and hands each of the
+// announcements within it to the gossiper as if it had been received on its
+// own. Batches are only used to carry announcements, so any other message
+// within the batch is ignored.
+func (p *peer) processCompressedBatch(batch *lnwire.CompressedBatch) {
+	msgs, err := batch.Messages()
+	if err != nil {
+		peerLog.Errorf("unable to decompress batch from %v: %v", p, err)
+		return
+	}
+
+	for _, msg := range msgs {
+		switch msg.(type) {
+		case *lnwire.ChannelUpdate,
+			*lnwire.ChannelAnnouncement,
+			*lnwire.NodeAnnouncement,
+			*lnwire.AnnounceSignatures:
+
+			p.server.authGossiper.ProcessRemoteAnnouncement(msg,
+				p.addr.IdentityKey)
+		default:
+			peerLog.Errorf("ignoring %v within compressed batch "+
+				"from %v", msg.MsgType(), p)
+		}
+	}
+}
+
 // logWireMessage logs the receipt or sending of particular wire message. This
 // function is used rather than just logging the message in order to produce
 // less spammy log messages in trace mode by setting the 'Curve" parameter to
@@ -1059,8 +1093,10 @@ out:
 				HodlHTLC:         cfg.HodlHTLC,
 				Registry:         p.server.invoices,
 				Switch:           p.server.htlcSwitch,
-				FwrdingPolicy:    p.server.cc.routingPolicy,
-				BlockEpochs:      blockEpoch,
+				FwrdingPolicy: p.server.fundingMgr.initialRoutingPolicy(
+					newChan.Capacity, p.addr.IdentityKey,
+				),
+				BlockEpochs: blockEpoch,
 			}
 			link := htlcswitch.NewChannelLink(linkConfig, newChan,
 				uint32(currentHeight))
@@ -1519,6 +1555,7 @@ func (p *peer) handleClosingSigned(localReq *htlcswitch.ChanClose,
 		SettledBalance: chanInfo.LocalBalance.ToSatoshis(),
 		CloseType:      channeldb.CooperativeClose,
 		IsPending:      true,
+		ShortChanID:    channel.ShortChanID(),
 	}
 	if err := channel.DeleteState(closeSummary); err != nil {
 		if localReq != nil {
@@ -1849,17 +1886,7 @@ func createGetLastUpdate(router *routing.ChannelRouter,
 			local = edge1
 		}
 
-		update := &lnwire.ChannelUpdate{
-			Signature:       local.Signature,
-			ChainHash:       info.ChainHash,
-			ShortChannelID:  lnwire.NewShortChanIDFromInt(local.ChannelID),
-			Timestamp:       uint32(local.LastUpdate.Unix()),
-			Flags:           local.Flags,
-			TimeLockDelta:   local.TimeLockDelta,
-			HtlcMinimumMsat: local.MinHTLC,
-			BaseFee:         uint32(local.FeeBaseMSat),
-			FeeRate:         uint32(local.FeeProportionalMillionths),
-		}
+		update := discovery.ChanUpdateFromPolicy(info.ChainHash, local)
 
 		hswcLog.Debugf("Sending latest channel_update: %v",
 			spew.Sdump(update))
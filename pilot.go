@@ -125,7 +125,7 @@ func initAutoPilot(svr *server, cfg *autoPilotConfig) (*autopilot.Agent, error)
 	// TODO(roasbeef): switch here to dispatch specified heuristic
 	minChanSize := svr.cc.wallet.Cfg.DefaultConstraints.DustLimit * 5
 	prefAttachment := autopilot.NewConstrainedPrefAttachment(
-		minChanSize, maxFundingAmount,
+		minChanSize, svr.fundingMgr.cfg.MaxChanSize,
 		uint16(cfg.MaxChannels), cfg.Allocation,
 	)
 
@@ -318,11 +318,11 @@ func addToTopologyChange(graph *channeldb.ChannelGraph, update *TopologyChange,
 				err)
 		}
 
-		// If the flag is one, then the advertising node is actually
-		// the second node.
+		// If the direction bit is set, then the advertising node is
+		// actually the second node.
 		sourceNode := edgeInfo.NodeKey1
 		connectingNode := edgeInfo.NodeKey2
-		if m.Flags == 1 {
+		if m.Flags&lnwire.ChanUpdateDirection != 0 {
 			sourceNode = edgeInfo.NodeKey2
 			connectingNode = edgeInfo.NodeKey1
 		}
@@ -346,6 +346,17 @@ func addToTopologyChange(graph *channeldb.ChannelGraph, update *TopologyChange,
 			edgeUpdate)
 		return nil
 
+	// A batch of channel updates generates a ChannelEdgeUpdate
+	// notification for each of the updates within it.
+	case []*channeldb.ChannelEdgePolicy:
+		for _, edge := range m {
+			err := addToTopologyChange(graph, update, edge)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+
 	default:
 		return fmt.Errorf("Unable to add to topology change, "+
 			"unknown message type %T", msg)
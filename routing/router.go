@@ -47,6 +47,14 @@ type ChannelGraphSource interface {
 	// edge considered as not fully constructed.
 	UpdateEdge(policy *channeldb.ChannelEdgePolicy) error
 
+	// DeleteEdge removes the channel identified by the passed short
+	// channel ID, along with both of its directed edges, from the graph.
+	DeleteEdge(chanID lnwire.ShortChannelID) error
+
+	// DeleteNode removes the node identified by the passed public key
+	// from the graph.
+	DeleteNode(node *btcec.PublicKey) error
+
 	// ForAllOutgoingChannels is used to iterate over all channels
 	// eminating from the "source" node which is the center of the
 	// star-graph.
@@ -64,10 +72,22 @@ type ChannelGraphSource interface {
 	// ForEachNode is used to iterate over every node in the known graph.
 	ForEachNode(func(node *channeldb.LightningNode) error) error
 
+	// FetchLightningNode attempts to look up a target node by its identity
+	// public key. channeldb.ErrGraphNodeNotFound is returned if the node
+	// doesn't exist within the graph.
+	FetchLightningNode(pub *btcec.PublicKey) (*channeldb.LightningNode, error)
+
 	// ForEachChannel is used to iterate over every channel in the known
 	// graph.
 	ForEachChannel(func(chanInfo *channeldb.ChannelEdgeInfo,
 		e1, e2 *channeldb.ChannelEdgePolicy) error) error
+
+	// ForEachNodeChannel is used to iterate over every channel of the
+	// target node for which the node has published a policy, along with
+	// the outgoing and incoming policy of the node for the channel.
+	ForEachNodeChannel(node *btcec.PublicKey,
+		cb func(chanInfo *channeldb.ChannelEdgeInfo, outPolicy,
+			inPolicy *channeldb.ChannelEdgePolicy) error) error
 }
 
 // FeeSchema is the set fee configuration for a Lighting Node on the network.
@@ -83,6 +103,20 @@ type FeeSchema struct {
 	// whose denominator is 1 million. As a result the effective fee rate
 	// charged per mSAT will be: (amount * FeeRate/1,000,000)
 	FeeRate uint32
+
+	// MaxHTLC is the largest HTLC that will be forwarded, which is
+	// advertised within the channel updates of the affected channels. If
+	// zero, the maximum HTLC of each channel is left unchanged.
+	MaxHTLC lnwire.MilliSatoshi
+
+	// TimeLockDelta is the CLTV delta advertised within the channel
+	// updates of the affected channels. If zero, the delta of each channel
+	// is left unchanged.
+	TimeLockDelta uint16
+
+	// ChanTimeLockDeltas overrides the TimeLockDelta of individual
+	// channels, keyed by their funding outpoint.
+	ChanTimeLockDeltas map[wire.OutPoint]uint16
 }
 
 // Config defines the configuration for the ChannelRouter. ALL elements within
@@ -117,9 +151,26 @@ type Config struct {
 	// the channel is marked as a zombie channel eligible for pruning.
 	ChannelPruneExpiry time.Duration
 
+	// NodePruneExpiry is the duration used to determine if the node
+	// announcement of a node should be pruned or not. If the delta between
+	// now and when the node last announced itself is greater than
+	// NodePruneExpiry, then the announced information of the node is
+	// dropped from the graph. The channels of the node are unaffected, as
+	// their staleness is governed by ChannelPruneExpiry instead. If zero,
+	// node announcements are never pruned.
+	NodePruneExpiry time.Duration
+
 	// GraphPruneInterval is used as an interval to determine how often we
 	// should examine the channel graph to garbage collect zombie channels.
 	GraphPruneInterval time.Duration
+
+	// UseNode, if set, is called with each node along the routes that are
+	// freshly found by FindRoutes, before they're returned. This allows
+	// any deferred work for the node, such as the validation of its node
+	// announcement, to be carried out once the node is first used for
+	// path finding. It must not block, as it's called while finding
+	// routes.
+	UseNode func(node *btcec.PublicKey)
 }
 
 // routeTuple is an entry within the ChannelRouter's route cache. We cache
@@ -521,71 +572,13 @@ func (r *ChannelRouter) networkHandler() {
 
 		// The graph prune ticker has ticked, so we'll examine the
 		// state of the known graph to filter out any zombie channels
-		// for pruning.
+		// and stale node announcements for pruning.
 		case <-graphPruneTicker.C:
-
-			var chansToPrune []wire.OutPoint
-			chanExpiry := r.cfg.ChannelPruneExpiry
-
-			log.Infof("Examining Channel Graph for zombie channels")
-
-			// First, we'll collect all the channels which are
-			// eligible for garbage collection due to being
-			// zombies.
-			filterPruneChans := func(info *channeldb.ChannelEdgeInfo,
-				e1, e2 *channeldb.ChannelEdgePolicy) error {
-
-				// If *both* edges haven't been updated for a
-				// period of chanExpiry, then we'll mark the
-				// channel itself as eligible for graph
-				// pruning.
-				e1Zombie, e2Zombie := true, true
-				if e1 != nil {
-					e1Zombie = time.Since(e1.LastUpdate) >= chanExpiry
-					log.Tracef("Edge #1 of ChannelPoint(%v) "+
-						"last update: %v",
-						info.ChannelPoint, e1.LastUpdate)
-				}
-				if e2 != nil {
-					e2Zombie = time.Since(e2.LastUpdate) >= chanExpiry
-					log.Tracef("Edge #2 of ChannelPoint(%v) "+
-						"last update: %v",
-						info.ChannelPoint, e2.LastUpdate)
-				}
-				if e1Zombie && e2Zombie {
-					log.Infof("ChannelPoint(%v) is a "+
-						"zombie, collecting to prune",
-						info.ChannelPoint)
-
-					// TODO(roasbeef): add ability to
-					// delete single directional edge
-					chansToPrune = append(chansToPrune,
-						info.ChannelPoint)
-				}
-
-				return nil
+			if err := r.pruneZombieChans(); err != nil {
+				log.Errorf("Unable to prune zombie chans: %v", err)
 			}
-			err := r.cfg.Graph.ForEachChannel(filterPruneChans)
-			if err != nil {
-				log.Errorf("Unable to local zombie chans: %v", err)
-				continue
-			}
-
-			log.Infof("Pruning %v Zombie Channels", len(chansToPrune))
-
-			// With the set zombie-like channels obtained, we'll do
-			// another pass to delete al zombie channels from the
-			// channel graph.
-			for _, chanToPrune := range chansToPrune {
-				log.Tracef("Pruning zombie chan ChannelPoint(%v)",
-					chanToPrune)
-
-				err := r.cfg.Graph.DeleteChannelEdge(&chanToPrune)
-				if err != nil {
-					log.Errorf("Unable to prune zombie "+
-						"chans: %v", err)
-					continue
-				}
+			if err := r.pruneStaleNodes(); err != nil {
+				log.Errorf("Unable to prune stale nodes: %v", err)
 			}
 
 		// The router has been signalled to exit, to we exit our main
@@ -596,6 +589,139 @@ func (r *ChannelRouter) networkHandler() {
 	}
 }
 
+// pruneZombieChans removes all channels from the graph for which neither of
+// the directed edges has been updated within the ChannelPruneExpiry.
+func (r *ChannelRouter) pruneZombieChans() error {
+	var chansToPrune []wire.OutPoint
+	chanExpiry := r.cfg.ChannelPruneExpiry
+
+	log.Infof("Examining Channel Graph for zombie channels")
+
+	// First, we'll collect all the channels which are
+	// eligible for garbage collection due to being
+	// zombies.
+	filterPruneChans := func(info *channeldb.ChannelEdgeInfo,
+		e1, e2 *channeldb.ChannelEdgePolicy) error {
+
+		// If *both* edges haven't been updated for a
+		// period of chanExpiry, then we'll mark the
+		// channel itself as eligible for graph
+		// pruning.
+		e1Zombie, e2Zombie := true, true
+		if e1 != nil {
+			e1Zombie = time.Since(e1.LastUpdate) >= chanExpiry
+			log.Tracef("Edge #1 of ChannelPoint(%v) "+
+				"last update: %v",
+				info.ChannelPoint, e1.LastUpdate)
+		}
+		if e2 != nil {
+			e2Zombie = time.Since(e2.LastUpdate) >= chanExpiry
+			log.Tracef("Edge #2 of ChannelPoint(%v) "+
+				"last update: %v",
+				info.ChannelPoint, e2.LastUpdate)
+		}
+		if e1Zombie && e2Zombie {
+			log.Infof("ChannelPoint(%v) is a "+
+				"zombie, collecting to prune",
+				info.ChannelPoint)
+
+			// TODO(roasbeef): add ability to
+			// delete single directional edge
+			chansToPrune = append(chansToPrune,
+				info.ChannelPoint)
+		}
+
+		return nil
+	}
+	err := r.cfg.Graph.ForEachChannel(filterPruneChans)
+	if err != nil {
+		return errors.Errorf("unable to locate zombie chans: %v", err)
+	}
+
+	log.Infof("Pruning %v Zombie Channels", len(chansToPrune))
+
+	// With the set zombie-like channels obtained, we'll do
+	// another pass to delete al zombie channels from the
+	// channel graph.
+	for _, chanToPrune := range chansToPrune {
+		log.Tracef("Pruning zombie chan ChannelPoint(%v)",
+			chanToPrune)
+
+		err := r.cfg.Graph.DeleteChannelEdge(&chanToPrune)
+		if err != nil {
+			log.Errorf("Unable to prune zombie "+
+				"chans: %v", err)
+			continue
+		}
+	}
+
+	return nil
+}
+
+// pruneStaleNodes drops the announced information of all nodes which haven't
+// re-announced themselves within the NodePruneExpiry. Such nodes are reset to
+// a bare vertex only known by its public key, such that their channels remain
+// usable for path finding, and a fresh announcement of the node will be
+// accepted once again.
+func (r *ChannelRouter) pruneStaleNodes() error {
+	nodeExpiry := r.cfg.NodePruneExpiry
+	if nodeExpiry == 0 {
+		return nil
+	}
+
+	log.Infof("Examining Channel Graph for stale node announcements")
+
+	selfPub := r.selfNode.PubKey.SerializeCompressed()
+
+	var nodesToPrune []*btcec.PublicKey
+	err := r.cfg.Graph.ForEachNode(nil, func(_ *bolt.Tx,
+		node *channeldb.LightningNode) error {
+
+		// We'll never prune our own node, nor nodes for which we
+		// haven't received an announcement in the first place.
+		if !node.HaveNodeAnnouncement {
+			return nil
+		}
+		if bytes.Equal(node.PubKey.SerializeCompressed(), selfPub) {
+			return nil
+		}
+
+		if time.Since(node.LastUpdate) >= nodeExpiry {
+			log.Debugf("Node %x last announced at %v, "+
+				"collecting to prune",
+				node.PubKey.SerializeCompressed(),
+				node.LastUpdate)
+
+			nodesToPrune = append(nodesToPrune, node.PubKey)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Errorf("unable to locate stale nodes: %v", err)
+	}
+
+	log.Infof("Pruning %v stale node announcements", len(nodesToPrune))
+
+	// Replacing the node with a bare vertex would leave its alias behind,
+	// so we'll delete the node entirely before re-adding it.
+	for _, pub := range nodesToPrune {
+		if err := r.cfg.Graph.DeleteLightningNode(pub); err != nil {
+			return err
+		}
+
+		err := r.cfg.Graph.AddLightningNode(&channeldb.LightningNode{
+			PubKey:               pub,
+			HaveNodeAnnouncement: false,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // processUpdate processes a new relate authenticated channel/edge, node or
 // channel/edge update network update. If the update didn't affect the internal
 // state of the draft due to either being out of date, invalid, or redundant,
@@ -755,68 +881,14 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 		}
 
 	case *channeldb.ChannelEdgePolicy:
-		channelID := lnwire.NewShortChanIDFromInt(msg.ChannelID)
-		edge1Timestamp, edge2Timestamp, exists, err := r.cfg.Graph.HasChannelEdge(
-			msg.ChannelID,
-		)
-		if err != nil && err != channeldb.ErrGraphNoEdgesFound {
-			return errors.Errorf("unable to check for edge "+
-				"existence: %v", err)
-
-		}
-
-		// As edges are directional edge node has a unique policy for
-		// the direction of the edge they control. Therefore we first
-		// check if we already have the most up to date information for
-		// that edge. If so, then we can exit early.
-		switch msg.Flags {
-
-		// A flag set of 0 indicates this is an announcement for the
-		// "first" node in the channel.
-		case 0:
-			if edge1Timestamp.After(msg.LastUpdate) ||
-				edge1Timestamp.Equal(msg.LastUpdate) {
-				return newErrf(ErrIgnored, "Ignoring announcement "+
-					"(flags=%v) for known chan_id=%v", msg.Flags,
-					msg.ChannelID)
-
-			}
-
-		// Similarly, a flag set of 1 indicates this is an announcement
-		// for the "second" node in the channel.
-		case 1:
-			if edge2Timestamp.After(msg.LastUpdate) ||
-				edge2Timestamp.Equal(msg.LastUpdate) {
-
-				return newErrf(ErrIgnored, "Ignoring announcement "+
-					"(flags=%v) for known chan_id=%v", msg.Flags,
-					msg.ChannelID)
-			}
-		}
-
-		if !exists {
-			// Before we can update the channel information, we'll
-			// ensure that the target channel is still open by
-			// querying the utxo-set for its existence.
-			chanPoint, err := r.fetchChanPoint(&channelID)
-			if err != nil {
-				return errors.Errorf("unable to fetch chan "+
-					"point for chan_id=%v: %v",
-					msg.ChannelID, err)
-			}
-			_, err = r.cfg.Chain.GetUtxo(
-				chanPoint, channelID.BlockHeight,
-			)
-			if err != nil {
-				return errors.Errorf("unable to fetch utxo for "+
-					"chan_id=%v: %v", msg.ChannelID, err)
-			}
+		if err := r.checkEdgePolicy(msg); err != nil {
+			return err
 		}
 
 		// Now that we know this isn't a stale update, we'll apply the
 		// new edge policy to the proper directional edge within the
 		// channel graph.
-		if err = r.cfg.Graph.UpdateEdgePolicy(msg); err != nil {
+		if err := r.cfg.Graph.UpdateEdgePolicy(msg); err != nil {
 			err := errors.Errorf("unable to add channel: %v", err)
 			log.Error(err)
 			return err
@@ -826,6 +898,25 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 		log.Infof("New channel update applied: %v",
 			spew.Sdump(msg))
 
+	// A batch of channel updates is applied within a single database
+	// transaction, such that either all or none of them are applied.
+	case []*channeldb.ChannelEdgePolicy:
+		for _, policy := range msg {
+			if err := r.checkEdgePolicy(policy); err != nil {
+				return err
+			}
+		}
+
+		if err := r.cfg.Graph.UpdateEdgePolicies(msg); err != nil {
+			err := errors.Errorf("unable to apply channel "+
+				"updates: %v", err)
+			log.Error(err)
+			return err
+		}
+
+		invalidateCache = true
+		log.Infof("Applied batch of %v channel updates", len(msg))
+
 	default:
 		return errors.Errorf("wrong routing update message type")
 	}
@@ -842,6 +933,67 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 	return nil
 }
 
+// checkEdgePolicy ensures that the passed channel update isn't stale, and that
+// its channel is still open if we don't know of the channel yet.
+func (r *ChannelRouter) checkEdgePolicy(
+	policy *channeldb.ChannelEdgePolicy) error {
+
+	channelID := lnwire.NewShortChanIDFromInt(policy.ChannelID)
+	edge1Timestamp, edge2Timestamp, exists, err :=
+		r.cfg.Graph.HasChannelEdge(policy.ChannelID)
+	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
+		return errors.Errorf("unable to check for edge "+
+			"existence: %v", err)
+	}
+
+	// As edges are directional edge node has a unique policy for the
+	// direction of the edge they control. Therefore we first check if we
+	// already have the most up to date information for that edge. If so,
+	// then we can exit early.
+	switch policy.Flags & lnwire.ChanUpdateDirection {
+
+	// A direction bit of 0 indicates this is an announcement for
+	// the "first" node in the channel.
+	case 0:
+		if edge1Timestamp.After(policy.LastUpdate) ||
+			edge1Timestamp.Equal(policy.LastUpdate) {
+			return newErrf(ErrIgnored, "Ignoring announcement "+
+				"(flags=%v) for known chan_id=%v", policy.Flags,
+				policy.ChannelID)
+
+		}
+
+	// Similarly, a direction bit of 1 indicates this is an
+	// announcement for the "second" node in the channel.
+	case 1:
+		if edge2Timestamp.After(policy.LastUpdate) ||
+			edge2Timestamp.Equal(policy.LastUpdate) {
+
+			return newErrf(ErrIgnored, "Ignoring announcement "+
+				"(flags=%v) for known chan_id=%v", policy.Flags,
+				policy.ChannelID)
+		}
+	}
+
+	if !exists {
+		// Before we can update the channel information, we'll ensure
+		// that the target channel is still open by querying the
+		// utxo-set for its existence.
+		chanPoint, err := r.fetchChanPoint(&channelID)
+		if err != nil {
+			return errors.Errorf("unable to fetch chan point for "+
+				"chan_id=%v: %v", policy.ChannelID, err)
+		}
+		_, err = r.cfg.Chain.GetUtxo(chanPoint, channelID.BlockHeight)
+		if err != nil {
+			return errors.Errorf("unable to fetch utxo for "+
+				"chan_id=%v: %v", policy.ChannelID, err)
+		}
+	}
+
+	return nil
+}
+
 // fetchChanPoint retrieves the original outpoint which is encoded within the
 // channelID.
 //
@@ -879,6 +1031,14 @@ func (r *ChannelRouter) fetchChanPoint(chanID *lnwire.ShortChannelID) (*wire.Out
 	}, nil
 }
 
+// FetchChanPoint returns the funding outpoint of the channel with the passed
+// short channel ID, as found within the chain.
+func (r *ChannelRouter) FetchChanPoint(chanID lnwire.ShortChannelID) (
+	*wire.OutPoint, error) {
+
+	return r.fetchChanPoint(&chanID)
+}
+
 // routingMsg couples a routing related routing topology update to the
 // error channel.
 type routingMsg struct {
@@ -994,6 +1154,12 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 		}),
 	)
 
+	// Now that we know which nodes the routes pass through, we'll notify
+	// the caller of their use.
+	if r.cfg.UseNode != nil {
+		r.useRouteNodes(validRoutes)
+	}
+
 	// Populate the cache with this set of fresh routes so we can
 	// reuse them in the future.
 	r.routeCacheMtx.Lock()
@@ -1316,8 +1482,10 @@ func (r *ChannelRouter) applyChannelUpdate(msg *lnwire.ChannelUpdate) error {
 		Flags:                     msg.Flags,
 		TimeLockDelta:             msg.TimeLockDelta,
 		MinHTLC:                   msg.HtlcMinimumMsat,
+		MaxHTLC:                   msg.HtlcMaximumMsat,
 		FeeBaseMSat:               lnwire.MilliSatoshi(msg.BaseFee),
 		FeeProportionalMillionths: lnwire.MilliSatoshi(msg.FeeRate),
+		ExtraOpaqueData:           msg.ExtraOpaqueData,
 	})
 	if err != nil {
 		return fmt.Errorf("Unable to apply channel update: %v", err)
@@ -1397,6 +1565,74 @@ func (r *ChannelRouter) UpdateEdge(update *channeldb.ChannelEdgePolicy) error {
 	}
 }
 
+// UpdateEdges applies the passed edge updates, as with UpdateEdge, within a
+// single database transaction. If any of the updates is rejected, then none of
+// them are applied.
+func (r *ChannelRouter) UpdateEdges(
+	updates []*channeldb.ChannelEdgePolicy) error {
+
+	rMsg := &routingMsg{
+		msg: updates,
+		err: make(chan error, 1),
+	}
+
+	select {
+	case r.networkUpdates <- rMsg:
+		select {
+		case err := <-rMsg.err:
+			return err
+		case <-r.quit:
+			return errors.New("router has been shut down")
+		}
+	case <-r.quit:
+		return errors.New("router has been shut down")
+	}
+}
+
+// DeleteEdge removes the channel identified by the passed short channel ID,
+// along with both of its directed edges, from the graph.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) DeleteEdge(chanID lnwire.ShortChannelID) error {
+	info, _, _, err := r.cfg.Graph.FetchChannelEdgesByID(chanID.ToUint64())
+	if err != nil {
+		return err
+	}
+
+	return r.cfg.Graph.DeleteChannelEdge(&info.ChannelPoint)
+}
+
+// DeleteNode removes the node identified by the passed public key from the
+// graph.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) DeleteNode(node *btcec.PublicKey) error {
+	return r.cfg.Graph.DeleteLightningNode(node)
+}
+
+// useRouteNodes calls the UseNode callback once for each distinct node along
+// the passed routes.
+func (r *ChannelRouter) useRouteNodes(routes []*Route) {
+	used := make(map[[33]byte]struct{})
+	for _, route := range routes {
+		for _, hop := range route.Hops {
+			if hop.Channel.Node == nil {
+				continue
+			}
+			node := hop.Channel.Node.PubKey
+
+			var pub [33]byte
+			copy(pub[:], node.SerializeCompressed())
+			if _, ok := used[pub]; ok {
+				continue
+			}
+			used[pub] = struct{}{}
+
+			r.cfg.UseNode(node)
+		}
+	}
+}
+
 // CurrentBlockHeight returns the block height from POV of the router subsystem.
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
@@ -1425,6 +1661,17 @@ func (r *ChannelRouter) ForEachNode(cb func(*channeldb.LightningNode) error) err
 	})
 }
 
+// FetchLightningNode attempts to look up a target node by its identity public
+// key. channeldb.ErrGraphNodeNotFound is returned if the node doesn't exist
+// within the graph.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) FetchLightningNode(pub *btcec.PublicKey) (
+	*channeldb.LightningNode, error) {
+
+	return r.cfg.Graph.FetchLightningNode(pub)
+}
+
 // ForAllOutgoingChannels is used to iterate over all outgiong channel owned by
 // the router.
 //
@@ -1449,6 +1696,28 @@ func (r *ChannelRouter) ForEachChannel(cb func(chanInfo *channeldb.ChannelEdgeIn
 	return r.cfg.Graph.ForEachChannel(cb)
 }
 
+// ForEachNodeChannel is used to iterate over every channel of the target node
+// for which the node has published a policy, along with the outgoing and
+// incoming policy of the node for the channel.
+//
+// NOTE: This method is part of the ChannelGraphSource interface.
+func (r *ChannelRouter) ForEachNodeChannel(node *btcec.PublicKey,
+	cb func(chanInfo *channeldb.ChannelEdgeInfo,
+		outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error) error {
+
+	dbNode, err := r.cfg.Graph.FetchLightningNode(node)
+	if err != nil {
+		return err
+	}
+
+	return dbNode.ForEachChannel(nil, func(_ *bolt.Tx,
+		c *channeldb.ChannelEdgeInfo,
+		outPolicy, inPolicy *channeldb.ChannelEdgePolicy) error {
+
+		return cb(c, outPolicy, inPolicy)
+	})
+}
+
 // AddProof updates the channel edge info with proof which is needed to
 // properly announce the edge to the rest of the network.
 //
@@ -563,3 +563,171 @@ func TestAddEdgeUnknownVertexes(t *testing.T) {
 		t.Fatalf("fetched node not equal to original")
 	}
 }
+
+// TestPruneStaleNodes tests that node announcements and channel updates are
+// pruned according to their own expiry, such that a stale node announcement is
+// pruned while the fresh channel updates of its channel are left untouched.
+func TestPruneStaleNodes(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	ctx.router.cfg.NodePruneExpiry = time.Hour * 24 * 60
+
+	// We'll start by adding a channel between two nodes along with fresh
+	// channel updates for both of its directions.
+	fundingTx, _, chanID, err := createChannelEdge(ctx,
+		bitcoinKey1.SerializeCompressed(),
+		bitcoinKey2.SerializeCompressed(),
+		10000, 500)
+	if err != nil {
+		t.Fatalf("unable to create channel edge: %v", err)
+	}
+	fundingBlock := &wire.MsgBlock{
+		Transactions: []*wire.MsgTx{fundingTx},
+	}
+	ctx.chain.addBlock(fundingBlock, chanID.BlockHeight)
+
+	edge := &channeldb.ChannelEdgeInfo{
+		ChannelID:   chanID.ToUint64(),
+		NodeKey1:    priv1.PubKey(),
+		NodeKey2:    priv2.PubKey(),
+		BitcoinKey1: bitcoinKey1,
+		BitcoinKey2: bitcoinKey2,
+		AuthProof:   nil,
+	}
+	if err := ctx.router.AddEdge(edge); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	for _, flags := range []uint16{0, 1} {
+		edgePolicy := &channeldb.ChannelEdgePolicy{
+			Signature:                 testSig,
+			ChannelID:                 edge.ChannelID,
+			LastUpdate:                time.Now(),
+			Flags:                     flags,
+			TimeLockDelta:             10,
+			MinHTLC:                   1,
+			FeeBaseMSat:               10,
+			FeeProportionalMillionths: 10000,
+		}
+		if err := ctx.router.UpdateEdge(edgePolicy); err != nil {
+			t.Fatalf("unable to update edge policy: %v", err)
+		}
+	}
+
+	// Next, we'll announce both nodes of the channel. The first node last
+	// announced itself beyond the node expiry, while the second node's
+	// announcement is fresh.
+	staleNode := &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		LastUpdate:           time.Now().Add(-time.Hour * 24 * 90),
+		Addresses:            testAddrs,
+		PubKey:               priv1.PubKey(),
+		Color:                color.RGBA{1, 2, 3, 0},
+		Alias:                "stale",
+		AuthSig:              testSig,
+		Features:             testFeatures,
+	}
+	freshNode := &channeldb.LightningNode{
+		HaveNodeAnnouncement: true,
+		LastUpdate:           time.Now(),
+		Addresses:            testAddrs,
+		PubKey:               priv2.PubKey(),
+		Color:                color.RGBA{1, 2, 3, 0},
+		Alias:                "fresh",
+		AuthSig:              testSig,
+		Features:             testFeatures,
+	}
+	for _, node := range []*channeldb.LightningNode{staleNode, freshNode} {
+		if err := ctx.router.AddNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	// With the graph set up, we'll run the prune sweep of the router.
+	if err := ctx.router.pruneZombieChans(); err != nil {
+		t.Fatalf("unable to prune zombie chans: %v", err)
+	}
+	if err := ctx.router.pruneStaleNodes(); err != nil {
+		t.Fatalf("unable to prune stale nodes: %v", err)
+	}
+
+	// Only the announcement of the stale node should've been pruned,
+	// leaving a bare vertex in its place.
+	node, err := ctx.graph.FetchLightningNode(priv1.PubKey())
+	if err != nil {
+		t.Fatalf("unable to fetch stale node: %v", err)
+	}
+	if node.HaveNodeAnnouncement {
+		t.Fatalf("expected stale node announcement to be pruned")
+	}
+	node, err = ctx.graph.FetchLightningNode(priv2.PubKey())
+	if err != nil {
+		t.Fatalf("unable to fetch fresh node: %v", err)
+	}
+	if !node.HaveNodeAnnouncement || node.Alias != freshNode.Alias {
+		t.Fatalf("expected fresh node announcement to be retained")
+	}
+
+	// The channel along with its fresh channel updates should remain
+	// within the graph.
+	_, e1, e2, err := ctx.graph.FetchChannelEdgesByID(edge.ChannelID)
+	if err != nil {
+		t.Fatalf("expected channel to be retained: %v", err)
+	}
+	if e1 == nil || e2 == nil {
+		t.Fatalf("expected channel updates to be retained")
+	}
+}
+
+// TestFindRoutesUseNode asserts that the UseNode callback is called exactly
+// once for each distinct node along the routes found by FindRoutes.
+func TestFindRoutesUseNode(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	used := make(map[[33]byte]int)
+	ctx.router.cfg.UseNode = func(node *btcec.PublicKey) {
+		var pub [33]byte
+		copy(pub[:], node.SerializeCompressed())
+		used[pub]++
+	}
+
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := ctx.aliases["luoji"]
+	routes, err := ctx.router.FindRoutes(target, paymentAmt)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+
+	for _, route := range routes {
+		for _, hop := range route.Hops {
+			node := hop.Channel.Node.PubKey
+
+			var pub [33]byte
+			copy(pub[:], node.SerializeCompressed())
+			if used[pub] != 1 {
+				t.Fatalf("expected node %x to be used once, "+
+					"was used %v times", pub[:], used[pub])
+			}
+		}
+	}
+
+	var targetPub [33]byte
+	copy(targetPub[:], target.SerializeCompressed())
+	if _, ok := used[targetPub]; !ok {
+		t.Fatal("target node wasn't used")
+	}
+}
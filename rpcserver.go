@@ -30,6 +30,7 @@ import (
 	"github.com/roasbeef/btcwallet/waddrmgr"
 	"github.com/tv42/zbase32"
 	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/discovery"
 	"github.com/viacoin/lnd/htlcswitch"
 	"github.com/viacoin/lnd/lnrpc"
 	"github.com/viacoin/lnd/lnwallet"
@@ -62,6 +63,7 @@ var (
 		"listpayments",
 		"decodepayreq",
 		"feereport",
+		"estimatefundingfee",
 	}
 )
 
@@ -923,6 +925,7 @@ func (r *rpcServer) forceCloseChan(channel *lnwallet.LightningChannel) (*chainha
 		Capacity:    chanInfo.Capacity,
 		CloseType:   channeldb.ForceClose,
 		IsPending:   true,
+		ShortChanID: channel.ShortChanID(),
 	}
 
 	// If our commitment output isn't dust or we have active HTLC's on the
@@ -2255,6 +2258,7 @@ func marshalDbEdge(edgeInfo *channeldb.ChannelEdgeInfo,
 			MinHtlc:          int64(c1.MinHTLC),
 			FeeBaseMsat:      int64(c1.FeeBaseMSat),
 			FeeRateMilliMsat: int64(c1.FeeProportionalMillionths),
+			Disabled:         c1.Flags&lnwire.ChanUpdateDisabled != 0,
 		}
 	}
 
@@ -2264,6 +2268,7 @@ func marshalDbEdge(edgeInfo *channeldb.ChannelEdgeInfo,
 			MinHtlc:          int64(c2.MinHTLC),
 			FeeBaseMsat:      int64(c2.FeeBaseMSat),
 			FeeRateMilliMsat: int64(c2.FeeProportionalMillionths),
+			Disabled:         c2.Flags&lnwire.ChanUpdateDisabled != 0,
 		}
 	}
 
@@ -2277,8 +2282,6 @@ func marshalDbEdge(edgeInfo *channeldb.ChannelEdgeInfo,
 func (r *rpcServer) GetChanInfo(ctx context.Context,
 	in *lnrpc.ChanInfoRequest) (*lnrpc.ChannelEdge, error) {
 
-	graph := r.server.chanDB.ChannelGraph()
-
 	// Check macaroon to see if this is allowed.
 	if r.authSvc != nil {
 		if err := macaroons.ValidateMacaroon(ctx, "getchaninfo",
@@ -2287,17 +2290,33 @@ func (r *rpcServer) GetChanInfo(ctx context.Context,
 		}
 	}
 
-	edgeInfo, edge1, edge2, err := graph.FetchChannelEdgesByID(in.ChanId)
-	if err != nil {
+	return fetchChanInfo(r.server.chanRouter, in.ChanId)
+}
+
+// fetchChanInfo retrieves the channel identified by the passed channel ID,
+// along with the routing policies in both of its directions, from the graph
+// of the passed router.
+func fetchChanInfo(router routing.ChannelGraphSource,
+	chanID uint64) (*lnrpc.ChannelEdge, error) {
+
+	edgeInfo, edge1, edge2, err := router.GetChannelByID(
+		lnwire.NewShortChanIDFromInt(chanID),
+	)
+	switch {
+	case err == channeldb.ErrEdgeNotFound ||
+		err == channeldb.ErrGraphNoEdgesFound:
+
+		return nil, fmt.Errorf("unable to find channel with "+
+			"chan_id=%v", chanID)
+
+	case err != nil:
 		return nil, err
 	}
 
 	// Convert the database's edge format into the network/RPC edge format
 	// which couples the edge itself along with the directional node
 	// routing policies of each node involved within the channel.
-	channelEdge := marshalDbEdge(edgeInfo, edge1, edge2)
-
-	return channelEdge, nil
+	return marshalDbEdge(edgeInfo, edge1, edge2), nil
 }
 
 // GetNodeInfo returns the latest advertised and aggregate authenticated
@@ -3011,11 +3030,25 @@ func (r *rpcServer) UpdateFees(ctx context.Context,
 	// With the scope resolved, we'll now send this to the
 	// AuthenticatedGossiper so it can propagate the new fee schema for out
 	// target channel(s).
-	err := r.server.authGossiper.PropagateFeeUpdate(
+	updateErr := r.server.authGossiper.PropagateFeeUpdate(
 		feeSchema, targetChans...,
 	)
-	if err != nil {
-		return nil, err
+	policyErr, partial := updateErr.(*discovery.ChanPolicyUpdateError)
+	if updateErr != nil && !partial {
+		return nil, updateErr
+	}
+
+	// If the update couldn't be applied to some of the channels, then
+	// we'll only update the links of the channels it was applied to, so
+	// that our links keep forwarding according to our advertised policy.
+	if partial {
+		var err error
+		targetChans, err = r.updatedPolicyTargets(
+			targetChans, policyErr,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Finally, we'll apply the set of active links amongst the target
@@ -3024,16 +3057,117 @@ func (r *rpcServer) UpdateFees(ctx context.Context,
 	// We create a partially policy as the logic won't overwrite a valid
 	// sub-policy with a "nil" one.
 	p := htlcswitch.ForwardingPolicy{
-		BaseFee: baseFeeMsat,
-		FeeRate: lnwire.MilliSatoshi(feeRateFixed),
+		BaseFee:       baseFeeMsat,
+		FeeRate:       lnwire.MilliSatoshi(feeRateFixed),
+		TimeLockDelta: uint32(feeSchema.TimeLockDelta),
 	}
-	err = r.server.htlcSwitch.UpdateForwardingPolicies(p, targetChans...)
-	if err != nil {
-		// If we're unable update the fees due to the links not being
-		// online, then we don't need to fail the call. We'll simply
-		// log the failure.
-		rpcsLog.Warnf("Unable to update link fees: %v", err)
+	if !partial || len(targetChans) != 0 {
+		err := r.server.htlcSwitch.UpdateForwardingPolicies(
+			p, targetChans...,
+		)
+		if err != nil {
+			// If we're unable update the fees due to the links not
+			// being online, then we don't need to fail the call.
+			// We'll simply log the failure.
+			rpcsLog.Warnf("Unable to update link fees: %v", err)
+		}
+	}
+
+	if partial {
+		return nil, policyErr
 	}
 
 	return &lnrpc.FeeUpdateResponse{}, nil
 }
+
+// updatedPolicyTargets returns the channels among the passed targets of a
+// policy update that the update was applied to, excluding the channels listed
+// within the passed error. If no targets are passed, then the update targeted
+// all of our open channels.
+func (r *rpcServer) updatedPolicyTargets(targetChans []wire.OutPoint,
+	policyErr *discovery.ChanPolicyUpdateError) ([]wire.OutPoint, error) {
+
+	if len(targetChans) == 0 {
+		openChans, err := r.server.chanDB.FetchAllChannels()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, channel := range openChans {
+			targetChans = append(
+				targetChans, channel.FundingOutpoint,
+			)
+		}
+	}
+
+	updatedChans := make([]wire.OutPoint, 0, len(targetChans))
+	for _, chanPoint := range targetChans {
+		if _, ok := policyErr.Failures[chanPoint]; ok {
+			continue
+		}
+		updatedChans = append(updatedChans, chanPoint)
+	}
+
+	return updatedChans, nil
+}
+
+// EstimateFundingFee returns the estimated on-chain fee of the funding
+// transaction for a channel of the given size, targeting confirmation within
+// the given number of blocks.
+func (r *rpcServer) EstimateFundingFee(ctx context.Context,
+	in *lnrpc.EstimateFundingFeeRequest) (*lnrpc.EstimateFundingFeeResponse,
+	error) {
+
+	if r.authSvc != nil {
+		if err := macaroons.ValidateMacaroon(ctx, "estimatefundingfee",
+			r.authSvc); err != nil {
+			return nil, err
+		}
+	}
+
+	fee, feePerWeight, err := estimateFundingFee(
+		r.server.cc.feeEstimator,
+		btcutil.Amount(in.LocalFundingAmount), in.TargetConf,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.EstimateFundingFeeResponse{
+		FeeSat:       int64(fee),
+		SatPerWeight: int64(feePerWeight),
+		TxWeight:     lnwallet.FundingTxWeight,
+	}, nil
+}
+
+// estimateFundingFee estimates the fee of the funding transaction for a
+// channel of the passed size, confirmed within the target number of blocks,
+// based on the rate returned by the passed fee estimator. As the inputs of the
+// funding transaction aren't known before it's crafted, the fee is estimated
+// for a representative funding transaction spending a single input.
+func estimateFundingFee(estimator lnwallet.FeeEstimator,
+	fundingAmt btcutil.Amount, targetConf int32) (btcutil.Amount, uint64,
+	error) {
+
+	switch {
+	case fundingAmt <= 0:
+		return 0, 0, fmt.Errorf("funding amount must be positive")
+	case fundingAmt > maxFundingAmount:
+		return 0, 0, fmt.Errorf("funding amount is too large, the max "+
+			"channel size is: %v", maxFundingAmount)
+	case targetConf <= 0:
+		return 0, 0, fmt.Errorf("confirmation target must be positive")
+	}
+
+	// An estimator without sufficient data to serve an estimate returns a
+	// zero fee rate, which we won't pass off as an estimate.
+	feePerWeight := estimator.EstimateFeePerWeight(uint32(targetConf))
+	if feePerWeight == 0 {
+		return 0, 0, fmt.Errorf("no fee estimate available for "+
+			"confirmation within %v blocks", targetConf)
+	}
+
+	fee := btcutil.Amount(feePerWeight * lnwallet.FundingTxWeight)
+
+	return fee, feePerWeight, nil
+}
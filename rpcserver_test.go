@@ -0,0 +1,229 @@
+// +build !rpctest
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcutil"
+	"github.com/viacoin/lnd/channeldb"
+	"github.com/viacoin/lnd/lnwallet"
+	"github.com/viacoin/lnd/lnwire"
+	"github.com/viacoin/lnd/routing"
+)
+
+// mockChanGraphSource is a routing.ChannelGraphSource which only serves a
+// static set of channels through GetChannelByID.
+type mockChanGraphSource struct {
+	routing.ChannelGraphSource
+
+	infos    map[uint64]*channeldb.ChannelEdgeInfo
+	policies map[uint64][2]*channeldb.ChannelEdgePolicy
+}
+
+func (m *mockChanGraphSource) GetChannelByID(chanID lnwire.ShortChannelID) (
+	*channeldb.ChannelEdgeInfo, *channeldb.ChannelEdgePolicy,
+	*channeldb.ChannelEdgePolicy, error) {
+
+	info, ok := m.infos[chanID.ToUint64()]
+	if !ok {
+		return nil, nil, nil, channeldb.ErrEdgeNotFound
+	}
+
+	policies := m.policies[chanID.ToUint64()]
+	return info, policies[0], policies[1], nil
+}
+
+// TestFetchChanInfo ensures that both directional routing policies of a known
+// channel are returned, and that querying an unknown channel fails.
+func TestFetchChanInfo(t *testing.T) {
+	t.Parallel()
+
+	priv1, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	priv2, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	const chanID = 1234
+	info := &channeldb.ChannelEdgeInfo{
+		ChannelID: chanID,
+		NodeKey1:  priv1.PubKey(),
+		NodeKey2:  priv2.PubKey(),
+		Capacity:  100000,
+	}
+	policy1 := &channeldb.ChannelEdgePolicy{
+		ChannelID:                 chanID,
+		LastUpdate:                time.Unix(1500000000, 0),
+		TimeLockDelta:             144,
+		MinHTLC:                   1000,
+		FeeBaseMSat:               1500,
+		FeeProportionalMillionths: 10,
+	}
+	policy2 := &channeldb.ChannelEdgePolicy{
+		ChannelID:                 chanID,
+		LastUpdate:                time.Unix(1500000001, 0),
+		Flags:                     lnwire.ChanUpdateDirection | lnwire.ChanUpdateDisabled,
+		TimeLockDelta:             40,
+		MinHTLC:                   1,
+		FeeBaseMSat:               1000,
+		FeeProportionalMillionths: 1,
+	}
+
+	graph := &mockChanGraphSource{
+		infos: map[uint64]*channeldb.ChannelEdgeInfo{
+			chanID: info,
+		},
+		policies: map[uint64][2]*channeldb.ChannelEdgePolicy{
+			chanID: {policy1, policy2},
+		},
+	}
+
+	edge, err := fetchChanInfo(graph, chanID)
+	if err != nil {
+		t.Fatalf("unable to fetch channel info: %v", err)
+	}
+
+	if edge.ChannelId != chanID {
+		t.Fatalf("expected channel id %v, got %v", chanID,
+			edge.ChannelId)
+	}
+	if edge.Capacity != int64(info.Capacity) {
+		t.Fatalf("expected capacity %v, got %v", info.Capacity,
+			edge.Capacity)
+	}
+
+	if edge.Node1Policy == nil || edge.Node2Policy == nil {
+		t.Fatalf("expected both policies, got %v and %v",
+			edge.Node1Policy, edge.Node2Policy)
+	}
+
+	policies := []struct {
+		expected *channeldb.ChannelEdgePolicy
+		disabled bool
+		timeLock uint32
+		minHtlc  int64
+		baseFee  int64
+		feeRate  int64
+	}{
+		{
+			expected: policy1,
+			disabled: edge.Node1Policy.Disabled,
+			timeLock: edge.Node1Policy.TimeLockDelta,
+			minHtlc:  edge.Node1Policy.MinHtlc,
+			baseFee:  edge.Node1Policy.FeeBaseMsat,
+			feeRate:  edge.Node1Policy.FeeRateMilliMsat,
+		},
+		{
+			expected: policy2,
+			disabled: edge.Node2Policy.Disabled,
+			timeLock: edge.Node2Policy.TimeLockDelta,
+			minHtlc:  edge.Node2Policy.MinHtlc,
+			baseFee:  edge.Node2Policy.FeeBaseMsat,
+			feeRate:  edge.Node2Policy.FeeRateMilliMsat,
+		},
+	}
+	for i, p := range policies {
+		expectedDisabled := p.expected.Flags&lnwire.ChanUpdateDisabled != 0
+		if p.disabled != expectedDisabled {
+			t.Fatalf("policy %v: expected disabled=%v, got %v", i,
+				expectedDisabled, p.disabled)
+		}
+		if p.timeLock != uint32(p.expected.TimeLockDelta) {
+			t.Fatalf("policy %v: expected time lock delta %v, "+
+				"got %v", i, p.expected.TimeLockDelta, p.timeLock)
+		}
+		if p.minHtlc != int64(p.expected.MinHTLC) {
+			t.Fatalf("policy %v: expected min htlc %v, got %v", i,
+				p.expected.MinHTLC, p.minHtlc)
+		}
+		if p.baseFee != int64(p.expected.FeeBaseMSat) {
+			t.Fatalf("policy %v: expected base fee %v, got %v", i,
+				p.expected.FeeBaseMSat, p.baseFee)
+		}
+		if p.feeRate != int64(p.expected.FeeProportionalMillionths) {
+			t.Fatalf("policy %v: expected fee rate %v, got %v", i,
+				p.expected.FeeProportionalMillionths, p.feeRate)
+		}
+	}
+
+	// Querying a channel that isn't within the graph should fail.
+	if _, err := fetchChanInfo(graph, chanID+1); err == nil {
+		t.Fatal("expected error for unknown channel")
+	}
+}
+
+// mockFeeEstimator is a lnwallet.FeeEstimator which serves a fixed fee rate
+// per confirmation target, and no estimate for any other target.
+type mockFeeEstimator struct {
+	feePerWeight map[uint32]uint64
+}
+
+func (m *mockFeeEstimator) EstimateFeePerByte(numBlocks uint32) uint64 {
+	return m.feePerWeight[numBlocks] * 4
+}
+
+func (m *mockFeeEstimator) EstimateFeePerWeight(numBlocks uint32) uint64 {
+	return m.feePerWeight[numBlocks]
+}
+
+func (m *mockFeeEstimator) EstimateConfirmation(satPerByte int64) uint32 {
+	return 0
+}
+
+var _ lnwallet.FeeEstimator = (*mockFeeEstimator)(nil)
+
+// TestEstimateFundingFee ensures that the estimated fee of a funding
+// transaction is the weight of the funding transaction template times the
+// estimated fee rate, and that requests without an available estimate fail.
+func TestEstimateFundingFee(t *testing.T) {
+	t.Parallel()
+
+	const (
+		targetConf   = 6
+		feePerWeight = 25
+	)
+	estimator := &mockFeeEstimator{
+		feePerWeight: map[uint32]uint64{
+			targetConf: feePerWeight,
+		},
+	}
+
+	fee, rate, err := estimateFundingFee(
+		estimator, btcutil.SatoshiPerBitcoin/10, targetConf,
+	)
+	if err != nil {
+		t.Fatalf("unable to estimate funding fee: %v", err)
+	}
+	if rate != feePerWeight {
+		t.Fatalf("expected fee rate %v, got %v", feePerWeight, rate)
+	}
+	expectedFee := btcutil.Amount(lnwallet.FundingTxWeight * feePerWeight)
+	if fee != expectedFee {
+		t.Fatalf("expected fee %v, got %v", expectedFee, fee)
+	}
+
+	tests := []struct {
+		name       string
+		fundingAmt btcutil.Amount
+		targetConf int32
+	}{
+		{"no estimate", btcutil.SatoshiPerBitcoin / 10, targetConf + 1},
+		{"zero amount", 0, targetConf},
+		{"amount too large", maxFundingAmount + 1, targetConf},
+		{"zero target", btcutil.SatoshiPerBitcoin / 10, 0},
+	}
+	for _, test := range tests {
+		_, _, err := estimateFundingFee(
+			estimator, test.fundingAmt, test.targetConf,
+		)
+		if err == nil {
+			t.Fatalf("%v: expected estimation to fail", test.name)
+		}
+	}
+}
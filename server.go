@@ -5,7 +5,9 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"net"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -14,6 +16,7 @@ import (
 	"github.com/boltdb/bolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/connmgr"
 	"github.com/roasbeef/btcd/wire"
@@ -23,6 +26,7 @@ import (
 	"github.com/viacoin/lnd/channeldb"
 	"github.com/viacoin/lnd/discovery"
 	"github.com/viacoin/lnd/lnrpc"
+	"github.com/viacoin/lnd/lnwallet/btcwallet"
 	"github.com/viacoin/lnd/lnwire"
 	"github.com/viacoin/lnd/routing"
 
@@ -183,24 +187,13 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		},
 	})
 
-	// If external IP addresses have been specified, add those to the list
-	// of this server's addresses.
-	selfAddrs := make([]net.Addr, 0, len(cfg.ExternalIPs))
-	for _, ip := range cfg.ExternalIPs {
-		var addr string
-		_, _, err = net.SplitHostPort(ip)
-		if err != nil {
-			addr = net.JoinHostPort(ip, strconv.Itoa(defaultPeerPort))
-		} else {
-			addr = ip
-		}
-
-		lnAddr, err := net.ResolveTCPAddr("tcp", addr)
-		if err != nil {
-			return nil, err
-		}
-
-		selfAddrs = append(selfAddrs, lnAddr)
+	// If external IP addresses or onion services have been specified, add
+	// those to the list of this server's addresses.
+	selfAddrs, err := parseAdvertisedAddrs(
+		cfg.ExternalIPs, cfg.ExternalOnions, defaultPeerPort,
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	chanGraph := chanDB.ChannelGraph()
@@ -246,6 +239,16 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 	nodeAnn.Signature = selfNode.AuthSig
 	s.currentNodeAnn = nodeAnn
 
+	// If the validation of node announcements is deferred, then the
+	// router will have the gossiper validate them once their nodes are
+	// first used for path finding.
+	var useNode func(*btcec.PublicKey)
+	if cfg.LazyNodeAnnValidation {
+		useNode = func(node *btcec.PublicKey) {
+			s.authGossiper.UseNode(node)
+		}
+	}
+
 	s.chanRouter, err = routing.New(routing.Config{
 		Graph:     chanGraph,
 		Chain:     cc.chainIO,
@@ -267,25 +270,172 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			return s.htlcSwitch.SendHTLC(firstHopPub, htlcAdd, errorDecryptor)
 		},
 		ChannelPruneExpiry: time.Duration(time.Hour * 24 * 14),
+		NodePruneExpiry:    time.Duration(time.Hour * 24 * 60),
 		GraphPruneInterval: time.Duration(time.Hour),
+		UseNode:            useNode,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create router: %v", err)
 	}
 
-	s.authGossiper, err = discovery.New(discovery.Config{
+	// If an announcement audit log has been configured, then we'll set up
+	// a dedicated rotator for it, so the gossiper can record each
+	// announcement that it rejects.
+	var annAuditLog io.Writer
+	if cfg.AnnAuditLog != "" {
+		annAuditLog, err = initAnnAuditRotator(cfg.AnnAuditLog)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gossiperCfg := discovery.Config{
 		Router:           s.chanRouter,
 		Notifier:         s.cc.chainNotifier,
 		ChainHash:        *activeNetParams.GenesisHash,
 		Broadcast:        s.BroadcastMessage,
 		SendToPeer:       s.SendToPeer,
 		ProofMatureDelta: 0,
-		TrickleDelay:     time.Millisecond * 300,
-		RetransmitDelay:  time.Minute * 30,
+		TrickleDelay:     cfg.TrickleDelay,
+		RetransmitDelay:  cfg.RetransmitDelay,
 		DB:               chanDB,
 		AnnSigner:        s.nodeSigner,
-	},
-		s.identityPriv.PubKey(),
+		AnnAuditLog:      annAuditLog,
+
+		SkipAnnValidation:     cfg.SkipAnnValidation,
+		MinChannelsToAnnounce: cfg.MinChansToAnnounce,
+		ResendSharedUpdates:   cfg.ResendSharedUpdates,
+		MinPeersForBroadcast:  cfg.MinPeersForBroadcast,
+		NoRelayGossip:         cfg.NoRelayGossip,
+		LazyNodeAnnValidation: cfg.LazyNodeAnnValidation,
+		InitialSyncStrategy:   cfg.initialSync,
+		InitialSyncHorizon:    cfg.InitialSyncHorizon,
+		OutdatedUpdateGrace:   cfg.OutdatedUpdateGrace,
+		EdgeUpdateBatchSize:   cfg.EdgeUpdateBatchSize,
+		RouterRetries:         cfg.RouterRetries,
+		RouterRetryBackoff:    cfg.RouterRetryBackoff,
+		StartupRetries:        cfg.StartupRetries,
+		StartupRetryBackoff:   cfg.StartupRetryBackoff,
+		NumConnectedPeers: func() int {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			return len(s.peersByPub)
+		},
+		FetchChanPoint: s.chanRouter.FetchChanPoint,
+	}
+
+	// If requested, we'll reject the announcements of our own closed
+	// channels that peers gossip back to us, rather than storing them
+	// once more.
+	if cfg.RejectClosedChanAnns {
+		gossiperCfg.FetchClosedChannels = func() (
+			[]lnwire.ShortChannelID, error) {
+
+			summaries, err := chanDB.FetchClosedChannels(false)
+			switch {
+			case err == channeldb.ErrNoClosedChannels:
+				return nil, nil
+			case err != nil:
+				return nil, err
+			}
+
+			// Summaries written before the short channel ID was
+			// recorded within them can't be matched against.
+			var chanIDs []lnwire.ShortChannelID
+			for _, summary := range summaries {
+				if summary.ShortChanID.ToUint64() == 0 {
+					continue
+				}
+				chanIDs = append(chanIDs, summary.ShortChanID)
+			}
+
+			return chanIDs, nil
+		}
+	}
+
+	// If requested, we'll drop the updates of channels that have been
+	// closed on-chain, and prune those channels from the graph.
+	if cfg.PruneClosedChans {
+		gossiperCfg.IsChannelClosed = func(chanPoint *wire.OutPoint,
+			heightHint uint32) (bool, error) {
+
+			_, err := s.cc.chainIO.GetUtxo(chanPoint, heightHint)
+			switch {
+			case err == btcwallet.ErrOutputSpent:
+				return true, nil
+			case err != nil:
+				return false, err
+			default:
+				return false, nil
+			}
+		}
+		gossiperCfg.PruneClosedChannels = true
+	}
+
+	// If requested, we'll compress the graph sent to peers that have
+	// signalled support for compressed announcements.
+	if cfg.CompressGossip {
+		gossiperCfg.SupportsCompression = func(
+			pub *btcec.PublicKey) bool {
+
+			peer, err := s.FindPeer(pub)
+			if err != nil || peer.localSharedFeatures == nil {
+				return false
+			}
+
+			return peer.localSharedFeatures.IsActive(
+				compressedGossipFeature,
+			)
+		}
+	}
+
+	// If requested, we'll disconnect from peers which persistently send
+	// us announcements for a chain other than ours, as they're either
+	// misconfigured or malicious.
+	if cfg.MaxWrongChainAnns != 0 {
+		gossiperCfg.MaxWrongChainAnns = cfg.MaxWrongChainAnns
+		gossiperCfg.WrongChainPeer = func(pub *btcec.PublicKey,
+			numAnns int) {
+
+			srvrLog.Warnf("Disconnecting from peer %x, which sent "+
+				"%v announcements for another chain",
+				pub.SerializeCompressed(), numAnns)
+
+			// Disconnecting waits for the peer to be torn down,
+			// which involves the gossiper, so we'll disconnect
+			// asynchronously.
+			go func() {
+				if err := s.DisconnectPeer(pub); err != nil {
+					srvrLog.Debugf("Unable to disconnect "+
+						"peer %x: %v",
+						pub.SerializeCompressed(), err)
+				}
+			}()
+		}
+	}
+
+	// If requested, we'll cross-check the heights of channel
+	// announcements against the checkpoints of the active network.
+	if cfg.ValidateCheckpoints {
+		gossiperCfg.Checkpoints = activeNetParams.Checkpoints
+		gossiperCfg.FetchBlockHash = func(height uint32) (*chainhash.Hash,
+			error) {
+
+			return s.cc.chainIO.GetBlockHash(int64(height))
+		}
+	}
+
+	// Before creating the gossiper, we'll ensure that it operates on the
+	// same chain as the rest of the daemon, as otherwise it'd silently
+	// filter out all announcements of our chain.
+	err = validateGossiperChain(&gossiperCfg, activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	s.authGossiper, err = discovery.New(
+		gossiperCfg, s.identityPriv.PubKey(),
 	)
 	if err != nil {
 		return nil, err
@@ -360,6 +510,14 @@ func (s *server) Start() error {
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
+	// Any outputs migrated from another node must be imported before the
+	// nursery is started, such that they're incubated as well.
+	if cfg.NurseryImport != "" {
+		err := s.utxoNursery.ImportStateFile(cfg.NurseryImport)
+		if err != nil {
+			return err
+		}
+	}
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
@@ -373,6 +531,15 @@ func (s *server) Start() error {
 		return err
 	}
 
+	// Once the router is running, we'll notify the gossiper of each of
+	// our channels that's closed, if it's to reject the announcements of
+	// such channels.
+	if cfg.RejectClosedChanAnns {
+		if err := s.notifyClosedChannels(); err != nil {
+			return err
+		}
+	}
+
 	// With all the relevant sub-systems started, we'll now attempt to
 	// establish persistent connections to our direct channel collaborators
 	// within the network.
@@ -399,6 +566,102 @@ func (s *server) Start() error {
 	return nil
 }
 
+// notifyClosedChannels launches a goroutine which notifies the gossiper of
+// each of our own channels that's closed, such that it can reject the
+// announcements of such channels gossiped back to us. The gossiper is also
+// notified of all channels pruned from the graph, such that they no longer
+// count towards the channels of their nodes.
+func (s *server) notifyClosedChannels() error {
+	graphSubscription, err := s.chanRouter.SubscribeTopology()
+	if err != nil {
+		return err
+	}
+
+	// The notifications of closed channels don't tell whether a channel
+	// was ours, so we'll track the IDs of our own channels within the
+	// graph, starting with those we already know of.
+	selfKey := s.identityPriv.PubKey()
+	ownChans := make(map[uint64]struct{})
+	err = s.chanRouter.ForAllOutgoingChannels(func(
+		info *channeldb.ChannelEdgeInfo,
+		_ *channeldb.ChannelEdgePolicy) error {
+
+		ownChans[info.ChannelID] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		graphSubscription.Cancel()
+		return err
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer graphSubscription.Cancel()
+		defer s.wg.Done()
+
+		for {
+			select {
+			case change, ok := <-graphSubscription.TopologyChanges:
+				// If the router is shutting down, then we will
+				// as well.
+				if !ok {
+					return
+				}
+
+				closed := ownClosedChannels(
+					selfKey, ownChans, change,
+				)
+				for _, chanID := range closed {
+					s.authGossiper.ChannelClosed(chanID)
+				}
+
+				pruned := make(
+					[]uint64, 0, len(change.ClosedChannels),
+				)
+				for _, chanClose := range change.ClosedChannels {
+					pruned = append(pruned, chanClose.ChanID)
+				}
+				s.authGossiper.ChannelsPruned(pruned...)
+
+			case <-s.quit:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// ownClosedChannels adds the channels of the passed topology change which
+// involve our own node to the set of IDs of our own channels, and returns the
+// IDs of our channels which the change reports as closed, removing them from
+// the set.
+func ownClosedChannels(selfKey *btcec.PublicKey, ownChans map[uint64]struct{},
+	change *routing.TopologyChange) []lnwire.ShortChannelID {
+
+	for _, update := range change.ChannelEdgeUpdates {
+		if update.AdvertisingNode.IsEqual(selfKey) ||
+			update.ConnectingNode.IsEqual(selfKey) {
+
+			ownChans[update.ChanID] = struct{}{}
+		}
+	}
+
+	var closed []lnwire.ShortChannelID
+	for _, chanClose := range change.ClosedChannels {
+		if _, ok := ownChans[chanClose.ChanID]; !ok {
+			continue
+		}
+		delete(ownChans, chanClose.ChanID)
+
+		closed = append(
+			closed, lnwire.NewShortChanIDFromInt(chanClose.ChanID),
+		)
+	}
+
+	return closed
+}
+
 // Stop gracefully shutsdown the main daemon server. This function will signal
 // any active goroutines, or helper objects to exit, then blocks until they've
 // all successfully exited. Additionally, any/all listeners are closed.
@@ -416,6 +679,12 @@ func (s *server) Stop() error {
 	s.chanRouter.Stop()
 	s.htlcSwitch.Stop()
 	s.utxoNursery.Stop()
+	if cfg.NurseryExport != "" {
+		err := s.utxoNursery.ExportStateFile(cfg.NurseryExport)
+		if err != nil {
+			srvrLog.Errorf("unable to export nursery state: %v", err)
+		}
+	}
 	s.breachArbiter.Stop()
 	s.authGossiper.Stop()
 	s.cc.wallet.Shutdown()
@@ -638,6 +907,103 @@ func (s *server) peerBootstrapper(numTargetPeers uint32,
 	}
 }
 
+// validateGossiperChain returns an error if the chain hash of the passed
+// gossiper config doesn't match the genesis hash of the passed chain params.
+func validateGossiperChain(cfg *discovery.Config,
+	params *chaincfg.Params) error {
+
+	if !cfg.ChainHash.IsEqual(params.GenesisHash) {
+		return fmt.Errorf("gossiper chain hash %v doesn't match genesis "+
+			"hash %v of active chain %v", cfg.ChainHash,
+			params.GenesisHash, params.Name)
+	}
+
+	return nil
+}
+
+// parseAdvertisedAddrs parses the set of external IP addresses and onion
+// services that we claim to be reachable at into the set of addresses to be
+// advertised within our node announcement. Any address without a port is
+// assumed to be reachable at the default port. The returned addresses are
+// de-duplicated, and sorted in ascending order of their address type: IPv4,
+// IPv6, v2 onion, then v3 onion.
+func parseAdvertisedAddrs(externalIPs, externalOnions []string,
+	defaultPort int) ([]net.Addr, error) {
+
+	// addrWithPort appends the default port to the passed address if it
+	// doesn't already specify one.
+	addrWithPort := func(addr string) string {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return net.JoinHostPort(addr, strconv.Itoa(defaultPort))
+		}
+		return addr
+	}
+
+	addrs := make([]net.Addr, 0, len(externalIPs)+len(externalOnions))
+	seen := make(map[string]struct{})
+	addAddr := func(addr net.Addr) {
+		if _, ok := seen[addr.String()]; ok {
+			return
+		}
+		seen[addr.String()] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+
+	for _, ip := range externalIPs {
+		lnAddr, err := net.ResolveTCPAddr("tcp", addrWithPort(ip))
+		if err != nil {
+			return nil, err
+		}
+
+		addAddr(lnAddr)
+	}
+
+	for _, onion := range externalOnions {
+		host, portStr, err := net.SplitHostPort(addrWithPort(onion))
+		if err != nil {
+			return nil, err
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port for onion "+
+				"service %v: %v", onion, err)
+		}
+
+		onionAddr, err := lnwire.NewOnionAddr(host, port)
+		if err != nil {
+			return nil, err
+		}
+
+		addAddr(onionAddr)
+	}
+
+	// addrTypeOrder returns the position of the address' type within the
+	// canonical ordering of address types.
+	addrTypeOrder := func(addr net.Addr) int {
+		switch a := addr.(type) {
+		case *net.TCPAddr:
+			if a.IP.To4() != nil {
+				return 0
+			}
+			return 1
+
+		case *lnwire.OnionAddr:
+			if a.IsV3() {
+				return 3
+			}
+			return 2
+
+		default:
+			return 4
+		}
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return addrTypeOrder(addrs[i]) < addrTypeOrder(addrs[j])
+	})
+
+	return addrs, nil
+}
+
 // genNodeAnnouncement generates and returns the current fully signed node
 // announcement. If refresh is true, then the time stamp of the announcement
 // will be updated in order to ensure it propagates through the network.
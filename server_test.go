@@ -0,0 +1,157 @@
+// +build !rpctest
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	bitcoinCfg "github.com/roasbeef/btcd/chaincfg"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/viacoin/lnd/discovery"
+	"github.com/viacoin/lnd/lnwire"
+)
+
+const (
+	testV2Onion = "expyuzz4wqqyqhjn.onion"
+	testV3Onion = "vww6ybal4bd7szmgncyruucpgfkqahzddi37ktceo3ah7ngmcopnpyyd.onion"
+)
+
+// TestParseAdvertisedAddrs ensures that a mixed set of IPv4, IPv6 and onion
+// addresses is de-duplicated and sorted into canonical order, and can be
+// encoded within a valid node announcement.
+func TestParseAdvertisedAddrs(t *testing.T) {
+	t.Parallel()
+
+	externalIPs := []string{
+		"[::1]:9000",
+		"127.0.0.1",
+		"127.0.0.1:9735",
+		"10.0.0.1:9000",
+	}
+	externalOnions := []string{
+		testV3Onion,
+		testV2Onion + ":9000",
+		testV3Onion + ":9735",
+	}
+
+	addrs, err := parseAdvertisedAddrs(
+		externalIPs, externalOnions, defaultPeerPort,
+	)
+	if err != nil {
+		t.Fatalf("unable to parse addrs: %v", err)
+	}
+
+	expectedAddrs := []string{
+		"127.0.0.1:9735",
+		"10.0.0.1:9000",
+		"[::1]:9000",
+		testV2Onion + ":9000",
+		testV3Onion + ":9735",
+	}
+	if len(addrs) != len(expectedAddrs) {
+		t.Fatalf("expected %v addrs, got %v: %v", len(expectedAddrs),
+			len(addrs), addrs)
+	}
+	for i, addr := range addrs {
+		if addr.String() != expectedAddrs[i] {
+			t.Fatalf("expected addr #%v to be %v, got %v", i,
+				expectedAddrs[i], addr)
+		}
+	}
+
+	// Next, we'll craft a signed node announcement advertising the
+	// addresses, and ensure that it survives an encoding round trip.
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	alias, err := lnwire.NewNodeAlias("alice")
+	if err != nil {
+		t.Fatalf("unable to create alias: %v", err)
+	}
+	nodeAnn := &lnwire.NodeAnnouncement{
+		Timestamp: 1,
+		NodeID:    privKey.PubKey(),
+		Alias:     alias,
+		Features:  globalFeatures,
+		Addresses: addrs,
+	}
+	nodeAnn.Signature, err = discovery.SignAnnouncement(
+		newNodeSigner(privKey), privKey.PubKey(), nodeAnn,
+	)
+	if err != nil {
+		t.Fatalf("unable to sign node announcement: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := nodeAnn.Encode(&b, 0); err != nil {
+		t.Fatalf("unable to encode node announcement: %v", err)
+	}
+
+	var decodedAnn lnwire.NodeAnnouncement
+	if err := decodedAnn.Decode(&b, 0); err != nil {
+		t.Fatalf("unable to decode node announcement: %v", err)
+	}
+
+	if len(decodedAnn.Addresses) != len(expectedAddrs) {
+		t.Fatalf("expected %v decoded addrs, got %v",
+			len(expectedAddrs), len(decodedAnn.Addresses))
+	}
+	for i, addr := range decodedAnn.Addresses {
+		if addr.String() != expectedAddrs[i] {
+			t.Fatalf("expected decoded addr #%v to be %v, got %v",
+				i, expectedAddrs[i], addr)
+		}
+	}
+
+	data, err := decodedAnn.DataToSign()
+	if err != nil {
+		t.Fatalf("unable to get data to sign: %v", err)
+	}
+	digest := chainhash.DoubleHashB(data)
+	if !decodedAnn.Signature.Verify(digest, privKey.PubKey()) {
+		t.Fatal("decoded node announcement has an invalid signature")
+	}
+}
+
+// TestParseAdvertisedAddrsInvalidOnion ensures that malformed onion services
+// are rejected.
+func TestParseAdvertisedAddrsInvalidOnion(t *testing.T) {
+	t.Parallel()
+
+	invalidOnions := []string{
+		"expyuzz4wqqyqhjn.com",
+		"tooshort.onion",
+		testV2Onion + ":99999",
+	}
+	for _, onion := range invalidOnions {
+		_, err := parseAdvertisedAddrs(
+			nil, []string{onion}, defaultPeerPort,
+		)
+		if err == nil {
+			t.Fatalf("invalid onion service %v was accepted", onion)
+		}
+	}
+}
+
+// TestValidateGossiperChain ensures that a gossiper config is only accepted
+// if its chain hash matches the genesis hash of the active chain.
+func TestValidateGossiperChain(t *testing.T) {
+	t.Parallel()
+
+	params := &bitcoinCfg.TestNet3Params
+
+	cfg := &discovery.Config{
+		ChainHash: *params.GenesisHash,
+	}
+	if err := validateGossiperChain(cfg, params); err != nil {
+		t.Fatalf("matching chain hash rejected: %v", err)
+	}
+
+	cfg.ChainHash = *bitcoinCfg.MainNetParams.GenesisHash
+	if err := validateGossiperChain(cfg, params); err == nil {
+		t.Fatal("mismatched chain hash accepted")
+	}
+}
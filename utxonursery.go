@@ -493,8 +493,7 @@ func (u *utxoNursery) NurseryReport(chanPoint *wire.OutPoint) (*contractMaturity
 		// height.
 		if immatureOutput.ConfHeight() != 0 {
 			report.confirmationHeight = immatureOutput.ConfHeight()
-			report.maturityHeight = (immatureOutput.BlocksToMaturity() +
-				immatureOutput.ConfHeight())
+			report.maturityHeight = immatureOutput.MaturityHeight()
 		}
 
 		return nil
@@ -611,57 +610,13 @@ func (k *kidOutput) waitForPromotion(db *channeldb.DB, confChan *chainntnfs.Conf
 			return err
 		}
 
-		// Next, fetch the kindergarten bucket. This output will remain
-		// in this bucket until it's fully mature.
-		kgtnBucket, err := tx.CreateBucketIfNotExists(kindergartenBucket)
-		if err != nil {
-			return err
-		}
-
-		maturityHeight := k.ConfHeight() + k.BlocksToMaturity()
-
-		heightBytes := make([]byte, 4)
-		byteOrder.PutUint32(heightBytes, maturityHeight)
-
-		// If there're any existing outputs for this particular block
-		// height target, then we'll append this new output to the
-		// serialized list of outputs.
-		var existingOutputs []byte
-		if results := kgtnBucket.Get(heightBytes); results != nil {
-			existingOutputs = results
-		}
-
-		// We'll grab the output's offset in the value for its maturity
-		// height so we can add this to the contract index.
-		outputOffset := len(existingOutputs)
-
-		b := bytes.NewBuffer(existingOutputs)
-		if err := k.Encode(b); err != nil {
-			return err
-		}
-		if err := kgtnBucket.Put(heightBytes, b.Bytes()); err != nil {
-			return err
-		}
-
-		// Finally, we'll insert a new entry into the contract index.
-		// The entry itself consists of 4 bytes for the height, and 4
-		// bytes for the offset within the value for the height.
-		var indexEntry [4 + 4]byte
-		copy(indexEntry[:4], heightBytes)
-		byteOrder.PutUint32(indexEntry[4:], uint32(outputOffset))
-
-		indexBucket, err := tx.CreateBucketIfNotExists(contractIndex)
-		if err != nil {
-			return err
-		}
-		err = indexBucket.Put(originPoint.Bytes(), indexEntry[:])
-		if err != nil {
+		if err := k.enterKindergarten(tx); err != nil {
 			return err
 		}
 
 		utxnLog.Infof("Outpoint %v now in kindergarten, will mature "+
 			"at height %v (delay of %v)", k.OutPoint(),
-			maturityHeight, k.BlocksToMaturity())
+			k.MaturityHeight(), k.BlocksToMaturity())
 		return nil
 	})
 	if err != nil {
@@ -670,6 +625,59 @@ func (k *kidOutput) waitForPromotion(db *channeldb.DB, confChan *chainntnfs.Conf
 	}
 }
 
+// enterKindergarten adds the confirmed output to the "kindergarten" database
+// bucket under its maturity height, and indexes it by the channel point of its
+// contract. The output will remain in this bucket until it's fully mature.
+func (k *kidOutput) enterKindergarten(tx *bolt.Tx) error {
+	var originPoint bytes.Buffer
+	if err := writeOutpoint(&originPoint, k.OriginChanPoint()); err != nil {
+		return err
+	}
+
+	kgtnBucket, err := tx.CreateBucketIfNotExists(kindergartenBucket)
+	if err != nil {
+		return err
+	}
+
+	maturityHeight := k.MaturityHeight()
+
+	heightBytes := make([]byte, 4)
+	byteOrder.PutUint32(heightBytes, maturityHeight)
+
+	// If there're any existing outputs for this particular block
+	// height target, then we'll append this new output to the
+	// serialized list of outputs.
+	var existingOutputs []byte
+	if results := kgtnBucket.Get(heightBytes); results != nil {
+		existingOutputs = results
+	}
+
+	// We'll grab the output's offset in the value for its maturity
+	// height so we can add this to the contract index.
+	outputOffset := len(existingOutputs)
+
+	b := bytes.NewBuffer(existingOutputs)
+	if err := k.Encode(b); err != nil {
+		return err
+	}
+	if err := kgtnBucket.Put(heightBytes, b.Bytes()); err != nil {
+		return err
+	}
+
+	// Finally, we'll insert a new entry into the contract index.
+	// The entry itself consists of 4 bytes for the height, and 4
+	// bytes for the offset within the value for the height.
+	var indexEntry [4 + 4]byte
+	copy(indexEntry[:4], heightBytes)
+	byteOrder.PutUint32(indexEntry[4:], uint32(outputOffset))
+
+	indexBucket, err := tx.CreateBucketIfNotExists(contractIndex)
+	if err != nil {
+		return err
+	}
+	return indexBucket.Put(originPoint.Bytes(), indexEntry[:])
+}
+
 // graduateKindergarten handles the steps invoked with moving funds from a
 // force close commitment transaction into a user's wallet after the output
 // from the commitment transaction has become spendable. graduateKindergarten
@@ -990,6 +998,17 @@ type babyOutput struct {
 
 	// timeoutTx is a fully-signed transaction that, upon confirmation,
 	// transitions the htlc into the delay+claim stage.
+	//
+	// NOTE: The fee of this transaction is fixed at the time the HTLC was
+	// added, and it can't be re-signed with a fresh fee rate by us alone.
+	// Spending the HTLC output through the timeout clause requires the
+	// remote party's signature, which commits to the entire transaction
+	// (SigHashAll), including the output amount that determines the fee.
+	// Any fee bump must therefore be done through a child transaction
+	// spending the second-level output once it's spendable.
+	//
+	// TODO(roasbeef): re-sign once the remote party's signature no longer
+	// commits to the outputs of the timeout transaction.
 	timeoutTx *wire.MsgTx
 
 	// kidOutput represents the CSV output to be swept after the timeoutTx has
@@ -1092,6 +1111,33 @@ func (k *kidOutput) ConfHeight() uint32 {
 	return k.confHeight
 }
 
+// MaturityHeight returns the absolute block height at which the output
+// becomes spendable. It's only meaningful once the confirmation height of the
+// output has been set.
+func (k *kidOutput) MaturityHeight() uint32 {
+	return k.confHeight + k.blocksToMaturity
+}
+
+// matureOutputs returns the subset of the passed outputs which are mature at
+// the target height, i.e. those maturing at or before it. As all of these
+// outputs are spendable at the target height, they can be swept together
+// within a single sweep transaction, saving on fees. Outputs that haven't been
+// confirmed yet are never considered mature.
+func matureOutputs(kids []*kidOutput, height uint32) []*kidOutput {
+	var mature []*kidOutput
+	for _, kid := range kids {
+		if kid.ConfHeight() == 0 {
+			continue
+		}
+
+		if kid.MaturityHeight() <= height {
+			mature = append(mature, kid)
+		}
+	}
+
+	return mature
+}
+
 // Encode converts a KidOutput struct into a form suitable for on-disk database
 // storage. Note that the signDescriptor struct field is included so that the
 // output's witness can be generated by createSweepTx() when the output becomes
@@ -5,14 +5,19 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/btcsuite/btclog"
 	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
+	"github.com/viacoin/lnd/channeldb"
 	"github.com/viacoin/lnd/lnwallet"
 )
 
@@ -355,3 +360,171 @@ func TestBabyOutputSerialization(t *testing.T) {
 
 	}
 }
+
+// TestMatureOutputs ensures that outputs are grouped for sweeping according
+// to their maturity height.
+func TestMatureOutputs(t *testing.T) {
+	t.Parallel()
+
+	var kids []*kidOutput
+	for i := range kidOutputs {
+		kid := kidOutputs[i]
+		kids = append(kids, &kid)
+	}
+
+	// An output that hasn't been confirmed yet should never be considered
+	// mature.
+	unconfirmed := kidOutputs[2]
+	unconfirmed.confHeight = 0
+	kids = append(kids, &unconfirmed)
+
+	tests := []struct {
+		height   uint32
+		expected []*kidOutput
+	}{
+		{
+			height:   kids[2].MaturityHeight() - 1,
+			expected: nil,
+		},
+		{
+			height:   kids[2].MaturityHeight(),
+			expected: []*kidOutput{kids[2]},
+		},
+		{
+			height:   kids[0].MaturityHeight(),
+			expected: []*kidOutput{kids[0], kids[2]},
+		},
+		{
+			height:   kids[1].MaturityHeight(),
+			expected: []*kidOutput{kids[0], kids[1], kids[2]},
+		},
+	}
+
+	for i, test := range tests {
+		mature := matureOutputs(kids, test.height)
+		if !reflect.DeepEqual(mature, test.expected) {
+			t.Fatalf("test #%v: expected %v mature outputs at "+
+				"height %v, got %v", i, len(test.expected),
+				test.height, len(mature))
+		}
+
+		for _, kid := range mature {
+			if kid.MaturityHeight() > test.height {
+				t.Fatalf("test #%v: output maturing at height "+
+					"%v returned for height %v", i,
+					kid.MaturityHeight(), test.height)
+			}
+		}
+	}
+}
+
+// TestNurseryExportSerialization ensures that a set of kid and baby outputs
+// written to a nursery export is read back unchanged.
+func TestNurseryExportSerialization(t *testing.T) {
+	var b bytes.Buffer
+	if err := writeNurseryExport(&b, kidOutputs, babyOutputs); err != nil {
+		t.Fatalf("unable to write nursery export: %v", err)
+	}
+
+	kids, babies, err := readNurseryExport(&b)
+	if err != nil {
+		t.Fatalf("unable to read nursery export: %v", err)
+	}
+
+	if !reflect.DeepEqual(kids, kidOutputs) {
+		t.Fatalf("unexpected kidOutputs, want %+v, got %+v",
+			kidOutputs, kids)
+	}
+	if !reflect.DeepEqual(babies, babyOutputs) {
+		t.Fatalf("unexpected babyOutputs, want %+v, got %+v",
+			babyOutputs, babies)
+	}
+}
+
+// TestNurseryExportImport ensures that the outputs imported into a nursery
+// from an export file are exported by it unchanged, both those awaiting the
+// confirmation of their commitment transaction and those awaiting maturity.
+func TestNurseryExportImport(t *testing.T) {
+	tempDirName, err := ioutil.TempDir("", "nurseryexport")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	// Disable logging to prevent panics bc. of global state
+	channeldb.UseLogger(btclog.Disabled)
+	utxnLog = btclog.Disabled
+
+	db, err := channeldb.Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open channeldb: %v", err)
+	}
+	defer db.Close()
+
+	nursery := newUtxoNursery(db, nil, nil)
+
+	// Along with the confirmed kid outputs, we'll export a copy of one of
+	// them whose commitment transaction hasn't confirmed yet.
+	preschoolKid := kidOutputs[0]
+	preschoolKid.confHeight = 0
+	kids := append([]kidOutput{preschoolKid}, kidOutputs...)
+
+	var export bytes.Buffer
+	if err := writeNurseryExport(&export, kids, nil); err != nil {
+		t.Fatalf("unable to write nursery export: %v", err)
+	}
+	importPath := filepath.Join(tempDirName, "import")
+	err = ioutil.WriteFile(importPath, export.Bytes(), 0600)
+	if err != nil {
+		t.Fatalf("unable to write import file: %v", err)
+	}
+
+	if err := nursery.ImportStateFile(importPath); err != nil {
+		t.Fatalf("unable to import nursery state: %v", err)
+	}
+
+	exportPath := filepath.Join(tempDirName, "export")
+	if err := nursery.ExportStateFile(exportPath); err != nil {
+		t.Fatalf("unable to export nursery state: %v", err)
+	}
+
+	f, err := os.Open(exportPath)
+	if err != nil {
+		t.Fatalf("unable to open export file: %v", err)
+	}
+	defer f.Close()
+
+	exportedKids, exportedBabies, err := readNurseryExport(f)
+	if err != nil {
+		t.Fatalf("unable to read nursery export: %v", err)
+	}
+	if len(exportedBabies) != 0 {
+		t.Fatalf("expected no baby outputs, got %v",
+			len(exportedBabies))
+	}
+
+	// The outputs are exported in the order of their stages rather than
+	// the order they were imported in, so we'll compare them as sets of
+	// their serialized forms.
+	serializedKids := func(kids []kidOutput) map[string]struct{} {
+		set := make(map[string]struct{})
+		for i := range kids {
+			var b bytes.Buffer
+			if err := kids[i].Encode(&b); err != nil {
+				t.Fatalf("unable to encode kid output: %v", err)
+			}
+			set[b.String()] = struct{}{}
+		}
+		return set
+	}
+	if len(exportedKids) != len(kids) {
+		t.Fatalf("expected %v kid outputs, got %v", len(kids),
+			len(exportedKids))
+	}
+	if !reflect.DeepEqual(serializedKids(exportedKids),
+		serializedKids(kids)) {
+
+		t.Fatalf("unexpected kid outputs, want %+v, got %+v", kids,
+			exportedKids)
+	}
+}